@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+type Controller struct {
+	authorizer authz.Authorizer
+	auditStore store.AuditStore
+}
+
+func NewController(authorizer authz.Authorizer, auditStore store.AuditStore) *Controller {
+	return &Controller{
+		authorizer: authorizer,
+		auditStore: auditStore,
+	}
+}
+
+func sanitizeFilter(filter *types.AuditFilter) error {
+	for _, action := range filter.Actions {
+		if err := audit.Action(action).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid audit action '%s'.", action)
+		}
+	}
+
+	for _, resourceType := range filter.ResourceTypes {
+		if err := audit.ResourceType(resourceType).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid audit resource type '%s'.", resourceType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const exportPageSize = 100
+
+// Export writes all audit events matching the filter to the writer as JSON lines.
+// Events are always exported in chronological order, so events logged during the export
+// are appended at the end and don't shift the pages that are yet to be read.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditFilter,
+	w io.Writer,
+) error {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return err
+	}
+
+	if err := sanitizeFilter(filter); err != nil {
+		return err
+	}
+
+	pageFilter := *filter
+	pageFilter.Order = enum.OrderAsc
+	pageFilter.Size = exportPageSize
+
+	enc := json.NewEncoder(w)
+
+	for page := 1; ; page++ {
+		pageFilter.Page = page
+
+		events, err := c.auditStore.List(ctx, &pageFilter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		for _, event := range events {
+			if err = enc.Encode(event); err != nil {
+				return fmt.Errorf("failed to write audit event: %w", err)
+			}
+		}
+
+		if len(events) < exportPageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List returns the audit events matching the filter.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditFilter,
+) ([]*types.AuditEvent, int64, error) {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return nil, 0, err
+	}
+
+	if err := sanitizeFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.auditStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	events, err := c.auditStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, count, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildcache
+
+import (
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+)
+
+const (
+	// maxKeyLength is the maximum length of a cache key.
+	maxKeyLength = 512
+)
+
+type Controller struct {
+	repoStore       store.RepoStore
+	cacheEntryStore store.CacheEntryStore
+	blobStore       blob.Store
+	maxSize         int64
+	maxEntrySize    int64
+}
+
+func NewController(
+	repoStore store.RepoStore,
+	cacheEntryStore store.CacheEntryStore,
+	blobStore blob.Store,
+	maxSize int64,
+	maxEntrySize int64,
+) *Controller {
+	return &Controller{
+		repoStore:       repoStore,
+		cacheEntryStore: cacheEntryStore,
+		blobStore:       blobStore,
+		maxSize:         maxSize,
+		maxEntrySize:    maxEntrySize,
+	}
+}
+
+// getCacheMetadata returns the build cache access granted to the session.
+// The build cache is only accessible with the cache token provided to pipeline steps.
+func getCacheMetadata(session *auth.Session) (*auth.CacheMetadata, error) {
+	if session == nil {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+
+	metadata, ok := session.Metadata.(*auth.CacheMetadata)
+	if !ok {
+		return nil, apiauth.ErrNotAuthorized
+	}
+
+	return metadata, nil
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return usererror.BadRequest("Cache key is required.")
+	}
+
+	if len(key) > maxKeyLength {
+		return usererror.BadRequestf("Cache key can have at most %d characters.", maxKeyLength)
+	}
+
+	return nil
+}
+
+func getBlobPath(repoID int64, identifier string) string {
+	return fmt.Sprintf("cache/%d/%s", repoID, identifier)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Restore finds the cache entry with the exact key, falling back to the most recent entry whose key starts
+// with one of the restore keys (in the provided order). Entries of the branch of the execution are preferred
+// over entries of the default branch of the repository.
+// It returns either a signed URL the entry can be downloaded from,
+// or, if the blob store doesn't support signed URLs, a reader of the entry content.
+func (c *Controller) Restore(
+	ctx context.Context,
+	session *auth.Session,
+	key string,
+	restoreKeys []string,
+) (*types.CacheEntry, string, io.ReadCloser, error) {
+	metadata, err := getCacheMetadata(session)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if err = validateKey(key); err != nil {
+		return nil, "", nil, err
+	}
+
+	repo, err := c.repoStore.Find(ctx, metadata.RepoID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	branches := []string{metadata.Branch}
+	if repo.DefaultBranch != metadata.Branch {
+		branches = append(branches, repo.DefaultBranch)
+	}
+
+	entry, err := c.find(ctx, repo.ID, branches, key, restoreKeys)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if err = c.cacheEntryStore.UpdateLastUsed(ctx, entry.ID, time.Now().UnixMilli()); err != nil {
+		// not critical, at worst the entry gets evicted earlier.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of cache entry %q", entry.Key)
+	}
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, entry.BlobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return entry, signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, entry.BlobPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, "", nil, usererror.NotFoundf("Content of cache entry %q not found.", entry.Key)
+	}
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download cache entry from blobstore: %w", err)
+	}
+
+	return entry, "", file, nil
+}
+
+func (c *Controller) find(
+	ctx context.Context,
+	repoID int64,
+	branches []string,
+	key string,
+	restoreKeys []string,
+) (*types.CacheEntry, error) {
+	for _, branch := range branches {
+		entry, err := c.cacheEntryStore.Find(ctx, repoID, branch, key)
+		if err == nil {
+			return entry, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find cache entry: %w", err)
+		}
+
+		for _, restoreKey := range restoreKeys {
+			if restoreKey == "" {
+				continue
+			}
+
+			entry, err = c.cacheEntryStore.FindByKeyPrefix(ctx, repoID, branch, restoreKey)
+			if err == nil {
+				return entry, nil
+			}
+			if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return nil, fmt.Errorf("failed to find cache entry by restore key: %w", err)
+			}
+		}
+	}
+
+	return nil, usererror.NotFoundf("No cache entry found for key %q.", key)
+}
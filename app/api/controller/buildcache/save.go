@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// evictionBatchSize is the number of cache entries loaded at once while evicting.
+	evictionBatchSize = 50
+)
+
+// Save stores the content as cache entry with the given key on the branch of the execution,
+// replacing an existing entry with the same key. If the build cache of the repository grows
+// beyond its maximum size, the least recently used entries get evicted.
+func (c *Controller) Save(
+	ctx context.Context,
+	session *auth.Session,
+	key string,
+	file io.Reader,
+) (*types.CacheEntry, error) {
+	metadata, err := getCacheMetadata(session)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metadata.Write {
+		return nil, usererror.Forbidden("The build cache is read-only for this execution.")
+	}
+
+	if err = validateKey(key); err != nil {
+		return nil, err
+	}
+
+	existing, err := c.cacheEntryStore.Find(ctx, metadata.RepoID, metadata.Branch, key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find existing cache entry: %w", err)
+	}
+
+	blobPath := getBlobPath(metadata.RepoID, uuid.New().String())
+
+	// read one byte more than allowed to detect entries exceeding the limit.
+	counter := blob.NewCountingReader(io.LimitReader(file, c.maxEntrySize+1))
+	if err = c.blobStore.Upload(ctx, counter, blobPath); err != nil {
+		return nil, fmt.Errorf("failed to upload cache entry: %w", err)
+	}
+
+	if counter.Count() > c.maxEntrySize {
+		c.deleteBlob(ctx, blobPath)
+		return nil, usererror.BadRequestf("Cache entry exceeds the maximum size of %d bytes.", c.maxEntrySize)
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.CacheEntry{
+		RepoID:   metadata.RepoID,
+		Branch:   metadata.Branch,
+		Key:      key,
+		Size:     counter.Count(),
+		BlobPath: blobPath,
+		Created:  now,
+		LastUsed: now,
+	}
+	if err = c.cacheEntryStore.Upsert(ctx, entry); err != nil {
+		c.deleteBlob(ctx, blobPath)
+		return nil, fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	if existing != nil {
+		c.deleteBlob(ctx, existing.BlobPath)
+	}
+
+	if err = c.evict(ctx, metadata.RepoID, entry.ID); err != nil {
+		// not critical, the eviction happens again with the next cache entry.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to evict cache entries of repo %d", metadata.RepoID)
+	}
+
+	return entry, nil
+}
+
+// evict deletes the least recently used cache entries of the repo until its build cache fits the maximum size.
+// The entry that was just stored is never evicted.
+func (c *Controller) evict(ctx context.Context, repoID int64, keepID int64) error {
+	total, err := c.cacheEntryStore.TotalSize(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to get total size of build cache: %w", err)
+	}
+
+	for total > c.maxSize {
+		entries, err := c.cacheEntryStore.ListLeastRecentlyUsed(ctx, repoID, evictionBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list least recently used cache entries: %w", err)
+		}
+
+		evicted := 0
+		for _, entry := range entries {
+			if total <= c.maxSize {
+				break
+			}
+			if entry.ID == keepID {
+				continue
+			}
+
+			if err = c.cacheEntryStore.Delete(ctx, entry.ID); err != nil {
+				return fmt.Errorf("failed to delete cache entry: %w", err)
+			}
+			c.deleteBlob(ctx, entry.BlobPath)
+
+			total -= entry.Size
+			evicted++
+		}
+
+		if evicted == 0 {
+			// only the kept entry is left.
+			break
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) deleteBlob(ctx context.Context, blobPath string) {
+	if err := c.blobStore.Delete(ctx, blobPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete cache blob %q", blobPath)
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -182,6 +183,19 @@ func (c *Controller) Report(
 		return nil, fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
 	}
 
+	if existingCheck.Status != statusCheckReport.Status {
+		c.eventReporter.StatusUpdated(ctx, &checkevents.StatusUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: session.Principal.ID,
+			CommitSHA:   commitSHA,
+			Identifier:  statusCheckReport.Identifier,
+			OldStatus:   existingCheck.Status,
+			Status:      statusCheckReport.Status,
+			Summary:     statusCheckReport.Summary,
+			Link:        statusCheckReport.Link,
+		})
+	}
+
 	return statusCheckReport, nil
 }
 
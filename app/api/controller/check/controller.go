@@ -22,6 +22,8 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/testreport"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -30,12 +32,16 @@ import (
 )
 
 type Controller struct {
-	tx         dbtx.Transactor
-	authorizer authz.Authorizer
-	repoStore  store.RepoStore
-	checkStore store.CheckStore
-	git        git.Interface
-	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	tx            dbtx.Transactor
+	authorizer    authz.Authorizer
+	repoStore     store.RepoStore
+	checkStore    store.CheckStore
+	git           git.Interface
+	sanitizers    map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	eventReporter *checkevents.Reporter
+
+	testReportStore store.TestReportStore
+	testReportSvc   *testreport.Service
 }
 
 func NewController(
@@ -45,14 +51,21 @@ func NewController(
 	checkStore store.CheckStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	eventReporter *checkevents.Reporter,
+	testReportStore store.TestReportStore,
+	testReportSvc *testreport.Service,
 ) *Controller {
 	return &Controller{
-		tx:         tx,
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		checkStore: checkStore,
-		git:        git,
-		sanitizers: sanitizers,
+		tx:            tx,
+		authorizer:    authorizer,
+		repoStore:     repoStore,
+		checkStore:    checkStore,
+		git:           git,
+		sanitizers:    sanitizers,
+		eventReporter: eventReporter,
+
+		testReportStore: testReportStore,
+		testReportSvc:   testReportSvc,
 	}
 }
 
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReportTests stores a JUnit or xUnit.net XML test report for the status check of a commit.
+// The branch is optional, reports of a branch are used as baseline for the test summary of pull requests.
+func (c *Controller) ReportTests(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	checkIdentifier string,
+	branch string,
+	file io.Reader,
+) (*types.TestReport, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !matcherCheckIdentifier.MatchString(checkIdentifier) {
+		return nil, usererror.BadRequestf("Check identifier must match the regular expression: %s",
+			regexpCheckIdentifier)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	_, err = c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Revision:   commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit sha=%s: %w", commitSHA, err)
+	}
+
+	report := &types.TestReport{
+		RepoID:          repo.ID,
+		CommitSHA:       commitSHA,
+		CheckIdentifier: checkIdentifier,
+		Branch:          branch,
+		CreatedBy:       session.Principal.ID,
+	}
+
+	if err = c.testReportSvc.Ingest(ctx, report, file); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ListTestCases lists the test cases of all test reports of a commit.
+func (c *Controller) ListTestCases(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	opts types.TestCaseListOptions,
+) ([]*types.TestCase, int, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	for _, status := range opts.Statuses {
+		if _, ok := status.Sanitize(); !ok {
+			return nil, 0, usererror.BadRequestf("Invalid test status %q.", status)
+		}
+	}
+
+	testCases, err := c.testReportStore.ListCases(ctx, repo.ID, commitSHA, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list test cases for repo=%s: %w", repo.Identifier, err)
+	}
+
+	if opts.Page == 1 && len(testCases) < opts.Size {
+		return testCases, len(testCases), nil
+	}
+
+	count, err := c.testReportStore.CountCases(ctx, repo.ID, commitSHA, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count test cases for repo=%s: %w", repo.Identifier, err)
+	}
+
+	return testCases, count, nil
+}
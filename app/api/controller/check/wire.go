@@ -17,6 +17,8 @@ package check
 import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/testreport"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -38,6 +40,9 @@ func ProvideController(
 	checkStore store.CheckStore,
 	rpcClient git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	eventReporter *checkevents.Reporter,
+	testReportStore store.TestReportStore,
+	testReportSvc *testreport.Service,
 ) *Controller {
 	return NewController(
 		tx,
@@ -46,5 +51,8 @@ func ProvideController(
 		checkStore,
 		rpcClient,
 		sanitizers,
+		eventReporter,
+		testReportStore,
+		testReportSvc,
 	)
 }
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// environmentMaxBranchPatterns defines the max allowed number of branch patterns of an environment.
+	environmentMaxBranchPatterns = 100
+	// environmentMaxPrincipals defines the max allowed number of principals allowed to deploy to an environment.
+	environmentMaxPrincipals = 100
+)
+
+type Controller struct {
+	authorizer       authz.Authorizer
+	environmentStore store.EnvironmentStore
+	deploymentStore  store.DeploymentStore
+	repoStore        store.RepoStore
+	spaceStore       store.SpaceStore
+	principalStore   store.PrincipalStore
+	auditService     audit.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	environmentStore store.EnvironmentStore,
+	deploymentStore store.DeploymentStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	auditService audit.Service,
+) *Controller {
+	return &Controller{
+		authorizer:       authorizer,
+		environmentStore: environmentStore,
+		deploymentStore:  deploymentStore,
+		repoStore:        repoStore,
+		spaceStore:       spaceStore,
+		principalStore:   principalStore,
+		auditService:     auditService,
+	}
+}
+
+// parent is the repository or space an environment belongs to.
+type parent = controller.Parent[enum.EnvironmentParent]
+
+// getParentCheckAccess finds the parent of the environments and checks the required permission.
+// The permission is provided as repo permission and translated in case the parent is a space.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	reqPermission enum.Permission,
+) (*parent, error) {
+	switch parentType {
+	case enum.EnvironmentParentRepo:
+		return controller.GetRepoParentCheckAccess(ctx, c.authorizer, c.repoStore, session,
+			parentType, parentRef, reqPermission)
+	case enum.EnvironmentParentSpace:
+		return controller.GetSpaceParentCheckAccess(ctx, c.authorizer, c.spaceStore, session,
+			parentType, parentRef, reqPermission)
+	default:
+		return nil, fmt.Errorf("environment parent type '%s' is not supported", parentType)
+	}
+}
+
+// getEnvironment finds the environment with the given identifier of the parent.
+func (c *Controller) getEnvironment(
+	ctx context.Context,
+	parent *parent,
+	identifier string,
+) (*types.Environment, error) {
+	if identifier == "" {
+		return nil, usererror.BadRequest("A valid environment identifier must be provided.")
+	}
+
+	env, err := c.environmentStore.FindByIdentifier(ctx, parent.Type, parent.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find environment %q: %w", identifier, err)
+	}
+
+	return env, nil
+}
+
+// sanitizeProtection validates the protection of an environment and removes duplicate principals.
+func (c *Controller) sanitizeProtection(ctx context.Context, protection *types.EnvironmentProtection) error {
+	if len(protection.Branches) > environmentMaxBranchPatterns {
+		return check.NewValidationErrorf("An environment can have at most %d branch patterns.",
+			environmentMaxBranchPatterns)
+	}
+	for _, pattern := range protection.Branches {
+		if pattern == "" || !doublestar.ValidatePattern(pattern) {
+			return check.NewValidationErrorf("The branch pattern '%s' is invalid.", pattern)
+		}
+	}
+
+	if len(protection.PrincipalIDs) > environmentMaxPrincipals {
+		return check.NewValidationErrorf("At most %d principals can be allowed to deploy to an environment.",
+			environmentMaxPrincipals)
+	}
+
+	principalIDs := make([]int64, 0, len(protection.PrincipalIDs))
+	seen := make(map[int64]bool, len(protection.PrincipalIDs))
+	for _, principalID := range protection.PrincipalIDs {
+		if seen[principalID] {
+			continue
+		}
+		seen[principalID] = true
+
+		_, err := c.principalStore.Find(ctx, principalID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return check.NewValidationErrorf("The principal %d doesn't exist.", principalID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find principal %d: %w", principalID, err)
+		}
+		principalIDs = append(principalIDs, principalID)
+	}
+	protection.PrincipalIDs = principalIDs
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateInput struct {
+	Identifier  string                      `json:"identifier"`
+	Description string                      `json:"description"`
+	Protection  types.EnvironmentProtection `json:"protection"`
+}
+
+// Create creates a new deployment environment.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	in *CreateInput,
+) (*types.Environment, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeCreateInput(ctx, in); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	env := &types.Environment{
+		ParentID:    parent.ID,
+		ParentType:  parent.Type,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Protection:  in.Protection,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.environmentStore.Create(ctx, env); err != nil {
+		return nil, fmt.Errorf("failed to create environment: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeEnvironment, env.Identifier),
+		audit.ActionCreated,
+		parent.SpacePath(),
+		audit.WithNewObject(env),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create environment operation: %s", err)
+	}
+
+	return env, nil
+}
+
+func (c *Controller) sanitizeCreateInput(ctx context.Context, in *CreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return c.sanitizeProtection(ctx, &in.Protection)
+}
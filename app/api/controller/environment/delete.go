@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes a deployment environment along with its deployment history.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	identifier string,
+) error {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	env, err := c.getEnvironment(ctx, parent, identifier)
+	if err != nil {
+		return err
+	}
+
+	if err = c.environmentStore.Delete(ctx, env.ID); err != nil {
+		return fmt.Errorf("failed to delete environment: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeEnvironment, env.Identifier),
+		audit.ActionDeleted,
+		parent.SpacePath(),
+		audit.WithOldObject(env),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete environment operation: %s", err)
+	}
+
+	return nil
+}
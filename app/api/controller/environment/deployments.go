@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListDeployments lists the deployment history of an environment, most recent first.
+func (c *Controller) ListDeployments(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	identifier string,
+	filter *types.DeploymentFilter,
+) ([]*types.Deployment, int64, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	env, err := c.getEnvironment(ctx, parent, identifier)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.deploymentStore.Count(ctx, env.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count deployments: %w", err)
+	}
+
+	deployments, err := c.deploymentStore.List(ctx, env.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	return deployments, count, nil
+}
+
+// ListCurrentDeployments lists what is currently deployed where:
+// the most recent successful deployment of the repository to each environment.
+func (c *Controller) ListCurrentDeployments(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.Deployment, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, enum.EnvironmentParentRepo, repoRef,
+		enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := c.deploymentStore.ListLatestByRepo(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest deployments: %w", err)
+	}
+
+	return deployments, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find finds a deployment environment.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	identifier string,
+) (*types.Environment, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getEnvironment(ctx, parent, identifier)
+}
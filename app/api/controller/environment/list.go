@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the deployment environments of the provided repository or space.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	filter *types.ListQueryFilter,
+) ([]*types.Environment, int64, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.environmentStore.Count(ctx, parent.Type, parent.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count environments: %w", err)
+	}
+
+	envs, err := c.environmentStore.List(ctx, parent.Type, parent.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	return envs, count, nil
+}
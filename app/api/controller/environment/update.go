@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UpdateInput struct {
+	Identifier  *string                      `json:"identifier"`
+	Description *string                      `json:"description"`
+	Protection  *types.EnvironmentProtection `json:"protection"`
+}
+
+// Update updates an existing deployment environment.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.EnvironmentParent,
+	parentRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.Environment, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := c.getEnvironment(ctx, parent, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeUpdateInput(ctx, in); err != nil {
+		return nil, err
+	}
+
+	oldEnv := *env
+
+	if in.Identifier != nil {
+		env.Identifier = *in.Identifier
+	}
+	if in.Description != nil {
+		env.Description = *in.Description
+	}
+	if in.Protection != nil {
+		env.Protection = *in.Protection
+	}
+
+	if err = c.environmentStore.Update(ctx, env); err != nil {
+		return nil, fmt.Errorf("failed to update environment: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeEnvironment, env.Identifier),
+		audit.ActionUpdated,
+		parent.SpacePath(),
+		audit.WithOldObject(oldEnv),
+		audit.WithNewObject(env),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update environment operation: %s", err)
+	}
+
+	return env, nil
+}
+
+func (c *Controller) sanitizeUpdateInput(ctx context.Context, in *UpdateInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+	if in.Description != nil {
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+	if in.Protection != nil {
+		if err := c.sanitizeProtection(ctx, in.Protection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const maxApprovalCommentLength = 1024
+
+type ApprovalInput struct {
+	Decision enum.ApprovalDecision `json:"decision"`
+	Comment  string                `json:"comment"`
+}
+
+func (in *ApprovalInput) sanitize() error {
+	decision, ok := in.Decision.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Decision must be either approved or rejected.")
+	}
+	in.Decision = decision
+
+	in.Comment = strings.TrimSpace(in.Comment)
+	if len(in.Comment) > maxApprovalCommentLength {
+		return check.NewValidationErrorf("Comment can't be longer than %d characters.", maxApprovalCommentLength)
+	}
+
+	return nil
+}
+
+// ListApprovals lists the approval stages of an execution along with the decisions of the approvers.
+func (c *Controller) ListApprovals(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.StageApproval, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	approvals, err := c.approvalStore.ListByExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	for _, approval := range approvals {
+		if err = c.fillApproval(ctx, approval); err != nil {
+			return nil, err
+		}
+	}
+
+	return approvals, nil
+}
+
+// Approve submits the decision of the principal on an approval stage of an execution.
+// A rejection completes the stage immediately, otherwise it completes once it got enough approvals.
+func (c *Controller) Approve(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *ApprovalInput,
+) (*types.StageApproval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	if stage.Type != types.StageTypeApproval {
+		return nil, usererror.BadRequest("The stage doesn't require approval.")
+	}
+
+	approval, err := c.approvalStore.FindByStageID(ctx, stage.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find approval of stage %d: %w", stageNum, err)
+	}
+
+	if stage.Status != enum.CIStatusBlocked {
+		return nil, usererror.BadRequest("The stage isn't waiting for approval.")
+	}
+
+	if !approval.IsApprover(session.Principal.ID) {
+		return nil, usererror.Forbidden("You are not an approver of the stage.")
+	}
+
+	err = c.approvalStore.UpsertDecision(ctx, &types.ApprovalDecision{
+		StageID:     stage.ID,
+		PrincipalID: session.Principal.ID,
+		Decision:    in.Decision,
+		Comment:     in.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store approval decision: %w", err)
+	}
+
+	decisions, err := c.approvalStore.ListDecisions(ctx, stage.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval decisions: %w", err)
+	}
+
+	approvals := 0
+	for _, decision := range decisions {
+		if decision.Decision == enum.ApprovalDecisionApproved && approval.IsApprover(decision.PrincipalID) {
+			approvals++
+		}
+	}
+
+	switch {
+	case in.Decision == enum.ApprovalDecisionRejected:
+		reason := fmt.Sprintf("Rejected by %s", session.Principal.DisplayName)
+		if in.Comment != "" {
+			reason += ": " + in.Comment
+		}
+		err = c.approvalService.Resolve(ctx, stage, enum.CIStatusDeclined, reason)
+	case approvals >= approval.MinApprovals:
+		err = c.approvalService.Resolve(ctx, stage, enum.CIStatusSuccess, "")
+	}
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// the stage got resolved by a concurrent decision or got canceled in the meantime.
+		log.Ctx(ctx).Debug().Msgf("approval stage %d got resolved concurrently", stage.ID)
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve approval stage: %w", err)
+	}
+
+	approval, err = c.approvalStore.FindByStageID(ctx, stage.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find approval of stage %d: %w", stageNum, err)
+	}
+
+	if err = c.fillApproval(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// fillApproval sets the approvers and the decisions of the approval.
+func (c *Controller) fillApproval(ctx context.Context, approval *types.StageApproval) error {
+	decisions, err := c.approvalStore.ListDecisions(ctx, approval.StageID)
+	if err != nil {
+		return fmt.Errorf("failed to list approval decisions: %w", err)
+	}
+
+	principalIDs := make([]int64, 0, len(approval.ApproverIDs)+len(decisions))
+	principalIDs = append(principalIDs, approval.ApproverIDs...)
+	for _, decision := range decisions {
+		principalIDs = append(principalIDs, decision.PrincipalID)
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch approver infos: %w", err)
+	}
+
+	approval.Approvers = make([]*types.PrincipalInfo, 0, len(approval.ApproverIDs))
+	for _, id := range approval.ApproverIDs {
+		if principal, ok := principals[id]; ok {
+			approval.Approvers = append(approval.Approvers, principal)
+		}
+	}
+
+	for _, decision := range decisions {
+		decision.Principal = principals[decision.PrincipalID]
+	}
+	approval.Decisions = decisions
+
+	return nil
+}
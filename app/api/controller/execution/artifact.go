@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArtifacts lists the artifacts uploaded by the stages of an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.Artifact, error) {
+	repo, pipeline, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.artifactStore.ListByExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	for _, artifact := range artifacts {
+		artifact.URL = c.urlProvider.GenerateAPIArtifactURL(repo.Path, pipeline.Identifier, execution.Number, artifact.ID)
+	}
+
+	return artifacts, nil
+}
+
+// DownloadArtifact returns either a signed URL the artifact can be downloaded from,
+// or, if the blob store doesn't support signed URLs, a reader of the artifact content.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	artifactID int64,
+) (*types.Artifact, string, io.ReadCloser, error) {
+	_, _, execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, artifactID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	if artifact.ExecutionID != execution.ID {
+		return nil, "", nil, usererror.NotFoundf("Artifact %d not found in execution %d.", artifactID, executionNum)
+	}
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, artifact.BlobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return artifact, signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, artifact.BlobPath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, "", nil, usererror.NotFoundf("Content of artifact %q not found.", artifact.Name)
+	}
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download artifact from blobstore: %w", err)
+	}
+
+	return artifact, "", file, nil
+}
+
+// getExecutionCheckAccess finds the execution and ensures the session can view its pipeline.
+func (c *Controller) getExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) (*types.Repository, *types.Pipeline, *types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return repo, pipeline, execution, nil
+}
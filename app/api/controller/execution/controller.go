@@ -16,24 +16,33 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	executionStore     store.ExecutionStore
+	checkStore         store.CheckStore
+	canceler           canceler.Canceler
+	commitService      commit.Service
+	triggerer          triggerer.Triggerer
+	repoStore          store.RepoStore
+	stageStore         store.StageStore
+	pipelineStore      store.PipelineStore
+	approvalStore      store.StageApprovalStore
+	approvalService    *approval.Service
+	principalInfoCache store.PrincipalInfoCache
+	artifactStore      store.ArtifactStore
+	blobStore          blob.Store
+	urlProvider        url.Provider
 }
 
 func NewController(
@@ -47,17 +56,29 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	approvalStore store.StageApprovalStore,
+	approvalService *approval.Service,
+	principalInfoCache store.PrincipalInfoCache,
+	artifactStore store.ArtifactStore,
+	blobStore blob.Store,
+	urlProvider url.Provider,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:                 tx,
+		authorizer:         authorizer,
+		executionStore:     executionStore,
+		checkStore:         checkStore,
+		canceler:           canceler,
+		commitService:      commitService,
+		triggerer:          triggerer,
+		repoStore:          repoStore,
+		stageStore:         stageStore,
+		pipelineStore:      pipelineStore,
+		approvalStore:      approvalStore,
+		approvalService:    approvalService,
+		principalInfoCache: principalInfoCache,
+		artifactStore:      artifactStore,
+		blobStore:          blobStore,
+		urlProvider:        urlProvider,
 	}
 }
@@ -33,6 +33,7 @@ func (c *Controller) Create(
 	repoRef string,
 	pipelineIdentifier string,
 	branch string,
+	environment string,
 ) (*types.Execution, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -83,6 +84,7 @@ func (c *Controller) Create(
 		Target:      branch,
 		Params:      map[string]string{},
 		Timestamp:   commit.Author.When.UnixMilli(),
+		Deploy:      environment,
 	}
 
 	// Trigger the execution
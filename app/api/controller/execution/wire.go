@@ -16,10 +16,13 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
@@ -41,7 +44,14 @@ func ProvideController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	approvalStore store.StageApprovalStore,
+	approvalService *approval.Service,
+	principalInfoCache store.PrincipalInfoCache,
+	artifactStore store.ArtifactStore,
+	blobStore blob.Store,
+	urlProvider url.Provider,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore,
+		approvalStore, approvalService, principalInfoCache, artifactStore, blobStore, urlProvider)
 }
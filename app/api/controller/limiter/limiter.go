@@ -22,6 +22,9 @@ import (
 
 var ErrMaxNumReposReached = errors.New("maximum number of repositories reached")
 var ErrMaxRepoSizeReached = errors.New("maximum size of repository reached")
+var ErrMaxBlobSizeReached = errors.New("maximum size of uploaded files reached")
+var ErrMaxPipelineMinutesReached = errors.New("maximum number of pipeline minutes reached")
+var ErrMaxConcurrentExecutionsReached = errors.New("maximum number of concurrent pipeline executions reached")
 
 // ResourceLimiter is an interface for managing resource limitation.
 type ResourceLimiter interface {
@@ -30,6 +33,15 @@ type ResourceLimiter interface {
 
 	// RepoSize allows repository growth up to a limit for the given repoID.
 	RepoSize(ctx context.Context, repoID int64) error
+
+	// BlobSize allows uploading files up to a limit for the given repoID.
+	BlobSize(ctx context.Context, repoID int64) error
+
+	// PipelineMinutes allows running pipelines up to a limit of pipeline minutes for the given repoID.
+	PipelineMinutes(ctx context.Context, repoID int64) error
+
+	// ConcurrentExecutions allows starting another pipeline execution for the given repoID.
+	ConcurrentExecutions(ctx context.Context, repoID int64) error
 }
 
 var _ ResourceLimiter = Unlimited{}
@@ -49,3 +61,15 @@ func (Unlimited) RepoCount(context.Context, int64, int) error {
 func (Unlimited) RepoSize(context.Context, int64) error {
 	return nil
 }
+
+func (Unlimited) BlobSize(context.Context, int64) error {
+	return nil
+}
+
+func (Unlimited) PipelineMinutes(context.Context, int64) error {
+	return nil
+}
+
+func (Unlimited) ConcurrentExecutions(context.Context, int64) error {
+	return nil
+}
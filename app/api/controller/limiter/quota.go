@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var _ ResourceLimiter = (*Quota)(nil)
+
+// Quota is a ResourceLimiter enforcing the quotas of spaces.
+// The quota of a space limits the resource consumption of the space together with all of its descendants,
+// hence a resource can only be consumed if none of the quotas up to the root space is exceeded.
+// Top level spaces fall back to the default limits for every resource their quota doesn't limit.
+type Quota struct {
+	defaults   types.SpaceQuota
+	spaceStore store.SpaceStore
+	repoStore  store.RepoStore
+	quotaStore store.QuotaStore
+}
+
+func NewQuota(
+	defaults types.SpaceQuota,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	quotaStore store.QuotaStore,
+) *Quota {
+	return &Quota{
+		defaults:   defaults,
+		spaceStore: spaceStore,
+		repoStore:  repoStore,
+		quotaStore: quotaStore,
+	}
+}
+
+// spaceQuota is the quota that applies to a space.
+type spaceQuota struct {
+	space *types.Space
+
+	// configured is the quota configured on the space, nil if there's none.
+	configured *types.SpaceQuota
+
+	// effective is the quota that applies to the space, including the default limits.
+	effective *types.SpaceQuota
+}
+
+func (q *Quota) RepoCount(ctx context.Context, spaceID int64, count int) error {
+	return q.check(ctx, spaceID, enum.QuotaResourceRepos, int64(count), ErrMaxNumReposReached)
+}
+
+func (q *Quota) RepoSize(ctx context.Context, repoID int64) error {
+	return q.checkRepo(ctx, repoID, enum.QuotaResourceRepoSize, ErrMaxRepoSizeReached)
+}
+
+func (q *Quota) BlobSize(ctx context.Context, repoID int64) error {
+	return q.checkRepo(ctx, repoID, enum.QuotaResourceBlobSize, ErrMaxBlobSizeReached)
+}
+
+func (q *Quota) PipelineMinutes(ctx context.Context, repoID int64) error {
+	return q.checkRepo(ctx, repoID, enum.QuotaResourcePipelineMinutes, ErrMaxPipelineMinutesReached)
+}
+
+func (q *Quota) ConcurrentExecutions(ctx context.Context, repoID int64) error {
+	return q.checkRepo(ctx, repoID, enum.QuotaResourceConcurrentExecutions, ErrMaxConcurrentExecutionsReached)
+}
+
+// Usage returns the quota configured on the space together with the consumption
+// and the effective limit of every resource.
+func (q *Quota) Usage(ctx context.Context, space *types.Space) (*types.SpaceQuotaUsage, error) {
+	quotas, err := q.quotas(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*types.ResourceUsage, len(quotas))
+	for i := range quotas {
+		if i > 0 && quotas[i].effective.IsEmpty() {
+			continue
+		}
+
+		usages[i], err = q.usage(ctx, quotas[i].space.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &types.SpaceQuotaUsage{
+		Quota:     quotas[0].configured,
+		Resources: make([]types.ResourceQuotaUsage, 0, len(enum.QuotaResources)),
+	}
+
+	for _, resource := range enum.QuotaResources {
+		resourceUsage := types.ResourceQuotaUsage{
+			Resource: resource,
+			Usage:    usages[0].Get(resource),
+		}
+
+		// the effective limit is the current usage plus the least amount still available in any quota.
+		for i := range quotas {
+			limit := quotas[i].effective.Limit(resource)
+			if limit == nil {
+				continue
+			}
+
+			effective := resourceUsage.Usage + *limit - usages[i].Get(resource)
+			if effective < 0 {
+				effective = 0
+			}
+
+			if resourceUsage.Limit == nil || effective < *resourceUsage.Limit {
+				resourceUsage.Limit = &effective
+				resourceUsage.LimitedBy = quotas[i].space.Path
+			}
+		}
+
+		res.Resources = append(res.Resources, resourceUsage)
+	}
+
+	return res, nil
+}
+
+func (q *Quota) checkRepo(
+	ctx context.Context,
+	repoID int64,
+	resource enum.QuotaResource,
+	errLimit error,
+) error {
+	repo, err := q.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	// a repo is out of a resource once its consumption reached the limit, hence checking for one more unit.
+	return q.check(ctx, repo.ParentID, resource, 1, errLimit)
+}
+
+// check returns errLimit if consuming the amount of the resource would exceed
+// the quota of the space or of any of its ancestors.
+func (q *Quota) check(
+	ctx context.Context,
+	spaceID int64,
+	resource enum.QuotaResource,
+	amount int64,
+	errLimit error,
+) error {
+	quotas, err := q.quotas(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range quotas {
+		limit := quota.effective.Limit(resource)
+		if limit == nil {
+			continue
+		}
+
+		usage, err := q.usage(ctx, quota.space.ID)
+		if err != nil {
+			return err
+		}
+
+		if usage.Get(resource)+amount > *limit {
+			return errLimit
+		}
+	}
+
+	return nil
+}
+
+// quotas returns the quotas of the space and all of its ancestors, starting with the space itself.
+func (q *Quota) quotas(ctx context.Context, spaceID int64) ([]spaceQuota, error) {
+	var quotas []spaceQuota
+	for spaceID > 0 {
+		space, err := q.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		configured, err := q.quotaStore.Find(ctx, spaceID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find quota of space %d: %w", spaceID, err)
+		}
+
+		effective := &types.SpaceQuota{SpaceID: spaceID}
+		if configured != nil {
+			*effective = *configured
+		}
+		if space.ParentID == 0 {
+			effective = q.withDefaults(effective)
+		}
+
+		quotas = append(quotas, spaceQuota{
+			space:      space,
+			configured: configured,
+			effective:  effective,
+		})
+
+		spaceID = space.ParentID
+	}
+
+	return quotas, nil
+}
+
+func (q *Quota) withDefaults(quota *types.SpaceQuota) *types.SpaceQuota {
+	res := *quota
+	if res.MaxRepos == nil {
+		res.MaxRepos = q.defaults.MaxRepos
+	}
+	if res.MaxRepoSize == nil {
+		res.MaxRepoSize = q.defaults.MaxRepoSize
+	}
+	if res.MaxBlobSize == nil {
+		res.MaxBlobSize = q.defaults.MaxBlobSize
+	}
+	if res.MaxPipelineMinutes == nil {
+		res.MaxPipelineMinutes = q.defaults.MaxPipelineMinutes
+	}
+	if res.MaxConcurrentExecutions == nil {
+		res.MaxConcurrentExecutions = q.defaults.MaxConcurrentExecutions
+	}
+	return &res
+}
+
+// usage returns the resource consumption of the space, pipeline minutes are counted per calendar month (UTC).
+func (q *Quota) usage(ctx context.Context, spaceID int64) (*types.ResourceUsage, error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usage, err := q.quotaStore.Usage(ctx, spaceID, monthStart.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource usage of space %d: %w", spaceID, err)
+	}
+
+	return usage, nil
+}
@@ -15,13 +15,44 @@
 package limiter
 
 import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
+	ProvideQuota,
 	ProvideLimiter,
 )
 
-func ProvideLimiter() (ResourceLimiter, error) {
-	return NewResourceLimiter(), nil
+func ProvideQuota(
+	config *types.Config,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	quotaStore store.QuotaStore,
+) *Quota {
+	return NewQuota(defaultQuota(config), spaceStore, repoStore, quotaStore)
+}
+
+func ProvideLimiter(quota *Quota) (ResourceLimiter, error) {
+	return quota, nil
+}
+
+// defaultQuota returns the default limits of top level spaces, a configured value of zero means unlimited.
+func defaultQuota(config *types.Config) types.SpaceQuota {
+	limit := func(value int64) *int64 {
+		if value <= 0 {
+			return nil
+		}
+		return &value
+	}
+
+	return types.SpaceQuota{
+		MaxRepos:                limit(config.Quota.MaxRepos),
+		MaxRepoSize:             limit(config.Quota.MaxRepoSize),
+		MaxBlobSize:             limit(config.Quota.MaxBlobSize),
+		MaxPipelineMinutes:      limit(config.Quota.MaxPipelineMinutes),
+		MaxConcurrentExecutions: limit(config.Quota.MaxConcurrentExecutions),
+	}
 }
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SanitizeMembershipRole validates the role of a space or repository membership and,
+// in case of a custom role, returns the custom role it refers to. Custom roles are resolved
+// in the provided space and its ancestor spaces, the closest definition wins.
+func SanitizeMembershipRole(
+	ctx context.Context,
+	spaceStore store.SpaceStore,
+	roleStore store.RoleStore,
+	spaceID int64,
+	role enum.MembershipRole,
+	customRole string,
+) (enum.MembershipRole, *types.Role, error) {
+	if role == "" && customRole != "" {
+		role = enum.MembershipRoleCustom
+	}
+
+	if role == "" {
+		return "", nil, usererror.BadRequest("Role must be provided")
+	}
+
+	role, ok := role.Sanitize()
+	if !ok {
+		return "", nil, usererror.BadRequestf("Provided role '%s' is not suppored. Valid values are: %v",
+			role, enum.MembershipRoles)
+	}
+
+	if role != enum.MembershipRoleCustom {
+		if customRole != "" {
+			return "", nil, usererror.BadRequestf("Custom role can only be provided with role '%s'",
+				enum.MembershipRoleCustom)
+		}
+
+		return role, nil, nil
+	}
+
+	if customRole == "" {
+		return "", nil, usererror.BadRequest("Custom role must be provided")
+	}
+
+	r, err := findRoleInHierarchy(ctx, spaceStore, roleStore, spaceID, customRole)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return role, r, nil
+}
+
+// findRoleInHierarchy finds the custom role in the space or the closest of its ancestor spaces defining it.
+func findRoleInHierarchy(
+	ctx context.Context,
+	spaceStore store.SpaceStore,
+	roleStore store.RoleStore,
+	spaceID int64,
+	identifier string,
+) (*types.Role, error) {
+	for spaceID != 0 {
+		r, err := roleStore.FindByIdentifier(ctx, spaceID, identifier)
+		if err == nil {
+			return r, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find custom role: %w", err)
+		}
+
+		space, err := spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return nil, usererror.BadRequestf("Custom role '%s' not found", identifier)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s *fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+type fakeRoleStore struct {
+	store.RoleStore
+	roles []*types.Role
+}
+
+func (s *fakeRoleStore) FindByIdentifier(_ context.Context, spaceID int64, identifier string) (*types.Role, error) {
+	for _, role := range s.roles {
+		if role.SpaceID == spaceID && role.Identifier == identifier {
+			return role, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestSanitizeMembershipRole_CustomRoleOfAncestor(t *testing.T) {
+	// space 1 is the grandparent of space 3.
+	spaceStore := &fakeSpaceStore{spaces: map[int64]*types.Space{
+		1: {ID: 1},
+		2: {ID: 2, ParentID: 1},
+		3: {ID: 3, ParentID: 2},
+	}}
+	roleStore := &fakeRoleStore{roles: []*types.Role{
+		{ID: 10, SpaceID: 1, Identifier: "reviewer"},
+		{ID: 11, SpaceID: 1, Identifier: "deployer"},
+		{ID: 12, SpaceID: 2, Identifier: "deployer"},
+	}}
+	ctx := context.Background()
+
+	role, customRole, err := SanitizeMembershipRole(ctx, spaceStore, roleStore, 3, "", "reviewer")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if role != enum.MembershipRoleCustom || customRole.ID != 10 {
+		t.Errorf("want the custom role of the grandparent space, got %s %d", role, customRole.ID)
+	}
+
+	// the closest definition of the role wins.
+	_, customRole, err = SanitizeMembershipRole(ctx, spaceStore, roleStore, 3, "", "deployer")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if customRole.ID != 12 {
+		t.Errorf("want the custom role of the parent space, got %d", customRole.ID)
+	}
+
+	_, _, err = SanitizeMembershipRole(ctx, spaceStore, roleStore, 3, "", "unknown")
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) {
+		t.Errorf("want a user error for an unknown role, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	notificationStore  store.NotificationStore
+	preferenceStore    store.NotificationPreferenceStore
+	repoStore          store.RepoStore
+	principalInfoCache store.PrincipalInfoCache
+	sseStreamer        sse.Streamer
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	notificationStore store.NotificationStore,
+	preferenceStore store.NotificationPreferenceStore,
+	repoStore store.RepoStore,
+	principalInfoCache store.PrincipalInfoCache,
+	sseStreamer sse.Streamer,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		notificationStore:  notificationStore,
+		preferenceStore:    preferenceStore,
+		repoStore:          repoStore,
+		principalInfoCache: principalInfoCache,
+		sseStreamer:        sseStreamer,
+	}
+}
+
+// checkUser ensures the inbox is only accessed by an authenticated user.
+func checkUser(session *auth.Session) error {
+	if session == nil {
+		return apiauth.ErrNotAuthenticated
+	}
+	if session.Principal.Type != enum.PrincipalTypeUser {
+		return apiauth.ErrNotAuthorized
+	}
+	return nil
+}
+
+// getRepoIDCheckAccess returns the ID of the referenced repository, or nil if no repository is referenced.
+func (c *Controller) getRepoIDCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*int64, error) {
+	if repoRef == "" {
+		return nil, nil
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return &repo.ID, nil
+}
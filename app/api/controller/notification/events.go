@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/sse"
+)
+
+// Events streams the notifications of the current user as they are created.
+func (c *Controller) Events(
+	ctx context.Context,
+	session *auth.Session,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	if err := checkUser(session); err != nil {
+		return nil, nil, nil, err
+	}
+
+	chEvents, chErr, sseCancel := c.sseStreamer.StreamPrincipal(ctx, session.Principal.ID)
+
+	return chEvents, chErr, sseCancel, nil
+}
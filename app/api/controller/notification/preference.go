@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type PreferenceInput struct {
+	// RepoRef is the repository the preference applies to. If empty, the preference applies to all repositories.
+	RepoRef  string                    `json:"repo_ref"`
+	Type     enum.NotificationType     `json:"type"`
+	Delivery enum.NotificationDelivery `json:"delivery"`
+}
+
+func (in *PreferenceInput) sanitize() error {
+	notificationType, ok := in.Type.Sanitize()
+	if !ok || notificationType == "" {
+		return usererror.BadRequestf("Invalid notification type '%s'.", in.Type)
+	}
+	in.Type = notificationType
+
+	delivery, ok := in.Delivery.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid notification delivery '%s'.", in.Delivery)
+	}
+	in.Delivery = delivery
+
+	return nil
+}
+
+// ListPreferences returns the notification preferences of the current user.
+func (c *Controller) ListPreferences(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.NotificationPreference, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.preferenceStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// UpdatePreference sets how emails of a notification type are delivered to the current user,
+// either for all repositories or for a specific one.
+func (c *Controller) UpdatePreference(
+	ctx context.Context,
+	session *auth.Session,
+	in *PreferenceInput,
+) (*types.NotificationPreference, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repoID, err := c.getRepoIDCheckAccess(ctx, session, in.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	pref := &types.NotificationPreference{
+		PrincipalID: session.Principal.ID,
+		RepoID:      repoID,
+		Type:        in.Type,
+		Delivery:    in.Delivery,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.preferenceStore.Upsert(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to update notification preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+// DeletePreference resets the delivery of a notification type for the current user to the default.
+func (c *Controller) DeletePreference(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	notificationType enum.NotificationType,
+) error {
+	if err := checkUser(session); err != nil {
+		return err
+	}
+
+	sanitizedType, ok := notificationType.Sanitize()
+	if !ok || sanitizedType == "" {
+		return usererror.BadRequestf("Invalid notification type '%s'.", notificationType)
+	}
+
+	repoID, err := c.getRepoIDCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return err
+	}
+
+	err = c.preferenceStore.Delete(ctx, session.Principal.ID, repoID, sanitizedType)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification preference: %w", err)
+	}
+
+	return nil
+}
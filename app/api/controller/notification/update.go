@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+type UpdateInput struct {
+	Read bool `json:"read"`
+}
+
+// Update marks a notification in the inbox of the current user as read or unread.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	notificationID int64,
+	in *UpdateInput,
+) (*types.Notification, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	principalID := session.Principal.ID
+
+	err := c.notificationStore.UpdateRead(ctx, principalID, notificationID, in.Read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification: %w", err)
+	}
+
+	notification, err := c.notificationStore.Find(ctx, principalID, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+type MarkAllReadOutput struct {
+	Count int64 `json:"count"`
+}
+
+// MarkAllRead marks all unread notifications in the inbox of the current user as read.
+func (c *Controller) MarkAllRead(
+	ctx context.Context,
+	session *auth.Session,
+) (*MarkAllReadOutput, error) {
+	if err := checkUser(session); err != nil {
+		return nil, err
+	}
+
+	count, err := c.notificationStore.UpdateAllRead(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return &MarkAllReadOutput{Count: count}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Parent is the repository or space a resource (like a webhook or an environment) belongs to.
+type Parent[T ~string] struct {
+	Type T
+	ID   int64
+	Path string
+
+	// SpaceID is the ID of the space the parent is part of.
+	SpaceID int64
+	// Repo is the repository in case the parent is a repository.
+	Repo *types.Repository
+}
+
+// SpacePath returns the path of the space the parent is part of.
+func (p *Parent[T]) SpacePath() string {
+	if p.Repo != nil {
+		return paths.Parent(p.Path)
+	}
+	return p.Path
+}
+
+// AuditData returns the audit log data identifying the parent.
+func (p *Parent[T]) AuditData() audit.FuncOption {
+	return audit.WithData(string(p.Type)+"_path", p.Path)
+}
+
+// spacePermissions maps the repo permissions required for operations on resources of a parent
+// to their space counterparts.
+var spacePermissions = map[enum.Permission]enum.Permission{
+	enum.PermissionRepoView: enum.PermissionSpaceView,
+	enum.PermissionRepoEdit: enum.PermissionSpaceEdit,
+}
+
+// GetRepoParentCheckAccess finds the repository parent and checks the required permission.
+func GetRepoParentCheckAccess[T ~string](
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	session *auth.Session,
+	parentType T,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*Parent[T], error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, authorizer, session, repo, reqPermission, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return &Parent[T]{Type: parentType, ID: repo.ID, Path: repo.Path, SpaceID: repo.ParentID, Repo: repo}, nil
+}
+
+// GetSpaceParentCheckAccess finds the space parent and checks the required permission.
+// The permission is provided as repo permission and translated to its space counterpart.
+func GetSpaceParentCheckAccess[T ~string](
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	session *auth.Session,
+	parentType T,
+	spaceRef string,
+	reqPermission enum.Permission,
+) (*Parent[T], error) {
+	if spaceRef == "" {
+		return nil, usererror.BadRequest("A valid space reference must be provided.")
+	}
+
+	space, err := spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	err = apiauth.CheckSpace(ctx, authorizer, session, space, spacePermissions[reqPermission], false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return &Parent[T]{Type: parentType, ID: space.ID, Path: space.Path, SpaceID: space.ID}, nil
+}
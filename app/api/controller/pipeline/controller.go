@@ -16,7 +16,10 @@ package pipeline
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 )
 
 type Controller struct {
@@ -25,6 +28,9 @@ type Controller struct {
 	triggerStore  store.TriggerStore
 	authorizer    authz.Authorizer
 	pipelineStore store.PipelineStore
+	auditService  audit.Service
+	commitService commit.Service
+	triggerer     triggerer.Triggerer
 }
 
 func NewController(
@@ -32,11 +38,17 @@ func NewController(
 	repoStore store.RepoStore,
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
+	auditService audit.Service,
+	commitService commit.Service,
+	triggerer triggerer.Triggerer,
 ) *Controller {
 	return &Controller{
 		repoStore:     repoStore,
 		triggerStore:  triggerStore,
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
+		auditService:  auditService,
+		commitService: commitService,
+		triggerer:     triggerer,
 	}
 }
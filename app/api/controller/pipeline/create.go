@@ -23,6 +23,9 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -44,6 +47,9 @@ type CreateInput struct {
 	Disabled      bool   `json:"disabled"`
 	DefaultBranch string `json:"default_branch"`
 	ConfigPath    string `json:"config_path"`
+
+	ConcurrencyGroup string `json:"concurrency_group"`
+	ConcurrencyLimit int    `json:"concurrency_limit"`
 }
 
 func (c *Controller) Create(
@@ -80,12 +86,27 @@ func (c *Controller) Create(
 		Created:       now,
 		Updated:       now,
 		Version:       0,
+
+		ConcurrencyGroup: in.ConcurrencyGroup,
+		ConcurrencyLimit: in.ConcurrencyLimit,
 	}
 	err = c.pipelineStore.Create(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("pipeline creation failed: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypePipeline, pipeline.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(pipeline),
+		audit.WithData("repo_path", repo.Path),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create pipeline operation: %s", err)
+	}
+
 	// Try to create a default trigger on pipeline creation.
 	// Default trigger operations are set on pull request created, reopened or updated.
 	// We log an error on failure but don't fail the op.
@@ -133,5 +154,23 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return errPipelineRequiresConfigPath
 	}
 
+	in.ConcurrencyGroup = strings.TrimSpace(in.ConcurrencyGroup)
+	if err := sanitizeConcurrency(in.ConcurrencyGroup, in.ConcurrencyLimit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sanitizeConcurrency verifies the concurrency group key expression and limit of a pipeline.
+func sanitizeConcurrency(group string, limit int) error {
+	if err := triggerer.ValidateConcurrencyGroup(group); err != nil {
+		return usererror.BadRequest(err.Error())
+	}
+
+	if limit < 0 || limit > triggerer.MaxConcurrencyLimit {
+		return usererror.BadRequestf("Concurrency limit must be between 0 and %d.", triggerer.MaxConcurrencyLimit)
+	}
+
 	return nil
 }
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"golang.org/x/exp/slices"
+)
+
+// maxValidateContentSize is the maximum size of the pipeline yaml provided for validation.
+const maxValidateContentSize = 1 << 20 // 1 MB
+
+type ValidateInput struct {
+	// Pipeline is the optional identifier of an existing pipeline.
+	// Its config path is used if none is provided, and its triggers are matched against the action.
+	Pipeline   string `json:"pipeline"`
+	ConfigPath string `json:"config_path"`
+	// Content is the pipeline yaml to validate. If empty, the yaml is read from the config path at the branch.
+	Content string `json:"content"`
+	// Branch is the branch the pipeline would run for, defaults to the default branch.
+	Branch string `json:"branch"`
+	// TargetBranch is the target branch of pull request actions, defaults to the branch.
+	TargetBranch string `json:"target_branch"`
+	// Action is the trigger action the stages are matched against. If empty, the stages are matched
+	// against a manual execution.
+	Action enum.TriggerAction `json:"action"`
+}
+
+// Validate runs the pipeline yaml through the conversion, template and plugin resolution and parsing
+// an execution would go through, and returns the errors of the yaml along with the expanded stages.
+// Nothing gets executed.
+func (c *Controller) Validate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ValidateInput,
+) (*types.PipelineValidation, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, in.Pipeline, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	pipeline := &types.Pipeline{
+		RepoID: repo.ID,
+	}
+	if in.Pipeline != "" {
+		pipeline, err = c.pipelineStore.FindByIdentifier(ctx, repo.ID, in.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pipeline: %w", err)
+		}
+	}
+
+	// If the pipeline doesn't specify a default branch, use the repo default branch.
+	defaultBranch := pipeline.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = repo.DefaultBranch
+	}
+
+	if err = sanitizeValidateInput(in, pipeline.ConfigPath, defaultBranch); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	// the config path decides about the conversion, hence validate a copy of the pipeline using the provided one.
+	validated := *pipeline
+	validated.ConfigPath = in.ConfigPath
+
+	ref := scm.ExpandRef(in.Branch, "refs/heads")
+
+	commit, err := c.commitService.FindRef(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     session.Principal.UID,
+		TriggeredBy: session.Principal.ID,
+		Action:      in.Action,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Ref:         ref,
+		Message:     commit.Message,
+		Title:       commit.Title,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Sender:      session.Principal.UID,
+		Source:      in.Branch,
+		Target:      in.TargetBranch,
+		Params:      map[string]string{},
+		Timestamp:   commit.Author.When.UnixMilli(),
+	}
+
+	var content *file.File
+	if in.Content != "" {
+		content = &file.File{Data: []byte(in.Content)}
+	}
+
+	result, err := c.triggerer.DryRun(ctx, &validated, hook, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pipeline: %w", err)
+	}
+
+	if pipeline.ID == 0 {
+		return result, nil
+	}
+
+	triggers, err := c.triggerStore.List(ctx, pipeline.ID, types.ListQueryFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list triggers: %w", err)
+	}
+
+	for _, trigger := range triggers {
+		result.Triggers = append(result.Triggers, &types.PipelineValidationTrigger{
+			Identifier: trigger.Identifier,
+			Actions:    trigger.Actions,
+			Disabled:   trigger.Disabled,
+			Matched:    !trigger.Disabled && slices.Contains(trigger.Actions, in.Action),
+		})
+	}
+
+	return result, nil
+}
+
+func sanitizeValidateInput(in *ValidateInput, configPath string, defaultBranch string) error {
+	if in.ConfigPath == "" {
+		in.ConfigPath = configPath
+	}
+	if in.ConfigPath == "" {
+		return errPipelineRequiresConfigPath
+	}
+
+	if len(in.Content) > maxValidateContentSize {
+		return usererror.BadRequestf("Pipeline content can have at most %d bytes.", maxValidateContentSize)
+	}
+
+	if in.Branch == "" {
+		in.Branch = defaultBranch
+	}
+	if in.TargetBranch == "" {
+		in.TargetBranch = in.Branch
+	}
+
+	if in.Action != "" {
+		action, ok := in.Action.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid trigger action %q.", in.Action)
+		}
+		in.Action = action
+	}
+
+	return nil
+}
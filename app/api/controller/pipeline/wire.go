@@ -16,7 +16,10 @@ package pipeline
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 
 	"github.com/google/wire"
 )
@@ -31,11 +34,17 @@ func ProvideController(
 	triggerStore store.TriggerStore,
 	authorizer authz.Authorizer,
 	pipelineStore store.PipelineStore,
+	auditService audit.Service,
+	commitService commit.Service,
+	triggerer triggerer.Triggerer,
 ) *Controller {
 	return NewController(
 		authorizer,
 		repoStore,
 		triggerStore,
 		pipelineStore,
+		auditService,
+		commitService,
+		triggerer,
 	)
 }
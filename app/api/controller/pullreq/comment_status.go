@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -66,6 +67,7 @@ func (c *Controller) CommentStatus(
 
 	var pr *types.PullReq
 	var act *types.PullReqActivity
+	var changed bool
 
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
 		pr, err = c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
@@ -82,7 +84,8 @@ func (c *Controller) CommentStatus(
 			return fmt.Errorf("failed to get comment: %w", err)
 		}
 
-		if !in.hasChanges(act, session.Principal.ID) {
+		changed = in.hasChanges(act, session.Principal.ID)
+		if !changed {
 			return nil
 		}
 
@@ -129,5 +132,14 @@ func (c *Controller) CommentStatus(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
 
+	if changed {
+		c.eventReporter.CommentStatusUpdated(ctx, &events.CommentStatusUpdatedPayload{
+			Base:       eventBase(pr, &session.Principal),
+			ActivityID: act.ID,
+			SourceSHA:  pr.SourceSHA,
+			Status:     in.Status,
+		})
+	}
+
 	return act, nil
 }
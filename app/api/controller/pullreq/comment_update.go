@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -106,6 +107,13 @@ func (c *Controller) CommentUpdate(
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
 
+	c.eventReporter.CommentUpdated(ctx, &events.CommentUpdatedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ActivityID: act.ID,
+		SourceSHA:  pr.SourceSHA,
+		IsReply:    act.IsReply(),
+	})
+
 	// Populate activity mentions (used only for response purposes).
 	act.Mentions = principalInfos
 
@@ -28,9 +28,11 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/testreport"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
@@ -62,6 +64,8 @@ type Controller struct {
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
 	locker              *locker.Locker
+	auditService        audit.Service
+	testReportService   *testreport.Service
 }
 
 func NewController(
@@ -87,6 +91,8 @@ func NewController(
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
 	locker *locker.Locker,
+	auditService audit.Service,
+	testReportService *testreport.Service,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -111,6 +117,8 @@ func NewController(
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
 		locker:              locker,
+		auditService:        auditService,
+		testReportService:   testReportService,
 	}
 }
 
@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,8 +27,10 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
@@ -435,6 +438,24 @@ func (c *Controller) Merge(
 		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
 	}
 
+	if activityPayload.RulesBypassed {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypePullRequest, strconv.FormatInt(pr.Number, 10)),
+			audit.ActionBypassed,
+			paths.Parent(targetRepo.Path),
+			audit.WithNewObject(violations),
+			audit.WithData(
+				"repo_path", targetRepo.Path,
+				"merge_method", string(in.Method),
+				"merge_sha", activityPayload.MergeSHA,
+			),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for merge pull request operation: %s", err)
+		}
+	}
+
 	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
 		Base:        eventBase(pr, &session.Principal),
 		MergeMethod: in.Method,
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	events "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -39,5 +40,11 @@ func (c *Controller) ReviewerDelete(ctx context.Context, session *auth.Session,
 	if err != nil {
 		return fmt.Errorf("failed to delete reviewer: %w", err)
 	}
+
+	c.eventReporter.ReviewerRemoved(ctx, &events.ReviewerRemovedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ReviewerID: reviewerID,
+	})
+
 	return nil
 }
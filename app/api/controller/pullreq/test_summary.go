@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TestSummary returns the summary of the test reports of the pull request's latest commit,
+// including the tests that newly fail compared with the target branch and the known flaky tests.
+func (c *Controller) TestSummary(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (types.PullReqTestSummary, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return types.PullReqTestSummary{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return types.PullReqTestSummary{}, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	summary, err := c.testReportService.PullReqSummary(ctx, repo.ID, pr)
+	if err != nil {
+		return types.PullReqTestSummary{}, fmt.Errorf("failed to summarize test reports: %w", err)
+	}
+
+	return summary, nil
+}
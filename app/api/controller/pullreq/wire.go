@@ -22,9 +22,11 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/testreport"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -45,7 +47,8 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	checkStore store.CheckStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service, locker *locker.Locker,
+	codeOwners *codeowners.Service, locker *locker.Locker, auditService audit.Service,
+	testReportService *testreport.Service,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -56,5 +59,6 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners, locker)
+		pullreqService, ruleManager, sseStreamer, codeOwners, locker, auditService,
+		testReportService)
 }
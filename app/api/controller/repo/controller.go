@@ -51,28 +51,31 @@ type Controller struct {
 	defaultBranch                 string
 	publicResourceCreationEnabled bool
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	settings           *settings.Service
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	locker             *locker.Locker
-	auditService       audit.Service
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
-	repoCheck          Check
+	tx                  dbtx.Transactor
+	urlProvider         url.Provider
+	authorizer          authz.Authorizer
+	repoStore           store.RepoStore
+	spaceStore          store.SpaceStore
+	pipelineStore       store.PipelineStore
+	principalStore      store.PrincipalStore
+	ruleStore           store.RuleStore
+	membershipStore     store.MembershipStore
+	repoMembershipStore store.RepoMembershipStore
+	roleStore           store.RoleStore
+	settings            *settings.Service
+	principalInfoCache  store.PrincipalInfoCache
+	protectionManager   *protection.Manager
+	git                 git.Interface
+	importer            *importer.Repository
+	codeOwners          *codeowners.Service
+	eventReporter       *repoevents.Reporter
+	indexer             keywordsearch.Indexer
+	resourceLimiter     limiter.ResourceLimiter
+	locker              *locker.Locker
+	auditService        audit.Service
+	mtxManager          lock.MutexManager
+	identifierCheck     check.RepoIdentifier
+	repoCheck           Check
 }
 
 func NewController(
@@ -85,6 +88,9 @@ func NewController(
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
 	ruleStore store.RuleStore,
+	membershipStore store.MembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
@@ -111,6 +117,9 @@ func NewController(
 		pipelineStore:                 pipelineStore,
 		principalStore:                principalStore,
 		ruleStore:                     ruleStore,
+		membershipStore:               membershipStore,
+		repoMembershipStore:           repoMembershipStore,
+		roleStore:                     roleStore,
 		settings:                      settings,
 		principalInfoCache:            principalInfoCache,
 		protectionManager:             protectionManager,
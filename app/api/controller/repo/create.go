@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
@@ -134,6 +135,11 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create repository operation: %s", err)
 	}
 
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
 	// backfil GitURL
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
 
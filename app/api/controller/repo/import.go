@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/audit"
@@ -110,6 +111,12 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		log.Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
 	}
 
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		IsImport:    true,
+	})
+
 	return repo, nil
 }
 
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type MembershipAddInput struct {
+	UserUID    string              `json:"user_uid"`
+	Role       enum.MembershipRole `json:"role"`
+	CustomRole string              `json:"custom_role"`
+}
+
+func (in *MembershipAddInput) Validate() error {
+	if in.UserUID == "" {
+		return usererror.BadRequest("UserUID must be provided")
+	}
+
+	return nil
+}
+
+// MembershipAdd adds a new membership to a repository.
+// Custom roles of repository memberships are resolved in the ancestor spaces of the repository.
+func (c *Controller) MembershipAdd(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MembershipAddInput,
+) (*types.RepoMembershipUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	role, customRole, err := controller.SanitizeMembershipRole(ctx, c.spaceStore, c.roleStore, repo.ParentID,
+		in.Role, in.CustomRole)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := types.RepoMembership{
+		RepoMembershipKey: types.RepoMembershipKey{
+			RepoID:      repo.ID,
+			PrincipalID: user.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      role,
+	}
+
+	if customRole != nil {
+		membership.CustomRoleID = &customRole.ID
+		membership.CustomRole = customRole.Identifier
+	}
+
+	err = c.repoMembershipStore.Create(ctx, &membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new repository membership: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepoMembership, user.UID),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(membership),
+		audit.WithData("repo_path", repo.Path),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add repository membership operation: %s", err)
+	}
+
+	result := &types.RepoMembershipUser{
+		RepoMembership: membership,
+		Principal:      *user.ToPrincipalInfo(),
+		AddedBy:        *session.Principal.ToPrincipalInfo(),
+	}
+
+	return result, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// MembershipEffective returns the effective access of a user to a repository:
+// the direct membership of the repository and all memberships inherited from its ancestor spaces,
+// together with the union of the permissions they grant.
+func (c *Controller) MembershipEffective(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	userUID string,
+) (*types.RepoMembershipEffective, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	result := &types.RepoMembershipEffective{
+		Principal:   *user.ToPrincipalInfo(),
+		Memberships: []types.EffectiveMembership{},
+	}
+
+	var permissions []enum.Permission
+
+	repoMembership, err := c.repoMembershipStore.Find(ctx, types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: user.ID,
+	})
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repository membership: %w", err)
+	}
+
+	if repoMembership != nil {
+		rolePermissions, err := c.membershipRolePermissions(ctx, repoMembership.Role, repoMembership.CustomRoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, rolePermissions...)
+		result.Memberships = append(result.Memberships, types.EffectiveMembership{
+			Role:       repoMembership.Role,
+			CustomRole: repoMembership.CustomRole,
+		})
+	}
+
+	for spaceID := repo.ParentID; spaceID > 0; {
+		space, err := c.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space with id %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+
+		membership, err := c.membershipStore.Find(ctx, types.MembershipKey{
+			SpaceID:     space.ID,
+			PrincipalID: user.ID,
+		})
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find membership of space '%s': %w", space.Path, err)
+		}
+
+		rolePermissions, err := c.membershipRolePermissions(ctx, membership.Role, membership.CustomRoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, rolePermissions...)
+		result.Memberships = append(result.Memberships, types.EffectiveMembership{
+			Role:          membership.Role,
+			CustomRole:    membership.CustomRole,
+			InheritedFrom: space.Path,
+		})
+	}
+
+	slices.Sort(permissions)
+	result.Permissions = slices.Compact(permissions)
+	if result.Permissions == nil {
+		result.Permissions = []enum.Permission{}
+	}
+
+	return result, nil
+}
+
+// membershipRolePermissions returns the permissions granted by a membership role.
+func (c *Controller) membershipRolePermissions(
+	ctx context.Context,
+	role enum.MembershipRole,
+	customRoleID *int64,
+) ([]enum.Permission, error) {
+	if role != enum.MembershipRoleCustom {
+		return role.Permissions(), nil
+	}
+
+	if customRoleID == nil {
+		return nil, nil
+	}
+
+	customRole, err := c.roleStore.Find(ctx, *customRoleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom role with id %d: %w", *customRoleID, err)
+	}
+
+	return customRole.Permissions, nil
+}
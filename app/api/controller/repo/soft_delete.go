@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
@@ -75,6 +76,12 @@ func (c *Controller) SoftDelete(
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete repository operation: %s", err)
 	}
 
+	c.eventReporter.SoftDeleted(ctx, &repoevents.SoftDeletedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		DeletedAt:   now,
+	})
+
 	return &SoftDeleteResponse{DeletedAt: now}, nil
 }
 
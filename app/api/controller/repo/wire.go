@@ -51,6 +51,9 @@ func ProvideController(
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
 	ruleStore store.RuleStore,
+	membershipStore store.MembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
@@ -69,7 +72,7 @@ func ProvideController(
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
-		principalStore, ruleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
+		principalStore, ruleStore, membershipStore, repoMembershipStore, roleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
 		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck, repoChecks)
 }
 
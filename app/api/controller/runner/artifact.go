@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MaxArtifactSize is the maximum size of a single artifact, enforced by the handler.
+	MaxArtifactSize = 512 << 20 // 512 MB
+
+	// maxArtifactNameLength is the maximum length of the name of an artifact.
+	maxArtifactNameLength = 255
+
+	artifactBlobPathFmt        = "artifacts/%d/%d/%s"
+	defaultArtifactContentType = "application/octet-stream"
+)
+
+// UploadArtifact stores a file produced by a stage assigned to the runner as artifact of its execution.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	name string,
+	contentType string,
+	file io.Reader,
+) (*types.Artifact, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.getStageVerifyAssignment(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.uploadArtifact(ctx, stage, name, contentType, file)
+}
+
+// UploadStepArtifact stores a file produced by a pipeline step as artifact of its execution.
+// Steps authenticate with the artifact token of their stage, which works for embedded and remote runners alike.
+func (c *Controller) UploadStepArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	contentType string,
+	file io.Reader,
+) (*types.Artifact, error) {
+	if session == nil {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+
+	metadata, ok := session.Metadata.(*auth.ArtifactMetadata)
+	if !ok {
+		return nil, apiauth.ErrNotAuthorized
+	}
+
+	stage, err := c.stageStore.Find(ctx, metadata.StageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	return c.uploadArtifact(ctx, stage, name, contentType, file)
+}
+
+func (c *Controller) uploadArtifact(
+	ctx context.Context,
+	stage *types.Stage,
+	name string,
+	contentType string,
+	file io.Reader,
+) (*types.Artifact, error) {
+	if stage.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can't be uploaded for a finished stage.")
+	}
+
+	if err := validateArtifactName(name); err != nil {
+		return nil, err
+	}
+
+	if contentType == "" {
+		contentType = defaultArtifactContentType
+	}
+
+	_, err := c.artifactStore.FindByName(ctx, stage.ExecutionID, name)
+	if err == nil {
+		return nil, usererror.Conflict(fmt.Sprintf("An artifact with name %q already exists.", name))
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find artifact by name: %w", err)
+	}
+
+	// the size of the file is only known once it's uploaded, hence a single upload can exceed the limit.
+	if err = c.limiter.BlobSize(ctx, stage.RepoID); err != nil {
+		return nil, fmt.Errorf("resource limit exceeded: %w", err)
+	}
+
+	// artifact names can contain slashes, hence the blob path uses a random identifier instead.
+	blobPath := fmt.Sprintf(artifactBlobPathFmt, stage.RepoID, stage.ExecutionID, uuid.New().String())
+
+	counter := blob.NewCountingReader(file)
+	if err = c.blobStore.Upload(ctx, counter, blobPath); err != nil {
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	artifact := &types.Artifact{
+		RepoID:      stage.RepoID,
+		ExecutionID: stage.ExecutionID,
+		StageID:     stage.ID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        counter.Count(),
+		BlobPath:    blobPath,
+		Created:     time.Now().UnixMilli(),
+	}
+	if err = c.artifactStore.Create(ctx, artifact); err != nil {
+		if dErr := c.blobStore.Delete(ctx, blobPath); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msgf("failed to delete blob of artifact %q", name)
+		}
+		return nil, fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	if err = c.quotaStore.AddBlobSize(ctx, stage.RepoID, artifact.Size); err != nil {
+		// not critical, the artifact is stored already.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update blob size of repo for artifact %q", name)
+	}
+
+	artifact.URL, err = c.getArtifactURL(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+// getArtifactURL returns the url of the artifact, which can be used as link of a check result.
+func (c *Controller) getArtifactURL(ctx context.Context, artifact *types.Artifact) (string, error) {
+	execution, err := c.executionStore.Find(ctx, artifact.ExecutionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.Find(ctx, execution.PipelineID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	repo, err := c.repoStore.Find(ctx, artifact.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	return c.urlProvider.GenerateAPIArtifactURL(repo.Path, pipeline.Identifier, execution.Number, artifact.ID), nil
+}
+
+// validateArtifactName ensures the name is a clean relative path, e.g. "dist/app.tar.gz".
+func validateArtifactName(name string) error {
+	if name == "" {
+		return usererror.BadRequest("Artifact name is required.")
+	}
+
+	if len(name) > maxArtifactNameLength {
+		return usererror.BadRequestf("Artifact name can have at most %d characters.", maxArtifactNameLength)
+	}
+
+	if strings.ContainsAny(name, "\\\x00") || path.IsAbs(name) || path.Clean(name) != name ||
+		name == ".." || strings.HasPrefix(name, "../") {
+		return usererror.BadRequest("Artifact name has to be a clean relative path.")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestUploadStepArtifact_Authorization(t *testing.T) {
+	c := &Controller{
+		stageStore: &fakeStageStore{stage: &types.Stage{ID: 10, Status: enum.CIStatusSuccess}},
+	}
+
+	tests := []struct {
+		name     string
+		metadata auth.Metadata
+		expErr   error
+	}{
+		{
+			name:   "no-token",
+			expErr: apiauth.ErrNotAuthorized,
+		},
+		{
+			name:     "cache-token",
+			metadata: &auth.CacheMetadata{RepoID: 1, Branch: "main", Write: true},
+			expErr:   apiauth.ErrNotAuthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{Metadata: test.metadata}
+			_, err := c.UploadStepArtifact(context.Background(), session, "report.xml", "", strings.NewReader(""))
+			if !errors.Is(err, test.expErr) {
+				t.Errorf("expected error %v, got %v", test.expErr, err)
+			}
+		})
+	}
+
+	// the artifact token is only valid while its stage is running.
+	session := &auth.Session{Metadata: &auth.ArtifactMetadata{StageID: 10}}
+	_, err := c.UploadStepArtifact(context.Background(), session, "report.xml", "", strings.NewReader(""))
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
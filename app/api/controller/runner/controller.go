@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/crypto"
+	"github.com/harness/gitness/app/services/testreport"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	runnerclient "github.com/drone/runner-go/client"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// runnerTokenLength is the number of random bytes of a runner token.
+	runnerTokenLength = 32
+)
+
+var errStageNotAssigned = usererror.Forbidden("The stage isn't assigned to the runner.")
+
+type Controller struct {
+	runnerStore    store.RunnerStore
+	repoStore      store.RepoStore
+	pipelineStore  store.PipelineStore
+	executionStore store.ExecutionStore
+	stageStore     store.StageStore
+	stepStore      store.StepStore
+	artifactStore  store.ArtifactStore
+	quotaStore     store.QuotaStore
+	blobStore      blob.Store
+	testReportSvc  *testreport.Service
+	limiter        limiter.ResourceLimiter
+	urlProvider    url.Provider
+	client         runnerclient.Client
+	auditService   audit.Service
+	authorizer     authz.Authorizer
+}
+
+func NewController(
+	runnerStore store.RunnerStore,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	artifactStore store.ArtifactStore,
+	quotaStore store.QuotaStore,
+	blobStore blob.Store,
+	testReportSvc *testreport.Service,
+	limiter limiter.ResourceLimiter,
+	urlProvider url.Provider,
+	client runnerclient.Client,
+	auditService audit.Service,
+	authorizer authz.Authorizer,
+) *Controller {
+	return &Controller{
+		runnerStore:    runnerStore,
+		repoStore:      repoStore,
+		pipelineStore:  pipelineStore,
+		executionStore: executionStore,
+		stageStore:     stageStore,
+		stepStore:      stepStore,
+		artifactStore:  artifactStore,
+		quotaStore:     quotaStore,
+		blobStore:      blobStore,
+		testReportSvc:  testReportSvc,
+		limiter:        limiter,
+		urlProvider:    urlProvider,
+		client:         client,
+		auditService:   auditService,
+		authorizer:     authorizer,
+	}
+}
+
+// authenticate finds the runner the token belongs to and records that the runner was seen.
+func (c *Controller) authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	if token == "" {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+
+	runner, err := c.runnerStore.FindByTokenHash(ctx, crypto.HashRandomSecret(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	runner.LastSeen = time.Now().UnixMilli()
+	if err = c.runnerStore.Update(ctx, runner); err != nil {
+		// not critical, the runner can still be served.
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last seen of runner %q", runner.Identifier)
+	}
+
+	return runner, nil
+}
+
+// getStageVerifyAssignment finds the stage and ensures it's assigned to the runner.
+func (c *Controller) getStageVerifyAssignment(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+) (*types.Stage, error) {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != runner.Machine() {
+		return nil, errStageNotAssigned
+	}
+
+	return stage, nil
+}
+
+// getStepVerifyAssignment finds the step and ensures its stage is assigned to the runner.
+func (c *Controller) getStepVerifyAssignment(
+	ctx context.Context,
+	runner *types.Runner,
+	stepID int64,
+) (*types.Step, error) {
+	step, err := c.stepStore.Find(ctx, stepID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find step: %w", err)
+	}
+
+	if _, err = c.getStageVerifyAssignment(ctx, runner, step.StageID); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+// generateToken generates a new random runner token.
+func generateToken() (string, error) {
+	b := make([]byte, runnerTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/crypto"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// runnerMaxLabels defines the max allowed number of labels of a runner.
+	runnerMaxLabels = 50
+	// runnerMaxLabelLength defines the max allowed length of a label key or value.
+	runnerMaxLabelLength = 256
+)
+
+type CreateInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
+}
+
+// Create registers a new remote runner and returns its token.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+) (*types.RunnerResponse, error) {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate runner token: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	runner := &types.Runner{
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Labels:      in.Labels,
+		TokenHash:   crypto.HashRandomSecret(token),
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.runnerStore.Create(ctx, runner); err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRunner, runner.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(runner),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create runner operation: %s", err)
+	}
+
+	return &types.RunnerResponse{Token: token, Runner: *runner}, nil
+}
+
+func sanitizeCreateInput(in *CreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	if len(in.Labels) > runnerMaxLabels {
+		return check.NewValidationErrorf("A runner can have at most %d labels.", runnerMaxLabels)
+	}
+	for key, value := range in.Labels {
+		if key == "" {
+			return check.NewValidationError("Label keys can't be empty.")
+		}
+		if len(key) > runnerMaxLabelLength || len(value) > runnerMaxLabelLength {
+			return check.NewValidationErrorf("Label keys and values can be at most %d characters long.",
+				runnerMaxLabelLength)
+		}
+	}
+	if len(in.Labels) == 0 {
+		in.Labels = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Delete deletes a remote runner. Its token becomes invalid immediately,
+// stages the runner is still executing are put back into the queue.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	identifier string,
+) error {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return err
+	}
+
+	runner, err := c.runnerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	if err = c.runnerStore.Delete(ctx, runner.ID); err != nil {
+		return fmt.Errorf("failed to delete runner: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRunner, runner.Identifier),
+		audit.ActionDeleted,
+		"",
+		audit.WithOldObject(runner),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete runner operation: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// Find finds a remote runner.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	identifier string,
+) (*types.Runner, error) {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return nil, err
+	}
+
+	runner, err := c.runnerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	return runner, nil
+}
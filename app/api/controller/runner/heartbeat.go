@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// runnerMaxPlatformLength defines the max allowed length of the platform details reported by a runner.
+const runnerMaxPlatformLength = 64
+
+type HeartbeatInput struct {
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Version string `json:"version"`
+}
+
+// Heartbeat records that the runner is alive and updates the platform details it reports.
+// Runners have to send heartbeats regularly, also while executing stages,
+// otherwise they are considered stale and their stages get reclaimed.
+func (c *Controller) Heartbeat(
+	ctx context.Context,
+	token string,
+	in *HeartbeatInput,
+) (*types.Runner, error) {
+	if err := sanitizeHeartbeatInput(in); err != nil {
+		return nil, err
+	}
+
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if runner.OS == in.OS && runner.Arch == in.Arch && runner.Version == in.Version {
+		return runner, nil
+	}
+
+	runner.OS = in.OS
+	runner.Arch = in.Arch
+	runner.Version = in.Version
+	runner.Updated = runner.LastSeen
+
+	if err = c.runnerStore.Update(ctx, runner); err != nil {
+		return nil, fmt.Errorf("failed to update runner: %w", err)
+	}
+
+	return runner, nil
+}
+
+func sanitizeHeartbeatInput(in *HeartbeatInput) error {
+	if len(in.OS) > runnerMaxPlatformLength ||
+		len(in.Arch) > runnerMaxPlatformLength ||
+		len(in.Version) > runnerMaxPlatformLength {
+		return check.NewValidationErrorf("The platform details can be at most %d characters long.",
+			runnerMaxPlatformLength)
+	}
+
+	return nil
+}
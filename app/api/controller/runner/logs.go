@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drone/drone-go/drone"
+)
+
+// WriteLogs appends lines to the live log stream of a step of a stage assigned to the runner.
+func (c *Controller) WriteLogs(
+	ctx context.Context,
+	token string,
+	stepID int64,
+	lines []*drone.Line,
+) error {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.getStepVerifyAssignment(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	if err = c.client.Batch(ctx, stepID, lines); err != nil {
+		return fmt.Errorf("failed to write logs: %w", err)
+	}
+
+	return nil
+}
+
+// UploadLogs stores the complete logs of a finished step of a stage assigned to the runner.
+func (c *Controller) UploadLogs(
+	ctx context.Context,
+	token string,
+	stepID int64,
+	lines []*drone.Line,
+) error {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.getStepVerifyAssignment(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	if err = c.client.Upload(ctx, stepID, lines); err != nil {
+		return fmt.Errorf("failed to upload logs: %w", err)
+	}
+
+	return nil
+}
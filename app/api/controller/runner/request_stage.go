@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	runnerclient "github.com/drone/runner-go/client"
+	"github.com/rs/zerolog/log"
+)
+
+// requestStageTimeout is the max duration a stage request waits for a matching stage.
+const requestStageTimeout = 30 * time.Second
+
+type RequestStageInput struct {
+	Kind    string `json:"kind"`
+	Type    string `json:"type"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Variant string `json:"variant"`
+	Kernel  string `json:"kernel"`
+}
+
+// RequestStage waits for the next stage in the queue that matches the platform and labels of the runner.
+// The stage is accepted on behalf of the runner and the details required for its execution are returned.
+// In case no matching stage was queued before the request timed out, nil is returned.
+func (c *Controller) RequestStage(
+	ctx context.Context,
+	token string,
+	in *RequestStageInput,
+) (*runnerclient.Context, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, requestStageTimeout)
+	defer cancel()
+
+	stage, err := c.client.Request(requestCtx, &runnerclient.Filter{
+		Kind:    in.Kind,
+		Type:    in.Type,
+		OS:      in.OS,
+		Arch:    in.Arch,
+		Variant: in.Variant,
+		Kernel:  in.Kernel,
+		Labels:  runner.Labels,
+	})
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to request stage: %w", err)
+	}
+
+	stage.Machine = runner.Machine()
+	if err = c.client.Accept(ctx, stage); err != nil {
+		// the stage might have been accepted by another runner - the runner just requests the next one.
+		log.Ctx(ctx).Debug().Err(err).Msgf("runner %q failed to accept stage %d", runner.Identifier, stage.ID)
+		return nil, nil
+	}
+
+	details, err := c.client.Detail(ctx, stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details of stage: %w", err)
+	}
+
+	return details, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UploadTestReport stores a JUnit or xUnit.net XML test report produced by a stage assigned to the runner.
+// The report belongs to the status check of the pipeline for the commit the execution ran on.
+func (c *Controller) UploadTestReport(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	file io.Reader,
+) (*types.TestReport, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.getStageVerifyAssignment(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage.Status.IsDone() {
+		return nil, usererror.BadRequest("Test reports can't be uploaded for a finished stage.")
+	}
+
+	execution, err := c.executionStore.Find(ctx, stage.ExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.Find(ctx, execution.PipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	report := &types.TestReport{
+		RepoID:          execution.RepoID,
+		CommitSHA:       execution.After,
+		CheckIdentifier: pipeline.Identifier,
+		CreatedBy:       execution.CreatedBy,
+	}
+
+	// only reports of the branch itself are a baseline for pull requests targeting it.
+	if execution.Event != enum.TriggerEventPullRequest {
+		report.Branch = execution.Target
+	}
+
+	if err = c.testReportSvc.Ingest(ctx, report, file); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-go/drone"
+)
+
+var errStepNotInStage = usererror.Forbidden("The step doesn't belong to the stage.")
+
+// UpdateStage updates the status of a stage assigned to the runner.
+// A pending or running status signals the start of the stage (creating its steps), any other its completion.
+// Only the status of the stage and its steps is taken from the runner, everything else is kept as stored.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	token string,
+	stageID int64,
+	in *drone.Stage,
+) (*drone.Stage, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := c.getStageVerifyAssignment(ctx, runner, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := manager.ConvertToDroneStage(stage)
+	update.Machine = runner.Machine()
+	update.Version = in.Version
+	update.Status = in.Status
+	update.Error = in.Error
+	update.ExitCode = in.ExitCode
+	update.Started = in.Started
+	update.Stopped = in.Stopped
+
+	status := enum.ParseCIStatus(in.Status)
+	starting := status == enum.CIStatusPending || status == enum.CIStatusRunning
+
+	update.Steps = make([]*drone.Step, len(in.Steps))
+	for i, inStep := range in.Steps {
+		if starting {
+			// the steps are created with the start of the stage.
+			update.Steps[i] = newStep(stage.ID, inStep)
+			continue
+		}
+
+		step, err := c.stepStore.Find(ctx, inStep.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find step: %w", err)
+		}
+
+		if step.StageID != stage.ID {
+			return nil, errStepNotInStage
+		}
+
+		update.Steps[i] = stepUpdate(step, inStep)
+	}
+
+	if err = c.client.Update(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to update stage: %w", err)
+	}
+
+	return update, nil
+}
+
+// UpdateStep updates the status of a step of a stage assigned to the runner.
+// A pending or running status signals the start of the step, any other its completion.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	token string,
+	stepID int64,
+	in *drone.Step,
+) (*drone.Step, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := c.getStepVerifyAssignment(ctx, runner, stepID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := stepUpdate(step, in)
+
+	if err = c.client.UpdateStep(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to update step: %w", err)
+	}
+
+	return update, nil
+}
+
+// stepUpdate returns the stored step with the status reported by the runner.
+func stepUpdate(step *types.Step, in *drone.Step) *drone.Step {
+	update := manager.ConvertToDroneStep(step)
+	update.Version = in.Version
+	update.Status = in.Status
+	update.Error = in.Error
+	update.ExitCode = in.ExitCode
+	update.Started = in.Started
+	update.Stopped = in.Stopped
+
+	return update
+}
+
+// newStep returns the step reported by the runner with the start of the stage, forced into the stage.
+func newStep(stageID int64, in *drone.Step) *drone.Step {
+	return &drone.Step{
+		StageID:   stageID,
+		Number:    in.Number,
+		Name:      in.Name,
+		Status:    in.Status,
+		Error:     in.Error,
+		ErrIgnore: in.ErrIgnore,
+		ExitCode:  in.ExitCode,
+		Started:   in.Started,
+		Stopped:   in.Stopped,
+		DependsOn: in.DependsOn,
+		Image:     in.Image,
+		Detached:  in.Detached,
+		Schema:    in.Schema,
+	}
+}
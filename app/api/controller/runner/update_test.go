@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	runnerclient "github.com/drone/runner-go/client"
+)
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	runner *types.Runner
+}
+
+func (s *fakeRunnerStore) FindByTokenHash(context.Context, string) (*types.Runner, error) {
+	runner := *s.runner
+	return &runner, nil
+}
+
+func (s *fakeRunnerStore) Update(context.Context, *types.Runner) error {
+	return nil
+}
+
+type fakeStageStore struct {
+	store.StageStore
+	stage *types.Stage
+}
+
+func (s *fakeStageStore) Find(context.Context, int64) (*types.Stage, error) {
+	stage := *s.stage
+	return &stage, nil
+}
+
+type fakeStepStore struct {
+	store.StepStore
+	steps map[int64]*types.Step
+}
+
+func (s *fakeStepStore) Find(_ context.Context, id int64) (*types.Step, error) {
+	step := *s.steps[id]
+	return &step, nil
+}
+
+type fakeClient struct {
+	runnerclient.Client
+	updated *drone.Stage
+}
+
+func (c *fakeClient) Update(_ context.Context, stage *drone.Stage) error {
+	c.updated = stage
+	return nil
+}
+
+func newUpdateTestController(client *fakeClient) *Controller {
+	runner := &types.Runner{ID: 1, Identifier: "runner-1"}
+	return &Controller{
+		runnerStore: &fakeRunnerStore{runner: runner},
+		stageStore: &fakeStageStore{stage: &types.Stage{
+			ID:          10,
+			ExecutionID: 5,
+			Name:        "build",
+			Machine:     runner.Machine(),
+		}},
+		stepStore: &fakeStepStore{steps: map[int64]*types.Step{
+			100: {ID: 100, StageID: 10, Name: "test"},
+			200: {ID: 200, StageID: 20, Name: "deploy"},
+		}},
+		client: client,
+	}
+}
+
+func TestUpdateStage_KeepsStoredStage(t *testing.T) {
+	client := &fakeClient{}
+	c := newUpdateTestController(client)
+
+	_, err := c.UpdateStage(context.Background(), "token", 10, &drone.Stage{
+		BuildID: 99,
+		Name:    "other",
+		Status:  drone.StatusPassing,
+		Steps:   []*drone.Step{{ID: 100, StageID: 20, Name: "other", Status: drone.StatusPassing}},
+	})
+	if err != nil {
+		t.Fatalf("failed to update stage: %v", err)
+	}
+
+	if client.updated.BuildID != 5 || client.updated.Name != "build" || client.updated.Status != drone.StatusPassing {
+		t.Errorf("expected the stored stage with the reported status, got %+v", client.updated)
+	}
+
+	step := client.updated.Steps[0]
+	if step.StageID != 10 || step.Name != "test" || step.Status != drone.StatusPassing {
+		t.Errorf("expected the stored step with the reported status, got %+v", step)
+	}
+}
+
+func TestUpdateStage_StepOfOtherStage(t *testing.T) {
+	client := &fakeClient{}
+	c := newUpdateTestController(client)
+
+	_, err := c.UpdateStage(context.Background(), "token", 10, &drone.Stage{
+		Status: drone.StatusPassing,
+		Steps:  []*drone.Step{{ID: 200, Status: drone.StatusFailing}},
+	})
+	if !errors.Is(err, errStepNotInStage) {
+		t.Fatalf("expected error %v, got %v", errStepNotInStage, err)
+	}
+
+	if client.updated != nil {
+		t.Errorf("expected the stage not to be updated")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// watchTimeout is the max duration a watch request waits for the cancellation of an execution.
+const watchTimeout = 30 * time.Second
+
+type WatchOutput struct {
+	Cancelled bool `json:"cancelled"`
+}
+
+// Watch waits for the cancellation of an execution the runner executes a stage of.
+// In case the execution wasn't canceled before the request timed out, false is returned.
+func (c *Controller) Watch(
+	ctx context.Context,
+	token string,
+	executionID int64,
+) (*WatchOutput, error) {
+	runner, err := c.authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := c.stageStore.List(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	assigned := false
+	for _, stage := range stages {
+		if stage.Machine == runner.Machine() {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		return nil, errStageNotAssigned
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, watchTimeout)
+	defer cancel()
+
+	cancelled, err := c.client.Watch(watchCtx, executionID)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		// cancellation events are only published to active watchers,
+		// the execution might have been canceled in between two watch requests of the runner.
+		execution, err := c.executionStore.Find(ctx, executionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find execution: %w", err)
+		}
+
+		return &WatchOutput{Cancelled: execution.Status.IsDone()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch execution: %w", err)
+	}
+
+	return &WatchOutput{Cancelled: cancelled}, nil
+}
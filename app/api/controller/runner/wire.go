@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/testreport"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
+
+	runnerclient "github.com/drone/runner-go/client"
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	runnerStore store.RunnerStore,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	artifactStore store.ArtifactStore,
+	quotaStore store.QuotaStore,
+	blobStore blob.Store,
+	testReportSvc *testreport.Service,
+	limiter limiter.ResourceLimiter,
+	urlProvider url.Provider,
+	client runnerclient.Client,
+	auditService audit.Service,
+	authorizer authz.Authorizer,
+) *Controller {
+	return NewController(
+		runnerStore,
+		repoStore,
+		pipelineStore,
+		executionStore,
+		stageStore,
+		stepStore,
+		artifactStore,
+		quotaStore,
+		blobStore,
+		testReportSvc,
+		limiter,
+		urlProvider,
+		client,
+		auditService,
+		authorizer,
+	)
+}
@@ -17,6 +17,7 @@ package secret
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
@@ -32,6 +33,7 @@ func ProvideController(
 	secretStore store.SecretStore,
 	authorizer authz.Authorizer,
 	spaceStore store.SpaceStore,
+	auditService audit.Service,
 ) *Controller {
-	return NewController(authorizer, encrypter, secretStore, spaceStore)
+	return NewController(authorizer, encrypter, secretStore, spaceStore, auditService)
 }
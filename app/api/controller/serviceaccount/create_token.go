@@ -22,9 +22,12 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type CreateTokenInput struct {
@@ -68,6 +71,18 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(token),
+		audit.WithData("token_owner", sa.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create service account token operation: %s", err)
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
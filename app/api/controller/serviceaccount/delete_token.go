@@ -16,10 +16,12 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
@@ -56,5 +58,22 @@ func (c *Controller) DeleteToken(
 		return usererror.ErrNotFound
 	}
 
-	return c.tokenStore.Delete(ctx, token.ID)
+	err = c.tokenStore.Delete(ctx, token.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionDeleted,
+		"",
+		audit.WithOldObject(token),
+		audit.WithData("token_owner", sa.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete service account token operation: %s", err)
+	}
+
+	return nil
 }
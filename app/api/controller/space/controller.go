@@ -40,25 +40,29 @@ type Controller struct {
 	nestedSpacesEnabled           bool
 	publicResourceCreationEnabled bool
 
-	tx              dbtx.Transactor
-	urlProvider     url.Provider
-	sseStreamer     sse.Streamer
-	identifierCheck check.SpaceIdentifier
-	authorizer      authz.Authorizer
-	spacePathStore  store.SpacePathStore
-	pipelineStore   store.PipelineStore
-	secretStore     store.SecretStore
-	connectorStore  store.ConnectorStore
-	templateStore   store.TemplateStore
-	spaceStore      store.SpaceStore
-	repoStore       store.RepoStore
-	principalStore  store.PrincipalStore
-	repoCtrl        *repo.Controller
-	membershipStore store.MembershipStore
-	importer        *importer.Repository
-	exporter        *exporter.Repository
-	resourceLimiter limiter.ResourceLimiter
-	auditService    audit.Service
+	tx                  dbtx.Transactor
+	urlProvider         url.Provider
+	sseStreamer         sse.Streamer
+	identifierCheck     check.SpaceIdentifier
+	authorizer          authz.Authorizer
+	spacePathStore      store.SpacePathStore
+	pipelineStore       store.PipelineStore
+	secretStore         store.SecretStore
+	connectorStore      store.ConnectorStore
+	templateStore       store.TemplateStore
+	spaceStore          store.SpaceStore
+	repoStore           store.RepoStore
+	principalStore      store.PrincipalStore
+	repoCtrl            *repo.Controller
+	membershipStore     store.MembershipStore
+	roleStore           store.RoleStore
+	repoMembershipStore store.RepoMembershipStore
+	importer            *importer.Repository
+	exporter            *exporter.Repository
+	resourceLimiter     limiter.ResourceLimiter
+	quota               *limiter.Quota
+	quotaStore          store.QuotaStore
+	auditService        audit.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -66,8 +70,9 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	spacePathStore store.SpacePathStore, pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
-	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
-	limiter limiter.ResourceLimiter, auditService audit.Service,
+	membershipStore store.MembershipStore, roleStore store.RoleStore,
+	repoMembershipStore store.RepoMembershipStore, importer *importer.Repository, exporter *exporter.Repository, limiter limiter.ResourceLimiter, quota *limiter.Quota,
+	quotaStore store.QuotaStore, auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
@@ -87,9 +92,13 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		principalStore:                principalStore,
 		repoCtrl:                      repoCtrl,
 		membershipStore:               membershipStore,
+		roleStore:                     roleStore,
+		repoMembershipStore:           repoMembershipStore,
 		importer:                      importer,
 		exporter:                      exporter,
 		resourceLimiter:               limiter,
+		quota:                         quota,
+		quotaStore:                    quotaStore,
 		auditService:                  auditService,
 	}
 }
@@ -22,16 +22,19 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipAddInput struct {
-	UserUID string              `json:"user_uid"`
-	Role    enum.MembershipRole `json:"role"`
+	UserUID    string              `json:"user_uid"`
+	Role       enum.MembershipRole `json:"role"`
+	CustomRole string              `json:"custom_role"`
 }
 
 func (in *MembershipAddInput) Validate() error {
@@ -39,19 +42,6 @@ func (in *MembershipAddInput) Validate() error {
 		return usererror.BadRequest("UserUID must be provided")
 	}
 
-	if in.Role == "" {
-		return usererror.BadRequest("Role must be provided")
-	}
-
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
 	return nil
 }
 
@@ -75,6 +65,11 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
+	role, customRole, err := c.sanitizeMembershipRole(ctx, space.ID, in.Role, in.CustomRole)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
@@ -92,7 +87,12 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		CreatedBy: session.Principal.ID,
 		Created:   now,
 		Updated:   now,
-		Role:      in.Role,
+		Role:      role,
+	}
+
+	if customRole != nil {
+		membership.CustomRoleID = &customRole.ID
+		membership.CustomRole = customRole.Identifier
 	}
 
 	err = c.membershipStore.Create(ctx, &membership)
@@ -100,6 +100,17 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, fmt.Errorf("failed to create new membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceMembership, user.UID),
+		audit.ActionCreated,
+		space.Path,
+		audit.WithNewObject(membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for add space membership operation: %s", err)
+	}
+
 	result := &types.MembershipUser{
 		Membership: membership,
 		Principal:  *user.ToPrincipalInfo(),
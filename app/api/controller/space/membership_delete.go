@@ -20,8 +20,11 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // MembershipDelete removes an existing membership from a space.
@@ -44,13 +47,31 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return fmt.Errorf("failed to find user by uid: %w", err)
 	}
 
-	err = c.membershipStore.Delete(ctx, types.MembershipKey{
+	membershipKey := types.MembershipKey{
 		SpaceID:     space.ID,
 		PrincipalID: user.ID,
-	})
+	}
+
+	membership, err := c.membershipStore.FindUser(ctx, membershipKey)
+	if err != nil {
+		return fmt.Errorf("failed to find user membership: %w", err)
+	}
+
+	err = c.membershipStore.Delete(ctx, membershipKey)
 	if err != nil {
 		return fmt.Errorf("failed to delete user membership: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceMembership, user.UID),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(membership.Membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete space membership operation: %s", err)
+	}
+
 	return nil
 }
@@ -19,31 +19,17 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
-	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type MembershipUpdateInput struct {
-	Role enum.MembershipRole `json:"role"`
-}
-
-func (in *MembershipUpdateInput) Validate() error {
-	if in.Role == "" {
-		return usererror.BadRequest("Role must be provided")
-	}
-
-	role, ok := in.Role.Sanitize()
-	if !ok {
-		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
-			in.Role, enum.MembershipRoles)
-		return usererror.BadRequest(msg)
-	}
-
-	in.Role = role
-
-	return nil
+	Role       enum.MembershipRole `json:"role"`
+	CustomRole string              `json:"custom_role"`
 }
 
 // MembershipUpdate changes the role of an existing membership.
@@ -62,7 +48,7 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
-	err = in.Validate()
+	role, customRole, err := c.sanitizeMembershipRole(ctx, space.ID, in.Role, in.CustomRole)
 	if err != nil {
 		return nil, err
 	}
@@ -80,16 +66,39 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, fmt.Errorf("failed to find membership for update: %w", err)
 	}
 
-	if membership.Role == in.Role {
+	var customRoleID *int64
+	var customRoleIdentifier string
+	if customRole != nil {
+		customRoleID = &customRole.ID
+		customRoleIdentifier = customRole.Identifier
+	}
+
+	if membership.Role == role && membership.CustomRole == customRoleIdentifier {
 		return membership, nil
 	}
 
-	membership.Role = in.Role
+	oldMembership := membership.Membership
+
+	membership.Role = role
+	membership.CustomRoleID = customRoleID
+	membership.CustomRole = customRoleIdentifier
 
 	err = c.membershipStore.Update(ctx, &membership.Membership)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update membership")
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceMembership, user.UID),
+		audit.ActionUpdated,
+		space.Path,
+		audit.WithOldObject(oldMembership),
+		audit.WithNewObject(membership.Membership),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space membership operation: %s", err)
+	}
+
 	return membership, nil
 }
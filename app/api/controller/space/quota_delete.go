@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QuotaDelete removes the quota of a space, the space is then only limited by the quotas of its parents.
+func (c *Controller) QuotaDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	quota, err := c.quotaStore.Find(ctx, space.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find space quota: %w", err)
+	}
+
+	err = c.quotaStore.Delete(ctx, space.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete space quota: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceQuota, space.Identifier),
+		audit.ActionDeleted,
+		space.Path,
+		audit.WithOldObject(quota),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete space quota operation: %s", err)
+	}
+
+	return nil
+}
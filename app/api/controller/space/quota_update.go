@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QuotaUpdateInput holds the limits of a space quota, a nil value means the resource isn't limited by the space.
+type QuotaUpdateInput struct {
+	MaxRepos                *int64 `json:"max_repos"`
+	MaxRepoSize             *int64 `json:"max_repo_size"` // in KiB
+	MaxBlobSize             *int64 `json:"max_blob_size"` // in bytes
+	MaxPipelineMinutes      *int64 `json:"max_pipeline_minutes"`
+	MaxConcurrentExecutions *int64 `json:"max_concurrent_executions"`
+}
+
+func (in *QuotaUpdateInput) sanitize() error {
+	for _, limit := range []*int64{
+		in.MaxRepos,
+		in.MaxRepoSize,
+		in.MaxBlobSize,
+		in.MaxPipelineMinutes,
+		in.MaxConcurrentExecutions,
+	} {
+		if limit != nil && *limit < 0 {
+			return usererror.BadRequest("Quota limits can't be negative.")
+		}
+	}
+
+	return nil
+}
+
+// QuotaUpdate sets the quota of a space, replacing all of its existing limits.
+// Quotas can only be changed by admins, as they restrict space owners.
+func (c *Controller) QuotaUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *QuotaUpdateInput,
+) (*types.SpaceQuota, error) {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	oldQuota, err := c.quotaStore.Find(ctx, space.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find space quota: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	quota := &types.SpaceQuota{
+		SpaceID:                 space.ID,
+		MaxRepos:                in.MaxRepos,
+		MaxRepoSize:             in.MaxRepoSize,
+		MaxBlobSize:             in.MaxBlobSize,
+		MaxPipelineMinutes:      in.MaxPipelineMinutes,
+		MaxConcurrentExecutions: in.MaxConcurrentExecutions,
+		Created:                 now,
+		Updated:                 now,
+	}
+	if oldQuota != nil {
+		quota.Created = oldQuota.Created
+	}
+
+	err = c.quotaStore.Upsert(ctx, quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert space quota: %w", err)
+	}
+
+	action := audit.ActionCreated
+	if oldQuota != nil {
+		action = audit.ActionUpdated
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeSpaceQuota, space.Identifier),
+		action,
+		space.Path,
+		audit.WithOldObject(oldQuota),
+		audit.WithNewObject(quota),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update space quota operation: %s", err)
+	}
+
+	return quota, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// sanitizeRolePermissions validates the permissions of a custom role, removes duplicates and sorts them.
+func sanitizeRolePermissions(permissions []enum.Permission) ([]enum.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, usererror.BadRequest("Role must have at least one permission")
+	}
+
+	result := make([]enum.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		permission, ok := p.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Provided permission '%s' is not supported. Valid values are: %v",
+				p, enum.MembershipPermissions)
+		}
+
+		result = append(result, permission)
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
+
+// sanitizeMembershipRole validates the role of a membership and,
+// in case of a custom role, returns the custom role of the space or its ancestors it refers to.
+func (c *Controller) sanitizeMembershipRole(
+	ctx context.Context,
+	spaceID int64,
+	role enum.MembershipRole,
+	customRole string,
+) (enum.MembershipRole, *types.Role, error) {
+	return controller.SanitizeMembershipRole(ctx, c.spaceStore, c.roleStore, spaceID, role, customRole)
+}
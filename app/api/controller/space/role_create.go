@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RoleCreateInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+func (in *RoleCreateInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if _, ok := enum.MembershipRole(in.Identifier).Sanitize(); ok {
+		return usererror.BadRequestf("Role identifier '%s' is reserved", in.Identifier)
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	permissions, err := sanitizeRolePermissions(in.Permissions)
+	if err != nil {
+		return err
+	}
+
+	in.Permissions = permissions
+
+	return nil
+}
+
+// RoleCreate creates a new custom membership role in a space.
+func (c *Controller) RoleCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *RoleCreateInput,
+) (*types.Role, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	role := &types.Role{
+		SpaceID:     space.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		Permissions: in.Permissions,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.roleStore.Create(ctx, role)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Role '%s' already exists", in.Identifier))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RoleDelete deletes a custom membership role from a space.
+// Roles that are assigned to any membership can't be deleted.
+func (c *Controller) RoleDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	roleIdentifier string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	role, err := c.roleStore.FindByIdentifier(ctx, space.ID, roleIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to find role: %w", err)
+	}
+
+	count, err := c.membershipStore.CountByRoleID(ctx, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count memberships with the role: %w", err)
+	}
+
+	repoCount, err := c.repoMembershipStore.CountByRoleID(ctx, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count repository memberships with the role: %w", err)
+	}
+
+	count += repoCount
+
+	if count > 0 {
+		return usererror.Conflict(fmt.Sprintf("Role '%s' is assigned to %d membership(s) and can't be deleted",
+			role.Identifier, count))
+	}
+
+	err = c.roleStore.Delete(ctx, role.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
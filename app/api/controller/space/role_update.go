@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RoleUpdateInput struct {
+	Description *string            `json:"description"`
+	Permissions *[]enum.Permission `json:"permissions"`
+}
+
+func (in *RoleUpdateInput) sanitize() error {
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Permissions != nil {
+		permissions, err := sanitizeRolePermissions(*in.Permissions)
+		if err != nil {
+			return err
+		}
+
+		in.Permissions = &permissions
+	}
+
+	return nil
+}
+
+// RoleUpdate updates the description and the permissions of a custom membership role.
+// Changed permissions are applied to all memberships with the role.
+func (c *Controller) RoleUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	roleIdentifier string,
+	in *RoleUpdateInput,
+) (*types.Role, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	role, err := c.roleStore.FindByIdentifier(ctx, space.ID, roleIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find role: %w", err)
+	}
+
+	if in.Description != nil {
+		role.Description = *in.Description
+	}
+
+	if in.Permissions != nil {
+		role.Permissions = *in.Permissions
+	}
+
+	err = c.roleStore.Update(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return role, nil
+}
@@ -41,12 +41,13 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore,
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
-	repoCtrl *repo.Controller, membershipStore store.MembershipStore, importer *importer.Repository,
-	exporter *exporter.Repository, limiter limiter.ResourceLimiter, auditService audit.Service,
+	repoCtrl *repo.Controller, membershipStore store.MembershipStore, roleStore store.RoleStore,
+	repoMembershipStore store.RepoMembershipStore, importer *importer.Repository, exporter *exporter.Repository, limiter limiter.ResourceLimiter,
+	quota *limiter.Quota, quotaStore store.QuotaStore, auditService audit.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
-		repoCtrl, membershipStore, importer, exporter, limiter, auditService)
+		repoCtrl, membershipStore, roleStore, repoMembershipStore, importer, exporter, limiter, quota, quotaStore, auditService)
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -49,16 +50,22 @@ type Controller struct {
 	authorizer authz.Authorizer
 	repoStore  store.RepoStore
 	blobStore  blob.Store
+	quotaStore store.QuotaStore
+	limiter    limiter.ResourceLimiter
 }
 
 func NewController(authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	blobStore blob.Store,
+	quotaStore store.QuotaStore,
+	limiter limiter.ResourceLimiter,
 ) *Controller {
 	return &Controller{
 		authorizer: authorizer,
 		repoStore:  repoStore,
 		blobStore:  blobStore,
+		quotaStore: quotaStore,
+		limiter:    limiter,
 	}
 }
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/google/uuid"
@@ -50,7 +51,14 @@ func (c *Controller) Upload(ctx context.Context,
 	if file == nil {
 		return nil, usererror.BadRequest("no file provided")
 	}
-	bufReader := bufio.NewReader(file)
+
+	// the size of the file is only known once it's uploaded, hence a single upload can exceed the limit.
+	if err := c.limiter.BlobSize(ctx, repo.ID); err != nil {
+		return nil, fmt.Errorf("resource limit exceeded: %w", err)
+	}
+
+	counter := blob.NewCountingReader(file)
+	bufReader := bufio.NewReader(counter)
 	// Check if the file is an image or video
 	extn, err := c.getFileExtension(bufReader)
 	if err != nil {
@@ -65,6 +73,12 @@ func (c *Controller) Upload(ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
+
+	err = c.quotaStore.AddBlobSize(ctx, repo.ID, counter.Count())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update blob size of repo: %w", err)
+	}
+
 	return &Result{
 		FilePath: fileName,
 	}, nil
@@ -15,6 +15,7 @@
 package upload
 
 import (
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/blob"
@@ -31,6 +32,8 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	blobStore blob.Store,
+	quotaStore store.QuotaStore,
+	limiter limiter.ResourceLimiter,
 ) *Controller {
-	return NewController(authorizer, repoStore, blobStore)
+	return NewController(authorizer, repoStore, blobStore, quotaStore, limiter)
 }
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxExternalIDLength is the max allowed length of the id of an identity of an external provider.
+const maxExternalIDLength = 1024
+
+type LinkIdentityInput struct {
+	// Provider is the external identity provider (ldap or oidc).
+	Provider string `json:"provider"`
+	// ExternalID identifies the user at the provider (the DN for ldap, the subject for oidc).
+	ExternalID string `json:"external_id"`
+}
+
+// AdminLinkIdentity links an existing user to its identity of an external provider, which allows the user
+// to log in via the provider. Existing users are never linked automatically, as emails can't be trusted.
+func (c *Controller) AdminLinkIdentity(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *LinkIdentityInput,
+) (*types.PrincipalIdentity, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	if err = sanitizeLinkIdentityInput(in); err != nil {
+		return nil, err
+	}
+
+	identity := &types.PrincipalIdentity{
+		Provider:    in.Provider,
+		ExternalID:  in.ExternalID,
+		PrincipalID: user.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.identityStore.Create(ctx, identity)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The identity is already linked to a user.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("admin %q linked user %q to %s identity %q",
+		session.Principal.UID, user.UID, identity.Provider, identity.ExternalID)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		audit.WithData("identity_linked", identity.Provider+":"+identity.ExternalID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for link identity operation: %s", err)
+	}
+
+	return identity, nil
+}
+
+// AdminUnlinkIdentity unlinks the user from its identities of the external provider.
+func (c *Controller) AdminUnlinkIdentity(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	provider string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return err
+	}
+
+	if err = checkIdentityProvider(provider); err != nil {
+		return err
+	}
+
+	if err = c.identityStore.DeleteByPrincipal(ctx, provider, user.ID); err != nil {
+		return fmt.Errorf("failed to unlink identities: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		audit.WithData("identity_unlinked", provider),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for unlink identity operation: %s", err)
+	}
+
+	return nil
+}
+
+func sanitizeLinkIdentityInput(in *LinkIdentityInput) error {
+	if err := checkIdentityProvider(in.Provider); err != nil {
+		return err
+	}
+
+	if in.ExternalID == "" {
+		return usererror.BadRequest("The external id of the identity must be provided.")
+	}
+	if len(in.ExternalID) > maxExternalIDLength {
+		return check.NewValidationErrorf("The external id of the identity can be at most %d characters long.",
+			maxExternalIDLength)
+	}
+
+	return nil
+}
+
+func checkIdentityProvider(provider string) error {
+	if provider != types.PrincipalIdentityProviderLDAP && provider != types.PrincipalIdentityProviderOIDC {
+		return usererror.BadRequestf("Unknown identity provider '%s'.", provider)
+	}
+
+	return nil
+}
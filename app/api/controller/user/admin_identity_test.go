@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+func TestAdminLinkIdentity(t *testing.T) {
+	admin := &types.User{ID: 1, UID: "admin", Email: "admin@example.com", Admin: true}
+	jdoe := &types.User{ID: 2, UID: "jdoe", Email: "jdoe@example.com"}
+	c := &Controller{
+		authorizer:     authz.NewUnsafeAuthorizer(),
+		principalStore: &fakePrincipalStore{users: []*types.User{admin, jdoe}},
+		identityStore:  &fakePrincipalIdentityStore{},
+		auditService:   audit.New(),
+	}
+	session := &auth.Session{Principal: *admin.ToPrincipal()}
+
+	in := &LinkIdentityInput{Provider: types.PrincipalIdentityProviderOIDC, ExternalID: "subject-1"}
+	if _, err := c.AdminLinkIdentity(context.Background(), session, jdoe.UID, in); err != nil {
+		t.Fatalf("failed to link identity: %v", err)
+	}
+
+	// the existing user can log in via the linked identity, even though its email is taken.
+	user, err := c.findExternalUser(context.Background(), externalIdentity{
+		provider:   types.PrincipalIdentityProviderOIDC,
+		externalID: "subject-1",
+		email:      jdoe.Email,
+	})
+	if err != nil {
+		t.Fatalf("failed to find external user: %v", err)
+	}
+	if user.ID != jdoe.ID {
+		t.Errorf("expected user %q, got %q", jdoe.UID, user.UID)
+	}
+
+	// an identity can't be linked to a second user.
+	_, err = c.AdminLinkIdentity(context.Background(), session, admin.UID, in)
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusConflict {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+
+	_, err = c.AdminLinkIdentity(context.Background(), session, admin.UID,
+		&LinkIdentityInput{Provider: "saml", ExternalID: "subject-1"})
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+}
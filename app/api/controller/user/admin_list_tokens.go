@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AdminListTokens lists all personal access and session tokens of a user.
+func (c *Controller) AdminListTokens(ctx context.Context, session *auth.Session,
+	userUID string) ([]*types.Token, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	var tokens []*types.Token
+	for _, tokenType := range []enum.TokenType{enum.TokenTypePAT, enum.TokenTypeSession} {
+		list, err := c.tokenStore.List(ctx, user.ID, tokenType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tokens of type %s: %w", tokenType, err)
+		}
+
+		tokens = append(tokens, list...)
+	}
+
+	return tokens, nil
+}
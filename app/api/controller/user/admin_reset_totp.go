@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AdminResetTOTP removes the two-factor authentication of a user, e.g. in case the user lost the device
+// and all recovery codes. If two-factor authentication is required, the user has to enroll again on next login.
+func (c *Controller) AdminResetTOTP(ctx context.Context, session *auth.Session, userUID string) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return err
+	}
+
+	if err = c.totpStore.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("admin %q reset two-factor authentication of user %q",
+		session.Principal.UID, user.UID)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		audit.WithData("two_factor_reset", "true"),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for reset totp operation: %s", err)
+	}
+
+	return nil
+}
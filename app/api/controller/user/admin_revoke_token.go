@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AdminRevokeToken revokes any token of a user, e.g. in case the token got compromised.
+// The token is deleted, so any further request authenticated with it fails immediately.
+func (c *Controller) AdminRevokeToken(ctx context.Context, session *auth.Session,
+	userUID string, tokenIdentifier string) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return err
+	}
+
+	token, err := c.tokenStore.FindByIdentifier(ctx, user.ID, tokenIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if !isUserTokenType(token.Type) {
+		// throw a not found error - no need for user to know about token.
+		return usererror.ErrNotFound
+	}
+
+	err = c.tokenStore.Delete(ctx, token.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("admin %q revoked %s token %q of user %q",
+		session.Principal.UID, token.Type, token.Identifier, user.UID)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionDeleted,
+		"",
+		audit.WithOldObject(token),
+		audit.WithData("token_owner", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for revoke token operation: %s", err)
+	}
+
+	return nil
+}
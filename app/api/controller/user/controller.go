@@ -18,7 +18,13 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -34,6 +40,17 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	totpStore         store.TOTPStore
+	encrypter         encrypt.Encrypter
+	config            *types.Config
+	oidcProvider      *oidc.Provider
+	ldapClient        *ldap.Client
+	groupSyncer       *groupsync.Syncer
+	auditService      audit.Service
+	identityStore     store.PrincipalIdentityStore
+	rateLimiter       ratelimit.RateLimiter
 }
 
 func NewController(
@@ -43,6 +60,17 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	totpStore store.TOTPStore,
+	encrypter encrypt.Encrypter,
+	config *types.Config,
+	oidcProvider *oidc.Provider,
+	ldapClient *ldap.Client,
+	groupSyncer *groupsync.Syncer,
+	auditService audit.Service,
+	identityStore store.PrincipalIdentityStore,
+	rateLimiter ratelimit.RateLimiter,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +79,17 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		totpStore:         totpStore,
+		encrypter:         encrypter,
+		config:            config,
+		oidcProvider:      oidcProvider,
+		ldapClient:        ldapClient,
+		groupSyncer:       groupSyncer,
+		auditService:      auditService,
+		identityStore:     identityStore,
+		rateLimiter:       rateLimiter,
 	}
 }
 
@@ -22,11 +22,13 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -50,7 +52,23 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
-	return c.CreateNoAuth(ctx, in, false)
+	user, err := c.CreateNoAuth(ctx, in, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(user),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create user operation: %s", err)
+	}
+
+	return user, nil
 }
 
 /*
@@ -16,15 +16,22 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 )
 
 type CreateTokenInput struct {
@@ -32,6 +39,12 @@ type CreateTokenInput struct {
 	UID        string         `json:"uid" deprecated:"true"`
 	Identifier string         `json:"identifier"`
 	Lifetime   *time.Duration `json:"lifetime"`
+
+	// Permissions optionally restricts the token to the provided permissions.
+	Permissions []enum.Permission `json:"permissions"`
+	// Spaces and Repos optionally restrict the token to the provided spaces and repositories (by reference).
+	Spaces []string `json:"spaces"`
+	Repos  []string `json:"repos"`
 }
 
 /*
@@ -57,6 +70,11 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	scope, err := c.resolveTokenScope(ctx, session, in)
+	if err != nil {
+		return nil, err
+	}
+
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
 		c.tokenStore,
@@ -64,11 +82,24 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.Identifier,
 		in.Lifetime,
+		scope,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeToken, token.Identifier),
+		audit.ActionCreated,
+		"",
+		audit.WithNewObject(token),
+		audit.WithData("token_owner", user.UID),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create token operation: %s", err)
+	}
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -87,5 +118,64 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
+	for i, p := range in.Permissions {
+		permission, ok := p.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Provided permission '%s' is not supported. Valid values are: %v",
+				p, enum.MembershipPermissions)
+		}
+
+		in.Permissions[i] = permission
+	}
+
+	slices.Sort(in.Permissions)
+	in.Permissions = slices.Compact(in.Permissions)
+
 	return nil
 }
+
+// resolveTokenScope converts the space and repository references of the input into the scope of the token.
+// The token can only be restricted to spaces and repositories the caller has access to.
+func (c *Controller) resolveTokenScope(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateTokenInput,
+) (types.TokenScope, error) {
+	scope := types.TokenScope{
+		Permissions: in.Permissions,
+	}
+
+	for _, spaceRef := range in.Spaces {
+		space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return types.TokenScope{}, usererror.BadRequestf("Space '%s' not found", spaceRef)
+		}
+		if err != nil {
+			return types.TokenScope{}, fmt.Errorf("failed to find space of token scope: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true); err != nil {
+			return types.TokenScope{}, err
+		}
+
+		scope.SpaceIDs = append(scope.SpaceIDs, space.ID)
+	}
+
+	for _, repoRef := range in.Repos {
+		repo, err := c.repoStore.FindByRef(ctx, repoRef)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return types.TokenScope{}, usererror.BadRequestf("Repository '%s' not found", repoRef)
+		}
+		if err != nil {
+			return types.TokenScope{}, fmt.Errorf("failed to find repository of token scope: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+			return types.TokenScope{}, err
+		}
+
+		scope.RepoIDs = append(scope.RepoIDs, repo.ID)
+	}
+
+	return scope, nil
+}
@@ -21,8 +21,11 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Delete deletes a user.
@@ -50,5 +53,20 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	return c.principalStore.DeleteUser(ctx, user.ID)
+	if err = c.principalStore.DeleteUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionDeleted,
+		"",
+		audit.WithOldObject(user),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete user operation: %s", err)
+	}
+
+	return nil
 }
@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/ldap"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
@@ -34,10 +37,15 @@ import (
 type LoginInput struct {
 	LoginIdentifier string `json:"login_identifier"`
 	Password        string `json:"password"`
+
+	// TOTPCode or RecoveryCode are required for users with two-factor authentication.
+	TOTPCode     string `json:"totp_code,omitempty"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
 }
 
 /*
  * Login attempts to login as a specific user - returns the session token if successful.
+ * The credentials are verified against the local password first and, if enabled, against LDAP afterwards.
  */
 func (c *Controller) Login(
 	ctx context.Context,
@@ -45,16 +53,88 @@ func (c *Controller) Login(
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
+	user, method, err := c.verifyLoginCredentials(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.createLoginSession(ctx, user, method, &TOTPCodeInput{
+		Code:         in.TOTPCode,
+		RecoveryCode: in.RecoveryCode,
+	})
+}
+
+// verifyLoginCredentials verifies the first factor of the login, which is either the local password or,
+// if enabled, the LDAP password. It returns the user and the login method that succeeded.
+func (c *Controller) verifyLoginCredentials(ctx context.Context, in *LoginInput) (*types.User, string, error) {
+	passwordLoginEnabled := !c.config.Auth.PasswordLoginDisabled
+	if !passwordLoginEnabled && !c.ldapClient.Enabled() {
+		return nil, "", usererror.Forbidden("Login with password is disabled")
+	}
+
+	if passwordLoginEnabled {
+		if user := c.verifyPassword(ctx, in); user != nil {
+			return user, loginMethodPassword, nil
+		}
+	}
+
+	if c.ldapClient.Enabled() {
+		user, err := c.loginLDAP(ctx, in)
+		if err != nil {
+			return nil, "", err
+		}
+		if user != nil {
+			return user, loginMethodLDAP, nil
+		}
+	}
+
+	// always return not found for security reasons.
+	c.auditLoginFailed(ctx, in.LoginIdentifier, "invalid_credentials")
+	return nil, "", usererror.ErrNotFound
+}
+
+// createLoginSession creates a new session for the user after the first factor of the login was verified.
+// Every login method has to go through here, as the second factor of the user is verified before the
+// session is created, and users that are required to use two-factor authentication have to enroll first.
+func (c *Controller) createLoginSession(
+	ctx context.Context,
+	user *types.User,
+	method string,
+	secondFactor *TOTPCodeInput,
+) (*types.TokenResponse, error) {
+	err := c.checkLoginSecondFactor(ctx, user, secondFactor)
+	if errors.Is(err, errInvalidTwoFactorCode) {
+		c.auditLoginFailed(ctx, user.UID, "invalid_two_factor_code")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIdentifier, err := generateSessionTokenIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	c.auditLogin(ctx, user, method)
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// verifyPassword returns the user if the login identifier and the password match, nil otherwise.
+func (c *Controller) verifyPassword(ctx context.Context, in *LoginInput) *types.User {
 	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
 	}
 
-	// always return not found for security reasons.
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).
-			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
-		return nil, usererror.ErrNotFound
+			Msgf("failed to retrieve user %q during login.", in.LoginIdentifier)
+		return nil
 	}
 
 	err = bcrypt.CompareHashAndPassword(
@@ -66,19 +146,84 @@ func (c *Controller) Login(
 			Str("user_uid", user.UID).
 			Msg("invalid password")
 
-		return nil, usererror.ErrNotFound
+		return nil
 	}
 
-	tokenIdentifier, err := generateSessionTokenIdentifier()
+	return user
+}
+
+// loginLDAP verifies the credentials against LDAP. Users are provisioned on their first login and linked
+// to their LDAP entry, and their space memberships are synced with their LDAP groups.
+// It returns nil if the user doesn't exist in LDAP or the password doesn't match.
+func (c *Controller) loginLDAP(ctx context.Context, in *LoginInput) (*types.User, error) {
+	identity, err := c.ldapClient.Authenticate(ctx, in.LoginIdentifier, in.Password)
+	if errors.Is(err, ldap.ErrUserNotFound) || errors.Is(err, ldap.ErrInvalidCredentials) {
+		log.Ctx(ctx).Debug().Err(err).
+			Msgf("ldap authentication of %q failed.", in.LoginIdentifier)
+		return nil, nil //nolint:nilnil // invalid credentials are reported as not found by the caller.
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to authenticate with ldap: %w", err)
 	}
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+
+	user, err := c.findExternalUser(ctx, externalIdentity{
+		provider:      types.PrincipalIdentityProviderLDAP,
+		externalID:    identity.DN,
+		username:      identity.Username,
+		email:         identity.Email,
+		displayName:   identity.DisplayName,
+		autoProvision: c.ldapClient.AutoProvision(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	if user.Blocked {
+		c.auditLoginFailed(ctx, user.UID, "user_blocked")
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	err = c.groupSyncer.Sync(ctx, user.ID, identity.Groups, c.ldapClient.GroupMappings())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync group memberships: %w", err)
+	}
+
+	return user, nil
+}
+
+const (
+	loginMethodPassword = "password"
+	loginMethodLDAP     = "ldap"
+	loginMethodOIDC     = "oidc"
+)
+
+// auditLogin records a successful login of the user in the audit log.
+func (c *Controller) auditLogin(ctx context.Context, user *types.User, method string) {
+	err := c.auditService.Log(ctx,
+		*user.ToPrincipal(),
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionLogin,
+		"",
+		audit.WithData("method", method),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for login operation: %s", err)
+	}
+}
+
+// auditLoginFailed records a failed login in the audit log.
+// The user might not exist, so the event is attributed to the provided login identifier.
+func (c *Controller) auditLoginFailed(ctx context.Context, loginIdentifier string, reason string) {
+	err := c.auditService.Log(ctx,
+		types.Principal{UID: loginIdentifier, Type: enum.PrincipalTypeUser},
+		audit.NewResource(audit.ResourceTypeUser, loginIdentifier),
+		audit.ActionLoginFailed,
+		"",
+		audit.WithData("reason", reason),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for failed login operation: %s", err)
+	}
 }
 
 func generateSessionTokenIdentifier() (string, error) {
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// loginChallengeLifetime is the time the user has to provide the second factor of a pending login.
+const loginChallengeLifetime = 10 * time.Minute
+
+// errInvalidLoginChallenge is returned if the challenge of a pending login is missing, invalid or expired.
+var errInvalidLoginChallenge = usererror.New(http.StatusUnauthorized, "Login challenge is invalid or expired")
+
+type LoginOIDCStartOutput struct {
+	RedirectURL string
+	AuthState   oidc.AuthState
+}
+
+// LoginOIDCStart starts the login via the OpenID Connect provider.
+// The returned auth state has to be presented on the callback.
+func (c *Controller) LoginOIDCStart(ctx context.Context) (*LoginOIDCStartOutput, error) {
+	if !c.oidcProvider.Enabled() {
+		return nil, usererror.Forbidden("Login with OIDC is disabled")
+	}
+
+	url, authState, err := c.oidcProvider.AuthCodeURL(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc auth code url: %w", err)
+	}
+
+	return &LoginOIDCStartOutput{
+		RedirectURL: url,
+		AuthState:   authState,
+	}, nil
+}
+
+type LoginOIDCCallbackInput struct {
+	AuthState oidc.AuthState
+	State     string
+	Code      string
+}
+
+type LoginOIDCCallbackOutput struct {
+	// Token is the session of the user, set if the login is completed.
+	Token *types.TokenResponse
+
+	// SecondFactorChallenge is set instead of the token if the user has to provide the second factor
+	// (or enroll first, see SecondFactorEnrollmentRequired) to complete the login.
+	SecondFactorChallenge          string
+	SecondFactorEnrollmentRequired bool
+}
+
+// LoginOIDCCallback completes the login via the OpenID Connect provider - returns the session token if successful.
+// Users are provisioned on their first login and linked to their subject, and their space memberships
+// are synced with their groups.
+// Users with two-factor authentication get a challenge instead, which has to be presented together with
+// the second factor to LoginOIDCSecondFactor.
+func (c *Controller) LoginOIDCCallback(
+	ctx context.Context,
+	in *LoginOIDCCallbackInput,
+) (*LoginOIDCCallbackOutput, error) {
+	if !c.oidcProvider.Enabled() {
+		return nil, usererror.Forbidden("Login with OIDC is disabled")
+	}
+
+	identity, err := c.oidcProvider.Exchange(ctx, in.AuthState, in.State, in.Code)
+	if errors.Is(err, oidc.ErrInvalidState) || errors.Is(err, oidc.ErrInvalidIDToken) ||
+		errors.Is(err, oidc.ErrEmailNotVerify) {
+		log.Ctx(ctx).Warn().Err(err).Msg("oidc login rejected")
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.loginOIDCIdentity(ctx, identity)
+}
+
+// loginOIDCIdentity logs in the user of the identity verified by the OpenID Connect provider.
+func (c *Controller) loginOIDCIdentity(ctx context.Context, identity *oidc.Identity) (*LoginOIDCCallbackOutput, error) {
+	user, err := c.findExternalUser(ctx, externalIdentity{
+		provider:      types.PrincipalIdentityProviderOIDC,
+		externalID:    identity.Subject,
+		username:      identity.Username,
+		email:         identity.Email,
+		displayName:   identity.DisplayName,
+		autoProvision: c.oidcProvider.AutoProvision(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		c.auditLoginFailed(ctx, user.UID, "user_blocked")
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	err = c.groupSyncer.Sync(ctx, user.ID, identity.Groups, c.oidcProvider.GroupMappings())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync group memberships: %w", err)
+	}
+
+	// the provider can't relay a code of the second factor, hence it's always missing at this point.
+	tokenResponse, err := c.createLoginSession(ctx, user, loginMethodOIDC, &TOTPCodeInput{})
+	enrollmentRequired := errors.Is(err, errTwoFactorEnrollmentRequired)
+	if !errors.Is(err, errTwoFactorRequired) && !enrollmentRequired {
+		if err != nil {
+			return nil, err
+		}
+
+		return &LoginOIDCCallbackOutput{Token: tokenResponse}, nil
+	}
+
+	challenge, err := jwt.GenerateForLogin(user.ID, loginMethodOIDC, loginChallengeLifetime, user.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate login challenge: %w", err)
+	}
+
+	return &LoginOIDCCallbackOutput{
+		SecondFactorChallenge:          challenge,
+		SecondFactorEnrollmentRequired: enrollmentRequired,
+	}, nil
+}
+
+// LoginOIDCSecondFactor completes a login via the OpenID Connect provider that is awaiting the second factor.
+func (c *Controller) LoginOIDCSecondFactor(
+	ctx context.Context,
+	challenge string,
+	in *TOTPCodeInput,
+) (*types.TokenResponse, error) {
+	user, err := c.verifyLoginChallenge(ctx, challenge, loginMethodOIDC)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.createLoginSession(ctx, user, loginMethodOIDC, in)
+}
+
+// LoginOIDCTOTPEnroll starts the enrollment of two-factor authentication during a login via the
+// OpenID Connect provider, for users that are required to use two-factor authentication.
+// The enrollment is completed by LoginOIDCSecondFactor with a code of the authenticator app.
+func (c *Controller) LoginOIDCTOTPEnroll(ctx context.Context, challenge string) (*types.TOTPEnrollment, error) {
+	user, err := c.verifyLoginChallenge(ctx, challenge, loginMethodOIDC)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.startLoginTOTPEnrollment(ctx, user)
+}
+
+// verifyLoginChallenge returns the user of a pending login that was started with the provided method.
+func (c *Controller) verifyLoginChallenge(ctx context.Context, challenge string, method string) (*types.User, error) {
+	if challenge == "" {
+		return nil, errInvalidLoginChallenge
+	}
+
+	var user *types.User
+	_, claims, err := jwt.ParseForLogin(challenge, func(principalID int64) (string, error) {
+		var err error
+		user, err = c.principalStore.FindUser(ctx, principalID)
+		if err != nil {
+			return "", err
+		}
+		return user.Salt, nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("invalid login challenge")
+		return nil, errInvalidLoginChallenge
+	}
+
+	if claims.Method != method {
+		return nil, errInvalidLoginChallenge
+	}
+
+	// the user might have been blocked since the challenge was issued.
+	if user.Blocked {
+		c.auditLoginFailed(ctx, user.UID, "user_blocked")
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	return user, nil
+}
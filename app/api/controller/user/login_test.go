@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/services/groupsync"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/ratelimit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeTOTPStore struct {
+	store.TOTPStore
+	totps map[int64]*types.TOTP
+}
+
+func (s *fakeTOTPStore) Find(_ context.Context, principalID int64) (*types.TOTP, error) {
+	t, ok := s.totps[principalID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return t, nil
+}
+
+// fakeTokenStore fails the test if a session is created.
+type fakeTokenStore struct {
+	store.TokenStore
+	t *testing.T
+}
+
+func (s *fakeTokenStore) Create(context.Context, *types.Token) error {
+	s.t.Fatal("unexpected session created")
+	return nil
+}
+
+func newLoginTestController(
+	t *testing.T,
+	user *types.User,
+	totp *types.TOTP,
+	twoFactorRequired bool,
+) *Controller {
+	totps := map[int64]*types.TOTP{}
+	if totp != nil {
+		totps[user.ID] = totp
+	}
+
+	config := &types.Config{}
+	config.Auth.TwoFactorRequired = twoFactorRequired
+
+	return &Controller{
+		principalStore: &fakePrincipalStore{users: []*types.User{user}},
+		tokenStore:     &fakeTokenStore{t: t},
+		totpStore:      &fakeTOTPStore{totps: totps},
+		config:         config,
+		oidcProvider:   oidc.NewProvider(oidc.Config{Enabled: true}),
+		groupSyncer:    groupsync.NewSyncer("", nil, nil, nil),
+		identityStore: &fakePrincipalIdentityStore{identities: []*types.PrincipalIdentity{
+			{Provider: types.PrincipalIdentityProviderOIDC, ExternalID: "subject-1", PrincipalID: user.ID},
+		}},
+		rateLimiter: ratelimit.NewInMemory(ratelimit.Config{}),
+	}
+}
+
+func TestCreateLoginSession_SecondFactor(t *testing.T) {
+	user := &types.User{ID: 1, UID: "jdoe", Email: "jdoe@example.com", Salt: "salt"}
+
+	tests := []struct {
+		name              string
+		totp              *types.TOTP
+		twoFactorRequired bool
+		wantErr           error
+	}{
+		{
+			name:    "enabled",
+			totp:    &types.TOTP{PrincipalID: user.ID, Enabled: true},
+			wantErr: errTwoFactorRequired,
+		},
+		{
+			name:              "required",
+			twoFactorRequired: true,
+			wantErr:           errTwoFactorEnrollmentRequired,
+		},
+		{
+			name:              "required with pending enrollment",
+			totp:              &types.TOTP{PrincipalID: user.ID},
+			twoFactorRequired: true,
+			wantErr:           errTwoFactorEnrollmentRequired,
+		},
+	}
+
+	for _, test := range tests {
+		for _, method := range []string{loginMethodPassword, loginMethodLDAP, loginMethodOIDC} {
+			t.Run(test.name+"/"+method, func(t *testing.T) {
+				c := newLoginTestController(t, user, test.totp, test.twoFactorRequired)
+
+				_, err := c.createLoginSession(context.Background(), user, method, &TOTPCodeInput{})
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("expected error %v, got %v", test.wantErr, err)
+				}
+			})
+		}
+	}
+}
+
+func TestLoginOIDCIdentity_SecondFactor(t *testing.T) {
+	user := &types.User{ID: 1, UID: "jdoe", Email: "jdoe@example.com", Salt: "salt"}
+	identity := &oidc.Identity{Subject: "subject-1", Email: user.Email}
+	ctx := context.Background()
+
+	c := newLoginTestController(t, user, &types.TOTP{PrincipalID: user.ID, Enabled: true}, false)
+
+	out, err := c.loginOIDCIdentity(ctx, identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Token != nil || out.SecondFactorChallenge == "" || out.SecondFactorEnrollmentRequired {
+		t.Fatalf("expected second factor challenge, got %+v", out)
+	}
+
+	// the challenge alone doesn't complete the login.
+	_, err = c.LoginOIDCSecondFactor(ctx, out.SecondFactorChallenge, &TOTPCodeInput{})
+	if !errors.Is(err, errTwoFactorRequired) {
+		t.Fatalf("expected error %v, got %v", errTwoFactorRequired, err)
+	}
+
+	_, err = c.LoginOIDCSecondFactor(ctx, out.SecondFactorChallenge+"x", &TOTPCodeInput{Code: "123456"})
+	if !errors.Is(err, errInvalidLoginChallenge) {
+		t.Fatalf("expected error %v, got %v", errInvalidLoginChallenge, err)
+	}
+
+	// challenges of other login methods aren't accepted.
+	challenge, err := jwt.GenerateForLogin(user.ID, loginMethodLDAP, loginChallengeLifetime, user.Salt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = c.LoginOIDCSecondFactor(ctx, challenge, &TOTPCodeInput{Code: "123456"})
+	if !errors.Is(err, errInvalidLoginChallenge) {
+		t.Fatalf("expected error %v, got %v", errInvalidLoginChallenge, err)
+	}
+}
+
+func TestLoginOIDCIdentity_EnrollmentRequired(t *testing.T) {
+	user := &types.User{ID: 1, UID: "jdoe", Email: "jdoe@example.com", Salt: "salt"}
+	identity := &oidc.Identity{Subject: "subject-1", Email: user.Email}
+	ctx := context.Background()
+
+	c := newLoginTestController(t, user, nil, true)
+
+	out, err := c.loginOIDCIdentity(ctx, identity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Token != nil || out.SecondFactorChallenge == "" || !out.SecondFactorEnrollmentRequired {
+		t.Fatalf("expected second factor enrollment challenge, got %+v", out)
+	}
+
+	_, err = c.LoginOIDCSecondFactor(ctx, out.SecondFactorChallenge, &TOTPCodeInput{})
+	if !errors.Is(err, errTwoFactorEnrollmentRequired) {
+		t.Fatalf("expected error %v, got %v", errTwoFactorEnrollmentRequired, err)
+	}
+}
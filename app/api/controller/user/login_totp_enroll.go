@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+// LoginTOTPEnroll starts the enrollment of two-factor authentication during login, for users that are
+// required to use two-factor authentication but aren't enrolled yet, and thus can't get a session.
+// The enrollment is completed by logging in with a code of the authenticator app.
+func (c *Controller) LoginTOTPEnroll(ctx context.Context, in *LoginInput) (*types.TOTPEnrollment, error) {
+	// no auth check required, password is used for it.
+
+	user, _, err := c.verifyLoginCredentials(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.startLoginTOTPEnrollment(ctx, user)
+}
+
+// startLoginTOTPEnrollment starts the enrollment of the user after the first factor of the login was verified.
+func (c *Controller) startLoginTOTPEnrollment(ctx context.Context, user *types.User) (*types.TOTPEnrollment, error) {
+	if !c.config.Auth.TwoFactorRequired {
+		return nil, usererror.BadRequest("Two-factor authentication isn't required, please enroll after login")
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t != nil && t.Enabled {
+		return nil, usererror.Conflict("Two-factor authentication is already enabled")
+	}
+
+	return c.startTOTPEnrollment(ctx, user)
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+// maxProvisionedUIDAttempts is the number of suffixes tried to find a free UID for a provisioned user.
+const maxProvisionedUIDAttempts = 10
+
+var illegalUIDCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9-_.]+`)
+
+// externalIdentity is the identity of a user at an external identity provider.
+type externalIdentity struct {
+	provider      string
+	externalID    string
+	username      string
+	email         string
+	displayName   string
+	autoProvision bool
+}
+
+// findExternalUser returns the user linked to the identity of the external identity provider.
+// On the first login of the identity a new user is provisioned and linked to it, if enabled.
+// Existing users are never linked to an identity automatically, even if the emails match, as this would allow
+// anyone controlling an identity of the provider to take over users (e.g. admins) with the same email.
+// Instead, an admin has to link them explicitly (see AdminLinkIdentity).
+func (c *Controller) findExternalUser(ctx context.Context, identity externalIdentity) (*types.User, error) {
+	if identity.externalID == "" {
+		return nil, fmt.Errorf("%s identity of %q is missing its external id", identity.provider, identity.email)
+	}
+
+	link, err := c.identityStore.Find(ctx, identity.provider, identity.externalID)
+	if err == nil {
+		return c.principalStore.FindUser(ctx, link.PrincipalID)
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find principal identity: %w", err)
+	}
+
+	_, err = findUserFromEmail(ctx, c.principalStore, identity.email)
+	if err == nil {
+		log.Ctx(ctx).Warn().Msgf("refused %s login of %q, the email belongs to a user that isn't linked to it",
+			identity.provider, identity.externalID)
+		return nil, usererror.Forbidden(
+			"A user with the same email already exists. An administrator has to link the user to this login first")
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if !identity.autoProvision {
+		log.Ctx(ctx).Info().Msgf("refused %s login of %q, the identity isn't linked to any user",
+			identity.provider, identity.externalID)
+		return nil, usererror.Forbidden("User doesn't exist")
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = c.provisionUser(ctx, identity.username, identity.email, identity.displayName)
+		if err != nil {
+			return err
+		}
+
+		err = c.identityStore.Create(ctx, &types.PrincipalIdentity{
+			Provider:    identity.provider,
+			ExternalID:  identity.externalID,
+			PrincipalID: user.ID,
+			Created:     time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link provisioned user to its identity: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUser creates a new user for an identity of an external identity provider.
+// The UID is derived from the username (or the local part of the email) and made unique.
+// The user gets a random password, as it is expected to log in via the identity provider only.
+func (c *Controller) provisionUser(
+	ctx context.Context,
+	username string,
+	email string,
+	displayName string,
+) (*types.User, error) {
+	uid := username
+	if uid == "" {
+		uid, _, _ = strings.Cut(email, "@")
+	}
+
+	uid = illegalUIDCharsRegex.ReplaceAllString(uid, "-")
+	if len(uid) > check.MaxIdentifierLength-5 {
+		uid = uid[:check.MaxIdentifierLength-5]
+	}
+	if uid == "" {
+		uid = "user"
+	}
+
+	if displayName == "" {
+		displayName = uid
+	}
+
+	candidate := uid
+	for i := 1; ; i++ {
+		_, err := findUserFromUID(ctx, c.principalStore, candidate)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check uid of provisioned user: %w", err)
+		}
+
+		if i >= maxProvisionedUIDAttempts {
+			return nil, fmt.Errorf("failed to find a free uid for provisioned user %q", uid)
+		}
+
+		candidate = fmt.Sprintf("%s-%d", uid, i)
+	}
+
+	user, err := c.CreateNoAuth(ctx, &CreateInput{
+		UID:         candidate,
+		Email:       email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(32),
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("provisioned user %q with email %q", user.UID, user.Email)
+
+	return user, nil
+}
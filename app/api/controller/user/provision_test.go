@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	users []*types.User
+}
+
+func (s *fakePrincipalStore) FindUser(_ context.Context, id int64) (*types.User, error) {
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePrincipalStore) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	for _, user := range s.users {
+		if user.UID == uid {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePrincipalStore) FindUserByEmail(_ context.Context, email string) (*types.User, error) {
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakePrincipalIdentityStore struct {
+	store.PrincipalIdentityStore
+	identities []*types.PrincipalIdentity
+}
+
+func (s *fakePrincipalIdentityStore) Find(
+	_ context.Context,
+	provider string,
+	externalID string,
+) (*types.PrincipalIdentity, error) {
+	for _, identity := range s.identities {
+		if identity.Provider == provider && identity.ExternalID == externalID {
+			return identity, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePrincipalIdentityStore) Create(_ context.Context, identity *types.PrincipalIdentity) error {
+	if _, err := s.Find(context.Background(), identity.Provider, identity.ExternalID); err == nil {
+		return gitness_store.ErrDuplicate
+	}
+	s.identities = append(s.identities, identity)
+	return nil
+}
+
+func TestFindExternalUser_EmailCollision(t *testing.T) {
+	admin := &types.User{ID: 1, UID: "admin", Email: "admin@example.com", Admin: true}
+	identityStore := &fakePrincipalIdentityStore{}
+	c := &Controller{
+		principalStore: &fakePrincipalStore{users: []*types.User{admin}},
+		identityStore:  identityStore,
+	}
+
+	for _, provider := range []string{types.PrincipalIdentityProviderLDAP, types.PrincipalIdentityProviderOIDC} {
+		user, err := c.findExternalUser(context.Background(), externalIdentity{
+			provider:      provider,
+			externalID:    "attacker",
+			username:      "attacker",
+			email:         admin.Email,
+			autoProvision: true,
+		})
+		if user != nil {
+			t.Fatalf("%s: expected no user, got %q", provider, user.UID)
+		}
+
+		var uErr *usererror.Error
+		if !errors.As(err, &uErr) || uErr.Status != http.StatusForbidden {
+			t.Fatalf("%s: expected forbidden error, got %v", provider, err)
+		}
+	}
+
+	if len(identityStore.identities) != 0 {
+		t.Fatalf("expected no identity to be linked, got %d", len(identityStore.identities))
+	}
+}
+
+func TestFindExternalUser_Linked(t *testing.T) {
+	linked := &types.User{ID: 2, UID: "jdoe", Email: "old@example.com"}
+	c := &Controller{
+		principalStore: &fakePrincipalStore{users: []*types.User{linked}},
+		identityStore: &fakePrincipalIdentityStore{identities: []*types.PrincipalIdentity{
+			{Provider: types.PrincipalIdentityProviderOIDC, ExternalID: "subject-1", PrincipalID: linked.ID},
+		}},
+	}
+
+	// the link is kept, even if the email changed at the provider.
+	user, err := c.findExternalUser(context.Background(), externalIdentity{
+		provider:   types.PrincipalIdentityProviderOIDC,
+		externalID: "subject-1",
+		email:      "new@example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != linked.ID {
+		t.Fatalf("expected user %d, got %d", linked.ID, user.ID)
+	}
+
+	// the same external id of another provider isn't linked.
+	_, err = c.findExternalUser(context.Background(), externalIdentity{
+		provider:   types.PrincipalIdentityProviderLDAP,
+		externalID: "subject-1",
+		email:      "new@example.com",
+	})
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusForbidden {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
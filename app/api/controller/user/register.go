@@ -35,6 +35,10 @@ type RegisterInput struct {
 // This doesn't require auth, but has limited functionalities (unable to create admin user for example).
 func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 	in *RegisterInput) (*types.TokenResponse, error) {
+	if c.config.Auth.PasswordLoginDisabled {
+		return nil, usererror.Forbidden("Registration with password is disabled")
+	}
+
 	signUpAllowed, err := sysCtrl.IsUserSignupAllowed(ctx)
 	if err != nil {
 		return nil, err
@@ -54,6 +58,11 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// no session is issued until the user enrolled a second factor, which can be done on login.
+	if c.config.Auth.TwoFactorRequired {
+		return nil, errTwoFactorEnrollmentRequired
+	}
+
 	// TODO: how should we name session tokens?
 	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register")
 	if err != nil {
@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/totp"
+	"github.com/harness/gitness/app/crypto"
+	"github.com/harness/gitness/ratelimit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+)
+
+// totpRecoveryCodeCount is the number of recovery codes generated on enrollment.
+const totpRecoveryCodeCount = 10
+
+// totpAttemptLimit limits the attempts to verify a code of the second factor per user
+// to prevent brute forcing the short codes: five attempts at once, afterward one attempt per minute.
+var totpAttemptLimit = ratelimit.Limit{Rate: 1.0 / 60, Burst: 5}
+
+var (
+	// errTwoFactorRequired is returned on login if the user has to provide a code of the second factor.
+	errTwoFactorRequired = usererror.NewWithPayload(http.StatusUnauthorized,
+		"Two-factor authentication code required", map[string]any{"two_factor_required": true})
+
+	// errTwoFactorEnrollmentRequired is returned on login if the user has to enroll a second factor first.
+	errTwoFactorEnrollmentRequired = usererror.NewWithPayload(http.StatusForbidden,
+		"Two-factor authentication is required, please enroll first",
+		map[string]any{"two_factor_enrollment_required": true})
+
+	// errInvalidTwoFactorCode is returned if the provided code of the second factor is invalid.
+	errInvalidTwoFactorCode = usererror.New(http.StatusUnauthorized, "Invalid two-factor authentication code")
+
+	// errTooManyTwoFactorAttempts is returned if the user exceeded the attempts to provide a code of the second factor.
+	errTooManyTwoFactorAttempts = usererror.New(http.StatusTooManyRequests,
+		"Too many two-factor authentication attempts, please retry later")
+)
+
+type TOTPCodeInput struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// findSessionUser returns the user of the session. TOTP is only managed by the users themselves.
+func (c *Controller) findSessionUser(ctx context.Context, session *auth.Session) (*types.User, error) {
+	if session.Principal.Type != enum.PrincipalTypeUser {
+		return nil, usererror.BadRequest("Two-factor authentication is only supported for users")
+	}
+
+	return c.principalStore.FindUser(ctx, session.Principal.ID)
+}
+
+// findTOTP returns the TOTP configuration of the user, or nil if the user doesn't have one.
+func (c *Controller) findTOTP(ctx context.Context, userID int64) (*types.TOTP, error) {
+	t, err := c.totpStore.Find(ctx, userID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // no totp configured is a valid state.
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp: %w", err)
+	}
+
+	return t, nil
+}
+
+// startTOTPEnrollment generates a new secret and new recovery codes for the user, replacing any pending enrollment.
+// The enrollment becomes effective once a valid code is provided.
+func (c *Controller) startTOTPEnrollment(ctx context.Context, user *types.User) (*types.TOTPEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedSecret, err := c.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	err = c.totpStore.Upsert(ctx, &types.TOTP{
+		PrincipalID:   user.ID,
+		Secret:        string(encryptedSecret),
+		Enabled:       false,
+		RecoveryCodes: recoveryCodeHashes,
+		Created:       now,
+		Updated:       now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store totp: %w", err)
+	}
+
+	return &types.TOTPEnrollment{
+		Secret:        secret,
+		URI:           totp.URI(c.config.Principal.System.DisplayName, user.Email, secret),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// checkTOTPAttempt consumes an attempt to verify a code of the second factor of the user.
+func (c *Controller) checkTOTPAttempt(ctx context.Context, principalID int64) error {
+	res, err := c.rateLimiter.Allow(ctx, "totp:"+strconv.FormatInt(principalID, 10), totpAttemptLimit)
+	if err != nil {
+		return fmt.Errorf("failed to check two-factor authentication attempts: %w", err)
+	}
+
+	if !res.Allowed {
+		return errTooManyTwoFactorAttempts
+	}
+
+	return nil
+}
+
+// verifyTOTPCode verifies the code against the TOTP secret of the user.
+// A code is accepted only once.
+func (c *Controller) verifyTOTPCode(ctx context.Context, t *types.TOTP, code string) (bool, error) {
+	if err := c.checkTOTPAttempt(ctx, t.PrincipalID); err != nil {
+		return false, err
+	}
+
+	secret, err := c.encrypter.Decrypt([]byte(t.Secret))
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	step, ok, err := totp.Validate(secret, code, time.Now(), t.LastUsedStep)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ok, err = c.totpStore.UpdateLastUsedStep(ctx, t.PrincipalID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to update last used step of totp: %w", err)
+	}
+
+	t.LastUsedStep = step
+
+	return ok, nil
+}
+
+// useRecoveryCode verifies the recovery code and removes it, as every recovery code can be used only once.
+func (c *Controller) useRecoveryCode(ctx context.Context, t *types.TOTP, code string) (bool, error) {
+	if err := c.checkTOTPAttempt(ctx, t.PrincipalID); err != nil {
+		return false, err
+	}
+
+	hash := crypto.HashRandomSecret(totp.NormalizeRecoveryCode(code))
+
+	for {
+		idx := slices.IndexFunc(t.RecoveryCodes, func(h string) bool {
+			return subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1
+		})
+		if idx < 0 {
+			return false, nil
+		}
+
+		recoveryCodes := slices.Delete(slices.Clone(t.RecoveryCodes), idx, idx+1)
+
+		ok, err := c.totpStore.UpdateRecoveryCodes(ctx, t.PrincipalID, t.RecoveryCodes, recoveryCodes)
+		if err != nil {
+			return false, fmt.Errorf("failed to remove used recovery code: %w", err)
+		}
+
+		if ok {
+			t.RecoveryCodes = recoveryCodes
+			return true, nil
+		}
+
+		// the recovery codes got changed in the meantime (e.g. by a concurrent login), check the code again.
+		current, err := c.findTOTP(ctx, t.PrincipalID)
+		if err != nil {
+			return false, err
+		}
+		if current == nil {
+			return false, nil
+		}
+
+		t.RecoveryCodes = current.RecoveryCodes
+	}
+}
+
+// verifySecondFactor verifies either the TOTP code or a recovery code of an enrolled user.
+func (c *Controller) verifySecondFactor(ctx context.Context, user *types.User, t *types.TOTP,
+	in *TOTPCodeInput) error {
+	var ok bool
+	var err error
+
+	switch {
+	case in.Code != "":
+		ok, err = c.verifyTOTPCode(ctx, t, in.Code)
+	case in.RecoveryCode != "":
+		ok, err = c.useRecoveryCode(ctx, t, in.RecoveryCode)
+		if ok {
+			log.Ctx(ctx).Info().Msgf("user %q used a recovery code, %d recovery codes left",
+				user.UID, len(t.RecoveryCodes))
+		}
+	default:
+		return errTwoFactorRequired
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// checkLoginSecondFactor verifies the second factor on login, independent of the login method.
+// If two-factor authentication is required but the user isn't enrolled yet, a pending enrollment
+// is confirmed with the provided code, otherwise the login is rejected until the user enrolled.
+func (c *Controller) checkLoginSecondFactor(ctx context.Context, user *types.User, in *TOTPCodeInput) error {
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if t != nil && t.Enabled {
+		return c.verifySecondFactor(ctx, user, t, in)
+	}
+
+	if !c.config.Auth.TwoFactorRequired {
+		return nil
+	}
+
+	if t == nil || in.Code == "" {
+		return errTwoFactorEnrollmentRequired
+	}
+
+	return c.confirmTOTPEnrollment(ctx, user, t, in.Code)
+}
+
+// confirmTOTPEnrollment enables a pending TOTP enrollment in case the code is valid.
+func (c *Controller) confirmTOTPEnrollment(ctx context.Context, user *types.User, t *types.TOTP, code string) error {
+	ok, err := c.verifyTOTPCode(ctx, t, code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errInvalidTwoFactorCode
+	}
+
+	t.Enabled = true
+	if err = c.totpStore.Update(ctx, t); err != nil {
+		return fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("user %q enabled two-factor authentication", user.UID)
+
+	return nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes, err := totp.GenerateRecoveryCodes(totpRecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = crypto.HashRandomSecret(code)
+	}
+
+	return codes, hashes, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TOTPDisable disables two-factor authentication of the current user.
+// A valid code or recovery code is required, and it isn't possible if two-factor authentication is enforced.
+func (c *Controller) TOTPDisable(ctx context.Context, session *auth.Session, in *TOTPCodeInput) error {
+	user, err := c.findSessionUser(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	if c.config.Auth.TwoFactorRequired {
+		return usererror.Forbidden("Two-factor authentication is required and can't be disabled")
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return usererror.ErrNotFound
+	}
+
+	// a pending enrollment can be canceled without a code.
+	if t.Enabled {
+		if err = c.verifySecondFactor(ctx, user, t, in); err != nil {
+			return err
+		}
+	}
+
+	if err = c.totpStore.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete totp: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("user %q disabled two-factor authentication", user.UID)
+
+	return nil
+}
+
+// TOTPRegenerateRecoveryCodes replaces the recovery codes of the current user with new ones.
+// A valid code of the authenticator app is required.
+func (c *Controller) TOTPRegenerateRecoveryCodes(ctx context.Context, session *auth.Session,
+	in *TOTPCodeInput) ([]string, error) {
+	user, err := c.findSessionUser(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil || !t.Enabled {
+		return nil, usererror.BadRequest("Two-factor authentication isn't enabled")
+	}
+
+	// recovery codes can't be used to get new recovery codes.
+	if err = c.verifySecondFactor(ctx, user, t, &TOTPCodeInput{Code: in.Code}); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	t.RecoveryCodes = hashes
+	if err = c.totpStore.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
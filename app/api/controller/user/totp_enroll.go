@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TOTPFind returns the status of the two-factor authentication of the current user.
+func (c *Controller) TOTPFind(ctx context.Context, session *auth.Session) (*types.TOTPStatus, error) {
+	user, err := c.findSessionUser(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil || !t.Enabled {
+		return &types.TOTPStatus{}, nil
+	}
+
+	return &types.TOTPStatus{
+		Enabled:           true,
+		RecoveryCodesLeft: len(t.RecoveryCodes),
+	}, nil
+}
+
+// TOTPEnroll starts the enrollment of two-factor authentication for the current user.
+// The returned secret has to be added to an authenticator app and confirmed with TOTPConfirm.
+func (c *Controller) TOTPEnroll(ctx context.Context, session *auth.Session) (*types.TOTPEnrollment, error) {
+	user, err := c.findSessionUser(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t != nil && t.Enabled {
+		return nil, usererror.Conflict("Two-factor authentication is already enabled")
+	}
+
+	return c.startTOTPEnrollment(ctx, user)
+}
+
+// TOTPConfirm completes the enrollment of two-factor authentication with a code of the authenticator app.
+func (c *Controller) TOTPConfirm(ctx context.Context, session *auth.Session,
+	in *TOTPCodeInput) (*types.TOTPStatus, error) {
+	user, err := c.findSessionUser(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	t, err := c.findTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, usererror.BadRequest("Two-factor authentication enrollment wasn't started")
+	}
+
+	if t.Enabled {
+		return nil, usererror.Conflict("Two-factor authentication is already enabled")
+	}
+
+	if err = c.confirmTOTPEnrollment(ctx, user, t, in.Code); err != nil {
+		return nil, err
+	}
+
+	return &types.TOTPStatus{
+		Enabled:           true,
+		RecoveryCodesLeft: len(t.RecoveryCodes),
+	}, nil
+}
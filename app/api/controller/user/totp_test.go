@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/crypto"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// fakeRecoveryCodesTOTPStore updates the recovery codes only if they match the expected ones.
+type fakeRecoveryCodesTOTPStore struct {
+	fakeTOTPStore
+}
+
+func (s *fakeRecoveryCodesTOTPStore) UpdateRecoveryCodes(
+	_ context.Context,
+	principalID int64,
+	expected []string,
+	recoveryCodes []string,
+) (bool, error) {
+	t := s.totps[principalID]
+	if t == nil || !slices.Equal(t.RecoveryCodes, expected) {
+		return false, nil
+	}
+
+	t.RecoveryCodes = recoveryCodes
+	return true, nil
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	user := &types.User{ID: 1, UID: "jdoe", Email: "jdoe@example.com", Salt: "salt"}
+	ctx := context.Background()
+
+	codes := []string{"aaaaa-aaaaa", "bbbbb-bbbbb", "ccccc-ccccc"}
+	stored := &types.TOTP{PrincipalID: user.ID, Enabled: true}
+	for _, code := range codes {
+		stored.RecoveryCodes = append(stored.RecoveryCodes, crypto.HashRandomSecret(code))
+	}
+
+	c := newLoginTestController(t, user, stored, false)
+	c.totpStore = &fakeRecoveryCodesTOTPStore{fakeTOTPStore: fakeTOTPStore{totps: map[int64]*types.TOTP{
+		user.ID: stored,
+	}}}
+
+	// two concurrent logins loaded the same recovery codes.
+	t1 := &types.TOTP{PrincipalID: user.ID, RecoveryCodes: slices.Clone(stored.RecoveryCodes)}
+	t2 := &types.TOTP{PrincipalID: user.ID, RecoveryCodes: slices.Clone(stored.RecoveryCodes)}
+
+	ok, err := c.useRecoveryCode(ctx, t1, codes[0])
+	if err != nil || !ok {
+		t.Fatalf("expected recovery code to be accepted, got ok=%t err=%v", ok, err)
+	}
+
+	// the same recovery code isn't accepted again, even with outdated recovery codes.
+	ok, err = c.useRecoveryCode(ctx, t2, codes[0])
+	if err != nil || ok {
+		t.Fatalf("expected used recovery code to be rejected, got ok=%t err=%v", ok, err)
+	}
+
+	// other recovery codes are still accepted.
+	ok, err = c.useRecoveryCode(ctx, t2, codes[1])
+	if err != nil || !ok {
+		t.Fatalf("expected recovery code to be accepted, got ok=%t err=%v", ok, err)
+	}
+
+	if !slices.Equal(stored.RecoveryCodes, []string{crypto.HashRandomSecret(codes[2])}) {
+		t.Errorf("unexpected recovery codes left: %v", stored.RecoveryCodes)
+	}
+}
+
+func TestVerifySecondFactor_Throttling(t *testing.T) {
+	user := &types.User{ID: 1, UID: "jdoe", Email: "jdoe@example.com", Salt: "salt"}
+	ctx := context.Background()
+
+	stored := &types.TOTP{PrincipalID: user.ID, Enabled: true}
+	c := newLoginTestController(t, user, stored, false)
+
+	for i := 0; i < totpAttemptLimit.Burst; i++ {
+		err := c.verifySecondFactor(ctx, user, stored, &TOTPCodeInput{RecoveryCode: "wrong"})
+		if !errors.Is(err, errInvalidTwoFactorCode) {
+			t.Fatalf("attempt %d: expected error %v, got %v", i, errInvalidTwoFactorCode, err)
+		}
+	}
+
+	for _, in := range []*TOTPCodeInput{{RecoveryCode: "wrong"}, {Code: "123456"}} {
+		err := c.verifySecondFactor(ctx, user, stored, in)
+		if !errors.Is(err, errTooManyTwoFactorAttempts) {
+			t.Fatalf("expected error %v, got %v", errTooManyTwoFactorAttempts, err)
+		}
+	}
+
+	// other users aren't affected.
+	other := &types.TOTP{PrincipalID: 2, Enabled: true}
+	err := c.verifySecondFactor(ctx, user, other, &TOTPCodeInput{RecoveryCode: "wrong"})
+	if !errors.Is(err, errInvalidTwoFactorCode) {
+		t.Fatalf("expected error %v, got %v", errInvalidTwoFactorCode, err)
+	}
+}
@@ -22,10 +22,12 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -53,6 +55,8 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
+	oldUser := *user
+
 	if in.DisplayName != nil {
 		user.DisplayName = *in.DisplayName
 	}
@@ -74,6 +78,25 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	auditOptions := []audit.Option{
+		audit.WithOldObject(oldUser),
+		audit.WithNewObject(user),
+	}
+	if in.Password != nil {
+		auditOptions = append(auditOptions, audit.WithData("password_changed", "true"))
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionUpdated,
+		"",
+		auditOptions...,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update user operation: %s", err)
+	}
+
 	return user, nil
 }
 
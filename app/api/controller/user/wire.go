@@ -16,8 +16,15 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -35,6 +42,17 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	totpStore store.TOTPStore,
+	encrypter encrypt.Encrypter,
+	config *types.Config,
+	oidcProvider *oidc.Provider,
+	ldapClient *ldap.Client,
+	groupSyncer *groupsync.Syncer,
+	auditService audit.Service,
+	identityStore store.PrincipalIdentityStore,
+	rateLimiter ratelimit.RateLimiter,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +60,16 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		spaceStore,
+		repoStore,
+		totpStore,
+		encrypter,
+		config,
+		oidcProvider,
+		ldapClient,
+		groupSyncer,
+		auditService,
+		identityStore,
+		rateLimiter)
 }
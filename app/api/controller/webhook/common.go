@@ -15,12 +15,25 @@
 package webhook
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 const (
@@ -28,6 +41,35 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxChatMentions defines the max allowed number of chat mentions of a webhook.
+	webhookMaxChatMentions = 1000
+	// webhookMaxChatHandleLength defines the max allowed length of a chat handle.
+	webhookMaxChatHandleLength = 256
+	// webhookMaxFilterPatterns defines the max allowed number of patterns per payload filter condition.
+	webhookMaxFilterPatterns = 100
+	// webhookMaxFilterExpressionLength defines the max allowed length of a payload filter expression.
+	webhookMaxFilterExpressionLength = 1024
+	// webhookMaxPayloadTemplateLength defines the max allowed length of a payload template.
+	webhookMaxPayloadTemplateLength = 16384
+	// webhookMaxPayloadMappingFields defines the max allowed number of fields of a payload mapping.
+	webhookMaxPayloadMappingFields = 100
+	// webhookMaxHeaders defines the max allowed number of custom headers of a webhook.
+	webhookMaxHeaders = 50
+	// webhookMaxHeaderValueLength defines the max allowed length of a custom header value.
+	webhookMaxHeaderValueLength = 4096
+)
+
+var (
+	// headerNameRegex matches valid HTTP header names (tokens as defined in RFC 7230).
+	headerNameRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+	// reservedHeaders are set by the system and can't be provided as custom headers.
+	reservedHeaders = map[string]bool{
+		"Content-Type":   true,
+		"Content-Length": true,
+		"Host":           true,
+		"User-Agent":     true,
+	}
 )
 
 var ErrInternalWebhookOperationNotAllowed = usererror.Forbidden("changes to internal webhooks are not allowed")
@@ -96,6 +138,201 @@ func checkTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// checkType validates the type of a webhook and returns the sanitized value.
+func checkType(webhookType enum.WebhookType) (enum.WebhookType, error) {
+	sanitized, ok := webhookType.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook type '%s' is invalid.", webhookType)
+	}
+
+	return sanitized, nil
+}
+
+// checkChatMentions validates the chat mentions of a webhook.
+func checkChatMentions(webhookType enum.WebhookType, mentions map[string]string) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	if !webhookType.IsChat() {
+		return check.NewValidationErrorf("Chat mentions are not supported by webhooks of type '%s'.", webhookType)
+	}
+
+	if len(mentions) > webhookMaxChatMentions {
+		return check.NewValidationErrorf("A webhook can have at most %d chat mentions.", webhookMaxChatMentions)
+	}
+
+	for uid, handle := range mentions {
+		if uid == "" || handle == "" {
+			return check.NewValidationError("Chat mentions require both a principal uid and a handle.")
+		}
+		if len(handle) > webhookMaxChatHandleLength {
+			return check.NewValidationErrorf("The chat handle of '%s' can be at most %d characters long.",
+				uid, webhookMaxChatHandleLength)
+		}
+	}
+
+	return nil
+}
+
+// checkPayloadFilter validates the payload filter of a webhook.
+func checkPayloadFilter(filter *types.WebhookPayloadFilter) error {
+	if filter == nil {
+		return nil
+	}
+
+	conditions := []struct {
+		name     string
+		patterns []string
+	}{
+		{name: "branch", patterns: filter.Branches},
+		{name: "tag", patterns: filter.Tags},
+		{name: "target branch", patterns: filter.TargetBranches},
+	}
+	for _, condition := range conditions {
+		if len(condition.patterns) > webhookMaxFilterPatterns {
+			return check.NewValidationErrorf("A webhook can have at most %d %s patterns.",
+				webhookMaxFilterPatterns, condition.name)
+		}
+		for _, pattern := range condition.patterns {
+			if pattern == "" || !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The %s pattern '%s' is invalid.", condition.name, pattern)
+			}
+		}
+	}
+
+	if len(filter.Expression) > webhookMaxFilterExpressionLength {
+		return check.NewValidationErrorf("The payload filter expression can be at most %d characters long.",
+			webhookMaxFilterExpressionLength)
+	}
+	if filter.Expression != "" {
+		if err := webhook.ValidatePayloadFilterExpression(filter.Expression); err != nil {
+			return check.NewValidationErrorf("The payload filter expression is invalid: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// checkContentType validates the content type of a webhook and returns the sanitized value.
+func checkContentType(contentType enum.WebhookContentType) (enum.WebhookContentType, error) {
+	sanitized, ok := contentType.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook content type '%s' is invalid.", contentType)
+	}
+
+	return sanitized, nil
+}
+
+// checkPayloadTemplate validates the payload template of a webhook.
+func checkPayloadTemplate(payloadTemplate *types.WebhookPayloadTemplate) error {
+	if payloadTemplate == nil {
+		return nil
+	}
+
+	if len(payloadTemplate.Template) > webhookMaxPayloadTemplateLength {
+		return check.NewValidationErrorf("The payload template can be at most %d characters long.",
+			webhookMaxPayloadTemplateLength)
+	}
+	if len(payloadTemplate.Mapping) > webhookMaxPayloadMappingFields {
+		return check.NewValidationErrorf("The payload mapping can have at most %d fields.",
+			webhookMaxPayloadMappingFields)
+	}
+	for field := range payloadTemplate.Mapping {
+		if field == "" {
+			return check.NewValidationError("The fields of the payload mapping can't be empty.")
+		}
+	}
+
+	if err := webhook.ValidatePayloadTemplate(payloadTemplate); err != nil {
+		return check.NewValidationErrorf("The payload template is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// checkPayloadFormat validates that the payload template and content type are supported by the webhook type.
+func checkPayloadFormat(
+	webhookType enum.WebhookType,
+	contentType enum.WebhookContentType,
+	payloadTemplate *types.WebhookPayloadTemplate,
+) error {
+	if !webhookType.IsChat() {
+		return nil
+	}
+
+	if !payloadTemplate.IsEmpty() {
+		return check.NewValidationErrorf("Payload templates are not supported by webhooks of type '%s'.",
+			webhookType)
+	}
+	if contentType == enum.WebhookContentTypeForm {
+		return check.NewValidationErrorf("Form encoding is not supported by webhooks of type '%s'.", webhookType)
+	}
+
+	return nil
+}
+
+// checkHeaders validates the custom headers of a webhook.
+func checkHeaders(headers map[string]string) error {
+	if len(headers) > webhookMaxHeaders {
+		return check.NewValidationErrorf("A webhook can have at most %d custom headers.", webhookMaxHeaders)
+	}
+
+	for name, value := range headers {
+		if !headerNameRegex.MatchString(name) {
+			return check.NewValidationErrorf("The header name '%s' is invalid.", name)
+		}
+		if reservedHeaders[http.CanonicalHeaderKey(name)] {
+			return check.NewValidationErrorf("The header '%s' is set by the system and can't be provided.", name)
+		}
+		if len(value) > webhookMaxHeaderValueLength {
+			return check.NewValidationErrorf("The value of header '%s' can be at most %d characters long.",
+				name, webhookMaxHeaderValueLength)
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return check.NewValidationErrorf("The value of header '%s' contains invalid characters.", name)
+		}
+		if _, err := webhook.SecretReferences(value); err != nil {
+			return check.NewValidationErrorf("The value of header '%s' is invalid: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkHeaderSecrets ensures that all secrets referenced by the custom headers exist in the space
+// and that the principal is allowed to access them, as their values are sent with every delivery.
+func (c *Controller) checkHeaderSecrets(
+	ctx context.Context,
+	session *auth.Session,
+	parent *parent,
+	headers map[string]string,
+) error {
+	for name, value := range headers {
+		// syntax has been validated already
+		identifiers, _ := webhook.SecretReferences(value)
+		for _, identifier := range identifiers {
+			err := apiauth.CheckSecret(ctx, c.authorizer, session, parent.SpacePath(), identifier,
+				enum.PermissionSecretAccess)
+			if err != nil {
+				return fmt.Errorf("failed to verify access to secret '%s' referenced by header '%s': %w",
+					identifier, name, err)
+			}
+
+			_, err = c.secretStore.FindByIdentifier(ctx, parent.SpaceID, identifier)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return check.NewValidationErrorf("The secret '%s' referenced by header '%s' doesn't exist.",
+					identifier, name)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to find secret '%s': %w", identifier, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // deduplicateTriggers de-duplicates the triggers provided by the user.
 func deduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	if len(in) == 0 {
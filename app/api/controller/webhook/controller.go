@@ -18,14 +18,13 @@ import (
 	"context"
 	"fmt"
 
-	apiauth "github.com/harness/gitness/app/api/auth"
-	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
-	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -37,8 +36,11 @@ type Controller struct {
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
+	secretStore           store.SecretStore
 	webhookService        *webhook.Service
 	encrypter             encrypt.Encrypter
+	auditService          audit.Service
 }
 
 func NewController(
@@ -48,8 +50,11 @@ func NewController(
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	secretStore store.SecretStore,
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
+	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		allowLoopback:         allowLoopback,
@@ -58,25 +63,34 @@ func NewController(
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
 		repoStore:             repoStore,
+		spaceStore:            spaceStore,
+		secretStore:           secretStore,
 		webhookService:        webhookService,
 		encrypter:             encrypter,
+		auditService:          auditService,
 	}
 }
 
-func (c *Controller) getRepoCheckAccess(ctx context.Context,
-	session *auth.Session, repoRef string, reqPermission enum.Permission) (*types.Repository, error) {
-	if repoRef == "" {
-		return nil, usererror.BadRequest("A valid repository reference must be provided.")
-	}
-
-	repo, err := c.repoStore.FindByRef(ctx, repoRef)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find repo: %w", err)
-	}
+// parent is the repository or space a webhook belongs to.
+type parent = controller.Parent[enum.WebhookParent]
 
-	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, false); err != nil {
-		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+// getParentCheckAccess finds the parent of the webhooks and checks the required permission.
+// The permission is provided as repo permission and translated in case the parent is a space.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	reqPermission enum.Permission,
+) (*parent, error) {
+	switch parentType {
+	case enum.WebhookParentRepo:
+		return controller.GetRepoParentCheckAccess(ctx, c.authorizer, c.repoStore, session,
+			parentType, parentRef, reqPermission)
+	case enum.WebhookParentSpace:
+		return controller.GetSpaceParentCheckAccess(ctx, c.authorizer, c.spaceStore, session,
+			parentType, parentRef, reqPermission)
+	default:
+		return nil, fmt.Errorf("webhook parent type '%s' is not supported", parentType)
 	}
-
-	return repo, nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -43,6 +44,15 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	Type         enum.WebhookType  `json:"type"`
+	ChatMentions map[string]string `json:"chat_mentions"`
+
+	PayloadFilter *types.WebhookPayloadFilter `json:"payload_filter"`
+
+	ContentType     enum.WebhookContentType       `json:"content_type"`
+	PayloadTemplate *types.WebhookPayloadTemplate `json:"payload_template"`
+	Headers         map[string]string             `json:"headers"`
 }
 
 // Create creates a new webhook.
@@ -51,7 +61,8 @@ type CreateInput struct {
 func (c *Controller) Create(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	in *CreateInput,
 	internal bool,
 ) (*types.Webhook, error) {
@@ -63,11 +74,15 @@ func (c *Controller) Create(
 
 	now := time.Now().UnixMilli()
 
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = c.checkHeaderSecrets(ctx, session, parent, in.Headers); err != nil {
+		return nil, err
+	}
+
 	encryptedSecret, err := c.encrypter.Encrypt(in.Secret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
@@ -80,8 +95,8 @@ func (c *Controller) Create(
 		CreatedBy:  session.Principal.ID,
 		Created:    now,
 		Updated:    now,
-		ParentID:   repo.ID,
-		ParentType: enum.WebhookParentRepo,
+		ParentID:   parent.ID,
+		ParentType: parent.Type,
 		Internal:   internal,
 
 		// user input
@@ -94,6 +109,12 @@ func (c *Controller) Create(
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		Type:                  in.Type,
+		ChatMentions:          in.ChatMentions,
+		PayloadFilter:         in.PayloadFilter,
+		ContentType:           in.ContentType,
+		PayloadTemplate:       in.PayloadTemplate,
+		Headers:               in.Headers,
 	}
 
 	err = c.webhookStore.Create(ctx, hook)
@@ -101,11 +122,12 @@ func (c *Controller) Create(
 	// internal hooks are hidden from non-internal read requests - properly communicate their existence on duplicate.
 	// This is best effort, any error we just ignore and fallback to original duplicate error.
 	if errors.Is(err, store.ErrDuplicate) && !internal {
-		existingHook, derr := c.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repo.ID, hook.Identifier)
+		existingHook, derr := c.webhookStore.FindByIdentifier(ctx, parent.Type, parent.ID, hook.Identifier)
 		if derr != nil {
 			log.Ctx(ctx).Warn().Err(derr).Msgf(
-				"failed to retrieve webhook for repo %d with identifier %q on duplicate error",
-				repo.ID,
+				"failed to retrieve webhook for %s %d with identifier %q on duplicate error",
+				parent.Type,
+				parent.ID,
 				hook.Identifier,
 			)
 		}
@@ -118,6 +140,18 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to store webhook: %w", err)
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionCreated,
+		parent.SpacePath(),
+		audit.WithNewObject(hook),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -155,7 +189,44 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+
+	webhookType, err := checkType(in.Type)
+	if err != nil {
+		return err
+	}
+	in.Type = webhookType
+
+	if err = checkChatMentions(in.Type, in.ChatMentions); err != nil {
+		return err
+	}
+
+	if err = checkPayloadFilter(in.PayloadFilter); err != nil {
+		return err
+	}
+
+	contentType, err := checkContentType(in.ContentType)
+	if err != nil {
+		return err
+	}
+	in.ContentType = contentType
+
+	if in.PayloadTemplate.IsEmpty() {
+		in.PayloadTemplate = nil
+	}
+	if err = checkPayloadTemplate(in.PayloadTemplate); err != nil {
+		return err
+	}
+	if err = checkPayloadFormat(in.Type, in.ContentType, in.PayloadTemplate); err != nil {
+		return err
+	}
+
+	if len(in.Headers) == 0 {
+		in.Headers = nil
+	}
+	if err = checkHeaders(in.Headers); err != nil { //nolint:revive
 		return err
 	}
 
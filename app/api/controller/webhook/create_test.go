@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeAuthorizer grants all permissions except accessing secrets.
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return resource.Type != enum.ResourceTypeSecret || permission != enum.PermissionSecretAccess, nil
+}
+
+func (a fakeAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
+	permissionChecks ...types.PermissionCheck) (bool, error) {
+	for _, p := range permissionChecks {
+		ok, err := a.Check(ctx, session, &p.Scope, &p.Resource, p.Permission)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	space *types.Space
+}
+
+func (s *fakeSpaceStore) FindByRef(context.Context, string) (*types.Space, error) {
+	return s.space, nil
+}
+
+// fakeSecretStore contains every secret.
+type fakeSecretStore struct {
+	store.SecretStore
+}
+
+func (s *fakeSecretStore) FindByIdentifier(_ context.Context, spaceID int64, identifier string) (*types.Secret, error) {
+	return &types.Secret{SpaceID: spaceID, Identifier: identifier}, nil
+}
+
+func TestCreate_HeaderSecretWithoutAccess(t *testing.T) {
+	c := &Controller{
+		authorizer:   fakeAuthorizer{},
+		spaceStore:   &fakeSpaceStore{space: &types.Space{ID: 1, Path: "acme"}},
+		secretStore:  &fakeSecretStore{},
+		webhookStore: nil, // the webhook must not be created.
+	}
+	session := &auth.Session{Principal: types.Principal{ID: 2, Type: enum.PrincipalTypeUser}}
+
+	_, err := c.Create(context.Background(), session, enum.WebhookParentSpace, "acme", &CreateInput{
+		Identifier: "hook",
+		URL:        "https://203.0.113.10/hook",
+		Headers:    map[string]string{"Authorization": `Bearer ${{ secrets.get("token") }}`},
+	}, false)
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Fatalf("expected error %v, got %v", apiauth.ErrNotAuthorized, err)
+	}
+}
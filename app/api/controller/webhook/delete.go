@@ -16,26 +16,31 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Delete deletes an existing webhook.
 func (c *Controller) Delete(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 	allowDeletingInternal bool,
 ) error {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return err
 	}
 
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 	if err != nil {
 		return err
 	}
@@ -43,5 +48,21 @@ func (c *Controller) Delete(
 		return ErrInternalWebhookOperationNotAllowed
 	}
 	// delete webhook
-	return c.webhookStore.Delete(ctx, webhook.ID)
+	if err = c.webhookStore.Delete(ctx, webhook.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, webhook.Identifier),
+		audit.ActionDeleted,
+		parent.SpacePath(),
+		audit.WithOldObject(webhook),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete webhook operation: %s", err)
+	}
+
+	return nil
 }
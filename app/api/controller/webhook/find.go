@@ -26,24 +26,26 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-// Find finds a webhook from the provided repository.
+// Find finds a webhook from the provided repository or space.
 func (c *Controller) Find(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 ) (*types.Webhook, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	return c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 }
 
 func (c *Controller) getWebhookVerifyOwnership(
 	ctx context.Context,
-	repoID int64,
+	parentType enum.WebhookParent,
+	parentID int64,
 	webhookIdentifier string,
 ) (*types.Webhook, error) {
 	// TODO: Remove once webhook identifier migration completed
@@ -56,15 +58,16 @@ func (c *Controller) getWebhookVerifyOwnership(
 	if err == nil {
 		webhook, err = c.webhookStore.Find(ctx, webhookID)
 	} else {
-		webhook, err = c.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, repoID, webhookIdentifier)
+		webhook, err = c.webhookStore.FindByIdentifier(ctx, parentType, parentID, webhookIdentifier)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find webhook with identifier %q: %w", webhookIdentifier, err)
 	}
 
-	// ensure the webhook actually belongs to the repo
-	if webhook.ParentType != enum.WebhookParentRepo || webhook.ParentID != repoID {
-		return nil, fmt.Errorf("webhook doesn't belong to requested repo. Returning error %w", usererror.ErrNotFound)
+	// ensure the webhook actually belongs to the parent
+	if webhook.ParentType != parentType || webhook.ParentID != parentID {
+		return nil, fmt.Errorf("webhook doesn't belong to requested %s. Returning error %w",
+			parentType, usererror.ErrNotFound)
 	}
 
 	return webhook, nil
@@ -28,17 +28,18 @@ import (
 func (c *Controller) FindExecution(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 	webhookExecutionID int64,
 ) (*types.WebhookExecution, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, err
 	}
 
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -23,26 +23,27 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-// List returns the webhooks from the provided repository.
+// List returns the webhooks from the provided repository or space.
 func (c *Controller) List(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	filter *types.WebhookFilter,
 ) ([]*types.Webhook, int64, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := c.webhookStore.Count(ctx, enum.WebhookParentRepo, repo.ID, filter)
+	count, err := c.webhookStore.Count(ctx, parent.Type, parent.ID, filter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count webhooks for repo with id %d: %w", repo.ID, err)
+		return nil, 0, fmt.Errorf("failed to count webhooks for %s with id %d: %w", parent.Type, parent.ID, err)
 	}
 
-	webhooks, err := c.webhookStore.List(ctx, enum.WebhookParentRepo, repo.ID, filter)
+	webhooks, err := c.webhookStore.List(ctx, parent.Type, parent.ID, filter)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list webhooks for repo with id %d: %w", repo.ID, err)
+		return nil, 0, fmt.Errorf("failed to list webhooks for %s with id %d: %w", parent.Type, parent.ID, err)
 	}
 
 	return webhooks, count, nil
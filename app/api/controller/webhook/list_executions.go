@@ -27,17 +27,18 @@ import (
 func (c *Controller) ListExecutions(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 	filter *types.WebhookExecutionFilter,
 ) ([]*types.WebhookExecution, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, err
 	}
 
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+)
+
+type RedeliverFailedInput struct {
+	// Since is the time (unix milliseconds) from which on failed deliveries are redelivered.
+	Since int64 `json:"since"`
+}
+
+type RedeliverFailedOutput struct {
+	// Queued is the number of failed deliveries that got queued for redelivery.
+	Queued int64 `json:"queued"`
+}
+
+// RedeliverFailed queues all webhook deliveries that failed since the provided time and never succeeded.
+// The deliveries are re-attempted in the background, with the same backoff as automatic retries.
+func (c *Controller) RedeliverFailed(
+	ctx context.Context,
+	session *auth.Session,
+	in *RedeliverFailedInput,
+) (*RedeliverFailedOutput, error) {
+	if err := apiauth.CheckSystemAdmin(ctx, c.authorizer, session); err != nil {
+		return nil, err
+	}
+
+	if in.Since <= 0 {
+		return nil, usererror.BadRequest("A valid since timestamp must be provided.")
+	}
+
+	queued, err := c.webhookService.RedeliverFailedSince(ctx, time.UnixMilli(in.Since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver failed webhook executions: %w", err)
+	}
+
+	return &RedeliverFailedOutput{Queued: queued}, nil
+}
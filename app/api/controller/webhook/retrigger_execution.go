@@ -29,17 +29,18 @@ import (
 func (c *Controller) RetriggerExecution(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 	webhookExecutionID int64,
 ) (*types.WebhookExecution, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+		return nil, fmt.Errorf("failed to acquire access to the %s: %w", parentType, err)
 	}
 
 	// get the webhook and ensure it belongs to us
-	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type TestInput struct {
+	// Trigger is the trigger whose sample payload is delivered (defaults to the first trigger of the webhook).
+	Trigger enum.WebhookTrigger `json:"trigger"`
+	// DryRun only renders the request without sending it.
+	DryRun bool `json:"dry_run"`
+}
+
+// Test delivers a sample payload to an existing webhook.
+func (c *Controller) Test(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentRef string,
+	webhookIdentifier string,
+	in *TestInput,
+) (*types.WebhookExecution, error) {
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the %s: %w", parentType, err)
+	}
+
+	// get the webhook and ensure it belongs to us
+	webhook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	triggerType, err := sanitizeTestTrigger(in.Trigger, webhook.Triggers)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := c.webhookService.TestWebhook(ctx, webhook, triggerType,
+		parent.Repo, parent.SpacePath(), &session.Principal, in.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test webhook: %w", err)
+	}
+
+	return execution, nil
+}
+
+// sanitizeTestTrigger returns the trigger used for a test delivery.
+func sanitizeTestTrigger(
+	triggerType enum.WebhookTrigger,
+	webhookTriggers []enum.WebhookTrigger,
+) (enum.WebhookTrigger, error) {
+	if triggerType == "" {
+		if len(webhookTriggers) > 0 {
+			return webhookTriggers[0], nil
+		}
+		return enum.WebhookTriggerBranchCreated, nil
+	}
+
+	sanitized, ok := triggerType.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", triggerType)
+	}
+
+	return sanitized, nil
+}
@@ -19,9 +19,12 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type UpdateInput struct {
@@ -36,13 +39,27 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	Type *enum.WebhookType `json:"type"`
+	// ChatMentions replaces the chat mentions of the webhook if provided (an empty object removes all mentions).
+	ChatMentions map[string]string `json:"chat_mentions"`
+	// PayloadFilter replaces the payload filter of the webhook if provided (an empty object removes the filter).
+	PayloadFilter *types.WebhookPayloadFilter `json:"payload_filter"`
+
+	ContentType *enum.WebhookContentType `json:"content_type"`
+	// PayloadTemplate replaces the payload template of the webhook if provided
+	// (an empty object restores the default payload).
+	PayloadTemplate *types.WebhookPayloadTemplate `json:"payload_template"`
+	// Headers replaces the custom headers of the webhook if provided (an empty object removes all headers).
+	Headers map[string]string `json:"headers"`
 }
 
 // Update updates an existing webhook.
 func (c *Controller) Update(
 	ctx context.Context,
 	session *auth.Session,
-	repoRef string,
+	parentType enum.WebhookParent,
+	parentRef string,
 	webhookIdentifier string,
 	in *UpdateInput,
 	allowModifyingInternal bool,
@@ -51,13 +68,13 @@ func (c *Controller) Update(
 		return nil, err
 	}
 
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	parent, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return nil, err
 	}
 
 	// get the hook and ensure it belongs to us
-	hook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	hook, err := c.getWebhookVerifyOwnership(ctx, parent.Type, parent.ID, webhookIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +83,8 @@ func (c *Controller) Update(
 		return nil, ErrInternalWebhookOperationNotAllowed
 	}
 
+	oldHook := *hook
+
 	// update webhook struct (only for values that are provided)
 	if in.Identifier != nil {
 		hook.Identifier = *in.Identifier
@@ -95,11 +114,65 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.Type != nil {
+		hook.Type = *in.Type
+	}
+	if in.ChatMentions != nil {
+		hook.ChatMentions = in.ChatMentions
+	}
+	if in.PayloadFilter != nil {
+		hook.PayloadFilter = in.PayloadFilter
+		if in.PayloadFilter.IsEmpty() {
+			hook.PayloadFilter = nil
+		}
+	}
+	if in.ContentType != nil {
+		hook.ContentType = *in.ContentType
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = in.PayloadTemplate
+		if in.PayloadTemplate.IsEmpty() {
+			hook.PayloadTemplate = nil
+		}
+	}
+	if in.Headers != nil {
+		hook.Headers = in.Headers
+		if len(in.Headers) == 0 {
+			hook.Headers = nil
+		}
+	}
+
+	// the secrets referenced by the resulting headers are checked even if the headers weren't provided,
+	// as otherwise updating e.g. only the URL would send existing secrets to a destination of the caller's choice.
+	if err = c.checkHeaderSecrets(ctx, session, parent, hook.Headers); err != nil {
+		return nil, err
+	}
+
+	// the chat mentions and payload format have to be valid for the resulting type of the webhook.
+	if err = checkChatMentions(hook.Type, hook.ChatMentions); err != nil {
+		return nil, err
+	}
+	if err = checkPayloadFormat(hook.Type, hook.ContentType, hook.PayloadTemplate); err != nil {
+		return nil, err
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
 	}
 
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeWebhook, hook.Identifier),
+		audit.ActionUpdated,
+		parent.SpacePath(),
+		audit.WithOldObject(oldHook),
+		audit.WithNewObject(hook),
+		parent.AuditData(),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update webhook operation: %s", err)
+	}
+
 	return hook, nil
 }
 
@@ -139,6 +212,29 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.Type != nil {
+		webhookType, err := checkType(*in.Type)
+		if err != nil {
+			return err
+		}
+		in.Type = &webhookType
+	}
+	if err := checkPayloadFilter(in.PayloadFilter); err != nil {
+		return err
+	}
+	if in.ContentType != nil {
+		contentType, err := checkContentType(*in.ContentType)
+		if err != nil {
+			return err
+		}
+		in.ContentType = &contentType
+	}
+	if err := checkPayloadTemplate(in.PayloadTemplate); err != nil {
+		return err
+	}
+	if err := checkHeaders(in.Headers); err != nil { //nolint:revive
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// fakeWebhookStore contains a single webhook and fails on updates.
+type fakeWebhookStore struct {
+	store.WebhookStore
+	hook *types.Webhook
+}
+
+func (s *fakeWebhookStore) FindByIdentifier(
+	context.Context,
+	enum.WebhookParent,
+	int64,
+	string,
+) (*types.Webhook, error) {
+	hook := *s.hook
+	return &hook, nil
+}
+
+func (s *fakeWebhookStore) Update(context.Context, *types.Webhook) error {
+	return errors.New("the webhook must not be updated")
+}
+
+func TestUpdate_URLWithExistingHeaderSecretWithoutAccess(t *testing.T) {
+	c := &Controller{
+		authorizer:  fakeAuthorizer{},
+		spaceStore:  &fakeSpaceStore{space: &types.Space{ID: 1, Path: "acme"}},
+		secretStore: &fakeSecretStore{},
+		webhookStore: &fakeWebhookStore{hook: &types.Webhook{
+			ID:         3,
+			ParentID:   1,
+			ParentType: enum.WebhookParentSpace,
+			Identifier: "hook",
+			URL:        "https://203.0.113.10/hook",
+			Headers:    map[string]string{"Authorization": `Bearer ${{ secrets.get("token") }}`},
+		}},
+	}
+	session := &auth.Session{Principal: types.Principal{ID: 2, Type: enum.PrincipalTypeUser}}
+
+	newURL := "https://198.51.100.20/hook"
+	_, err := c.Update(context.Background(), session, enum.WebhookParentSpace, "acme", "hook", &UpdateInput{
+		URL: &newURL,
+	}, false)
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Fatalf("expected error %v, got %v", apiauth.ErrNotAuthorized, err)
+	}
+}
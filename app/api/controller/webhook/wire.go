@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
@@ -30,10 +31,11 @@ var WireSet = wire.NewSet(
 
 func ProvideController(config webhook.Config, authorizer authz.Authorizer,
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
-	repoStore store.RepoStore, webhookService *webhook.Service, encrypter encrypt.Encrypter,
+	repoStore store.RepoStore, spaceStore store.SpaceStore, secretStore store.SecretStore,
+	webhookService *webhook.Service, encrypter encrypt.Encrypter, auditService audit.Service,
 ) *Controller {
 	return NewController(
 		config.AllowLoopback, config.AllowPrivateNetwork, authorizer,
 		webhookStore, webhookExecutionStore,
-		repoStore, webhookService, encrypter)
+		repoStore, spaceStore, secretStore, webhookService, encrypter, auditService)
 }
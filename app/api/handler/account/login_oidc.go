@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+)
+
+// oidcStateCookieName is the name of the cookie that holds the state of an ongoing OIDC login.
+const oidcStateCookieName = "oidc_state"
+
+// oidcChallengeCookieName is the name of the cookie that holds the challenge of an OIDC login
+// that is awaiting the second factor.
+const oidcChallengeCookieName = "oidc_challenge"
+
+// oidcStateCookieExpiry is the time the user has to complete the login with the OIDC provider.
+const oidcStateCookieExpiry = 10 * time.Minute
+
+// HandleLoginOIDC returns an http.HandlerFunc that redirects the user to the OIDC provider.
+func HandleLoginOIDC(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		out, err := userCtrl.LoginOIDCStart(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		http.SetCookie(w, newOIDCStateCookie(r, out.AuthState.Encode(), time.Now().Add(oidcStateCookieExpiry)))
+
+		http.Redirect(w, r, out.RedirectURL, http.StatusFound)
+	}
+}
+
+func newOIDCStateCookie(r *http.Request, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:  oidcStateCookieName,
+		Value: value,
+		// the callback is a cross-site navigation coming from the provider, hence strict mode can't be used.
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+		Expires:  expires,
+	}
+}
+
+func newOIDCChallengeCookie(r *http.Request, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:  oidcChallengeCookieName,
+		Value: value,
+		// the second factor is provided from the UI, hence the cookie is never needed for cross-site requests.
+		SameSite: http.SameSiteStrictMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+		Expires:  expires,
+	}
+}
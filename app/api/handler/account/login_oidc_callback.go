@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+)
+
+// HandleLoginOIDCCallback returns an http.HandlerFunc that completes the login with the OIDC provider
+// and redirects the user to the UI on success.
+func HandleLoginOIDCCallback(userCtrl *user.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		stateCookie, err := r.Cookie(oidcStateCookieName)
+		if err != nil {
+			render.BadRequestf(ctx, w, "OIDC login state is missing.")
+			return
+		}
+
+		// the state can only be used once.
+		http.SetCookie(w, newOIDCStateCookie(r, "", time.UnixMilli(0)))
+
+		authState, err := oidc.DecodeAuthState(stateCookie.Value)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid OIDC login state: %s.", err)
+			return
+		}
+
+		query := r.URL.Query()
+		if providerErr := query.Get("error"); providerErr != "" {
+			render.TranslatedUserError(ctx, w,
+				usererror.Newf(http.StatusUnauthorized, "OIDC login failed: %s", providerErr))
+			return
+		}
+
+		out, err := userCtrl.LoginOIDCCallback(ctx, &user.LoginOIDCCallbackInput{
+			AuthState: authState,
+			State:     query.Get("state"),
+			Code:      query.Get("code"),
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if out.SecondFactorChallenge != "" {
+			// the UI completes the login with the second factor, the challenge is kept in a cookie for that.
+			http.SetCookie(w, newOIDCChallengeCookie(r, out.SecondFactorChallenge, time.Now().Add(oidcStateCookieExpiry)))
+
+			param := "two_factor_required"
+			if out.SecondFactorEnrollmentRequired {
+				param = "two_factor_enrollment_required"
+			}
+
+			http.Redirect(w, r, withQueryParam(uiURL, param, "true"), http.StatusFound)
+			return
+		}
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, out.Token, cookieName)
+		}
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+// withQueryParam returns the url with the query parameter added.
+func withQueryParam(rawURL string, key string, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
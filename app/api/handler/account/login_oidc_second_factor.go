@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+)
+
+// HandleLoginOIDCSecondFactor returns an http.HandlerFunc that completes an OIDC login
+// that is awaiting the second factor and returns an authentication token on success.
+func HandleLoginOIDCSecondFactor(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(user.TOTPCodeInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			return
+		}
+
+		tokenResponse, err := userCtrl.LoginOIDCSecondFactor(ctx, oidcChallenge(r), in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// the login is completed, hence the challenge isn't needed anymore.
+		http.SetCookie(w, newOIDCChallengeCookie(r, "", time.UnixMilli(0)))
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, cookieName)
+		}
+
+		render.JSON(w, http.StatusOK, tokenResponse)
+	}
+}
+
+// HandleLoginOIDCTOTPEnroll returns an http.HandlerFunc that starts the two-factor authentication
+// enrollment of a user that is required to enroll before completing an OIDC login.
+func HandleLoginOIDCTOTPEnroll(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		enrollment, err := userCtrl.LoginOIDCTOTPEnroll(ctx, oidcChallenge(r))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, enrollment)
+	}
+}
+
+// oidcChallenge returns the challenge of the pending OIDC login, or an empty string if there is none.
+func oidcChallenge(r *http.Request) string {
+	cookie, err := r.Cookie(oidcChallengeCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/audit"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleExport returns an http.HandlerFunc that streams all audit events
+// matching the filter as JSON lines, one event per line.
+func HandleExport(auditCtrl *audit.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		filter, err := request.ParseAuditFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", "attachment; filename=audit.jsonl")
+		w.Header().Set("Content-Type", "application/x-ndjson")
+
+		err = auditCtrl.Export(ctx, session, filter, w)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildcache
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/buildcache"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderCacheKey is the response header containing the key of the restored cache entry,
+// which differs from the requested key in case a restore key matched.
+const HeaderCacheKey = "X-Cache-Key"
+
+// HandleRestore returns a http.HandlerFunc that restores a build cache entry.
+func HandleRestore(buildCacheCtrl *buildcache.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		key := request.GetCacheKeyFromQuery(r)
+		restoreKeys := request.GetRestoreKeysFromQuery(r)
+
+		entry, signedURL, file, err := buildCacheCtrl.Restore(ctx, session, key, restoreKeys)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		w.Header().Set(HeaderCacheKey, entry.Key)
+
+		if file == nil {
+			http.Redirect(w, r, signedURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		render.Reader(ctx, w, http.StatusOK, file)
+		if err = file.Close(); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to close cache entry after rendering")
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+func getParentRefFromPath(r *http.Request, parentType enum.EnvironmentParent) (string, error) {
+	switch parentType {
+	case enum.EnvironmentParentRepo:
+		return request.GetRepoRefFromPath(r)
+	case enum.EnvironmentParentSpace:
+		return request.GetSpaceRefFromPath(r)
+	default:
+		return "", fmt.Errorf("environment parent type '%s' is not supported", parentType)
+	}
+}
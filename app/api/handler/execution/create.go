@@ -38,8 +38,9 @@ func HandleCreate(executionCtrl *execution.Controller) http.HandlerFunc {
 		}
 
 		branch := request.GetBranchFromQuery(r)
+		environment := request.GetEnvironmentFromQuery(r)
 
-		execution, err := executionCtrl.Create(ctx, session, repoRef, pipelineIdentifier, branch)
+		execution, err := executionCtrl.Create(ctx, session, repoRef, pipelineIdentifier, branch, environment)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleUploadArtifact returns a http.HandlerFunc that stores an artifact produced by a stage.
+func HandleUploadArtifact(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token := request.GetRunnerTokenFromHeader(r)
+
+		stageID, err := request.GetStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		name := request.GetArtifactNameFromQuery(r)
+		contentType := r.Header.Get("Content-Type")
+
+		r.Body = http.MaxBytesReader(w, r.Body, runner.MaxArtifactSize)
+
+		artifact, err := runnerCtrl.UploadArtifact(ctx, token, stageID, name, contentType, r.Body)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, artifact)
+	}
+}
+
+// HandleUploadStepArtifact returns a http.HandlerFunc that stores an artifact uploaded by a pipeline step.
+func HandleUploadStepArtifact(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		name := request.GetArtifactNameFromQuery(r)
+		contentType := r.Header.Get("Content-Type")
+
+		r.Body = http.MaxBytesReader(w, r.Body, runner.MaxArtifactSize)
+
+		artifact, err := runnerCtrl.UploadStepArtifact(ctx, session, name, contentType, r.Body)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, artifact)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/drone/drone-go/drone"
+)
+
+// HandleWriteLogs returns a http.HandlerFunc that appends lines to the live logs of a step.
+func HandleWriteLogs(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return handleLogs(runnerCtrl.WriteLogs)
+}
+
+// HandleUploadLogs returns a http.HandlerFunc that stores the complete logs of a finished step.
+func HandleUploadLogs(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return handleLogs(runnerCtrl.UploadLogs)
+}
+
+func handleLogs(
+	fn func(ctx context.Context, token string, stepID int64, lines []*drone.Line) error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token := request.GetRunnerTokenFromHeader(r)
+
+		stepID, err := request.GetStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		var lines []*drone.Line
+		err = json.NewDecoder(r.Body).Decode(&lines)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		if err = fn(ctx, token, stepID, lines); err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
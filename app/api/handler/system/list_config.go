@@ -25,6 +25,9 @@ import (
 type ConfigOutput struct {
 	UserSignupAllowed             bool `json:"user_signup_allowed"`
 	PublicResourceCreationEnabled bool `json:"public_resource_creation_enabled"`
+	PasswordLoginEnabled          bool `json:"password_login_enabled"`
+	OIDCLoginEnabled              bool `json:"oidc_login_enabled"`
+	LDAPLoginEnabled              bool `json:"ldap_login_enabled"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -42,6 +45,9 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			UserSignupAllowed:             userSignupAllowed,
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+			PasswordLoginEnabled:          !config.Auth.PasswordLoginDisabled,
+			OIDCLoginEnabled:              config.OIDC.Enabled,
+			LDAPLoginEnabled:              config.LDAP.Enabled,
 		})
 	}
 }
@@ -21,15 +21,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleCreate returns a http.HandlerFunc that creates a new webhook.
-func HandleCreate(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleCreate(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -42,7 +43,7 @@ func HandleCreate(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		hook, err := webhookCtrl.Create(ctx, session, repoRef, in, false)
+		hook, err := webhookCtrl.Create(ctx, session, parentType, parentRef, in, false)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,15 +20,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleDelete returns a http.HandlerFunc that deletes a webhook.
-func HandleDelete(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleDelete(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -40,7 +41,7 @@ func HandleDelete(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = webhookCtrl.Delete(ctx, session, repoRef, webhookIdentifier, false)
+		err = webhookCtrl.Delete(ctx, session, parentType, parentRef, webhookIdentifier, false)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,15 +20,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleFind returns a http.HandlerFunc that finds a webhook.
-func HandleFind(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleFind(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -40,7 +41,7 @@ func HandleFind(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		webhook, err := webhookCtrl.Find(ctx, session, repoRef, webhookIdentifier)
+		webhook, err := webhookCtrl.Find(ctx, session, parentType, parentRef, webhookIdentifier)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
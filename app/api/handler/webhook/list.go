@@ -24,12 +24,12 @@ import (
 )
 
 // HandleList returns a http.HandlerFunc that lists webhooks.
-func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleList(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -43,7 +43,7 @@ func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		// always skip internal for requests from handler
 		filter.SkipInternal = true
 
-		webhooks, totalCount, err := webhookCtrl.List(ctx, session, repoRef, filter)
+		webhooks, totalCount, err := webhookCtrl.List(ctx, session, parentType, parentRef, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,15 +20,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleListExecutions returns a http.HandlerFunc that lists webhook executions.
-func HandleListExecutions(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleListExecutions(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -42,7 +43,7 @@ func HandleListExecutions(webhookCtrl *webhook.Controller) http.HandlerFunc {
 
 		filter := request.ParseWebhookExecutionFilter(r)
 
-		executions, err := webhookCtrl.ListExecutions(ctx, session, repoRef, webhookIdentifier, filter)
+		executions, err := webhookCtrl.ListExecutions(ctx, session, parentType, parentRef, webhookIdentifier, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -20,15 +20,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleRetriggerExecution returns a http.HandlerFunc that retriggers a webhook executions.
-func HandleRetriggerExecution(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleRetriggerExecution(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -46,7 +47,7 @@ func HandleRetriggerExecution(webhookCtrl *webhook.Controller) http.HandlerFunc
 			return
 		}
 
-		execution, err := webhookCtrl.RetriggerExecution(ctx, session, repoRef, webhookIdentifier, webhookExecutionID)
+		execution, err := webhookCtrl.RetriggerExecution(ctx, session, parentType, parentRef, webhookIdentifier, webhookExecutionID)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
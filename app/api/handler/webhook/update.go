@@ -21,15 +21,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
 )
 
 // HandleUpdate returns a http.HandlerFunc that updates an existing webhook.
-func HandleUpdate(webhookCtrl *webhook.Controller) http.HandlerFunc {
+func HandleUpdate(webhookCtrl *webhook.Controller, parentType enum.WebhookParent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		repoRef, err := request.GetRepoRefFromPath(r)
+		parentRef, err := getParentRefFromPath(r, parentType)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -48,7 +49,7 @@ func HandleUpdate(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		hook, err := webhookCtrl.Update(ctx, session, repoRef, webhookIdentifier, in, false)
+		hook, err := webhookCtrl.Update(ctx, session, parentType, parentRef, webhookIdentifier, in, false)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
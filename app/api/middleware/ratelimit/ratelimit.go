@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/ratelimit"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	headerLimit      = "X-RateLimit-Limit"
+	headerRemaining  = "X-RateLimit-Remaining"
+	headerReset      = "X-RateLimit-Reset"
+	headerBudget     = "X-RateLimit-Resource"
+	headerRetryAfter = "Retry-After"
+
+	headerForwardedFor = "X-Forwarded-For"
+	headerRealIP       = "X-Real-IP"
+)
+
+// Budget is a named rate limit, requests only consume tokens of the budget they are limited by.
+type Budget struct {
+	Name  string
+	Limit ratelimit.Limit
+}
+
+// BudgetFunc returns the budget a request is limited by.
+type BudgetFunc func(r *http.Request) Budget
+
+// Static returns a BudgetFunc limiting all requests by the same budget.
+func Static(budget Budget) BudgetFunc {
+	return func(*http.Request) Budget {
+		return budget
+	}
+}
+
+/*
+ * Limit returns an http.HandlerFunc middleware that rate limits requests.
+ * Requests of authenticated principals are limited per principal, all other requests per client IP.
+ * The client IP is the remote address, forwarded headers are only honored for requests of trusted proxies.
+ * The state of the rate limit is returned in the X-RateLimit-* headers,
+ * in case the limit is exceeded an error is rendered.
+ */
+func Limit(
+	limiter ratelimit.RateLimiter,
+	budgetFn BudgetFunc,
+	trustedProxies ratelimit.TrustedProxies,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			budget := budgetFn(r)
+
+			res, err := limiter.Allow(ctx, key(r, budget, trustedProxies), budget.Limit)
+			if err != nil {
+				// don't block requests in case the rate limiter is unavailable.
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to apply rate limit %q", budget.Name)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set(headerLimit, strconv.Itoa(res.Limit))
+			h.Set(headerRemaining, strconv.Itoa(res.Remaining))
+			h.Set(headerReset, strconv.FormatInt(time.Now().Add(res.ResetAfter).Unix(), 10))
+			h.Set(headerBudget, budget.Name)
+
+			if !res.Allowed {
+				h.Set(headerRetryAfter, strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+
+				log.Ctx(ctx).Debug().Msgf("rate limit %q exceeded", budget.Name)
+
+				render.UserError(ctx, w, usererror.ErrTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// key returns the key of the token bucket used for the request.
+func key(r *http.Request, budget Budget, trustedProxies ratelimit.TrustedProxies) string {
+	if session, ok := request.AuthSessionFrom(r.Context()); ok {
+		return budget.Name + ":principal:" + strconv.FormatInt(session.Principal.ID, 10)
+	}
+
+	return budget.Name + ":ip:" + clientIP(r, trustedProxies)
+}
+
+// clientIP returns the IP of the client that sent the request.
+// Forwarded headers can be set by anyone, hence they are only used if the request comes from a trusted proxy.
+// In that case the client is the closest address in the X-Forwarded-For chain that isn't a trusted proxy.
+func clientIP(r *http.Request, trustedProxies ratelimit.TrustedProxies) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remote = host
+	}
+
+	remoteIP := net.ParseIP(remote)
+	if remoteIP == nil || !trustedProxies.Contains(remoteIP) {
+		return remote
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values(headerForwardedFor), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			// the chain can't be trusted beyond a malformed entry.
+			break
+		}
+		if !trustedProxies.Contains(ip) {
+			return ip.String()
+		}
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(headerRealIP))); ip != nil {
+		return ip.String()
+	}
+
+	return remote
+}
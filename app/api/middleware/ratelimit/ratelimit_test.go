@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/ratelimit"
+)
+
+func TestClientIP(t *testing.T) {
+	var trustedProxies ratelimit.TrustedProxies
+	if err := trustedProxies.Decode("10.0.0.0/8, 192.168.1.1"); err != nil {
+		t.Fatalf("failed to decode trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		remoteAddr  string
+		forwarded   string
+		realIP      string
+		wantAddress string
+	}{
+		{
+			name:        "no proxy",
+			remoteAddr:  "203.0.113.1:1234",
+			wantAddress: "203.0.113.1",
+		},
+		{
+			name:        "spoofed headers of untrusted client",
+			remoteAddr:  "203.0.113.1:1234",
+			forwarded:   "198.51.100.1",
+			realIP:      "198.51.100.2",
+			wantAddress: "203.0.113.1",
+		},
+		{
+			name:        "trusted proxy",
+			remoteAddr:  "10.1.2.3:1234",
+			forwarded:   "198.51.100.1",
+			wantAddress: "198.51.100.1",
+		},
+		{
+			name:        "trusted proxy chain with spoofed entry",
+			remoteAddr:  "10.1.2.3:1234",
+			forwarded:   "198.51.100.9, 203.0.113.1, 192.168.1.1",
+			wantAddress: "203.0.113.1",
+		},
+		{
+			name:        "trusted proxy with real ip",
+			remoteAddr:  "192.168.1.1:1234",
+			realIP:      "198.51.100.2",
+			wantAddress: "198.51.100.2",
+		},
+		{
+			name:        "trusted proxy without headers",
+			remoteAddr:  "10.1.2.3:1234",
+			wantAddress: "10.1.2.3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = test.remoteAddr
+			if test.forwarded != "" {
+				r.Header.Set(headerForwardedFor, test.forwarded)
+			}
+			if test.realIP != "" {
+				r.Header.Set(headerRealIP, test.realIP)
+			}
+
+			if got := clientIP(r, trustedProxies); got != test.wantAddress {
+				t.Errorf("expected client ip %q, got %q", test.wantAddress, got)
+			}
+		})
+	}
+}
@@ -51,6 +51,13 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// callback of the OIDC provider.
+type loginOIDCCallbackRequest struct {
+	State string `query:"state"`
+	Code  string `query:"code"`
+	Error string `query:"error"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -63,8 +70,21 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	onLoginTOTPEnroll := openapi3.Operation{}
+	onLoginTOTPEnroll.WithTags("account")
+	onLoginTOTPEnroll.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginTOTPEnroll"})
+	_ = reflector.SetRequest(&onLoginTOTPEnroll, new(loginRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(types.TOTPEnrollment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&onLoginTOTPEnroll, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/totp/enroll", onLoginTOTPEnroll)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
@@ -83,5 +103,47 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(types.TokenResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	onLoginOIDC := openapi3.Operation{}
+	onLoginOIDC.WithTags("account")
+	onLoginOIDC.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDC"})
+	_ = reflector.SetRequest(&onLoginOIDC, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDC, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/login", onLoginOIDC)
+
+	onLoginOIDCCallback := openapi3.Operation{}
+	onLoginOIDCCallback.WithTags("account")
+	onLoginOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDCCallback"})
+	_ = reflector.SetRequest(&onLoginOIDCCallback, new(loginOIDCCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/callback", onLoginOIDCCallback)
+
+	onLoginOIDCSecondFactor := openapi3.Operation{}
+	onLoginOIDCSecondFactor.WithTags("account")
+	onLoginOIDCSecondFactor.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDCSecondFactor"})
+	_ = reflector.SetRequest(&onLoginOIDCSecondFactor, new(user.TOTPCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginOIDCSecondFactor, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginOIDCSecondFactor, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDCSecondFactor, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginOIDCSecondFactor, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/oidc/second-factor", onLoginOIDCSecondFactor)
+
+	onLoginOIDCTOTPEnroll := openapi3.Operation{}
+	onLoginOIDCTOTPEnroll.WithTags("account")
+	onLoginOIDCTOTPEnroll.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginOIDCTOTPEnroll"})
+	_ = reflector.SetRequest(&onLoginOIDCTOTPEnroll, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginOIDCTOTPEnroll, new(types.TOTPEnrollment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&onLoginOIDCTOTPEnroll, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginOIDCTOTPEnroll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginOIDCTOTPEnroll, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onLoginOIDCTOTPEnroll, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/oidc/totp/enroll", onLoginOIDCTOTPEnroll)
 }
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type restoreCacheRequest struct {
+	Key         string   `query:"key" required:"true"`
+	RestoreKeys []string `query:"restore_key" description:"Key prefixes used in case no entry matches the key."`
+}
+
+type saveCacheRequest struct {
+	Key     string `query:"key" required:"true"`
+	Content string `json:"-" format:"binary" description:"Binary content of the cache entry"`
+}
+
+type uploadStepArtifactRequest struct {
+	Name    string `query:"name" required:"true"`
+	Content string `json:"-" format:"binary" description:"Binary content of the artifact"`
+}
+
+// buildCacheOperations registers the build cache and artifact endpoints,
+// which require the cache and artifact tokens provided to pipeline steps.
+func buildCacheOperations(reflector *openapi3.Reflector) {
+	opRestore := openapi3.Operation{}
+	opRestore.WithTags("build cache")
+	opRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restoreBuildCache"})
+	_ = reflector.SetRequest(&opRestore, new(restoreCacheRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opRestore, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opRestore, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/cache", opRestore)
+
+	opSave := openapi3.Operation{}
+	opSave.WithTags("build cache")
+	opSave.WithMapOfAnything(map[string]interface{}{"operationId": "saveBuildCache"})
+	_ = reflector.SetRequest(&opSave, new(saveCacheRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSave, new(types.CacheEntry), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opSave, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSave, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSave, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSave, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/cache", opSave)
+
+	opUploadArtifact := openapi3.Operation{}
+	opUploadArtifact.WithTags("pipeline")
+	opUploadArtifact.WithMapOfAnything(map[string]interface{}{"operationId": "uploadStepArtifact"})
+	_ = reflector.SetRequest(&opUploadArtifact, new(uploadStepArtifactRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(types.Artifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUploadArtifact, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/artifacts", opUploadArtifact)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -54,6 +55,22 @@ var queryParameterStatusCheckSince = openapi3.ParameterOrRef{
 	},
 }
 
+type reportTestResultsRequest struct {
+	repoRequest
+	CommitSHA string `path:"commit_sha"`
+	Check     string `query:"check" required:"true" description:"The identifier of the status check."`
+	Branch    string `query:"branch" description:"The branch the tests ran for, used as baseline for pull requests."`
+	// Note: The JUnit or xUnit.net XML test report is sent as request body.
+	Content string `json:"-" format:"binary" description:"JUnit or xUnit.net XML test report"`
+}
+
+type listTestCasesRequest struct {
+	repoRequest
+	CommitSHA string            `path:"commit_sha"`
+	Check     string            `query:"check" description:"The identifier of the status check."`
+	Statuses  []enum.TestStatus `query:"status" description:"The statuses of the test cases."`
+}
+
 func checkOperations(reflector *openapi3.Reflector) {
 	const tag = "status_checks"
 
@@ -106,4 +123,29 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent",
 		listStatusCheckRecent)
+
+	reportTestResults := openapi3.Operation{}
+	reportTestResults.WithTags(tag)
+	reportTestResults.WithMapOfAnything(map[string]interface{}{"operationId": "reportTestResults"})
+	_ = reflector.SetRequest(&reportTestResults, new(reportTestResultsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&reportTestResults, new(types.TestReport), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&reportTestResults, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportTestResults, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportTestResults, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportTestResults, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/commits/{commit_sha}/tests",
+		reportTestResults)
+
+	listTestCases := openapi3.Operation{}
+	listTestCases.WithTags(tag)
+	listTestCases.WithParameters(queryParameterPage, queryParameterLimit)
+	listTestCases.WithMapOfAnything(map[string]interface{}{"operationId": "listTestCases"})
+	_ = reflector.SetRequest(&listTestCases, new(listTestCasesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listTestCases, new([]types.TestCase), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listTestCases, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listTestCases, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listTestCases, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listTestCases, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}/tests",
+		listTestCases)
 }
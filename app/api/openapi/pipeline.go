@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
@@ -49,6 +50,17 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type approveExecutionRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+	execution.ApprovalInput
+}
+
+type getExecutionArtifactRequest struct {
+	executionRequest
+	ArtifactID string `path:"artifact_id"`
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -63,6 +75,11 @@ type createPipelineRequest struct {
 	pipeline.CreateInput
 }
 
+type validatePipelineRequest struct {
+	repoRequest
+	pipeline.ValidateInput
+}
+
 type getExecutionRequest struct {
 	executionRequest
 }
@@ -113,6 +130,20 @@ var queryParameterBranch = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterEnvironment = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamEnvironment,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Environment the execution deploys to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 func pipelineOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("pipeline")
@@ -137,6 +168,18 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opPipelines, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines", opPipelines)
 
+	opValidate := openapi3.Operation{}
+	opValidate.WithTags("pipeline")
+	opValidate.WithMapOfAnything(map[string]interface{}{"operationId": "validatePipeline"})
+	_ = reflector.SetRequest(&opValidate, new(validatePipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opValidate, new(types.PipelineValidation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines/validate", opValidate)
+
 	opFind := openapi3.Operation{}
 	opFind.WithTags("pipeline")
 	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPipeline"})
@@ -174,7 +217,7 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 
 	executionCreate := openapi3.Operation{}
 	executionCreate.WithTags("pipeline")
-	executionCreate.WithParameters(queryParameterBranch)
+	executionCreate.WithParameters(queryParameterBranch, queryParameterEnvironment)
 	executionCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createExecution"})
 	_ = reflector.SetRequest(&executionCreate, new(createExecutionRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&executionCreate, new(types.Execution), http.StatusCreated)
@@ -209,6 +252,60 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionListApprovals := openapi3.Operation{}
+	executionListApprovals.WithTags("pipeline")
+	executionListApprovals.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionApprovals"})
+	_ = reflector.SetRequest(&executionListApprovals, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionListApprovals, []types.StageApproval{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/approvals",
+		executionListApprovals)
+
+	executionApprove := openapi3.Operation{}
+	executionApprove.WithTags("pipeline")
+	executionApprove.WithMapOfAnything(map[string]interface{}{"operationId": "approveExecutionStage"})
+	_ = reflector.SetRequest(&executionApprove, new(approveExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionApprove, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionApprove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionApprove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/approvals/{stage_number}",
+		executionApprove)
+
+	executionListArtifacts := openapi3.Operation{}
+	executionListArtifacts.WithTags("pipeline")
+	executionListArtifacts.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionArtifacts"})
+	_ = reflector.SetRequest(&executionListArtifacts, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, []types.Artifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts",
+		executionListArtifacts)
+
+	executionDownloadArtifact := openapi3.Operation{}
+	executionDownloadArtifact.WithTags("pipeline")
+	executionDownloadArtifact.WithMapOfAnything(map[string]interface{}{"operationId": "downloadExecutionArtifact"})
+	_ = reflector.SetRequest(&executionDownloadArtifact, new(getExecutionArtifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&executionDownloadArtifact, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_id}",
+		executionDownloadArtifact)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
@@ -142,6 +142,10 @@ type getPullReqChecksRequest struct {
 	pullReqRequest
 }
 
+type getPullReqTestSummaryRequest struct {
+	pullReqRequest
+}
+
 var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -624,4 +628,16 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusForbidden))
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/checks", opChecks))
+
+	opTestSummary := openapi3.Operation{}
+	opTestSummary.WithTags("pullreq")
+	opTestSummary.WithMapOfAnything(map[string]interface{}{"operationId": "testSummaryPullReq"})
+	_ = reflector.SetRequest(&opTestSummary, new(getPullReqTestSummaryRequest), http.MethodGet)
+	panicOnErr(reflector.SetJSONResponse(&opTestSummary, new(types.PullReqTestSummary), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opTestSummary, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opTestSummary, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opTestSummary, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opTestSummary, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/tests",
+		opTestSummary))
 }
@@ -1134,4 +1134,78 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{git_ref}.{format}", opArchive)
+
+	opMembershipAdd := openapi3.Operation{}
+	opMembershipAdd.WithTags("repository")
+	opMembershipAdd.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipAdd"})
+	_ = reflector.SetRequest(&opMembershipAdd, struct {
+		repoRequest
+		repo.MembershipAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, &types.RepoMembershipUser{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/members", opMembershipAdd)
+
+	opMembershipEffective := openapi3.Operation{}
+	opMembershipEffective.WithTags("repository")
+	opMembershipEffective.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipEffective"})
+	_ = reflector.SetRequest(&opMembershipEffective, struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipEffective, &types.RepoMembershipEffective{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipEffective, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipEffective, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipEffective, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipEffective, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/members/{user_uid}", opMembershipEffective)
+
+	opMembershipDelete := openapi3.Operation{}
+	opMembershipDelete.WithTags("repository")
+	opMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipDelete"})
+	_ = reflector.SetRequest(&opMembershipDelete, struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/members/{user_uid}", opMembershipDelete)
+
+	opMembershipUpdate := openapi3.Operation{}
+	opMembershipUpdate.WithTags("repository")
+	opMembershipUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipUpdate"})
+	_ = reflector.SetRequest(&opMembershipUpdate, &struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+		repo.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, &types.RepoMembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/members/{user_uid}", opMembershipUpdate)
+
+	opMembershipList := openapi3.Operation{}
+	opMembershipList.WithTags("repository")
+	opMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "repoMembershipList"})
+	opMembershipList.WithParameters(
+		queryParameterMembershipUsers,
+		queryParameterOrder, queryParameterSortMembershipUsers,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opMembershipList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipList, []types.RepoMembershipUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/members", opMembershipList)
 }
@@ -151,6 +151,20 @@ var queryParameterMembershipUsers = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryRoles = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the roles are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterSortMembershipUsers = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -427,4 +441,101 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opRoleCreate := openapi3.Operation{}
+	opRoleCreate.WithTags("space")
+	opRoleCreate.WithMapOfAnything(map[string]interface{}{"operationId": "roleCreate"})
+	_ = reflector.SetRequest(&opRoleCreate, struct {
+		spaceRequest
+		space.RoleCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRoleCreate, &types.Role{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRoleCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRoleCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRoleCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRoleCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRoleCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/roles", opRoleCreate)
+
+	opRoleUpdate := openapi3.Operation{}
+	opRoleUpdate.WithTags("space")
+	opRoleUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "roleUpdate"})
+	_ = reflector.SetRequest(&opRoleUpdate, struct {
+		spaceRequest
+		RoleIdentifier string `path:"role_identifier"`
+		space.RoleUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, &types.Role{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRoleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/roles/{role_identifier}", opRoleUpdate)
+
+	opRoleDelete := openapi3.Operation{}
+	opRoleDelete.WithTags("space")
+	opRoleDelete.WithMapOfAnything(map[string]interface{}{"operationId": "roleDelete"})
+	_ = reflector.SetRequest(&opRoleDelete, struct {
+		spaceRequest
+		RoleIdentifier string `path:"role_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRoleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRoleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRoleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRoleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRoleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRoleDelete, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/roles/{role_identifier}", opRoleDelete)
+
+	opRoleList := openapi3.Operation{}
+	opRoleList.WithTags("space")
+	opRoleList.WithMapOfAnything(map[string]interface{}{"operationId": "roleList"})
+	opRoleList.WithParameters(queryParameterQueryRoles, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opRoleList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRoleList, []types.Role{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRoleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRoleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRoleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRoleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/roles", opRoleList)
+
+	opQuotaFind := openapi3.Operation{}
+	opQuotaFind.WithTags("space")
+	opQuotaFind.WithMapOfAnything(map[string]interface{}{"operationId": "quotaFind"})
+	_ = reflector.SetRequest(&opQuotaFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(types.SpaceQuotaUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/quota", opQuotaFind)
+
+	opQuotaUpdate := openapi3.Operation{}
+	opQuotaUpdate.WithTags("space")
+	opQuotaUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "quotaUpdate"})
+	_ = reflector.SetRequest(&opQuotaUpdate, struct {
+		spaceRequest
+		space.QuotaUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(types.SpaceQuota), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/quota", opQuotaUpdate)
+
+	opQuotaDelete := openapi3.Operation{}
+	opQuotaDelete.WithTags("space")
+	opQuotaDelete.WithMapOfAnything(map[string]interface{}{"operationId": "quotaDelete"})
+	_ = reflector.SetRequest(&opQuotaDelete, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opQuotaDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/quota", opQuotaDelete)
 }
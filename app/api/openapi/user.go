@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -31,6 +32,23 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type notificationListRequest struct {
+	Read *bool `query:"read"`
+
+	// include pagination request
+	paginationRequest
+}
+
+type notificationUpdateRequest struct {
+	NotificationID int64 `path:"notification_id"`
+	notification.UpdateInput
+}
+
+type notificationPreferenceDeleteRequest struct {
+	Type    string `query:"type"`
+	RepoRef string `query:"repo_ref"`
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -99,4 +117,105 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opFindTOTP := openapi3.Operation{}
+	opFindTOTP.WithTags("user")
+	opFindTOTP.WithMapOfAnything(map[string]interface{}{"operationId": "findTOTP"})
+	_ = reflector.SetRequest(&opFindTOTP, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindTOTP, new(types.TOTPStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindTOTP, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/totp", opFindTOTP)
+
+	opEnrollTOTP := openapi3.Operation{}
+	opEnrollTOTP.WithTags("user")
+	opEnrollTOTP.WithMapOfAnything(map[string]interface{}{"operationId": "enrollTOTP"})
+	_ = reflector.SetRequest(&opEnrollTOTP, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opEnrollTOTP, new(types.TOTPEnrollment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opEnrollTOTP, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opEnrollTOTP, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/totp", opEnrollTOTP)
+
+	opConfirmTOTP := openapi3.Operation{}
+	opConfirmTOTP.WithTags("user")
+	opConfirmTOTP.WithMapOfAnything(map[string]interface{}{"operationId": "confirmTOTP"})
+	_ = reflector.SetRequest(&opConfirmTOTP, new(user.TOTPCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opConfirmTOTP, new(types.TOTPStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opConfirmTOTP, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opConfirmTOTP, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opConfirmTOTP, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/totp/confirm", opConfirmTOTP)
+
+	opDisableTOTP := openapi3.Operation{}
+	opDisableTOTP.WithTags("user")
+	opDisableTOTP.WithMapOfAnything(map[string]interface{}{"operationId": "disableTOTP"})
+	_ = reflector.SetRequest(&opDisableTOTP, new(user.TOTPCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDisableTOTP, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDisableTOTP, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDisableTOTP, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDisableTOTP, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/totp/disable", opDisableTOTP)
+
+	opRecoveryCodes := openapi3.Operation{}
+	opRecoveryCodes.WithTags("user")
+	opRecoveryCodes.WithMapOfAnything(map[string]interface{}{"operationId": "regenerateTOTPRecoveryCodes"})
+	_ = reflector.SetRequest(&opRecoveryCodes, new(user.TOTPCodeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new([]string), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRecoveryCodes, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/totp/recovery-codes", opRecoveryCodes)
+
+	opListNotifications := openapi3.Operation{}
+	opListNotifications.WithTags("user")
+	opListNotifications.WithMapOfAnything(map[string]interface{}{"operationId": "listNotifications"})
+	_ = reflector.SetRequest(&opListNotifications, new(notificationListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotifications, new([]*types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opListNotifications)
+
+	opMarkAllNotificationsRead := openapi3.Operation{}
+	opMarkAllNotificationsRead.WithTags("user")
+	opMarkAllNotificationsRead.WithMapOfAnything(map[string]interface{}{"operationId": "markAllNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkAllNotificationsRead, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkAllNotificationsRead, new(notification.MarkAllReadOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkAllNotificationsRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/mark-read", opMarkAllNotificationsRead)
+
+	opUpdateNotification := openapi3.Operation{}
+	opUpdateNotification.WithTags("user")
+	opUpdateNotification.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotification"})
+	_ = reflector.SetRequest(&opUpdateNotification, new(notificationUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notifications/{notification_id}", opUpdateNotification)
+
+	opListNotificationPrefs := openapi3.Operation{}
+	opListNotificationPrefs.WithTags("user")
+	opListNotificationPrefs.WithMapOfAnything(map[string]interface{}{"operationId": "listNotificationPreferences"})
+	_ = reflector.SetRequest(&opListNotificationPrefs, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotificationPrefs, new([]*types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationPrefs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications/preferences", opListNotificationPrefs)
+
+	opUpdateNotificationPref := openapi3.Operation{}
+	opUpdateNotificationPref.WithTags("user")
+	opUpdateNotificationPref.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotificationPreference"})
+	_ = reflector.SetRequest(&opUpdateNotificationPref, new(notification.PreferenceInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPref, new(types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPref, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPref, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationPref, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/notifications/preferences", opUpdateNotificationPref)
+
+	opDeleteNotificationPref := openapi3.Operation{}
+	opDeleteNotificationPref.WithTags("user")
+	opDeleteNotificationPref.WithMapOfAnything(map[string]interface{}{"operationId": "deleteNotificationPreference"})
+	_ = reflector.SetRequest(&opDeleteNotificationPref, new(notificationPreferenceDeleteRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/notifications/preferences", opDeleteNotificationPref)
 }
@@ -55,6 +55,39 @@ type (
 		adminUsersRequest
 		user.UpdateAdminInput
 	}
+
+	// adminAuditListRequest is the request for listing and exporting audit events.
+	adminAuditListRequest struct {
+		Actions       []string `query:"action"`
+		ResourceTypes []string `query:"resource_type"`
+		PrincipalUID  string   `query:"principal_uid"`
+		SpacePath     string   `query:"space_path"`
+		Query         string   `query:"query"`
+		CreatedLt     int64    `query:"created_lt"`
+		CreatedGt     int64    `query:"created_gt"`
+		Order         string   `query:"order"         enum:"asc,desc"`
+
+		// include pagination request
+		paginationRequest
+	}
+
+	// adminUsersTokenRequest is the request for token specific admin user operations.
+	adminUsersTokenRequest struct {
+		adminUsersRequest
+		TokenIdentifier string `path:"token_identifier"`
+	}
+
+	// adminUsersLinkIdentityRequest is the request for linking a user to an external identity.
+	adminUsersLinkIdentityRequest struct {
+		adminUsersRequest
+		user.LinkIdentityInput
+	}
+
+	// adminUsersIdentityRequest is the request for identity provider specific admin user operations.
+	adminUsersIdentityRequest struct {
+		adminUsersRequest
+		IdentityProvider string `path:"identity_provider"`
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -117,4 +150,74 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opListTokens := openapi3.Operation{}
+	opListTokens.WithTags("admin")
+	opListTokens.WithMapOfAnything(map[string]interface{}{"operationId": "adminListUserTokens"})
+	_ = reflector.SetRequest(&opListTokens, new(adminUsersRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListTokens, new([]*types.Token), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListTokens, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListTokens, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/users/{user_uid}/tokens", opListTokens)
+
+	opRevokeToken := openapi3.Operation{}
+	opRevokeToken.WithTags("admin")
+	opRevokeToken.WithMapOfAnything(map[string]interface{}{"operationId": "adminRevokeUserToken"})
+	_ = reflector.SetRequest(&opRevokeToken, new(adminUsersTokenRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRevokeToken, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRevokeToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevokeToken, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/admin/users/{user_uid}/tokens/{token_identifier}", opRevokeToken)
+
+	opResetTOTP := openapi3.Operation{}
+	opResetTOTP.WithTags("admin")
+	opResetTOTP.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetUserTOTP"})
+	_ = reflector.SetRequest(&opResetTOTP, new(adminUsersRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opResetTOTP, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opResetTOTP, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opResetTOTP, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}/totp", opResetTOTP)
+
+	opLinkIdentity := openapi3.Operation{}
+	opLinkIdentity.WithTags("admin")
+	opLinkIdentity.WithMapOfAnything(map[string]interface{}{"operationId": "adminLinkUserIdentity"})
+	_ = reflector.SetRequest(&opLinkIdentity, new(adminUsersLinkIdentityRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLinkIdentity, new(types.PrincipalIdentity), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opLinkIdentity, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLinkIdentity, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opLinkIdentity, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLinkIdentity, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/identities", opLinkIdentity)
+
+	opUnlinkIdentity := openapi3.Operation{}
+	opUnlinkIdentity.WithTags("admin")
+	opUnlinkIdentity.WithMapOfAnything(map[string]interface{}{"operationId": "adminUnlinkUserIdentity"})
+	_ = reflector.SetRequest(&opUnlinkIdentity, new(adminUsersIdentityRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnlinkIdentity, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUnlinkIdentity, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUnlinkIdentity, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnlinkIdentity, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/admin/users/{user_uid}/identities/{identity_provider}", opUnlinkIdentity)
+
+	opListAudit := openapi3.Operation{}
+	opListAudit.WithTags("admin")
+	opListAudit.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditEvents"})
+	_ = reflector.SetRequest(&opListAudit, new(adminAuditListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListAudit, new([]*types.AuditEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListAudit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListAudit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListAudit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit", opListAudit)
+
+	opExportAudit := openapi3.Operation{}
+	opExportAudit.WithTags("admin")
+	opExportAudit.WithMapOfAnything(map[string]interface{}{"operationId": "adminExportAuditEvents"})
+	_ = reflector.SetRequest(&opExportAudit, new(adminAuditListRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opExportAudit, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetJSONResponse(&opExportAudit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opExportAudit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExportAudit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit/export", opExportAudit)
 }
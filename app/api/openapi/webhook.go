@@ -60,6 +60,11 @@ type updateWebhookRequest struct {
 	webhook.UpdateInput
 }
 
+type testWebhookRequest struct {
+	webhookRequest
+	webhook.TestInput
+}
+
 type listWebhookExecutionsRequest struct {
 	webhookRequest
 }
@@ -73,6 +78,39 @@ type getWebhookExecutionRequest struct {
 	webhookExecutionRequest
 }
 
+type createSpaceWebhookRequest struct {
+	spaceRequest
+	webhook.CreateInput
+}
+
+type listSpaceWebhooksRequest struct {
+	spaceRequest
+}
+
+type spaceWebhookRequest struct {
+	spaceRequest
+	ID int64 `path:"webhook_identifier"`
+}
+
+type updateSpaceWebhookRequest struct {
+	spaceWebhookRequest
+	webhook.UpdateInput
+}
+
+type testSpaceWebhookRequest struct {
+	spaceWebhookRequest
+	webhook.TestInput
+}
+
+type listSpaceWebhookExecutionsRequest struct {
+	spaceWebhookRequest
+}
+
+type spaceWebhookExecutionRequest struct {
+	spaceWebhookRequest
+	ID int64 `path:"webhook_execution_id"`
+}
+
 var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -207,4 +245,145 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&retriggerWebhookExecution, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}", retriggerWebhookExecution)
+
+	testWebhook := openapi3.Operation{}
+	testWebhook.WithTags("webhook")
+	testWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testWebhook"})
+	_ = reflector.SetRequest(&testWebhook, new(testWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/test", testWebhook)
+
+	spaceWebhookOperations(reflector)
+
+	redeliverFailedWebhooks := openapi3.Operation{}
+	redeliverFailedWebhooks.WithTags("admin")
+	redeliverFailedWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "adminRedeliverFailedWebhooks"})
+	_ = reflector.SetRequest(&redeliverFailedWebhooks, new(webhook.RedeliverFailedInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&redeliverFailedWebhooks, new(webhook.RedeliverFailedOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&redeliverFailedWebhooks, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&redeliverFailedWebhooks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&redeliverFailedWebhooks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&redeliverFailedWebhooks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/webhooks/redeliver", redeliverFailedWebhooks)
+}
+
+//nolint:funlen
+func spaceWebhookOperations(reflector *openapi3.Reflector) {
+	createSpaceWebhook := openapi3.Operation{}
+	createSpaceWebhook.WithTags("webhook")
+	createSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "createSpaceWebhook"})
+	_ = reflector.SetRequest(&createSpaceWebhook, new(createSpaceWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createSpaceWebhook, new(webhookType), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/webhooks", createSpaceWebhook)
+
+	listSpaceWebhooks := openapi3.Operation{}
+	listSpaceWebhooks.WithTags("webhook")
+	listSpaceWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceWebhooks"})
+	listSpaceWebhooks.WithParameters(queryParameterQueryWebhook, queryParameterSortWebhook, queryParameterOrder,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listSpaceWebhooks, new(listSpaceWebhooksRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new([]webhookType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceWebhooks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/webhooks", listSpaceWebhooks)
+
+	getSpaceWebhook := openapi3.Operation{}
+	getSpaceWebhook.WithTags("webhook")
+	getSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceWebhook"})
+	_ = reflector.SetRequest(&getSpaceWebhook, new(spaceWebhookRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getSpaceWebhook, new(webhookType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}", getSpaceWebhook)
+
+	updateSpaceWebhook := openapi3.Operation{}
+	updateSpaceWebhook.WithTags("webhook")
+	updateSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceWebhook"})
+	_ = reflector.SetRequest(&updateSpaceWebhook, new(updateSpaceWebhookRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateSpaceWebhook, new(webhookType), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}", updateSpaceWebhook)
+
+	deleteSpaceWebhook := openapi3.Operation{}
+	deleteSpaceWebhook.WithTags("webhook")
+	deleteSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceWebhook"})
+	_ = reflector.SetRequest(&deleteSpaceWebhook, new(spaceWebhookRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteSpaceWebhook, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}", deleteSpaceWebhook)
+
+	listSpaceWebhookExecutions := openapi3.Operation{}
+	listSpaceWebhookExecutions.WithTags("webhook")
+	listSpaceWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceWebhookExecutions"})
+	listSpaceWebhookExecutions.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listSpaceWebhookExecutions, new(listSpaceWebhookExecutionsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookExecutions, new([]types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions", listSpaceWebhookExecutions)
+
+	getSpaceWebhookExecution := openapi3.Operation{}
+	getSpaceWebhookExecution.WithTags("webhook")
+	getSpaceWebhookExecution.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceWebhookExecution"})
+	_ = reflector.SetRequest(&getSpaceWebhookExecution, new(spaceWebhookExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getSpaceWebhookExecution, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getSpaceWebhookExecution, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getSpaceWebhookExecution, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getSpaceWebhookExecution, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getSpaceWebhookExecution, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}",
+		getSpaceWebhookExecution)
+
+	retriggerSpaceWebhookExecution := openapi3.Operation{}
+	retriggerSpaceWebhookExecution.WithTags("webhook")
+	retriggerSpaceWebhookExecution.WithMapOfAnything(
+		map[string]interface{}{"operationId": "retriggerSpaceWebhookExecution"})
+	_ = reflector.SetRequest(&retriggerSpaceWebhookExecution, new(spaceWebhookExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookExecution, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookExecution, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookExecution, new(usererror.Error),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookExecution, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&retriggerSpaceWebhookExecution, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger",
+		retriggerSpaceWebhookExecution)
+
+	testSpaceWebhook := openapi3.Operation{}
+	testSpaceWebhook.WithTags("webhook")
+	testSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testSpaceWebhook"})
+	_ = reflector.SetRequest(&testSpaceWebhook, new(testSpaceWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/test", testSpaceWebhook)
 }
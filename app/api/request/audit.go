@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamAction       = "action"
+	QueryParamResourceType = "resource_type"
+	QueryParamPrincipalUID = "principal_uid"
+	QueryParamSpacePath    = "space_path"
+)
+
+// ParseAuditFilter extracts the audit log query parameters from the url.
+func ParseAuditFilter(r *http.Request) (*types.AuditFilter, error) {
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, _ := QueryParamList(r, QueryParamAction)
+	resourceTypes, _ := QueryParamList(r, QueryParamResourceType)
+	principalUID, _ := QueryParam(r, QueryParamPrincipalUID)
+	spacePath, _ := QueryParam(r, QueryParamSpacePath)
+
+	return &types.AuditFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		CreatedFilter:   createdFilter,
+		Actions:         actions,
+		ResourceTypes:   resourceTypes,
+		PrincipalUID:    principalUID,
+		SpacePath:       spacePath,
+		Order:           ParseOrder(r),
+	}, nil
+}
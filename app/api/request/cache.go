@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+)
+
+const (
+	QueryParamCacheKey   = "key"
+	QueryParamRestoreKey = "restore_key"
+)
+
+// GetCacheKeyFromQuery returns the key of the build cache entry from the query.
+func GetCacheKeyFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamCacheKey, "")
+}
+
+// GetRestoreKeysFromQuery returns the fallback key prefixes for restoring a build cache entry from the query.
+func GetRestoreKeysFromQuery(r *http.Request) []string {
+	restoreKeys, _ := QueryParamList(r, QueryParamRestoreKey)
+	return restoreKeys
+}
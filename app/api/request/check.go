@@ -18,6 +18,12 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamCheck      = "check"
+	QueryParamTestStatus = "status"
 )
 
 // ParseCheckListOptions extracts the status check list API options from the url.
@@ -39,3 +45,24 @@ func ParseCheckRecentOptions(r *http.Request) (types.CheckRecentOptions, error)
 		Since: since,
 	}, nil
 }
+
+// GetCheckIdentifierFromQuery returns the identifier of the status check from the query.
+func GetCheckIdentifierFromQuery(r *http.Request) (string, error) {
+	return QueryParamOrError(r, QueryParamCheck)
+}
+
+// ParseTestCaseListOptions extracts the test case list API options from the url.
+func ParseTestCaseListOptions(r *http.Request) types.TestCaseListOptions {
+	statuses, _ := QueryParamList(r, QueryParamTestStatus)
+
+	opts := types.TestCaseListOptions{
+		Pagination:      ParsePaginationFromRequest(r),
+		CheckIdentifier: QueryParamOrDefault(r, QueryParamCheck, ""),
+	}
+
+	for _, status := range statuses {
+		opts.Statuses = append(opts.Statuses, enum.TestStatus(status))
+	}
+
+	return opts
+}
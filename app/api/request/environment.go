@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamEnvironmentIdentifier = "environment_identifier"
+	QueryParamEnvironment          = "environment"
+	QueryParamDeploymentStatus     = "status"
+)
+
+func GetEnvironmentIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamEnvironmentIdentifier)
+}
+
+// GetEnvironmentFromQuery returns the environment a pipeline execution deploys to.
+func GetEnvironmentFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamEnvironment, "")
+}
+
+// ParseDeploymentFilter extracts the deployment query parameters for listing from the url.
+func ParseDeploymentFilter(r *http.Request) *types.DeploymentFilter {
+	return &types.DeploymentFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		Status:     enum.CIStatus(QueryParamOrDefault(r, QueryParamDeploymentStatus, "")),
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamNotificationID = "notification_id"
+	QueryParamRead          = "read"
+	QueryParamRepoRef       = "repo_ref"
+)
+
+func GetNotificationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamNotificationID)
+}
+
+// ParseNotificationPreferenceRef extracts the repository and notification type of a preference from the url.
+func ParseNotificationPreferenceRef(r *http.Request) (string, enum.NotificationType, error) {
+	notificationType, err := QueryParamOrError(r, QueryParamType)
+	if err != nil {
+		return "", "", err
+	}
+
+	return QueryParamOrDefault(r, QueryParamRepoRef, ""), enum.NotificationType(notificationType), nil
+}
+
+// ParseNotificationFilter extracts the notification inbox query parameters from the url.
+func ParseNotificationFilter(r *http.Request) (*types.NotificationFilter, error) {
+	filter := &types.NotificationFilter{
+		Pagination: ParsePaginationFromRequest(r),
+	}
+
+	if _, ok := QueryParam(r, QueryParamRead); ok {
+		read, err := QueryParamAsBoolOrDefault(r, QueryParamRead, false)
+		if err != nil {
+			return nil, err
+		}
+		filter.Read = &read
+	}
+
+	return filter, nil
+}
@@ -28,6 +28,8 @@ const (
 	PathParamServiceAccountUID = "sa_uid"
 
 	PathParamPrincipalID = "principal_id"
+
+	PathParamIdentityProvider = "identity_provider"
 )
 
 // GetUserIDFromPath returns the user id from the request path.
@@ -48,6 +50,10 @@ func GetUserUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamUserUID)
 }
 
+func GetIdentityProviderFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamIdentityProvider)
+}
+
 func GetServiceAccountUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamServiceAccountUID)
 }
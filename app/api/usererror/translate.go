@@ -77,7 +77,11 @@ func Translate(ctx context.Context, err error) *Error {
 		return ErrCyclicHierarchy
 	case errors.Is(err, store.ErrSpaceWithChildsCantBeDeleted):
 		return ErrSpaceWithChildsCantBeDeleted
-	case errors.Is(err, limiter.ErrMaxNumReposReached):
+	case errors.Is(err, limiter.ErrMaxNumReposReached),
+		errors.Is(err, limiter.ErrMaxRepoSizeReached),
+		errors.Is(err, limiter.ErrMaxBlobSizeReached),
+		errors.Is(err, limiter.ErrMaxPipelineMinutesReached),
+		errors.Is(err, limiter.ErrMaxConcurrentExecutionsReached):
 		return Forbidden(err.Error())
 
 	//	upload errors
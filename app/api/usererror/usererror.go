@@ -89,6 +89,9 @@ var (
 		"The requested resource is temporarily locked, please retry the operation.",
 	)
 
+	// ErrTooManyRequests is returned if the principal exceeded its rate limit.
+	ErrTooManyRequests = New(http.StatusTooManyRequests, "Too many requests, please retry the operation later.")
+
 	// ErrEmptyRepoNeedsBranch is returned if no branch found on the githook post receieve for empty repositories.
 	ErrEmptyRepoNeedsBranch = New(http.StatusBadRequest,
 		"Pushing to an empty repository requires at least one branch with commits.")
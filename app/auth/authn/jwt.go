@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
@@ -28,10 +29,15 @@ import (
 	"github.com/harness/gitness/types"
 
 	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// tokenLastUsedUpdateInterval is the minimum time between two updates of the last used time of a token.
+// It prevents a database write on every authenticated request.
+const tokenLastUsedUpdateInterval = time.Minute
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
 	cookieName     string
@@ -90,6 +96,19 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		}
 	case claims.Membership != nil:
 		metadata = a.metadataFromMembershipClaims(claims.Membership)
+	case claims.Cache != nil:
+		metadata = &auth.CacheMetadata{
+			RepoID: claims.Cache.RepoID,
+			Branch: claims.Cache.Branch,
+			Write:  claims.Cache.Write,
+		}
+	case claims.Artifact != nil:
+		metadata = &auth.ArtifactMetadata{
+			StageID: claims.Artifact.StageID,
+		}
+	case claims.Login != nil:
+		// the login is pending until the second factor is provided.
+		return nil, fmt.Errorf("jwt of a pending login can't be used for authentication")
 	default:
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
@@ -117,9 +136,17 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 			principal.ID, tkn.PrincipalID)
 	}
 
+	now := time.Now()
+	if tkn.LastUsed == nil || now.Sub(time.UnixMilli(*tkn.LastUsed)) >= tokenLastUsedUpdateInterval {
+		if err = a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now.UnixMilli()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of token %d", tkn.ID)
+		}
+	}
+
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		Scope:     tkn.TokenScope,
 	}, nil
 }
 
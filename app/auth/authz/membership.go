@@ -16,11 +16,14 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -32,15 +35,18 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		repoStore:       repoStore,
 	}
 }
 
@@ -72,34 +78,34 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// cache and artifact tokens are only accepted by their dedicated APIs, which don't use the authorizer
+	switch session.Metadata.(type) {
+	case *auth.CacheMetadata, *auth.ArtifactMetadata:
+		return false, nil
+	}
+
+	// restricted tokens limit the access of any principal, including system admins
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.ImpactsAuthorization() {
+		allowed, err := a.checkWithTokenScope(ctx, &tokenMetadata.Scope, scope, resource, permission)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
 
-	var spacePath string
-
 	//nolint:exhaustive // we want to fail on anything else
 	switch resource.Type {
-	case enum.ResourceTypeSpace:
-		spacePath = paths.Concatenate(scope.SpacePath, resource.Identifier)
-
-	case enum.ResourceTypeRepo:
-		spacePath = scope.SpacePath
-
-	case enum.ResourceTypeServiceAccount:
-		spacePath = scope.SpacePath
-
-	case enum.ResourceTypePipeline:
-		spacePath = scope.SpacePath
-
-	case enum.ResourceTypeSecret:
-		spacePath = scope.SpacePath
-
-	case enum.ResourceTypeConnector:
-		spacePath = scope.SpacePath
-
-	case enum.ResourceTypeTemplate:
-		spacePath = scope.SpacePath
+	case enum.ResourceTypeSpace,
+		enum.ResourceTypeRepo,
+		enum.ResourceTypeServiceAccount,
+		enum.ResourceTypePipeline,
+		enum.ResourceTypeSecret,
+		enum.ResourceTypeConnector,
+		enum.ResourceTypeTemplate:
+		// access to resources inside of spaces is granted by memberships
 
 	case enum.ResourceTypeUser:
 		// a user is allowed to view / edit themselves
@@ -115,23 +121,35 @@ func (a *MembershipAuthorizer) Check(
 	case enum.ResourceTypeService:
 		return false, nil
 
+	// system wide operations (like managing runners or reading the audit log) are reserved for admins only
+	case enum.ResourceTypeSystem:
+		return false, nil
+
 	default:
 		return false, nil
 	}
 
-	// ephemeral membership overrides any other space memberships of the principal
-	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
-		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
-	}
+	spacePath, repoPath := resourcePaths(scope, resource)
+
+	switch metadata := session.Metadata.(type) {
+	case *auth.MembershipMetadata:
+		// ephemeral membership overrides any other space memberships of the principal
+		return a.checkWithMembershipMetadata(ctx, metadata, spacePath, permission)
+
+	case *auth.TokenMetadata:
+		// token scope was already verified, access is granted by the memberships of the principal
 
-	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
-		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
+	default:
+		// ensure we aren't bypassing unknown metadata with impact on authorization
+		if metadata != nil && metadata.ImpactsAuthorization() {
+			return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", metadata)
+		}
 	}
 
 	return a.permissionCache.Get(ctx, PermissionCacheKey{
 		PrincipalID: session.Principal.ID,
 		SpaceRef:    spacePath,
+		RepoRef:     repoPath,
 		Permission:  permission,
 	})
 }
@@ -148,6 +166,102 @@ func (a *MembershipAuthorizer) CheckAll(ctx context.Context, session *auth.Sessi
 	return true, nil
 }
 
+// resourcePaths returns the path of the space and, if applicable, the path of the repository
+// that contain the requested resource.
+func resourcePaths(scope *types.Scope, resource *types.Resource) (string, string) {
+	//nolint:exhaustive // all other resources are directly inside of the scope space
+	switch resource.Type {
+	case enum.ResourceTypeSpace:
+		return paths.Concatenate(scope.SpacePath, resource.Identifier), ""
+
+	case enum.ResourceTypeRepo:
+		if resource.Identifier == "" {
+			return scope.SpacePath, ""
+		}
+		return scope.SpacePath, paths.Concatenate(scope.SpacePath, resource.Identifier)
+
+	case enum.ResourceTypePipeline:
+		if scope.Repo == "" {
+			return scope.SpacePath, ""
+		}
+		return scope.SpacePath, paths.Concatenate(scope.SpacePath, scope.Repo)
+
+	default:
+		return scope.SpacePath, ""
+	}
+}
+
+// checkWithTokenScope checks that the requested permission and resource are within the scope of a restricted token.
+func (a *MembershipAuthorizer) checkWithTokenScope(
+	ctx context.Context,
+	tokenScope *types.TokenScope,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if !tokenScope.HasPermission(permission) {
+		log.Ctx(ctx).Debug().Msgf("requested permission '%s' is outside of the token scope", permission)
+		return false, nil
+	}
+
+	if !tokenScope.IsResourceRestricted() {
+		return true, nil
+	}
+
+	var spacePath, repoPath string
+
+	//nolint:exhaustive // resources outside of spaces can't be accessed with resource restricted tokens
+	switch resource.Type {
+	case enum.ResourceTypeSpace,
+		enum.ResourceTypeRepo,
+		enum.ResourceTypeServiceAccount,
+		enum.ResourceTypePipeline,
+		enum.ResourceTypeSecret,
+		enum.ResourceTypeConnector,
+		enum.ResourceTypeTemplate:
+		spacePath, repoPath = resourcePaths(scope, resource)
+	default:
+		return false, nil
+	}
+
+	for _, spaceID := range tokenScope.SpaceIDs {
+		space, err := a.spaceStore.Find(ctx, spaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find space of token scope: %w", err)
+		}
+
+		if paths.IsAncesterOf(space.Path, spacePath) {
+			return true, nil
+		}
+	}
+
+	if repoPath == "" {
+		log.Ctx(ctx).Debug().Msgf("requested space '%s' is outside of the token scope", spacePath)
+		return false, nil
+	}
+
+	for _, repoID := range tokenScope.RepoIDs {
+		repo, err := a.repoStore.Find(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find repository of token scope: %w", err)
+		}
+
+		if strings.EqualFold(repo.Path, repoPath) {
+			return true, nil
+		}
+	}
+
+	log.Ctx(ctx).Debug().Msgf("requested repository '%s' is outside of the token scope", repoPath)
+
+	return false, nil
+}
+
 // checkWithMembershipMetadata checks access using the ephemeral membership provided in the metadata.
 func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	ctx context.Context,
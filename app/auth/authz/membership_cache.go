@@ -30,33 +30,58 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+// PermissionCacheKey is the key of the PermissionCache.
+// RepoRef is only set if the permission is requested for a specific repository (or one of its resources),
+// in which case repository memberships are taken into account as well.
 type PermissionCacheKey struct {
 	PrincipalID int64
 	SpaceRef    string
+	RepoRef     string
 	Permission  enum.Permission
 }
 type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 	membershipStore store.MembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:          spaceStore,
+		repoStore:           repoStore,
+		membershipStore:     membershipStore,
+		repoMembershipStore: repoMembershipStore,
+		roleStore:           roleStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore          store.SpaceStore
+	repoStore           store.RepoStore
+	membershipStore     store.MembershipStore
+	repoMembershipStore store.RepoMembershipStore
+	roleStore           store.RoleStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
 	spaceRef := key.SpaceRef
 	principalID := key.PrincipalID
 
+	// Repository memberships grant permissions only to the repository itself.
+	if key.RepoRef != "" {
+		hasPermission, err := g.repoMembershipHasPermission(ctx, key)
+		if err != nil {
+			return false, err
+		}
+
+		if hasPermission {
+			return true, nil
+		}
+	}
+
 	// Find the first existing space.
 	space, err := g.findFirstExistingSpace(ctx, spaceRef)
 	// authz fails if no active space is found on the path; admins can still operate on deleted top-level spaces.
@@ -81,9 +106,15 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 		}
 
 		// If the membership is defined in the current space, check if the user has the required permission.
-		if membership != nil &&
-			roleHasPermission(membership.Role, key.Permission) {
-			return true, nil
+		if membership != nil {
+			hasPermission, err := g.roleHasPermission(ctx, membership.Role, membership.CustomRoleID, key.Permission)
+			if err != nil {
+				return false, err
+			}
+
+			if hasPermission {
+				return true, nil
+			}
 		}
 
 		// If membership with the requested permission has not been found in the current space,
@@ -102,6 +133,55 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 	return false, nil
 }
 
+// repoMembershipHasPermission checks if the principal has a membership of the repository
+// that grants the requested permission.
+func (g permissionCacheGetter) repoMembershipHasPermission(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	repo, err := g.repoStore.FindByRef(ctx, key.RepoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repository '%s': %w", key.RepoRef, err)
+	}
+
+	membership, err := g.repoMembershipStore.Find(ctx, types.RepoMembershipKey{
+		RepoID:      repo.ID,
+		PrincipalID: key.PrincipalID,
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo membership: %w", err)
+	}
+
+	return g.roleHasPermission(ctx, membership.Role, membership.CustomRoleID, key.Permission)
+}
+
+// roleHasPermission checks if the role of a membership grants the permission,
+// either as one of the predefined roles or through the custom role of the membership.
+func (g permissionCacheGetter) roleHasPermission(
+	ctx context.Context,
+	role enum.MembershipRole,
+	customRoleID *int64,
+	permission enum.Permission,
+) (bool, error) {
+	if role != enum.MembershipRoleCustom {
+		return roleHasPermission(role, permission), nil
+	}
+
+	if customRoleID == nil {
+		return false, nil
+	}
+
+	customRole, err := g.roleStore.Find(ctx, *customRoleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find custom role with id %d: %w", *customRoleID, err)
+	}
+
+	return customRole.HasPermission(permission), nil
+}
+
 func roleHasPermission(role enum.MembershipRole, permission enum.Permission) bool {
 	_, hasRole := slices.BinarySearch(role.Permissions(), permission)
 	return hasRole
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMembershipAuthorizer_CheckSystemAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		admin    bool
+		metadata auth.Metadata
+		exp      bool
+	}{
+		{
+			name:  "admin",
+			admin: true,
+			exp:   true,
+		},
+		{
+			name:  "non-admin",
+			admin: false,
+			exp:   false,
+		},
+		{
+			name:     "admin-with-unrestricted-token",
+			admin:    true,
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT},
+			exp:      true,
+		},
+		{
+			name:  "admin-with-permission-restricted-token",
+			admin: true,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scope:     types.TokenScope{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			},
+			exp: false,
+		},
+		{
+			name:  "admin-with-resource-restricted-token",
+			admin: true,
+			metadata: &auth.TokenMetadata{
+				TokenType: enum.TokenTypePAT,
+				Scope:     types.TokenScope{SpaceIDs: []int64{1}},
+			},
+			exp: false,
+		},
+	}
+
+	authorizer := NewMembershipAuthorizer(nil, nil, nil)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{
+				Principal: types.Principal{ID: 1, UID: "admin", Admin: test.admin},
+				Metadata:  test.metadata,
+			}
+
+			allowed, err := authorizer.Check(context.Background(), session, &types.Scope{},
+				&types.Resource{Type: enum.ResourceTypeSystem}, enum.PermissionSystemAdmin)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if allowed != test.exp {
+				t.Errorf("expected allowed=%t, got %t", test.exp, allowed)
+			}
+		})
+	}
+}
@@ -28,14 +28,18 @@ var WireSet = wire.NewSet(
 	ProvidePermissionCache,
 )
 
-func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore)
+func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore, repoStore store.RepoStore) Authorizer {
+	return NewMembershipAuthorizer(pCache, spaceStore, repoStore)
 }
 
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 	membershipStore store.MembershipStore,
+	repoMembershipStore store.RepoMembershipStore,
+	roleStore store.RoleStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceStore, repoStore, membershipStore, repoMembershipStore, roleStore,
+		permissionCacheTimeout)
 }
@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/groupsync"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid ldap credentials")
+	ErrUserNotFound       = errors.New("ldap user not found")
+)
+
+const (
+	connTimeout = 10 * time.Second
+
+	placeholderLogin    = "{login}"
+	placeholderDN       = "{dn}"
+	placeholderUsername = "{username}"
+)
+
+var _ groupsync.GroupSource = (*Client)(nil)
+
+// Identity is the identity of the user as stored in the directory.
+type Identity struct {
+	DN          string
+	Username    string
+	Email       string
+	DisplayName string
+	Groups      []string
+}
+
+// Client authenticates users against an LDAP server and looks up their groups.
+// A new connection is established for every operation, as operations are rare (login and periodic sync).
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) *Client {
+	return &Client{
+		config: config,
+	}
+}
+
+// Enabled returns true if the login via LDAP is enabled.
+func (c *Client) Enabled() bool {
+	return c.config.Enabled
+}
+
+// GroupMappings returns the group mappings configured for LDAP.
+func (c *Client) GroupMappings() []groupsync.GroupMapping {
+	return c.config.GroupMappings
+}
+
+// AutoProvision returns true if users should be created on their first login.
+func (c *Client) AutoProvision() bool {
+	return c.config.AutoProvision
+}
+
+// Authenticate verifies the credentials of the user by binding as the user and returns the identity of the user.
+func (c *Client) Authenticate(ctx context.Context, login string, password string) (*Identity, error) {
+	// an empty password would result in an unauthenticated bind, which succeeds for any user on most servers.
+	if login == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := strings.ReplaceAll(c.config.UserFilter, placeholderLogin, ldap.EscapeFilter(login))
+
+	entry, err := c.searchUser(conn, c.config.UserBaseDN, ldap.ScopeWholeSubtree, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind as ldap user: %w", err)
+	}
+
+	// groups are searched with the service account, users might not have permissions to do so.
+	if err = c.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+
+	identity, err := c.identityFromEntry(conn, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Debug().Msgf("authenticated ldap user %q", identity.DN)
+
+	return identity, nil
+}
+
+// Provider returns the provider of the identities the groups are looked up for.
+func (c *Client) Provider() string {
+	return types.PrincipalIdentityProviderLDAP
+}
+
+// LookupGroups returns the groups of the user with the provided DN.
+// The DN is the one stored when the user logged in, as other attributes (like the email) can't be trusted.
+// If the user doesn't exist in the directory (anymore) an empty list is returned.
+func (c *Client) LookupGroups(_ context.Context, dn string) ([]string, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := c.searchUser(conn, dn, ldap.ScopeBaseObject, "(objectClass=*)")
+	if errors.Is(err, ErrUserNotFound) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.searchGroups(conn, entry)
+}
+
+func (c *Client) connect() (*ldap.Conn, error) {
+	if !c.config.Enabled {
+		return nil, errors.New("ldap is not enabled")
+	}
+
+	tlsConfig := &tls.Config{
+		//nolint:gosec // skipping verification is an explicit choice of the admin.
+		InsecureSkipVerify: c.config.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := ldap.DialURL(c.config.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: connTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+
+	conn.SetTimeout(connTimeout)
+
+	if c.config.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start tls with ldap server: %w", err)
+		}
+	}
+
+	if err = c.bindServiceAccount(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *Client) bindServiceAccount(conn *ldap.Conn) error {
+	var err error
+	if c.config.BindDN != "" {
+		err = conn.Bind(c.config.BindDN, c.config.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bind ldap service account: %w", err)
+	}
+
+	return nil
+}
+
+// searchUser returns the single user entry below the base DN that matches the filter.
+func (c *Client) searchUser(conn *ldap.Conn, baseDN string, scope int, filter string) (*ldap.Entry, error) {
+	attributes := []string{
+		c.config.UsernameAttribute,
+		c.config.EmailAttribute,
+		c.config.NameAttribute,
+	}
+	if c.config.GroupBaseDN == "" {
+		attributes = append(attributes, c.config.MemberOfAttribute)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		baseDN,
+		scope, ldap.NeverDerefAliases, 2, int(connTimeout.Seconds()), false,
+		filter,
+		attributes,
+		nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, ErrUserNotFound
+	}
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("ldap user search with filter %q returned more than one user", filter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search ldap user: %w", err)
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return result.Entries[0], nil
+	default:
+		return nil, fmt.Errorf("ldap user search with filter %q returned more than one user", filter)
+	}
+}
+
+func (c *Client) searchGroups(conn *ldap.Conn, entry *ldap.Entry) ([]string, error) {
+	if c.config.GroupBaseDN == "" {
+		return groupsFromMemberOf(entry.GetAttributeValues(c.config.MemberOfAttribute)), nil
+	}
+
+	filter := strings.NewReplacer(
+		placeholderDN, ldap.EscapeFilter(entry.DN),
+		placeholderUsername, ldap.EscapeFilter(entry.GetAttributeValue(c.config.UsernameAttribute)),
+	).Replace(c.config.GroupFilter)
+
+	result, err := conn.SearchWithPaging(ldap.NewSearchRequest(
+		c.config.GroupBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(connTimeout.Seconds()), false,
+		filter,
+		[]string{c.config.GroupNameAttribute},
+		nil,
+	), 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search ldap groups: %w", err)
+	}
+
+	groups := make([]string, 0, len(result.Entries))
+	for _, group := range result.Entries {
+		if name := group.GetAttributeValue(c.config.GroupNameAttribute); name != "" {
+			groups = append(groups, name)
+		}
+	}
+
+	return groups, nil
+}
+
+func (c *Client) identityFromEntry(conn *ldap.Conn, entry *ldap.Entry) (*Identity, error) {
+	identity := &Identity{
+		DN:          entry.DN,
+		Username:    entry.GetAttributeValue(c.config.UsernameAttribute),
+		Email:       entry.GetAttributeValue(c.config.EmailAttribute),
+		DisplayName: entry.GetAttributeValue(c.config.NameAttribute),
+	}
+
+	if identity.Email == "" {
+		return nil, fmt.Errorf("ldap user %q has no attribute %q", entry.DN, c.config.EmailAttribute)
+	}
+
+	groups, err := c.searchGroups(conn, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	identity.Groups = groups
+
+	return identity, nil
+}
+
+// groupsFromMemberOf returns the names of the groups, which is the value of the first RDN of the group DNs.
+func groupsFromMemberOf(groupDNs []string) []string {
+	groups := make([]string, 0, len(groupDNs))
+	for _, groupDN := range groupDNs {
+		dn, err := ldap.ParseDN(groupDN)
+		if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+			continue
+		}
+
+		groups = append(groups, dn.RDNs[0].Attributes[0].Value)
+	}
+
+	return groups
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupsFromMemberOf(t *testing.T) {
+	groupDNs := []string{
+		"CN=Developers,OU=Groups,DC=acme,DC=com",
+		"cn=release\\, managers,ou=groups,dc=acme,dc=com",
+		"invalid",
+		"",
+	}
+
+	want := []string{"Developers", "release, managers"}
+
+	got := groupsFromMemberOf(groupDNs)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("groups mismatch: want=%v got=%v", want, got)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"github.com/harness/gitness/app/services/groupsync"
+)
+
+// Config defines the configuration of the LDAP / Active Directory server.
+type Config struct {
+	Enabled            bool
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+
+	// BindDN and BindPassword are the credentials of the service account used to search for users and groups.
+	BindDN       string
+	BindPassword string
+
+	// UserBaseDN is the base DN of the user search.
+	UserBaseDN string
+	// UserFilter is the filter of the user search, the placeholder {login} is replaced with the login identifier.
+	UserFilter string
+
+	UsernameAttribute string
+	EmailAttribute    string
+	NameAttribute     string
+
+	// GroupBaseDN is the base DN of the group search. If it is empty,
+	// the groups are read from the MemberOfAttribute of the user entry instead.
+	GroupBaseDN string
+	// GroupFilter is the filter of the group search, the placeholders {dn} and {username}
+	// are replaced with the DN and the username of the user.
+	GroupFilter        string
+	GroupNameAttribute string
+	MemberOfAttribute  string
+
+	AutoProvision bool
+
+	GroupMappings []groupsync.GroupMapping
+}
@@ -14,7 +14,10 @@
 
 package auth
 
-import "github.com/harness/gitness/types/enum"
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
 
 type Metadata interface {
 	ImpactsAuthorization() bool
@@ -31,10 +34,13 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+
+	// Scope contains the optional restrictions of the token.
+	Scope types.TokenScope
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return m.Scope.IsRestricted()
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
@@ -46,3 +52,25 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// CacheMetadata contains information about the build cache access granted to a pipeline step.
+// It doesn't grant access to any other resource.
+type CacheMetadata struct {
+	RepoID int64
+	Branch string
+	Write  bool
+}
+
+func (m *CacheMetadata) ImpactsAuthorization() bool {
+	return true
+}
+
+// ArtifactMetadata contains the stage a pipeline step is allowed to upload artifacts for.
+// It doesn't grant access to any other resource.
+type ArtifactMetadata struct {
+	StageID int64
+}
+
+func (m *ArtifactMetadata) ImpactsAuthorization() bool {
+	return true
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"github.com/harness/gitness/app/services/groupsync"
+)
+
+// Config defines the configuration of the OpenID Connect provider.
+type Config struct {
+	Enabled      bool
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	UsernameClaim string
+	EmailClaim    string
+	NameClaim     string
+	GroupsClaim   string
+
+	SkipEmailVerification bool
+	AutoProvision         bool
+
+	GroupMappings []groupsync.GroupMapping
+}
@@ -0,0 +1,362 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/services/groupsync"
+
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrNotEnabled     = errors.New("oidc login is not enabled")
+	ErrInvalidState   = errors.New("oidc state mismatch")
+	ErrInvalidIDToken = errors.New("invalid oidc id token")
+	ErrEmailNotVerify = errors.New("email of the oidc identity isn't verified")
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Identity is the identity of the user as reported by the provider.
+type Identity struct {
+	Subject     string
+	Email       string
+	DisplayName string
+	Username    string
+	Groups      []string
+}
+
+// AuthState holds the values of an authorization request that are needed to verify the callback.
+type AuthState struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// Encode returns the auth state in a form that can be stored in a cookie.
+func (s AuthState) Encode() string {
+	data, _ := json.Marshal(s)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeAuthState decodes an auth state that was encoded with AuthState.Encode.
+func DecodeAuthState(raw string) (AuthState, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return AuthState{}, fmt.Errorf("failed to decode auth state: %w", err)
+	}
+
+	var s AuthState
+	if err = json.Unmarshal(data, &s); err != nil {
+		return AuthState{}, fmt.Errorf("failed to unmarshal auth state: %w", err)
+	}
+
+	if s.State == "" || s.Nonce == "" || s.CodeVerifier == "" {
+		return AuthState{}, errors.New("auth state is incomplete")
+	}
+
+	return s, nil
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// Provider implements the authorization code flow with PKCE against an OpenID Connect provider.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mx        sync.Mutex
+	discovery *discovery
+}
+
+func NewProvider(config Config) *Provider {
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Enabled returns true if the login via the provider is enabled.
+func (p *Provider) Enabled() bool {
+	return p.config.Enabled
+}
+
+// GroupMappings returns the group mappings configured for the provider.
+func (p *Provider) GroupMappings() []groupsync.GroupMapping {
+	return p.config.GroupMappings
+}
+
+// AutoProvision returns true if users should be created on their first login.
+func (p *Provider) AutoProvision() bool {
+	return p.config.AutoProvision
+}
+
+// AuthCodeURL returns the URL of the provider the user has to be redirected to,
+// as well as the auth state that has to be presented when the user comes back.
+func (p *Provider) AuthCodeURL(ctx context.Context) (string, AuthState, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return "", AuthState{}, err
+	}
+
+	authState := AuthState{
+		State:        randomString(),
+		Nonce:        randomString(),
+		CodeVerifier: randomString() + randomString(),
+	}
+
+	challenge := sha256.Sum256([]byte(authState.CodeVerifier))
+
+	url := oauthConfig.AuthCodeURL(authState.State,
+		oauth2.SetAuthURLParam("nonce", authState.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	return url, authState, nil
+}
+
+// Exchange verifies the callback of the provider, exchanges the code for tokens
+// and returns the identity of the user.
+func (p *Provider) Exchange(
+	ctx context.Context,
+	authState AuthState,
+	state string,
+	code string,
+) (*Identity, error) {
+	if state == "" || state != authState.State {
+		return nil, ErrInvalidState
+	}
+
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+
+	token, err := oauthConfig.Exchange(ctx, code,
+		oauth2.SetAuthURLParam("code_verifier", authState.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange oidc code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response doesn't contain an id token", ErrInvalidIDToken)
+	}
+
+	claims, err := p.verifyIDToken(rawIDToken, authState.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// claims returned by the userinfo endpoint take precedence, as some providers keep the id token minimal.
+	if p.discovery.UserinfoEndpoint != "" {
+		userinfo, err := p.userinfo(ctx, oauthConfig.TokenSource(ctx, token))
+		if err != nil {
+			return nil, err
+		}
+
+		if sub, _ := userinfo["sub"].(string); sub != claims["sub"] {
+			return nil, fmt.Errorf("%w: subject of userinfo doesn't match", ErrInvalidIDToken)
+		}
+
+		for k, v := range userinfo {
+			claims[k] = v
+		}
+	}
+
+	return p.identityFromClaims(claims)
+}
+
+// verifyIDToken validates the claims of the id token.
+// The signature isn't verified as the token was received directly from the token endpoint of the provider,
+// which is permitted by the OpenID Connect specification (section 3.1.3.7).
+func (p *Provider) verifyIDToken(rawIDToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(rawIDToken, claims); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	if !claims.VerifyIssuer(p.discovery.Issuer, true) {
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrInvalidIDToken)
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidIDToken)
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidIDToken)
+	}
+
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("%w: subject is missing", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) userinfo(ctx context.Context, tokenSource oauth2.TokenSource) (map[string]any, error) {
+	client := oauth2.NewClient(ctx, tokenSource)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.discovery.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create userinfo request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo request failed with status %d", resp.StatusCode)
+	}
+
+	userinfo := map[string]any{}
+	if err = json.NewDecoder(resp.Body).Decode(&userinfo); err != nil {
+		return nil, fmt.Errorf("failed to decode userinfo: %w", err)
+	}
+
+	return userinfo, nil
+}
+
+func (p *Provider) identityFromClaims(claims map[string]any) (*Identity, error) {
+	identity := &Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims[p.config.EmailClaim].(string)
+	identity.DisplayName, _ = claims[p.config.NameClaim].(string)
+	identity.Username, _ = claims[p.config.UsernameClaim].(string)
+
+	if identity.Email == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", ErrInvalidIDToken, p.config.EmailClaim)
+	}
+
+	if !p.config.SkipEmailVerification {
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return nil, ErrEmailNotVerify
+		}
+	}
+
+	switch groups := claims[p.config.GroupsClaim].(type) {
+	case []any:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				identity.Groups = append(identity.Groups, s)
+			}
+		}
+	case string:
+		identity.Groups = strings.Split(groups, ",")
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	if !p.config.Enabled {
+		return nil, ErrNotEnabled
+	}
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  d.AuthorizationEndpoint,
+			TokenURL: d.TokenEndpoint,
+		},
+	}, nil
+}
+
+// discover fetches the provider metadata. The result is cached after the first successful request.
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc discovery request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request oidc discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery request failed with status %d", resp.StatusCode)
+	}
+
+	d := &discovery{}
+	if err = json.NewDecoder(resp.Body).Decode(d); err != nil {
+		return nil, fmt.Errorf("failed to decode oidc discovery document: %w", err)
+	}
+
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch: expected %q, got %q", issuer, d.Issuer)
+	}
+
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	p.discovery = d
+
+	return d, nil
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+func TestCode(t *testing.T) {
+	// test vectors of RFC 6238, appendix B (SHA1), truncated to 6 digits.
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		exp  string
+	}{
+		{unix: 59, exp: "287082"},
+		{unix: 1111111109, exp: "081804"},
+		{unix: 1111111111, exp: "050471"},
+		{unix: 1234567890, exp: "005924"},
+		{unix: 2000000000, exp: "279037"},
+		{unix: 20000000000, exp: "353130"},
+	}
+
+	for _, test := range tests {
+		code, err := Code(secret, Step(time.Unix(test.unix, 0)))
+		if err != nil {
+			t.Errorf("got an error: %s", err.Error())
+			continue
+		}
+
+		if code != test.exp {
+			t.Errorf("code mismatch for time %d: want=%s got=%s", test.unix, test.exp, code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %s", err.Error())
+	}
+
+	now := time.Unix(1700000000, 0)
+	current := Step(now)
+
+	previousCode, _ := Code(secret, current-1)
+	currentCode, _ := Code(secret, current)
+	oldCode, _ := Code(secret, current-2)
+
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep int64
+		exp          bool
+	}{
+		{name: "current", code: currentCode, exp: true},
+		{name: "previous", code: previousCode, exp: true},
+		{name: "with-spaces", code: currentCode[:3] + " " + currentCode[3:], exp: true},
+		{name: "too-old", code: oldCode, exp: false},
+		{name: "replay", code: currentCode, lastUsedStep: current, exp: false},
+		{name: "invalid", code: "abc", exp: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			step, ok, err := Validate(secret, test.code, now, test.lastUsedStep)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if ok != test.exp {
+				t.Errorf("validation mismatch: want=%t got=%t", test.exp, ok)
+			}
+
+			if ok && step <= test.lastUsedStep {
+				t.Errorf("returned step %d isn't after the last used step %d", step, test.lastUsedStep)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const ApprovalRequestedEvent events.EventType = "approval-requested"
+
+// ApprovalRequestedPayload is sent when an approval stage of an execution got blocked waiting for approval.
+type ApprovalRequestedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	ExecutionID int64 `json:"execution_id"`
+	StageID     int64 `json:"stage_id"`
+}
+
+func (r *Reporter) ApprovalRequested(ctx context.Context, payload *ApprovalRequestedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ApprovalRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send approval requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported approval requested event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterApprovalRequested(fn events.HandlerFunc[*ApprovalRequestedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ApprovalRequestedEvent, fn, opts...)
+}
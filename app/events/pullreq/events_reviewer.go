@@ -52,3 +52,34 @@ func (r *Reader) RegisterReviewerAdded(
 ) error {
 	return events.ReaderRegisterEvent(r.innerReader, ReviewerAddedEvent, fn, opts...)
 }
+
+const ReviewerRemovedEvent events.EventType = "reviewer-removed"
+
+type ReviewerRemovedPayload struct {
+	Base
+	ReviewerID int64 `json:"reviewer_id"`
+}
+
+func (r *Reporter) ReviewerRemoved(
+	ctx context.Context,
+	payload *ReviewerRemovedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewerRemovedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request reviewer removed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request reviewer removed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewerRemoved(
+	fn events.HandlerFunc[*ReviewerRemovedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewerRemovedEvent, fn, opts...)
+}
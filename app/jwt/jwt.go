@@ -36,6 +36,9 @@ type Claims struct {
 
 	Token      *SubClaimsToken      `json:"tkn,omitempty"`
 	Membership *SubClaimsMembership `json:"ms,omitempty"`
+	Cache      *SubClaimsCache      `json:"cch,omitempty"`
+	Login      *SubClaimsLogin      `json:"lgn,omitempty"`
+	Artifact   *SubClaimsArtifact   `json:"art,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -50,6 +53,24 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// SubClaimsCache contains the build cache access the JWT was created for.
+type SubClaimsCache struct {
+	RepoID int64  `json:"rid,omitempty"`
+	Branch string `json:"br,omitempty"`
+	Write  bool   `json:"w,omitempty"`
+}
+
+// SubClaimsArtifact contains the stage the JWT grants to upload artifacts for.
+type SubClaimsArtifact struct {
+	StageID int64 `json:"sid,omitempty"`
+}
+
+// SubClaimsLogin contains the pending login the JWT was created for.
+// The first factor of the login was verified, but the second factor is still missing.
+type SubClaimsLogin struct {
+	Method string `json:"mth,omitempty"`
+}
+
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
 	var expiresAt int64
@@ -111,3 +132,120 @@ func GenerateWithMembership(
 
 	return res, nil
 }
+
+// GenerateWithCacheAccess generates a jwt that only grants access to the build cache of a repo branch.
+func GenerateWithCacheAccess(
+	principalID int64,
+	cache *SubClaimsCache,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		Cache:       cache,
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// GenerateWithArtifactAccess generates a jwt that only grants to upload artifacts of a stage.
+func GenerateWithArtifactAccess(
+	principalID int64,
+	artifact *SubClaimsArtifact,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		Artifact:    artifact,
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// GenerateForLogin generates a jwt for a pending login of the principal that is awaiting the second factor.
+func GenerateForLogin(
+	principalID int64,
+	method string,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		Login: &SubClaimsLogin{
+			Method: method,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// ParseForLogin parses and verifies a jwt generated by GenerateForLogin.
+// The secret is looked up using the principal id of the jwt.
+func ParseForLogin(
+	str string,
+	secret func(principalID int64) (string, error),
+) (int64, *SubClaimsLogin, error) {
+	claims := &Claims{}
+	parsed, err := jwt.ParseWithClaims(str, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		s, err := secret(claims.PrincipalID)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	})
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "Failed to parse token")
+	}
+
+	if !parsed.Valid || claims.Login == nil {
+		return 0, nil, errors.New("token isn't valid for a pending login")
+	}
+
+	return claims.PrincipalID, claims.Login, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:pipeline:approval"
+
+	jobTypeExpire        = "gitness:pipeline:approval-expire"
+	jobCronExpire        = "* * * * *" // every minute
+	jobMaxDurationExpire = 5 * time.Minute
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+// Notifier informs principals about a stage waiting for their approval.
+type Notifier interface {
+	SendApprovalRequested(
+		ctx context.Context,
+		recipientIDs []int64,
+		repo *types.Repository,
+		pipeline *types.Pipeline,
+		execution *types.Execution,
+		approval *types.StageApproval,
+	) error
+}
+
+// Service resolves approval stages of executions: it completes them once they got approved or rejected,
+// fails them once they timed out and notifies the approvers about stages waiting for their approval.
+type Service struct {
+	jobScheduler   *job.Scheduler
+	approvalStore  store.StageApprovalStore
+	stageStore     store.StageStore
+	executionStore store.ExecutionStore
+	pipelineStore  store.PipelineStore
+	repoStore      store.RepoStore
+	manager        manager.ExecutionManager
+	sseStreamer    sse.Streamer
+	notifier       Notifier
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	approvalStore store.StageApprovalStore,
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	manager manager.ExecutionManager,
+	sseStreamer sse.Streamer,
+	notifier Notifier,
+) (*Service, error) {
+	s := &Service{
+		jobScheduler:   jobScheduler,
+		approvalStore:  approvalStore,
+		stageStore:     stageStore,
+		executionStore: executionStore,
+		pipelineStore:  pipelineStore,
+		repoStore:      repoStore,
+		manager:        manager,
+		sseStreamer:    sseStreamer,
+		notifier:       notifier,
+	}
+
+	if err := executor.Register(jobTypeExpire, s); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for approval expiration: %w", err)
+	}
+
+	_, err := pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterApprovalRequested(s.handleEventApprovalRequested)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for approvals: %w", err)
+	}
+
+	return s, nil
+}
+
+// Register schedules the recurring approval expiration job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.jobScheduler.AddRecurring(ctx, jobTypeExpire, jobTypeExpire, jobCronExpire, jobMaxDurationExpire)
+	if err != nil {
+		return fmt.Errorf("failed to schedule approval expiration job: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve completes the blocked approval stage with the provided status and continues the execution.
+func (s *Service) Resolve(ctx context.Context, stage *types.Stage, status enum.CIStatus, reason string) error {
+	if stage.Status != enum.CIStatusBlocked {
+		return fmt.Errorf("stage %d isn't waiting for approval", stage.ID)
+	}
+
+	stage.Status = status
+	stage.Error = reason
+	stage.Stopped = time.Now().UnixMilli()
+
+	// the manager persists the stage and schedules or skips the downstream stages.
+	if err := s.manager.AfterStage(ctx, stage); err != nil {
+		return fmt.Errorf("failed to complete approval stage: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fails all blocked approval stages whose timeout passed.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	approvals, err := s.approvalStore.ListExpired(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list expired approvals: %w", err)
+	}
+
+	var expired, failed int
+	for _, approval := range approvals {
+		if err := s.expire(ctx, approval); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to expire approval of stage %d", approval.StageID)
+			failed++
+			continue
+		}
+		expired++
+	}
+
+	result := fmt.Sprintf("expired %d approval stages (%d failed)", expired, failed)
+
+	if expired > 0 || failed > 0 {
+		log.Ctx(ctx).Info().Msg(result)
+	}
+
+	return result, nil
+}
+
+func (s *Service) expire(ctx context.Context, approval *types.StageApproval) error {
+	stage, err := s.stageStore.Find(ctx, approval.StageID)
+	if err != nil {
+		return fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Status != enum.CIStatusBlocked {
+		return nil
+	}
+
+	err = s.Resolve(ctx, stage, enum.CIStatusFailure, "Approval timed out")
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// the stage got approved, rejected or canceled in the meantime.
+		return nil
+	}
+
+	return err
+}
+
+// handleEventApprovalRequested informs the approvers about the stage waiting for their approval.
+func (s *Service) handleEventApprovalRequested(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ApprovalRequestedPayload],
+) error {
+	approval, err := s.approvalStore.FindByStageID(ctx, event.Payload.StageID)
+	if err != nil {
+		return fmt.Errorf("failed to find approval: %w", err)
+	}
+
+	if approval.Status != enum.CIStatusBlocked {
+		return nil
+	}
+
+	execution, err := s.executionStore.Find(ctx, event.Payload.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, execution.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeApprovalRequested, approval)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish approval requested event")
+	}
+
+	err = s.notifier.SendApprovalRequested(ctx, approval.ApproverIDs, repo, pipeline, execution, approval)
+	if err != nil {
+		return fmt.Errorf("failed to notify approvers: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+// ProvideService provides the service resolving approval stages of executions.
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	approvalStore store.StageApprovalStore,
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	manager manager.ExecutionManager,
+	sseStreamer sse.Streamer,
+	notifier Notifier,
+) (*Service, error) {
+	return NewService(ctx,
+		Config{
+			EventReaderName: config.InstanceID,
+			Concurrency:     config.Notification.Concurrency,
+			MaxRetries:      config.Notification.MaxRetries,
+		},
+		jobScheduler, executor, pipelineReaderFactory, approvalStore, stageStore, executionStore,
+		pipelineStore, repoStore, manager, sseStreamer, notifier)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -36,6 +37,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/maps"
 )
 
 const (
@@ -148,6 +150,7 @@ type Manager struct {
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	Reporter *pipelineevents.Reporter
 }
 
 func New(
@@ -167,6 +170,7 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -185,6 +189,7 @@ func New(
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
+		Reporter:         reporter,
 	}
 }
 
@@ -348,6 +353,25 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		return nil, err
 	}
 
+	cacheToken, err := m.createCacheToken(repo, execution)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: failed to create cache token")
+		return nil, err
+	}
+
+	artifactToken, err := m.createArtifactToken(stage)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: failed to create artifact token")
+		return nil, err
+	}
+
+	// the tokens are ephemeral, hence they're only added to the params sent to the runner and never stored.
+	params := make(map[string]string, len(execution.Params)+2)
+	maps.Copy(params, execution.Params)
+	params["GITNESS_CACHE_TOKEN"] = cacheToken
+	params["GITNESS_ARTIFACT_TOKEN"] = artifactToken
+	execution.Params = params
+
 	return &ExecutionContext{
 		Repo:      repo,
 		Execution: execution,
@@ -383,6 +407,59 @@ func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	}, nil
 }
 
+// createCacheToken creates the token that grants the steps of the execution access to the build cache.
+func (m *Manager) createCacheToken(repo *types.Repository, execution *types.Execution) (string, error) {
+	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
+
+	token, err := jwt.GenerateWithCacheAccess(
+		pipelinePrincipal.ID,
+		cacheAccess(repo, execution),
+		pipelineJWTLifetime,
+		pipelinePrincipal.Salt,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create jwt: %w", err)
+	}
+
+	return token, nil
+}
+
+// createArtifactToken creates the token that grants the steps of the stage to upload artifacts.
+func (m *Manager) createArtifactToken(stage *types.Stage) (string, error) {
+	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
+
+	token, err := jwt.GenerateWithArtifactAccess(
+		pipelinePrincipal.ID,
+		&jwt.SubClaimsArtifact{StageID: stage.ID},
+		pipelineJWTLifetime,
+		pipelinePrincipal.Salt,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create jwt: %w", err)
+	}
+
+	return token, nil
+}
+
+// cacheAccess returns the build cache access of the execution, which is limited to the cache of its branch.
+// Pull request executions use the cache of their source branch. As they run code that isn't merged yet,
+// they can't write to the cache of the default branch, which is read by the executions of all other branches.
+func cacheAccess(repo *types.Repository, execution *types.Execution) *jwt.SubClaimsCache {
+	if execution.Event == enum.TriggerEventPullRequest {
+		return &jwt.SubClaimsCache{
+			RepoID: repo.ID,
+			Branch: execution.Source,
+			Write:  execution.Source != repo.DefaultBranch,
+		}
+	}
+
+	return &jwt.SubClaimsCache{
+		RepoID: repo.ID,
+		Branch: execution.Target,
+		Write:  true,
+	}
+}
+
 // Before signals the build step is about to start.
 func (m *Manager) BeforeStep(_ context.Context, step *types.Step) error {
 	log := log.With().
@@ -465,6 +542,7 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+		Reporter:    m.Reporter,
 	}
 	return t.do(noContext, stage)
 }
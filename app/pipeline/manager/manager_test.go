@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCacheAccess(t *testing.T) {
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+
+	tests := []struct {
+		name      string
+		execution *types.Execution
+		want      jwt.SubClaimsCache
+	}{
+		{
+			name:      "push",
+			execution: &types.Execution{Event: enum.TriggerEventPush, Source: "main", Target: "main"},
+			want:      jwt.SubClaimsCache{RepoID: 1, Branch: "main", Write: true},
+		},
+		{
+			name:      "pull request",
+			execution: &types.Execution{Event: enum.TriggerEventPullRequest, Source: "feature", Target: "main"},
+			want:      jwt.SubClaimsCache{RepoID: 1, Branch: "feature", Write: true},
+		},
+		{
+			name:      "pull request from default branch",
+			execution: &types.Execution{Event: enum.TriggerEventPullRequest, Source: "main", Target: "release"},
+			want:      jwt.SubClaimsCache{RepoID: 1, Branch: "main", Write: false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := cacheAccess(repo, test.execution); *got != test.want {
+				t.Errorf("expected cache access %+v, got %+v", test.want, *got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -43,6 +44,7 @@ type teardown struct {
 	Repos       store.RepoStore
 	Steps       store.StepStore
 	Stages      store.StageStore
+	Reporter    *pipelineevents.Reporter
 }
 
 //nolint:gocognit // refactor if needed.
@@ -150,6 +152,10 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			execution.Status = enum.CIStatusError
 			break
 		}
+		if sibling.Status == enum.CIStatusDeclined {
+			execution.Status = enum.CIStatusDeclined
+			break
+		}
 	}
 	if execution.Started == 0 {
 		execution.Started = execution.Finished
@@ -174,6 +180,14 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			Msg("manager: could not publish execution completed event")
 	}
 
+	t.Reporter.ExecutionCompleted(ctx, &pipelineevents.ExecutionCompletedPayload{
+		RepoID:      execution.RepoID,
+		PipelineID:  execution.PipelineID,
+		ExecutionID: execution.ID,
+		PrincipalID: execution.CreatedBy,
+		Status:      execution.Status,
+	})
+
 	pipeline, err := t.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot find pipeline")
@@ -261,7 +275,6 @@ func isexecutionComplete(stages []*types.Stage) bool {
 		if stage.Status == enum.CIStatusPending ||
 			stage.Status == enum.CIStatusRunning ||
 			stage.Status == enum.CIStatusWaitingOnDeps ||
-			stage.Status == enum.CIStatusDeclined ||
 			stage.Status == enum.CIStatusBlocked {
 			return false
 		}
@@ -320,9 +333,18 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
-		log.Debug().Msg("manager: schedule next stage")
+		// approval stages don't run on a runner, they block the execution until they get approved.
+		if sibling.Type == types.StageTypeApproval {
+			log.Debug().Msg("manager: block stage for approval")
+
+			sibling.Status = enum.CIStatusBlocked
+			sibling.Started = time.Now().UnixMilli()
+		} else {
+			log.Debug().Msg("manager: schedule next stage")
+
+			sibling.Status = enum.CIStatusPending
+		}
 
-		sibling.Status = enum.CIStatusPending
 		err := t.Stages.Update(noContext, sibling)
 		if errors.Is(err, gitness_store.ErrVersionConflict) {
 			rErr := t.resync(ctx, sibling)
@@ -337,6 +359,15 @@ func (t *teardown) scheduleDownstream(
 			errs = multierror.Append(errs, err)
 		}
 
+		if sibling.Status == enum.CIStatusBlocked {
+			t.Reporter.ApprovalRequested(ctx, &pipelineevents.ApprovalRequestedPayload{
+				RepoID:      sibling.RepoID,
+				ExecutionID: sibling.ExecutionID,
+				StageID:     sibling.ID,
+			})
+			continue
+		}
+
 		err = t.Scheduler.Schedule(noContext, sibling)
 		if err != nil {
 			log.Error().Err(err).
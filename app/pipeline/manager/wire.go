@@ -15,6 +15,7 @@
 package manager
 
 import (
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -51,9 +52,10 @@ func ProvideExecutionManager(
 	secretStore store.SecretStore,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
-	userStore store.PrincipalStore) ExecutionManager {
+	userStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
-		logStore, logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore)
+		logStore, logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore, reporter)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reclaimer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReclaim        = "gitness:pipeline:reclaim"
+	jobCronReclaim        = "* * * * *" // every minute
+	jobMaxDurationReclaim = 5 * time.Minute
+)
+
+// Reclaimer detects remote runners that stopped sending heartbeats
+// and puts the stages they accepted back into the queue, so they can be picked up by another runner.
+type Reclaimer struct {
+	staleTimeout time.Duration
+	tx           dbtx.Transactor
+	jobScheduler *job.Scheduler
+	runnerStore  store.RunnerStore
+	stageStore   store.StageStore
+	stepStore    store.StepStore
+	logStream    livelog.LogStream
+	scheduler    scheduler.Scheduler
+}
+
+func New(
+	staleTimeout time.Duration,
+	tx dbtx.Transactor,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	logStream livelog.LogStream,
+	scheduler scheduler.Scheduler,
+) (*Reclaimer, error) {
+	if staleTimeout <= 0 {
+		return nil, errors.New("stale timeout of runners has to be positive")
+	}
+
+	r := &Reclaimer{
+		staleTimeout: staleTimeout,
+		tx:           tx,
+		jobScheduler: jobScheduler,
+		runnerStore:  runnerStore,
+		stageStore:   stageStore,
+		stepStore:    stepStore,
+		logStream:    logStream,
+		scheduler:    scheduler,
+	}
+
+	if err := executor.Register(jobTypeReclaim, r); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for stage reclaiming: %w", err)
+	}
+
+	return r, nil
+}
+
+// Register schedules the recurring stage reclaim job.
+func (r *Reclaimer) Register(ctx context.Context) error {
+	err := r.jobScheduler.AddRecurring(ctx, jobTypeReclaim, jobTypeReclaim, jobCronReclaim, jobMaxDurationReclaim)
+	if err != nil {
+		return fmt.Errorf("failed to schedule stage reclaim job: %w", err)
+	}
+
+	return nil
+}
+
+// IsStale returns true if the runner didn't send a heartbeat within the stale timeout.
+func (r *Reclaimer) IsStale(runner *types.Runner, now time.Time) bool {
+	return now.Sub(time.UnixMilli(runner.LastSeen)) > r.staleTimeout
+}
+
+// Handle reclaims all incomplete stages accepted by remote runners that are stale or got deleted.
+func (r *Reclaimer) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	stages, err := r.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	now := time.Now()
+	stale := map[string]bool{}
+
+	var reclaimed, failed int
+	for _, stage := range stages {
+		identifier, ok := strings.CutPrefix(stage.Machine, types.RunnerMachinePrefix)
+		if !ok {
+			continue
+		}
+
+		isStale, known := stale[identifier]
+		if !known {
+			runner, err := r.runnerStore.FindByIdentifier(ctx, identifier)
+			switch {
+			case errors.Is(err, gitness_store.ErrResourceNotFound):
+				isStale = true
+			case err != nil:
+				return "", fmt.Errorf("failed to find runner %q: %w", identifier, err)
+			default:
+				isStale = r.IsStale(runner, now)
+			}
+			stale[identifier] = isStale
+		}
+
+		if !isStale {
+			continue
+		}
+
+		if err := r.reclaim(ctx, stage); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to reclaim stage %d of runner %q", stage.ID, identifier)
+			failed++
+			continue
+		}
+		reclaimed++
+	}
+
+	result := fmt.Sprintf("reclaimed %d stages of stale runners (%d failed)", reclaimed, failed)
+
+	if reclaimed > 0 || failed > 0 {
+		log.Ctx(ctx).Info().Msg(result)
+	}
+
+	return result, nil
+}
+
+// reclaim resets the stage to pending, removes the steps created by the runner and schedules the stage again.
+func (r *Reclaimer) reclaim(ctx context.Context, stage *types.Stage) error {
+	stages, err := r.stageStore.ListWithSteps(ctx, stage.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages with steps: %w", err)
+	}
+
+	var steps []*types.Step
+	for _, s := range stages {
+		if s.ID == stage.ID {
+			steps = s.Steps
+		}
+	}
+
+	stage.Machine = ""
+	stage.Status = enum.CIStatusPending
+	stage.Error = ""
+	stage.ExitCode = 0
+	stage.Started = 0
+	stage.Stopped = 0
+	stage.Steps = nil
+
+	// the stage update guards the step removal: if the stage got updated in the meantime
+	// (e.g. completed or canceled), its steps have to stay untouched.
+	err = r.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.stageStore.Update(ctx, stage); err != nil {
+			return fmt.Errorf("failed to update stage: %w", err)
+		}
+
+		if err := r.stepStore.DeleteByStageID(ctx, stage.ID); err != nil {
+			return fmt.Errorf("failed to delete steps: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		// the stage will be reevaluated next run.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		err = r.logStream.Delete(ctx, step.ID)
+		if err != nil && !errors.Is(err, livelog.ErrStreamNotFound) {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete log stream of step %d", step.ID)
+		}
+	}
+
+	if err = r.scheduler.Schedule(ctx, stage); err != nil {
+		return fmt.Errorf("failed to schedule stage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reclaimer
+
+import (
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideReclaimer,
+)
+
+// ProvideReclaimer provides a reclaimer of stages accepted by stale remote runners.
+func ProvideReclaimer(
+	config *types.Config,
+	tx dbtx.Transactor,
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	logStream livelog.LogStream,
+	scheduler scheduler.Scheduler,
+) (*Reclaimer, error) {
+	return New(config.CI.RunnerStaleTimeout, tx, jobScheduler, executor,
+		runnerStore, stageStore, stepStore, logStream, scheduler)
+}
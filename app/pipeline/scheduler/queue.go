@@ -150,6 +150,12 @@ func (q *queue) signal(ctx context.Context) error {
 			continue
 		}
 
+		// if the pipeline defines a concurrency group we need to make sure
+		// the group limit is not exceeded by other executions of the group.
+		if !withinConcurrencyGroup(item, items) {
+			continue
+		}
+
 		// if the system defines concurrency limits
 		// per repository we need to make sure those limits
 		// are not exceeded before proceeding.
@@ -269,6 +275,29 @@ func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	return count < stage.Limit
 }
 
+func withinConcurrencyGroup(stage *types.Stage, siblings []*types.Stage) bool {
+	if stage.ConcurrencyGroup == "" || stage.ConcurrencyLimit == 0 {
+		return true
+	}
+	executions := map[int64]struct{}{}
+	for _, sibling := range siblings {
+		if sibling.RepoID != stage.RepoID {
+			continue
+		}
+		if sibling.ExecutionID == stage.ExecutionID {
+			continue
+		}
+		if sibling.ConcurrencyGroup != stage.ConcurrencyGroup {
+			continue
+		}
+		if sibling.ExecutionID < stage.ExecutionID ||
+			sibling.Status == enum.CIStatusRunning {
+			executions[sibling.ExecutionID] = struct{}{}
+		}
+	}
+	return len(executions) < stage.ConcurrencyLimit
+}
+
 func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool {
 	// if no throttle limit is defined (default) then
 	// return false to indicate no throttling is needed.
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestWithinConcurrencyGroup(t *testing.T) {
+	stage := func(id, executionID int64, group string, status enum.CIStatus) *types.Stage {
+		return &types.Stage{
+			ID:               id,
+			ExecutionID:      executionID,
+			RepoID:           1,
+			Status:           status,
+			ConcurrencyGroup: group,
+			ConcurrencyLimit: 1,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		stage    *types.Stage
+		siblings []*types.Stage
+		want     bool
+	}{
+		{
+			name:  "no group",
+			stage: stage(3, 2, "", enum.CIStatusPending),
+			siblings: []*types.Stage{
+				stage(1, 1, "", enum.CIStatusRunning),
+			},
+			want: true,
+		},
+		{
+			name:  "older execution of the group is running",
+			stage: stage(3, 2, "deploy", enum.CIStatusPending),
+			siblings: []*types.Stage{
+				stage(1, 1, "deploy", enum.CIStatusRunning),
+			},
+			want: false,
+		},
+		{
+			name:  "older execution of another group is running",
+			stage: stage(3, 2, "deploy", enum.CIStatusPending),
+			siblings: []*types.Stage{
+				stage(1, 1, "test", enum.CIStatusRunning),
+			},
+			want: true,
+		},
+		{
+			name:  "other stage of the same execution is running",
+			stage: stage(3, 2, "deploy", enum.CIStatusPending),
+			siblings: []*types.Stage{
+				stage(2, 2, "deploy", enum.CIStatusRunning),
+			},
+			want: true,
+		},
+		{
+			name:  "newer execution of the group is pending",
+			stage: stage(3, 2, "deploy", enum.CIStatusPending),
+			siblings: []*types.Stage{
+				stage(4, 3, "deploy", enum.CIStatusPending),
+			},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := withinConcurrencyGroup(test.stage, test.siblings); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
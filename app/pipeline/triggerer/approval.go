@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-yaml/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// approvalConfig is the approval section of a pipeline of type approval:
+//
+//	kind: pipeline
+//	type: approval
+//	name: sign-off
+//	depends_on: [ build ]
+//	approval:
+//	  approvers: [ alice, bob ]
+//	  min_approvals: 1
+//	  timeout: 24h
+type approvalConfig struct {
+	Approvers    []string `yaml:"approvers"`
+	MinApprovals int      `yaml:"min_approvals"`
+	Timeout      string   `yaml:"timeout"`
+}
+
+// approvalDocument holds the fields of a yaml document required to configure an approval stage.
+// The approval section isn't known to the drone yaml parser, hence the raw document is parsed again.
+type approvalDocument struct {
+	Kind     string          `yaml:"kind"`
+	Type     string          `yaml:"type"`
+	Name     string          `yaml:"name"`
+	Approval *approvalConfig `yaml:"approval"`
+}
+
+// parseApprovals returns the approval configurations of all approval stages of the yaml, by stage name.
+func parseApprovals(data []byte) (map[string]*approvalConfig, error) {
+	resources, err := yaml.ParseRawBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := map[string]*approvalConfig{}
+	for _, resource := range resources {
+		if resource == nil || resource.Type != types.StageTypeApproval {
+			continue
+		}
+
+		doc := &approvalDocument{}
+		if err = yamlv3.Unmarshal(resource.Data, doc); err != nil {
+			return nil, fmt.Errorf("failed to parse approval stage: %w", err)
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+
+		if doc.Approval == nil {
+			doc.Approval = &approvalConfig{}
+		}
+
+		configs[name] = doc.Approval
+	}
+
+	return configs, nil
+}
+
+// createApproval converts the approval configuration of a stage into its approval requirements.
+func (t *triggerer) createApproval(
+	ctx context.Context,
+	stageName string,
+	config *approvalConfig,
+	now int64,
+) (*types.StageApproval, error) {
+	if config == nil {
+		config = &approvalConfig{}
+	}
+
+	approval := &types.StageApproval{
+		StageName:    stageName,
+		MinApprovals: config.MinApprovals,
+		Created:      now,
+	}
+
+	if approval.MinApprovals < 0 {
+		return nil, fmt.Errorf("approval stage %q: min_approvals can't be negative", stageName)
+	}
+	if approval.MinApprovals == 0 {
+		approval.MinApprovals = 1
+	}
+
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("approval stage %q: invalid timeout: %w", stageName, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("approval stage %q: timeout has to be positive", stageName)
+		}
+		approval.Timeout = timeout.Milliseconds()
+	}
+
+	seen := map[string]struct{}{}
+	for _, uid := range config.Approvers {
+		uid = strings.TrimSpace(uid)
+		if _, ok := seen[strings.ToLower(uid)]; ok {
+			continue
+		}
+		seen[strings.ToLower(uid)] = struct{}{}
+
+		principal, err := t.principalStore.FindByUID(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("approval stage %q: failed to find approver %q: %w", stageName, uid, err)
+		}
+
+		approval.ApproverIDs = append(approval.ApproverIDs, principal.ID)
+	}
+
+	// without explicit approvers anyone able to trigger the execution could approve it, including its trigger.
+	if len(approval.ApproverIDs) == 0 {
+		return nil, fmt.Errorf("approval stage %q: at least one approver is required", stageName)
+	}
+
+	if approval.MinApprovals > len(approval.ApproverIDs) {
+		return nil, fmt.Errorf("approval stage %q: min_approvals can't be greater than the number of approvers",
+			stageName)
+	}
+
+	return approval, nil
+}
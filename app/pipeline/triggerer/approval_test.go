@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals []*types.Principal
+}
+
+func (s *fakePrincipalStore) FindByUID(_ context.Context, uid string) (*types.Principal, error) {
+	for _, principal := range s.principals {
+		if principal.UID == uid {
+			return principal, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestCreateApproval(t *testing.T) {
+	tr := &triggerer{principalStore: &fakePrincipalStore{principals: []*types.Principal{
+		{ID: 1, UID: "alice"},
+		{ID: 2, UID: "bob"},
+	}}}
+
+	tests := []struct {
+		name    string
+		config  *approvalConfig
+		wantErr bool
+	}{
+		{
+			name:   "approvers",
+			config: &approvalConfig{Approvers: []string{"alice", "bob", "Alice"}, MinApprovals: 2},
+		},
+		{
+			name:    "no approval section",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "no approvers",
+			config:  &approvalConfig{MinApprovals: 1},
+			wantErr: true,
+		},
+		{
+			name:    "too few approvers",
+			config:  &approvalConfig{Approvers: []string{"alice"}, MinApprovals: 2},
+			wantErr: true,
+		},
+		{
+			name:    "unknown approver",
+			config:  &approvalConfig{Approvers: []string{"mallory"}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			approval, err := tr.createApproval(context.Background(), "sign-off", test.config, 0)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got approval %+v", approval)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(approval.ApproverIDs) != 2 || approval.MinApprovals != 2 {
+				t.Fatalf("unexpected approval %+v", approval)
+			}
+		})
+	}
+}
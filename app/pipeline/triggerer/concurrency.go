@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// MaxConcurrencyGroupLength is the maximum length of a concurrency group key expression.
+	MaxConcurrencyGroupLength = 255
+	// MaxConcurrencyLimit is the maximum number of concurrent executions of a concurrency group.
+	MaxConcurrencyLimit = 100
+)
+
+// concurrencyGroupVars are the variables available in concurrency group key expressions.
+var concurrencyGroupVars = []string{"pipeline", "ref", "branch", "source", "target", "event"}
+
+// ValidateConcurrencyGroup verifies that the concurrency group key expression
+// only references known variables.
+func ValidateConcurrencyGroup(expr string) error {
+	if len(expr) > MaxConcurrencyGroupLength {
+		return fmt.Errorf("concurrency group can have at most %d characters", MaxConcurrencyGroupLength)
+	}
+
+	var unknown []string
+	os.Expand(expr, func(name string) string {
+		if !slices.Contains(concurrencyGroupVars, name) {
+			unknown = append(unknown, name)
+		}
+		return ""
+	})
+	if len(unknown) > 0 {
+		return fmt.Errorf("concurrency group references unknown variables %q, available variables are %q",
+			unknown, concurrencyGroupVars)
+	}
+
+	return nil
+}
+
+// ExpandConcurrencyGroup returns the concurrency group of the execution
+// by expanding the concurrency group key expression of the pipeline.
+func ExpandConcurrencyGroup(pipeline *types.Pipeline, execution *types.Execution) string {
+	if pipeline.ConcurrencyGroup == "" {
+		return ""
+	}
+
+	// for pull requests the branch is the source branch, otherwise the branch that got updated.
+	branch := execution.Target
+	if execution.Event == enum.TriggerEventPullRequest {
+		branch = execution.Source
+	}
+
+	vars := map[string]string{
+		"pipeline": pipeline.Identifier,
+		"ref":      execution.Ref,
+		"branch":   branch,
+		"source":   execution.Source,
+		"target":   execution.Target,
+		"event":    execution.Event,
+	}
+
+	group := os.Expand(pipeline.ConcurrencyGroup, func(name string) string {
+		return vars[name]
+	})
+
+	return strings.TrimSpace(group)
+}
+
+// cancelSuperseded cancels the older pending and running executions of the execution's
+// concurrency group that were triggered for the same ref (i.e. the same pull request or branch).
+func (t *triggerer) cancelSuperseded(ctx context.Context, repo *types.Repository, execution *types.Execution) {
+	if execution.ConcurrencyGroup == "" {
+		return
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("execution.id", execution.ID).
+		Str("execution.concurrency_group", execution.ConcurrencyGroup).
+		Logger()
+
+	executions, err := t.executionStore.ListIncompleteInConcurrencyGroup(ctx, repo.ID, execution.ConcurrencyGroup)
+	if err != nil {
+		log.Error().Err(err).Msg("trigger: failed to list executions of concurrency group")
+		return
+	}
+
+	for _, superseded := range executions {
+		if superseded.ID >= execution.ID || superseded.Ref != execution.Ref {
+			continue
+		}
+
+		err = t.canceler.Cancel(ctx, repo, superseded)
+		if err != nil {
+			log.Error().Err(err).Int64("superseded.id", superseded.ID).
+				Msg("trigger: failed to cancel superseded execution")
+			continue
+		}
+
+		log.Info().Int64("superseded.id", superseded.ID).Msg("trigger: cancelled superseded execution")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestExpandConcurrencyGroup(t *testing.T) {
+	pipeline := &types.Pipeline{Identifier: "deploy", ConcurrencyGroup: "${pipeline}-${branch}"}
+
+	pullReq := &types.Execution{
+		Event:  enum.TriggerEventPullRequest,
+		Ref:    "refs/pullreq/1/head",
+		Source: "feature",
+		Target: "main",
+	}
+	if got := ExpandConcurrencyGroup(pipeline, pullReq); got != "deploy-feature" {
+		t.Errorf("want deploy-feature, got %s", got)
+	}
+
+	push := &types.Execution{
+		Event:  enum.TriggerEventPush,
+		Ref:    "refs/heads/main",
+		Source: "main",
+		Target: "main",
+	}
+	if got := ExpandConcurrencyGroup(pipeline, push); got != "deploy-main" {
+		t.Errorf("want deploy-main, got %s", got)
+	}
+
+	if got := ExpandConcurrencyGroup(&types.Pipeline{}, push); got != "" {
+		t.Errorf("want empty group, got %s", got)
+	}
+}
+
+func TestValidateConcurrencyGroup(t *testing.T) {
+	if err := ValidateConcurrencyGroup("${pipeline}-$ref-${event}"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ValidateConcurrencyGroup("${commit}"); err == nil {
+		t.Errorf("expected error for unknown variable")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+)
+
+// findDeployEnvironment returns the environment the hook deploys to, or nil if it isn't a deployment.
+// Deployments are restricted by the protection of the environment, independent of what triggered them.
+// As environments of a repo or space can't weaken the protection of an environment with the same identifier
+// of an ancestor space, the protection of every matching environment has to allow the deployment.
+func (t *triggerer) findDeployEnvironment(
+	ctx context.Context,
+	repo *types.Repository,
+	base *Hook,
+) (*types.Environment, error) {
+	if base.Deploy == "" {
+		return nil, nil //nolint:nilnil // not deploying is a valid state.
+	}
+
+	envs, err := t.findEnvironments(ctx, repo, base.Deploy)
+	if err != nil {
+		return nil, err
+	}
+
+	// the deployed code is the one of the source branch (e.g. of a pull request).
+	for _, env := range envs {
+		if err = checkDeployProtection(env, base.TriggeredBy, base.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	// the deployment is recorded for the closest environment.
+	return envs[0], nil
+}
+
+// findEnvironments finds all environments with the identifier the repo can deploy to, ordered from the
+// environment of the repo over the environment of its space to the environments of the ancestor spaces.
+func (t *triggerer) findEnvironments(
+	ctx context.Context,
+	repo *types.Repository,
+	identifier string,
+) ([]*types.Environment, error) {
+	var envs []*types.Environment
+
+	env, err := t.environmentStore.FindByIdentifier(ctx, enum.EnvironmentParentRepo, repo.ID, identifier)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find environment of repo: %w", err)
+	}
+	if err == nil {
+		envs = append(envs, env)
+	}
+
+	for spaceID := repo.ParentID; spaceID != 0; {
+		env, err = t.environmentStore.FindByIdentifier(ctx, enum.EnvironmentParentSpace, spaceID, identifier)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find environment of space: %w", err)
+		}
+		if err == nil {
+			envs = append(envs, env)
+		}
+
+		space, err := t.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+		spaceID = space.ParentID
+	}
+
+	if len(envs) == 0 {
+		return nil, usererror.NotFoundf("Environment '%s' not found.", identifier)
+	}
+
+	return envs, nil
+}
+
+// checkDeployProtection ensures the protection of the environment allows the principal to deploy the branch.
+func checkDeployProtection(env *types.Environment, principalID int64, branch string) error {
+	protection := env.Protection
+
+	if len(protection.PrincipalIDs) > 0 && !slices.Contains(protection.PrincipalIDs, principalID) {
+		return usererror.Forbidden(
+			fmt.Sprintf("You are not allowed to deploy to environment '%s'.", env.Identifier))
+	}
+
+	if len(protection.Branches) == 0 {
+		return nil
+	}
+	for _, pattern := range protection.Branches {
+		if ok, _ := doublestar.Match(pattern, branch); ok {
+			return nil
+		}
+	}
+
+	return usererror.Forbidden(
+		fmt.Sprintf("Branch '%s' can't be deployed to environment '%s'.", branch, env.Identifier))
+}
+
+// createDeployment records the execution as deployment to the environment.
+func (t *triggerer) createDeployment(
+	ctx context.Context,
+	env *types.Environment,
+	execution *types.Execution,
+) {
+	err := t.deploymentStore.Create(ctx, &types.Deployment{
+		EnvironmentID: env.ID,
+		RepoID:        execution.RepoID,
+		PipelineID:    execution.PipelineID,
+		ExecutionID:   execution.ID,
+		Ref:           execution.Ref,
+		SHA:           execution.After,
+		CreatedBy:     execution.CreatedBy,
+		Created:       time.Now().UnixMilli(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Msgf("failed to record execution %d as deployment to environment %q", execution.ID, env.Identifier)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeEnvironmentStore struct {
+	store.EnvironmentStore
+	envs []*types.Environment
+}
+
+func (s *fakeEnvironmentStore) FindByIdentifier(
+	_ context.Context,
+	parentType enum.EnvironmentParent,
+	parentID int64,
+	identifier string,
+) (*types.Environment, error) {
+	for _, env := range s.envs {
+		if env.ParentType == parentType && env.ParentID == parentID && env.Identifier == identifier {
+			return env, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s *fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+func TestFindDeployEnvironment(t *testing.T) {
+	repo := &types.Repository{ID: 1, ParentID: 2}
+	spaceStore := &fakeSpaceStore{spaces: map[int64]*types.Space{
+		2: {ID: 2, ParentID: 4},
+		4: {ID: 4},
+	}}
+	// the space environment can't be weakened by an environment of the repo with the same identifier.
+	prodSpace := &types.Environment{
+		ID:         5,
+		ParentType: enum.EnvironmentParentSpace,
+		ParentID:   4,
+		Identifier: "prod",
+		Protection: types.EnvironmentProtection{
+			Branches: []string{"main", "release"},
+		},
+	}
+	prodRepo := &types.Environment{
+		ID:         3,
+		ParentType: enum.EnvironmentParentRepo,
+		ParentID:   repo.ID,
+		Identifier: "prod",
+		Protection: types.EnvironmentProtection{
+			Branches:     []string{"*"},
+			PrincipalIDs: []int64{10},
+		},
+	}
+	staging := &types.Environment{
+		ID:         3,
+		ParentType: enum.EnvironmentParentRepo,
+		ParentID:   repo.ID,
+		Identifier: "staging",
+		Protection: types.EnvironmentProtection{
+			Branches:     []string{"main"},
+			PrincipalIDs: []int64{10},
+		},
+	}
+	tr := &triggerer{
+		environmentStore: &fakeEnvironmentStore{envs: []*types.Environment{prodSpace, prodRepo, staging}},
+		spaceStore:       spaceStore,
+	}
+
+	tests := []struct {
+		name       string
+		hook       *Hook
+		wantEnv    *types.Environment
+		wantStatus int
+	}{
+		{
+			name: "no deployment",
+			hook: &Hook{TriggeredBy: 11, Source: "feature"},
+		},
+		{
+			name:    "allowed",
+			hook:    &Hook{Deploy: "staging", TriggeredBy: 10, Source: "main"},
+			wantEnv: staging,
+		},
+		{
+			name:    "allowed by all matching environments",
+			hook:    &Hook{Deploy: "prod", TriggeredBy: 10, Source: "release"},
+			wantEnv: prodRepo,
+		},
+		{
+			name:       "branch not allowed by space environment",
+			hook:       &Hook{Deploy: "prod", TriggeredBy: 10, Source: "feature"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unknown environment",
+			hook:       &Hook{Deploy: "qa", TriggeredBy: 10, Source: "main"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "push by principal without permission",
+			hook:       &Hook{Deploy: "staging", Action: enum.TriggerActionBranchUpdated, TriggeredBy: 11, Source: "main"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "pull request of other branch",
+			hook: &Hook{Deploy: "staging", Action: enum.TriggerActionPullReqCreated, TriggeredBy: 10,
+				Source: "feature"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env, err := tr.findDeployEnvironment(context.Background(), repo, test.hook)
+			if test.wantStatus != 0 {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+					t.Fatalf("expected error with status %d, got %v", test.wantStatus, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if env != test.wantEnv {
+				t.Fatalf("expected environment %v, got %v", test.wantEnv, env)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/triggerer/dag"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-yaml/yaml"
+	"github.com/drone/drone-yaml/yaml/linter"
+)
+
+const (
+	// FormatDrone is the format of drone yaml, optionally written in jsonnet or starlark.
+	FormatDrone = "drone"
+	// FormatV1 is the format of v1 yaml.
+	FormatV1 = "v1"
+)
+
+var (
+	// yamlLineRegexp matches the line reported in yaml parsing errors, e.g. "yaml: line 3: ...".
+	yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+	// scriptPositionRegexp matches the position reported in jsonnet and starlark errors, e.g. ".drone.star:3:5".
+	scriptPositionRegexp = regexp.MustCompile(`:(\d+):(\d+)`)
+)
+
+// DryRun converts, resolves and parses the pipeline yaml for the hook exactly like Trigger does,
+// but instead of creating an execution it reports the errors in the yaml and the expanded stages.
+// If file is nil, the yaml is read from the pipeline's config path at the commit of the hook.
+func (t *triggerer) DryRun(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	file *file.File,
+) (*types.PipelineValidation, error) {
+	event := base.event()
+
+	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if file == nil {
+		file, err = t.fileService.Get(ctx, repo, pipeline.ConfigPath, base.After)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find yaml: %w", err)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	execution := newExecution(repo, pipeline, base, event, now)
+
+	result := &types.PipelineValidation{
+		Event: event,
+	}
+
+	if isV1Yaml(file.Data) {
+		result.Format = FormatV1
+
+		stages, err := parseV1Stages(ctx, file.Data, repo, execution, t.templateStore, t.pluginStore)
+		if err != nil {
+			result.Errors = append(result.Errors, newValidationError(err, 0))
+			return result, nil
+		}
+
+		for _, stage := range stages {
+			result.Stages = append(result.Stages, &types.PipelineValidationStage{
+				Name:      stage.Name,
+				DependsOn: stage.DependsOn,
+				Status:    stage.Status,
+				Matched:   true,
+			})
+		}
+	} else {
+		result.Format = FormatDrone
+		t.dryRunDrone(ctx, repo, pipeline, execution, base, file, result)
+	}
+
+	result.Valid = len(result.Errors) == 0
+
+	return result, nil
+}
+
+// dryRunDrone converts and parses the drone yaml and adds the errors and the stage DAG to the result.
+//
+//nolint:gocognit // refactor if needed.
+func (t *triggerer) dryRunDrone(
+	ctx context.Context,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+	base *Hook,
+	file *file.File,
+	result *types.PipelineValidation,
+) {
+	converted, err := t.converterService.Convert(ctx, &converter.ConvertArgs{
+		Repo:      repo,
+		Pipeline:  pipeline,
+		Execution: execution,
+		File:      file,
+	})
+	if err != nil {
+		result.Errors = append(result.Errors, newValidationError(err, 0))
+		return
+	}
+	if !bytes.Equal(converted.Data, file.Data) {
+		result.Expanded = string(converted.Data)
+	}
+
+	// the documents are parsed one by one first, to report the line numbers relative to the whole file.
+	for _, doc := range splitDocuments(converted.Data) {
+		if _, err = yaml.ParseBytes(doc.data); err != nil {
+			result.Errors = append(result.Errors, newValidationError(err, doc.offset))
+		}
+	}
+	if len(result.Errors) > 0 {
+		return
+	}
+
+	manifest, err := yaml.ParseBytes(converted.Data)
+	if err != nil {
+		result.Errors = append(result.Errors, newValidationError(err, 0))
+		return
+	}
+
+	if err = linter.Manifest(manifest, true); err != nil {
+		result.Errors = append(result.Errors, newValidationError(err, 0))
+		return
+	}
+
+	approvalConfigs, err := parseApprovals(converted.Data)
+	if err != nil {
+		result.Errors = append(result.Errors, newValidationError(err, 0))
+		return
+	}
+
+	event := execution.Event
+	graph := dag.New()
+	var matched []*yaml.Pipeline
+	for _, document := range manifest.Resources {
+		pipeline, ok := document.(*yaml.Pipeline)
+		if !ok {
+			continue
+		}
+		name := pipeline.Name
+		if name == "" {
+			name = "default"
+		}
+		node := graph.Add(name, pipeline.DependsOn...)
+		node.Skip = true
+
+		stage := &types.PipelineValidationStage{
+			Name:      name,
+			Kind:      pipeline.Kind,
+			Type:      pipeline.Type,
+			DependsOn: pipeline.DependsOn,
+			Events:    pipeline.Trigger.Event.Include,
+			Branches:  pipeline.Trigger.Branch.Include,
+		}
+		result.Stages = append(result.Stages, stage)
+
+		if reason := skipReason(pipeline, base, event, repo.Path); reason != "" {
+			stage.SkipReason = reason
+			continue
+		}
+
+		stage.Matched = true
+		matched = append(matched, pipeline)
+		node.Skip = false
+	}
+
+	if graph.DetectCycles() {
+		result.Errors = append(result.Errors, types.PipelineValidationError{
+			Message: "Dependency cycle detected in Pipeline",
+		})
+		return
+	}
+
+	now := execution.Created
+	stages := make(map[string]*types.Stage, len(matched))
+	stageList := make([]*types.Stage, 0, len(matched))
+	for i, match := range matched {
+		stage := newDroneStage(repo.ID, int64(i+1), match, now)
+		if stage.Type == types.StageTypeApproval {
+			_, err = t.createApproval(ctx, stage.Name, approvalConfigs[stage.Name], now)
+			if err != nil {
+				result.Errors = append(result.Errors, newValidationError(err, 0))
+			}
+		}
+		stages[stage.Name] = stage
+		stageList = append(stageList, stage)
+	}
+
+	resolveDependencies(graph, stageList, now)
+
+	for _, info := range result.Stages {
+		stage, ok := stages[info.Name]
+		if !ok {
+			continue
+		}
+		info.Kind = stage.Kind
+		info.Type = stage.Type
+		info.DependsOn = stage.DependsOn
+		info.Status = stage.Status
+	}
+}
+
+// document is a document of a multi-document yaml along with the number of lines preceding it.
+type document struct {
+	offset int
+	data   []byte
+}
+
+// splitDocuments splits the yaml into its documents the same way the drone yaml parser does.
+func splitDocuments(data []byte) []document {
+	docs := []document{{}}
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "---") {
+			docs = append(docs, document{offset: i + 1})
+			continue
+		}
+		if strings.HasPrefix(line, "...") {
+			break
+		}
+		last := &docs[len(docs)-1]
+		last.data = append(last.data, line...)
+		last.data = append(last.data, '\n')
+	}
+	return docs
+}
+
+// newValidationError converts the error into a validation error, extracting its position if it's known.
+// The offset is added to yaml error lines of documents that don't start at the beginning of the file.
+func newValidationError(err error, offset int) types.PipelineValidationError {
+	validationErr := types.PipelineValidationError{
+		Message: err.Error(),
+	}
+
+	if m := yamlLineRegexp.FindStringSubmatch(validationErr.Message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		validationErr.Line = line + offset
+	} else if m := scriptPositionRegexp.FindStringSubmatch(validationErr.Message); m != nil {
+		validationErr.Line, _ = strconv.Atoi(m[1])
+		validationErr.Column, _ = strconv.Atoi(m[2])
+	}
+
+	return validationErr
+}
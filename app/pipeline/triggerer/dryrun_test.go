@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type noopConverter struct{}
+
+func (noopConverter) Convert(_ context.Context, args *converter.ConvertArgs) (*file.File, error) {
+	return args.File, nil
+}
+
+func TestDryRunDrone(t *testing.T) {
+	const data = `kind: pipeline
+type: docker
+name: build
+steps:
+- name: test
+  image: golang
+
+---
+kind: pipeline
+type: docker
+name: publish
+depends_on: [ build ]
+trigger:
+  event: [ push ]
+steps:
+- name: publish
+  image: plugins/docker
+
+---
+kind: pipeline
+type: docker
+name: notify
+depends_on: [ publish ]
+steps:
+- name: notify
+  image: plugins/slack
+`
+
+	tr := &triggerer{converterService: noopConverter{}}
+	repo := &types.Repository{ID: 1, Path: "space/repo"}
+	pipeline := &types.Pipeline{ConfigPath: ".drone.yml"}
+	base := &Hook{Ref: "refs/heads/main", Source: "main", Target: "main"}
+	execution := newExecution(repo, pipeline, base, base.event(), 0)
+
+	result := &types.PipelineValidation{}
+	tr.dryRunDrone(context.Background(), repo, pipeline, execution, base, &file.File{Data: []byte(data)}, result)
+
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if len(result.Stages) != 3 {
+		t.Fatalf("want 3 stages, got %d", len(result.Stages))
+	}
+
+	build, publish, notify := result.Stages[0], result.Stages[1], result.Stages[2]
+	if !build.Matched || build.Status != enum.CIStatusPending {
+		t.Errorf("want build to be matched and pending, got %t %s", build.Matched, build.Status)
+	}
+	if publish.Matched || publish.SkipReason != "does not match event" {
+		t.Errorf("want publish to be skipped due to event, got %t %q", publish.Matched, publish.SkipReason)
+	}
+	if !reflect.DeepEqual(publish.Events, []string{"push"}) {
+		t.Errorf("want publish events [push], got %v", publish.Events)
+	}
+	// the dependency of notify on the skipped publish stage is resolved to the dependencies of publish.
+	if !notify.Matched || !reflect.DeepEqual(notify.DependsOn, []string{"build"}) ||
+		notify.Status != enum.CIStatusWaitingOnDeps {
+		t.Errorf("want notify to be matched and to depend on build, got %t %v %s",
+			notify.Matched, notify.DependsOn, notify.Status)
+	}
+}
+
+func TestDryRunDroneErrorLine(t *testing.T) {
+	const data = `kind: pipeline
+name: build
+steps:
+- name: test
+  image: golang
+---
+kind: pipeline
+name: publish
+steps:
+  - name: publish
+   image: plugins/docker
+`
+
+	tr := &triggerer{converterService: noopConverter{}}
+	repo := &types.Repository{ID: 1}
+	pipeline := &types.Pipeline{ConfigPath: ".drone.yml"}
+	base := &Hook{}
+	execution := newExecution(repo, pipeline, base, base.event(), 0)
+
+	result := &types.PipelineValidation{}
+	tr.dryRunDrone(context.Background(), repo, pipeline, execution, base, &file.File{Data: []byte(data)}, result)
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("want 1 error, got %v", result.Errors)
+	}
+	// the error is reported at line 4 of the second document, which starts after line 6.
+	if result.Errors[0].Line != 10 {
+		t.Errorf("want error at line 10, got %d: %s", result.Errors[0].Line, result.Errors[0].Message)
+	}
+}
+
+func TestNewValidationError(t *testing.T) {
+	tests := []struct {
+		err    error
+		offset int
+		line   int
+		column int
+	}{
+		{err: errors.New("yaml: line 3: mapping values are not allowed in this context"), offset: 5, line: 8},
+		{err: errors.New(".drone.star:4:7: undefined: foo"), line: 4, column: 7},
+		{err: errors.New("linter: duplicate pipeline names")},
+	}
+
+	for _, test := range tests {
+		got := newValidationError(test.err, test.offset)
+		if got.Line != test.line || got.Column != test.column {
+			t.Errorf("%q: want %d:%d, got %d:%d", test.err, test.line, test.column, got.Line, got.Column)
+		}
+	}
+}
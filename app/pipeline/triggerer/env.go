@@ -37,6 +37,8 @@ func Envs(
 	urlProvider url.Provider,
 ) map[string]string {
 	return map[string]string{
-		"DRONE_BUILD_LINK": urlProvider.GenerateUIBuildURL(repo.Path, pipeline.Identifier, pipeline.Seq),
+		"DRONE_BUILD_LINK":     urlProvider.GenerateUIBuildURL(repo.Path, pipeline.Identifier, pipeline.Seq),
+		"GITNESS_CACHE_URL":    urlProvider.GenerateContainerCacheURL(),
+		"GITNESS_ARTIFACT_URL": urlProvider.GenerateContainerArtifactURL(),
 	}
 }
@@ -21,6 +21,9 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/limiter"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -70,6 +73,16 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+	Deploy       string             `json:"deploy_to"`
+	DeployID     int64              `json:"deploy_id"` // set by the triggerer, based on Deploy
+}
+
+// event returns the event of the hook. Executions deploying to an environment are promote events.
+func (h *Hook) event() string {
+	if h.Deploy != "" {
+		return enum.TriggerEventPromote
+	}
+	return string(h.Action.GetTriggerEvent())
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -77,6 +90,9 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// DryRun validates the pipeline yaml for the hook and returns its expanded stages without executing it.
+	DryRun(ctx context.Context, pipeline *types.Pipeline, hook *Hook, file *file.File) (*types.PipelineValidation, error)
 }
 
 type triggerer struct {
@@ -92,6 +108,14 @@ type triggerer struct {
 	repoStore        store.RepoStore
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
+	limiter          limiter.ResourceLimiter
+	approvalStore    store.StageApprovalStore
+	principalStore   store.PrincipalStore
+	reporter         *pipelineevents.Reporter
+	canceler         canceler.Canceler
+	spaceStore       store.SpaceStore
+	environmentStore store.EnvironmentStore
+	deploymentStore  store.DeploymentStore
 }
 
 func New(
@@ -107,6 +131,14 @@ func New(
 	converterService converter.Service,
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
+	limiter limiter.ResourceLimiter,
+	approvalStore store.StageApprovalStore,
+	principalStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter,
+	canceler canceler.Canceler,
+	spaceStore store.SpaceStore,
+	environmentStore store.EnvironmentStore,
+	deploymentStore store.DeploymentStore,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -121,6 +153,14 @@ func New(
 		repoStore:        repoStore,
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
+		limiter:          limiter,
+		approvalStore:    approvalStore,
+		principalStore:   principalStore,
+		reporter:         reporter,
+		canceler:         canceler,
+		spaceStore:       spaceStore,
+		environmentStore: environmentStore,
+		deploymentStore:  deploymentStore,
 	}
 }
 
@@ -146,7 +186,7 @@ func (t *triggerer) Trigger(
 		}
 	}()
 
-	event := string(base.Action.GetTriggerEvent())
+	event := base.event()
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -154,6 +194,28 @@ func (t *triggerer) Trigger(
 		return nil, err
 	}
 
+	env, err := t.findDeployEnvironment(ctx, repo, base)
+	if err != nil {
+		log.Info().Err(err).Msg("trigger: deployment rejected")
+		return nil, err
+	}
+	if env != nil {
+		// don't modify the hook of the caller, it might be used for triggering other pipelines.
+		hook := *base
+		hook.DeployID = env.ID
+		base = &hook
+	}
+
+	if err := t.limiter.PipelineMinutes(ctx, repo.ID); err != nil {
+		log.Info().Err(err).Msg("trigger: pipeline minutes limit reached")
+		return nil, fmt.Errorf("resource limit exceeded: %w", err)
+	}
+
+	if err := t.limiter.ConcurrentExecutions(ctx, repo.ID); err != nil {
+		log.Info().Err(err).Msg("trigger: concurrent executions limit reached")
+		return nil, fmt.Errorf("resource limit exceeded: %w", err)
+	}
+
 	file, err := t.fileService.Get(ctx, repo, pipeline.ConfigPath, base.After)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not find yaml")
@@ -161,41 +223,14 @@ func (t *triggerer) Trigger(
 	}
 
 	now := time.Now().UnixMilli()
-	execution := &types.Execution{
-		RepoID:     repo.ID,
-		PipelineID: pipeline.ID,
-		Trigger:    base.Trigger,
-		CreatedBy:  base.TriggeredBy,
-		Parent:     base.Parent,
-		Status:     enum.CIStatusPending,
-		Event:      event,
-		Action:     string(base.Action),
-		Link:       base.Link,
-		// Timestamp:    base.Timestamp,
-		Title:        trunc(base.Title, 2000),
-		Message:      trunc(base.Message, 2000),
-		Before:       base.Before,
-		After:        base.After,
-		Ref:          base.Ref,
-		Fork:         base.Fork,
-		Source:       base.Source,
-		Target:       base.Target,
-		Author:       base.AuthorLogin,
-		AuthorName:   base.AuthorName,
-		AuthorEmail:  base.AuthorEmail,
-		AuthorAvatar: base.AuthorAvatar,
-		Params:       base.Params,
-		Debug:        base.Debug,
-		Sender:       base.Sender,
-		Cron:         base.Cron,
-		Created:      now,
-		Updated:      now,
-	}
+	execution := newExecution(repo, pipeline, base, event, now)
+	execution.ConcurrencyGroup = ExpandConcurrencyGroup(pipeline, execution)
 
 	// For drone, follow the existing path of calculating dependencies, creating a DAG,
 	// and creating stages accordingly. For V1 YAML - for now we can just parse the stages
 	// and create them sequentially.
 	stages := []*types.Stage{}
+	approvals := map[string]*types.StageApproval{}
 	//nolint:nestif // refactor if needed
 	if !isV1Yaml(file.Data) {
 		// Convert from jsonnet/starlark to drone yaml
@@ -223,6 +258,12 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		approvalConfigs, err := parseApprovals(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse approval stages")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
 		var matched []*yaml.Pipeline
 		var dag = dag.New()
 		for _, document := range manifest.Resources {
@@ -232,7 +273,6 @@ func (t *triggerer) Trigger(
 			}
 			// TODO add repo
 			// TODO add instance
-			// TODO add target
 			// TODO add ref
 			name := pipeline.Name
 			if name == "" {
@@ -241,23 +281,13 @@ func (t *triggerer) Trigger(
 			node := dag.Add(name, pipeline.DependsOn...)
 			node.Skip = true
 
-			switch {
-			case skipBranch(pipeline, base.Target):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match branch")
-			case skipEvent(pipeline, event):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match event")
-			case skipAction(pipeline, string(base.Action)):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match action")
-			case skipRef(pipeline, base.Ref):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match ref")
-			case skipRepo(pipeline, repo.Path):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match repo")
-			case skipCron(pipeline, base.Cron):
-				log.Info().Str("pipeline", name).Msg("trigger: skipping pipeline, does not match cron job")
-			default:
-				matched = append(matched, pipeline)
-				node.Skip = false
+			if reason := skipReason(pipeline, base, event, repo.Path); reason != "" {
+				log.Info().Str("pipeline", name).Msgf("trigger: skipping pipeline, %s", reason)
+				continue
 			}
+
+			matched = append(matched, pipeline)
+			node.Skip = false
 		}
 
 		if dag.DetectCycles() {
@@ -271,64 +301,19 @@ func (t *triggerer) Trigger(
 		}
 
 		for i, match := range matched {
-			onSuccess := match.Trigger.Status.Match(string(enum.CIStatusSuccess))
-			onFailure := match.Trigger.Status.Match(string(enum.CIStatusFailure))
-			if len(match.Trigger.Status.Include)+len(match.Trigger.Status.Exclude) == 0 {
-				onFailure = false
-			}
-
-			stage := &types.Stage{
-				RepoID:    repo.ID,
-				Number:    int64(i + 1),
-				Name:      match.Name,
-				Kind:      match.Kind,
-				Type:      match.Type,
-				OS:        match.Platform.OS,
-				Arch:      match.Platform.Arch,
-				Variant:   match.Platform.Variant,
-				Kernel:    match.Platform.Version,
-				Limit:     match.Concurrency.Limit,
-				Status:    enum.CIStatusWaitingOnDeps,
-				DependsOn: match.DependsOn,
-				OnSuccess: onSuccess,
-				OnFailure: onFailure,
-				Labels:    match.Node,
-				Created:   now,
-				Updated:   now,
-			}
-			if stage.Kind == "pipeline" && stage.Type == "" {
-				stage.Type = "docker"
-			}
-			if stage.OS == "" {
-				stage.OS = "linux"
-			}
-			if stage.Arch == "" {
-				stage.Arch = "amd64"
-			}
-
-			if stage.Name == "" {
-				stage.Name = "default"
-			}
-			if len(stage.DependsOn) == 0 {
-				stage.Status = enum.CIStatusPending
+			stage := newDroneStage(repo.ID, int64(i+1), match, now)
+			if stage.Type == types.StageTypeApproval {
+				approval, err := t.createApproval(ctx, stage.Name, approvalConfigs[stage.Name], now)
+				if err != nil {
+					log.Warn().Err(err).Msg("trigger: invalid approval stage")
+					return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+				}
+				approvals[stage.Name] = approval
 			}
 			stages = append(stages, stage)
 		}
 
-		for _, stage := range stages {
-			// here we re-work the dependencies for the stage to
-			// account for the fact that some steps may be skipped
-			// and may otherwise break the dependency chain.
-			stage.DependsOn = dag.Dependencies(stage.Name)
-
-			// if the stage is pending dependencies, but those
-			// dependencies are skipped, the stage can be executed
-			// immediately.
-			if stage.Status == enum.CIStatusWaitingOnDeps &&
-				len(stage.DependsOn) == 0 {
-				stage.Status = enum.CIStatusPending
-			}
-		}
+		resolveDependencies(dag, stages, now)
 	} else {
 		stages, err = parseV1Stages(
 			ctx, file.Data, repo, execution, t.templateStore, t.pluginStore)
@@ -348,7 +333,12 @@ func (t *triggerer) Trigger(
 	execution.Number = pipeline.Seq
 	execution.Params = combine(execution.Params, Envs(repo, pipeline, t.urlProvider))
 
-	err = t.createExecutionWithStages(ctx, execution, stages)
+	for _, stage := range stages {
+		stage.ConcurrencyGroup = execution.ConcurrencyGroup
+		stage.ConcurrencyLimit = pipeline.ConcurrencyLimit
+	}
+
+	err = t.createExecutionWithStages(ctx, execution, stages, approvals)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: cannot create execution")
 		return nil, err
@@ -360,7 +350,22 @@ func (t *triggerer) Trigger(
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
 
+	if env != nil {
+		t.createDeployment(ctx, env, execution)
+	}
+
+	// newer executions supersede the older ones of the same concurrency group.
+	t.cancelSuperseded(ctx, repo, execution)
+
 	for _, stage := range stages {
+		if stage.Status == enum.CIStatusBlocked {
+			t.reporter.ApprovalRequested(ctx, &pipelineevents.ApprovalRequestedPayload{
+				RepoID:      repo.ID,
+				ExecutionID: execution.ID,
+				StageID:     stage.ID,
+			})
+			continue
+		}
 		if stage.Status != enum.CIStatusPending {
 			continue
 		}
@@ -374,6 +379,143 @@ func (t *triggerer) Trigger(
 	return execution, nil
 }
 
+// newExecution returns a new execution of the pipeline for the hook.
+func newExecution(
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	base *Hook,
+	event string,
+	now int64,
+) *types.Execution {
+	return &types.Execution{
+		RepoID:     repo.ID,
+		PipelineID: pipeline.ID,
+		Trigger:    base.Trigger,
+		CreatedBy:  base.TriggeredBy,
+		Parent:     base.Parent,
+		Status:     enum.CIStatusPending,
+		Event:      event,
+		Action:     string(base.Action),
+		Link:       base.Link,
+		// Timestamp:    base.Timestamp,
+		Title:        trunc(base.Title, 2000),
+		Message:      trunc(base.Message, 2000),
+		Before:       base.Before,
+		After:        base.After,
+		Ref:          base.Ref,
+		Fork:         base.Fork,
+		Source:       base.Source,
+		Target:       base.Target,
+		Author:       base.AuthorLogin,
+		AuthorName:   base.AuthorName,
+		AuthorEmail:  base.AuthorEmail,
+		AuthorAvatar: base.AuthorAvatar,
+		Params:       base.Params,
+		Debug:        base.Debug,
+		Sender:       base.Sender,
+		Cron:         base.Cron,
+		Deploy:       base.Deploy,
+		DeployID:     base.DeployID,
+		Created:      now,
+		Updated:      now,
+	}
+}
+
+// skipReason returns the reason why the pipeline of the drone yaml doesn't match the hook,
+// or an empty string if the pipeline matches.
+func skipReason(pipeline *yaml.Pipeline, base *Hook, event string, repoPath string) string {
+	switch {
+	case skipBranch(pipeline, base.Target):
+		return "does not match branch"
+	case skipEvent(pipeline, event):
+		return "does not match event"
+	case skipAction(pipeline, string(base.Action)):
+		return "does not match action"
+	case skipRef(pipeline, base.Ref):
+		return "does not match ref"
+	case skipRepo(pipeline, repoPath):
+		return "does not match repo"
+	case skipCron(pipeline, base.Cron):
+		return "does not match cron job"
+	case skipTarget(pipeline, base.Deploy):
+		return "does not match deploy target"
+	default:
+		return ""
+	}
+}
+
+// newDroneStage creates the stage of a matched pipeline of the drone yaml.
+func newDroneStage(repoID int64, number int64, match *yaml.Pipeline, now int64) *types.Stage {
+	onSuccess := match.Trigger.Status.Match(string(enum.CIStatusSuccess))
+	onFailure := match.Trigger.Status.Match(string(enum.CIStatusFailure))
+	if len(match.Trigger.Status.Include)+len(match.Trigger.Status.Exclude) == 0 {
+		onFailure = false
+	}
+
+	stage := &types.Stage{
+		RepoID:    repoID,
+		Number:    number,
+		Name:      match.Name,
+		Kind:      match.Kind,
+		Type:      match.Type,
+		OS:        match.Platform.OS,
+		Arch:      match.Platform.Arch,
+		Variant:   match.Platform.Variant,
+		Kernel:    match.Platform.Version,
+		Limit:     match.Concurrency.Limit,
+		Status:    enum.CIStatusWaitingOnDeps,
+		DependsOn: match.DependsOn,
+		OnSuccess: onSuccess,
+		OnFailure: onFailure,
+		Labels:    match.Node,
+		Created:   now,
+		Updated:   now,
+	}
+	if stage.Kind == "pipeline" && stage.Type == "" {
+		stage.Type = "docker"
+	}
+	if stage.OS == "" {
+		stage.OS = "linux"
+	}
+	if stage.Arch == "" {
+		stage.Arch = "amd64"
+	}
+
+	if stage.Name == "" {
+		stage.Name = "default"
+	}
+	if len(stage.DependsOn) == 0 {
+		stage.Status = enum.CIStatusPending
+	}
+
+	return stage
+}
+
+// resolveDependencies re-works the dependencies of the stages using the dag and sets the initial stage statuses.
+func resolveDependencies(graph *dag.Dag, stages []*types.Stage, now int64) {
+	for _, stage := range stages {
+		// here we re-work the dependencies for the stage to
+		// account for the fact that some steps may be skipped
+		// and may otherwise break the dependency chain.
+		stage.DependsOn = graph.Dependencies(stage.Name)
+
+		// if the stage is pending dependencies, but those
+		// dependencies are skipped, the stage can be executed
+		// immediately.
+		if stage.Status == enum.CIStatusWaitingOnDeps &&
+			len(stage.DependsOn) == 0 {
+			stage.Status = enum.CIStatusPending
+		}
+
+		// approval stages that can be executed immediately block the execution until they get approved.
+		if stage.Status == enum.CIStatusPending &&
+			stage.Type == types.StageTypeApproval {
+			stage.Status = enum.CIStatusBlocked
+			stage.Started = now
+		}
+	}
+}
+
 func trunc(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -490,11 +632,13 @@ func isV1Yaml(data []byte) bool {
 	return regexp.MustCompilePOSIX(`^spec:`).Match(data)
 }
 
-// createExecutionWithStages writes an execution along with its stages in a single transaction.
+// createExecutionWithStages writes an execution along with its stages
+// and the approval requirements of its approval stages in a single transaction.
 func (t *triggerer) createExecutionWithStages(
 	ctx context.Context,
 	execution *types.Execution,
 	stages []*types.Stage,
+	approvals map[string]*types.StageApproval,
 ) error {
 	return t.tx.WithTx(ctx, func(ctx context.Context) error {
 		err := t.executionStore.Create(ctx, execution)
@@ -508,6 +652,19 @@ func (t *triggerer) createExecutionWithStages(
 			if err != nil {
 				return err
 			}
+
+			approval, ok := approvals[stage.Name]
+			if !ok {
+				continue
+			}
+
+			approval.StageID = stage.ID
+			approval.ExecutionID = execution.ID
+			approval.RepoID = stage.RepoID
+			err = t.approvalStore.Create(ctx, approval)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -540,7 +697,7 @@ func (t *triggerer) createExecutionWithError(
 		Parent:       base.Parent,
 		Status:       enum.CIStatusError,
 		Error:        message,
-		Event:        string(base.Action.GetTriggerEvent()),
+		Event:        base.event(),
 		Action:       string(base.Action),
 		Link:         base.Link,
 		Title:        base.Title,
@@ -558,6 +715,8 @@ func (t *triggerer) createExecutionWithError(
 		AuthorAvatar: base.AuthorAvatar,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
+		Deploy:       base.Deploy,
+		DeployID:     base.DeployID,
 		Created:      now,
 		Updated:      now,
 		Started:      now,
@@ -15,6 +15,9 @@
 package triggerer
 
 import (
+	"github.com/harness/gitness/app/api/controller/limiter"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -44,8 +47,17 @@ func ProvideTriggerer(
 	urlProvider url.Provider,
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
+	limiter limiter.ResourceLimiter,
+	approvalStore store.StageApprovalStore,
+	principalStore store.PrincipalStore,
+	reporter *pipelineevents.Reporter,
+	canceler canceler.Canceler,
+	spaceStore store.SpaceStore,
+	environmentStore store.EnvironmentStore,
+	deploymentStore store.DeploymentStore,
 ) Triggerer {
 	return New(executionStore, checkStore, stageStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
-		templateStore, pluginStore)
+		templateStore, pluginStore, limiter, approvalStore, principalStore, reporter, canceler,
+		spaceStore, environmentStore, deploymentStore)
 }
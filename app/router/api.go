@@ -19,18 +19,23 @@ import (
 	"fmt"
 	"net/http"
 
+	controlleraudit "github.com/harness/gitness/app/api/controller/audit"
+	controllerbuildcache "github.com/harness/gitness/app/api/controller/buildcache"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	controllerenvironment "github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	controllernotification "github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -41,12 +46,16 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handleraudit "github.com/harness/gitness/app/api/handler/audit"
+	handlerbuildcache "github.com/harness/gitness/app/api/handler/buildcache"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
+	handlerenvironment "github.com/harness/gitness/app/api/handler/environment"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
+	handlernotification "github.com/harness/gitness/app/api/handler/notification"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -54,6 +63,7 @@ import (
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -70,11 +80,13 @@ import (
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/app/api/middleware/nocache"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	middlewareratelimit "github.com/harness/gitness/app/api/middleware/ratelimit"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -122,6 +134,12 @@ func NewAPIHandler(
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	auditCtrl *controlleraudit.Controller,
+	notificationCtrl *controllernotification.Controller,
+	runnerCtrl *controllerrunner.Controller,
+	environmentCtrl *controllerenvironment.Controller,
+	buildCacheCtrl *controllerbuildcache.Controller,
+	rateLimiter ratelimit.RateLimiter,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -145,11 +163,15 @@ func NewAPIHandler(
 
 	r.Use(audit.Middleware())
 
+	if config.RateLimit.Enabled {
+		r.Use(middlewareratelimit.Limit(rateLimiter, restRateLimitBudget(config), config.RateLimit.TrustedProxies))
+	}
+
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 			webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			searchCtrl, auditCtrl, notificationCtrl, runnerCtrl, environmentCtrl, buildCacheCtrl)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -195,18 +217,26 @@ func setupRoutesV1(r chi.Router,
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	auditCtrl *controlleraudit.Controller,
+	notificationCtrl *controllernotification.Controller,
+	runnerCtrl *controllerrunner.Controller,
+	environmentCtrl *controllerenvironment.Controller,
+	buildCacheCtrl *controllerbuildcache.Controller,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, webhookCtrl, environmentCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, environmentCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, appCtx, userCtrl, notificationCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, auditCtrl, webhookCtrl, runnerCtrl)
+	setupRunner(r, runnerCtrl)
+	setupBuildCache(r, buildCacheCtrl)
+	setupStepArtifacts(r, runnerCtrl)
 	setupAccount(r, userCtrl, sysCtrl, config)
 	setupSystem(r, config, sysCtrl)
 	setupResources(r)
@@ -215,7 +245,13 @@ func setupRoutesV1(r chi.Router,
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(
+	r chi.Router,
+	appCtx context.Context,
+	spaceCtrl *space.Controller,
+	webhookCtrl *webhook.Controller,
+	environmentCtrl *controllerenvironment.Controller,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -250,6 +286,25 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route("/roles", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleRoleList(spaceCtrl))
+				r.Post("/", handlerspace.HandleRoleCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamRoleIdentifier), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleRoleUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleRoleDelete(spaceCtrl))
+				})
+			})
+
+			r.Route("/quota", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleQuotaFind(spaceCtrl))
+				r.Put("/", handlerspace.HandleQuotaUpdate(spaceCtrl))
+				r.Delete("/", handlerspace.HandleQuotaDelete(spaceCtrl))
+			})
+
+			SetupWebhook(r, webhookCtrl, enum.WebhookParentSpace)
+
+			SetupEnvironments(r, environmentCtrl, enum.EnvironmentParentSpace)
 		})
 	})
 }
@@ -265,6 +320,7 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	environmentCtrl *controllerenvironment.Controller,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -359,7 +415,7 @@ func setupRepos(r chi.Router,
 
 			SetupPullReq(r, pullreqCtrl)
 
-			SetupWebhook(r, webhookCtrl)
+			SetupWebhook(r, webhookCtrl, enum.WebhookParentRepo)
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
@@ -368,6 +424,11 @@ func setupRepos(r chi.Router,
 			SetupUploads(r, uploadCtrl)
 
 			SetupRules(r, repoCtrl)
+
+			SetupMembers(r, repoCtrl)
+
+			SetupEnvironments(r, environmentCtrl, enum.EnvironmentParentRepo)
+			r.Get("/deployments", handlerenvironment.HandleListCurrentDeployments(environmentCtrl))
 		})
 	})
 }
@@ -391,6 +452,7 @@ func setupPipelines(
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerpipeline.HandleCreate(pipelineCtrl))
 		r.Get("/generate", handlerrepo.HandlePipelineGenerate(repoCtrl))
+		r.Post("/validate", handlerpipeline.HandleValidate(pipelineCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPipelineIdentifier), func(r chi.Router) {
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
@@ -461,6 +523,14 @@ func setupExecutions(
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Route("/approvals", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListApprovals(executionCtrl))
+				r.Post(fmt.Sprintf("/{%s}", request.PathParamStageNumber), handlerexecution.HandleApprove(executionCtrl))
+			})
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamArtifactID), handlerexecution.HandleDownloadArtifact(executionCtrl))
+			})
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
@@ -548,38 +618,62 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
+			r.Get("/tests", handlerpullreq.HandleTestSummary(pullreqCtrl))
 		})
 	})
 }
 
-func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
+func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller, parentType enum.WebhookParent) {
 	r.Route("/webhooks", func(r chi.Router) {
-		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl))
-		r.Get("/", handlerwebhook.HandleList(webhookCtrl))
+		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl, parentType))
+		r.Get("/", handlerwebhook.HandleList(webhookCtrl, parentType))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
-			r.Get("/", handlerwebhook.HandleFind(webhookCtrl))
-			r.Patch("/", handlerwebhook.HandleUpdate(webhookCtrl))
-			r.Delete("/", handlerwebhook.HandleDelete(webhookCtrl))
+			r.Get("/", handlerwebhook.HandleFind(webhookCtrl, parentType))
+			r.Patch("/", handlerwebhook.HandleUpdate(webhookCtrl, parentType))
+			r.Delete("/", handlerwebhook.HandleDelete(webhookCtrl, parentType))
+			r.Post("/test", handlerwebhook.HandleTest(webhookCtrl, parentType))
 
 			r.Route("/executions", func(r chi.Router) {
-				r.Get("/", handlerwebhook.HandleListExecutions(webhookCtrl))
+				r.Get("/", handlerwebhook.HandleListExecutions(webhookCtrl, parentType))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
-					r.Get("/", handlerwebhook.HandleFindExecution(webhookCtrl))
-					r.Post("/retrigger", handlerwebhook.HandleRetriggerExecution(webhookCtrl))
+					r.Get("/", handlerwebhook.HandleFindExecution(webhookCtrl, parentType))
+					r.Post("/retrigger", handlerwebhook.HandleRetriggerExecution(webhookCtrl, parentType))
 				})
 			})
 		})
 	})
 }
 
+func SetupEnvironments(
+	r chi.Router,
+	environmentCtrl *controllerenvironment.Controller,
+	parentType enum.EnvironmentParent,
+) {
+	r.Route("/environments", func(r chi.Router) {
+		r.Post("/", handlerenvironment.HandleCreate(environmentCtrl, parentType))
+		r.Get("/", handlerenvironment.HandleList(environmentCtrl, parentType))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamEnvironmentIdentifier), func(r chi.Router) {
+			r.Get("/", handlerenvironment.HandleFind(environmentCtrl, parentType))
+			r.Patch("/", handlerenvironment.HandleUpdate(environmentCtrl, parentType))
+			r.Delete("/", handlerenvironment.HandleDelete(environmentCtrl, parentType))
+			r.Get("/deployments", handlerenvironment.HandleListDeployments(environmentCtrl, parentType))
+		})
+	})
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.Route("/tests", func(r chi.Router) {
+				r.Post("/", handlercheck.HandleTestReport(checkCtrl))
+				r.Get("/", handlercheck.HandleTestCaseList(checkCtrl))
+			})
 		})
 	})
 }
@@ -596,7 +690,25 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func SetupMembers(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/members", func(r chi.Router) {
+		r.Get("/", handlerrepo.HandleMembershipList(repoCtrl))
+		r.Post("/", handlerrepo.HandleMembershipAdd(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+			r.Get("/", handlerrepo.HandleMembershipEffective(repoCtrl))
+			r.Delete("/", handlerrepo.HandleMembershipDelete(repoCtrl))
+			r.Patch("/", handlerrepo.HandleMembershipUpdate(repoCtrl))
+		})
+	})
+}
+
+// nolint: revive // it's the app context, it shouldn't be the first argument
+func setupUser(
+	r chi.Router,
+	appCtx context.Context,
+	userCtrl *user.Controller,
+	notificationCtrl *controllernotification.Controller,
+) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
@@ -604,6 +716,15 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
 
+		// TWO-FACTOR AUTHENTICATION
+		r.Route("/totp", func(r chi.Router) {
+			r.Get("/", handleruser.HandleFindTOTP(userCtrl))
+			r.Post("/", handleruser.HandleEnrollTOTP(userCtrl))
+			r.Post("/confirm", handleruser.HandleConfirmTOTP(userCtrl))
+			r.Post("/disable", handleruser.HandleDisableTOTP(userCtrl))
+			r.Post("/recovery-codes", handleruser.HandleRegenerateTOTPRecoveryCodes(userCtrl))
+		})
+
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypePAT))
@@ -624,6 +745,24 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// NOTIFICATION INBOX
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlernotification.HandleList(notificationCtrl))
+			r.Get("/events", handlernotification.HandleEvents(appCtx, notificationCtrl))
+			r.Post("/mark-read", handlernotification.HandleMarkAllRead(notificationCtrl))
+
+			r.Route("/preferences", func(r chi.Router) {
+				r.Get("/", handlernotification.HandleListPreferences(notificationCtrl))
+				r.Put("/", handlernotification.HandleUpdatePreference(notificationCtrl))
+				r.Delete("/", handlernotification.HandleDeletePreference(notificationCtrl))
+			})
+
+			// per notification operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationID), func(r chi.Router) {
+				r.Patch("/", handlernotification.HandleUpdate(notificationCtrl))
+			})
+		})
 	})
 }
 
@@ -676,7 +815,13 @@ func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	userCtrl *user.Controller,
+	auditCtrl *controlleraudit.Controller,
+	webhookCtrl *webhook.Controller,
+	runnerCtrl *controllerrunner.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -688,14 +833,87 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+
+				r.Route("/tokens", func(r chi.Router) {
+					r.Get("/", users.HandleListTokens(userCtrl))
+					r.Delete(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), users.HandleRevokeToken(userCtrl))
+				})
+
+				r.Delete("/totp", users.HandleResetTOTP(userCtrl))
+
+				r.Route("/identities", func(r chi.Router) {
+					r.Post("/", users.HandleLinkIdentity(userCtrl))
+					r.Delete(fmt.Sprintf("/{%s}", request.PathParamIdentityProvider), users.HandleUnlinkIdentity(userCtrl))
+				})
+			})
+		})
+
+		r.Route("/audit", func(r chi.Router) {
+			r.Get("/", handleraudit.HandleList(auditCtrl))
+			r.Get("/export", handleraudit.HandleExport(auditCtrl))
+		})
+
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Post("/redeliver", handlerwebhook.HandleRedeliverFailed(webhookCtrl))
+		})
+
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+			r.Post("/", handlerrunner.HandleCreate(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRunnerIdentifier), func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleFind(runnerCtrl))
+				r.Delete("/", handlerrunner.HandleDelete(runnerCtrl))
 			})
 		})
 	})
 }
 
+// setupRunner sets up the routes used by remote runners, which authenticate with their runner token.
+func setupRunner(r chi.Router, runnerCtrl *controllerrunner.Controller) {
+	r.Route("/runner", func(r chi.Router) {
+		r.Post("/heartbeat", handlerrunner.HandleHeartbeat(runnerCtrl))
+		r.Post("/stages/request", handlerrunner.HandleRequestStage(runnerCtrl))
+		r.Route(fmt.Sprintf("/stages/{%s}", request.PathParamStageID), func(r chi.Router) {
+			r.Post("/", handlerrunner.HandleUpdateStage(runnerCtrl))
+			r.Post("/artifacts", handlerrunner.HandleUploadArtifact(runnerCtrl))
+			r.Post("/tests", handlerrunner.HandleUploadTestReport(runnerCtrl))
+		})
+
+		r.Route(fmt.Sprintf("/steps/{%s}", request.PathParamStepID), func(r chi.Router) {
+			r.Post("/", handlerrunner.HandleUpdateStep(runnerCtrl))
+			r.Post("/logs/batch", handlerrunner.HandleWriteLogs(runnerCtrl))
+			r.Post("/logs/upload", handlerrunner.HandleUploadLogs(runnerCtrl))
+		})
+
+		r.Get(fmt.Sprintf("/executions/{%s}/watch", request.PathParamExecutionID), handlerrunner.HandleWatch(runnerCtrl))
+	})
+}
+
+// setupBuildCache sets up the routes used by pipeline steps, which authenticate with their cache token.
+func setupBuildCache(r chi.Router, buildCacheCtrl *controllerbuildcache.Controller) {
+	r.Route("/cache", func(r chi.Router) {
+		r.Get("/", handlerbuildcache.HandleRestore(buildCacheCtrl))
+		r.Put("/", handlerbuildcache.HandleSave(buildCacheCtrl))
+	})
+}
+
+// setupStepArtifacts sets up the routes used by pipeline steps, which authenticate with their artifact token.
+func setupStepArtifacts(r chi.Router, runnerCtrl *controllerrunner.Controller) {
+	r.Post("/artifacts", handlerrunner.HandleUploadStepArtifact(runnerCtrl))
+}
+
 func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Post("/login/totp/enroll", account.HandleLoginTOTPEnroll(userCtrl))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
+
+	r.Route("/oidc", func(r chi.Router) {
+		r.Get("/login", account.HandleLoginOIDC(userCtrl))
+		r.Get("/callback", account.HandleLoginOIDCCallback(userCtrl, cookieName, config.URL.UI))
+		r.Post("/second-factor", account.HandleLoginOIDCSecondFactor(userCtrl, cookieName))
+		r.Post("/totp/enroll", account.HandleLoginOIDCTOTPEnroll(userCtrl))
+	})
 }
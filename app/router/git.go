@@ -24,9 +24,13 @@ import (
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewareratelimit "github.com/harness/gitness/app/api/middleware/ratelimit"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/ratelimit"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/go-chi/chi"
@@ -41,9 +45,11 @@ type GitHandler interface {
 
 // NewGitHandler returns a new GitHandler.
 func NewGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	rateLimiter ratelimit.RateLimiter,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -61,6 +67,12 @@ func NewGitHandler(
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
 
+	r.Use(audit.Middleware())
+
+	if config.RateLimit.Enabled {
+		r.Use(middlewareratelimit.Limit(rateLimiter, gitRateLimitBudget(config), config.RateLimit.TrustedProxies))
+	}
+
 	r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 		// routes that aren't coming from git
 		r.Group(func(r chi.Router) {
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"strings"
+
+	middlewareratelimit "github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/ratelimit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	rateLimitBudgetREST           = "rest"
+	rateLimitBudgetGitUploadPack  = "git-upload-pack"
+	rateLimitBudgetGitReceivePack = "git-receive-pack"
+)
+
+// restRateLimitBudget returns the rate limit budget of api calls.
+func restRateLimitBudget(config *types.Config) middlewareratelimit.BudgetFunc {
+	return middlewareratelimit.Static(middlewareratelimit.Budget{
+		Name: rateLimitBudgetREST,
+		Limit: ratelimit.Limit{
+			Rate:  config.RateLimit.RESTRate,
+			Burst: config.RateLimit.RESTBurst,
+		},
+	})
+}
+
+// gitRateLimitBudget returns the rate limit budget of git calls.
+// Pushes are limited separately from fetches, everything that isn't a push is limited as fetch.
+func gitRateLimitBudget(config *types.Config) middlewareratelimit.BudgetFunc {
+	uploadPack := middlewareratelimit.Budget{
+		Name: rateLimitBudgetGitUploadPack,
+		Limit: ratelimit.Limit{
+			Rate:  config.RateLimit.UploadPackRate,
+			Burst: config.RateLimit.UploadPackBurst,
+		},
+	}
+	receivePack := middlewareratelimit.Budget{
+		Name: rateLimitBudgetGitReceivePack,
+		Limit: ratelimit.Limit{
+			Rate:  config.RateLimit.ReceivePackRate,
+			Burst: config.RateLimit.ReceivePackBurst,
+		},
+	}
+
+	return func(r *http.Request) middlewareratelimit.Budget {
+		if strings.HasSuffix(r.URL.Path, "/git-"+string(enum.GitServiceTypeReceivePack)) {
+			return receivePack
+		}
+
+		// the ref advertisement (info/refs) is requested for pushes as well.
+		if service, err := request.GetGitServiceTypeFromQuery(r); err == nil &&
+			service == enum.GitServiceTypeReceivePack {
+			return receivePack
+		}
+
+		return uploadPack
+	}
+}
@@ -18,18 +18,23 @@ import (
 	"context"
 	"strings"
 
+	controlleraudit "github.com/harness/gitness/app/api/controller/audit"
+	controllerbuildcache "github.com/harness/gitness/app/api/controller/buildcache"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	controllerenvironment "github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	controllernotification "github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -43,6 +48,7 @@ import (
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -77,14 +83,18 @@ func ProvideRouter(
 }
 
 func ProvideGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	rateLimiter ratelimit.RateLimiter,
 ) GitHandler {
 	return NewGitHandler(
+		config,
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		rateLimiter,
 	)
 }
 
@@ -114,11 +124,18 @@ func ProvideAPIHandler(
 	sysCtrl *system.Controller,
 	blobCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	auditCtrl *controlleraudit.Controller,
+	notificationCtrl *controllernotification.Controller,
+	runnerCtrl *controllerrunner.Controller,
+	environmentCtrl *controllerenvironment.Controller,
+	buildCacheCtrl *controllerbuildcache.Controller,
+	rateLimiter ratelimit.RateLimiter,
 ) APIHandler {
 	return NewAPIHandler(appCtx, config,
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl)
+		githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
+		auditCtrl, notificationCtrl, runnerCtrl, environmentCtrl, buildCacheCtrl, rateLimiter)
 }
 
 func ProvideWebHandler(config *types.Config, openapi openapi.Service) WebHandler {
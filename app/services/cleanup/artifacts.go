@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeArtifacts        = "gitness:cleanup:artifacts"
+	jobCronArtifacts        = "37 */2 * * *" // At minute 37 past every 2nd hour.
+	jobMaxDurationArtifacts = 10 * time.Minute
+
+	artifactsCleanupBatchSize = 100
+)
+
+type artifactsCleanupJob struct {
+	retentionTime time.Duration
+
+	artifactStore store.ArtifactStore
+	quotaStore    store.QuotaStore
+	blobStore     blob.Store
+}
+
+func newArtifactsCleanupJob(
+	retentionTime time.Duration,
+	artifactStore store.ArtifactStore,
+	quotaStore store.QuotaStore,
+	blobStore blob.Store,
+) *artifactsCleanupJob {
+	return &artifactsCleanupJob{
+		retentionTime: retentionTime,
+
+		artifactStore: artifactStore,
+		quotaStore:    quotaStore,
+		blobStore:     blobStore,
+	}
+}
+
+// Handle purges the artifacts of executions that are past the retention time, from the blob store and the DB.
+func (j *artifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging artifacts older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	purged := 0
+	for {
+		artifacts, err := j.artifactStore.ListCreatedBefore(ctx, olderThan.UnixMilli(), artifactsCleanupBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list old artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			err = j.blobStore.Delete(ctx, artifact.BlobPath)
+			if err != nil && !errors.Is(err, blob.ErrNotFound) {
+				return "", fmt.Errorf("failed to delete blob of artifact %d: %w", artifact.ID, err)
+			}
+
+			if err = j.artifactStore.Delete(ctx, artifact.ID); err != nil {
+				return "", fmt.Errorf("failed to delete artifact %d: %w", artifact.ID, err)
+			}
+
+			if err = j.quotaStore.AddBlobSize(ctx, artifact.RepoID, -artifact.Size); err != nil {
+				// the repository might have been purged already.
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to reduce blob size of repo %d", artifact.RepoID)
+			}
+
+			purged++
+		}
+
+		if len(artifacts) < artifactsCleanupBatchSize {
+			break
+		}
+	}
+
+	result := "no old artifacts found"
+	if purged > 0 {
+		result = fmt.Sprintf("purged %d artifacts", purged)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCacheEntries        = "gitness:cleanup:cache-entries"
+	jobCronCacheEntries        = "7 */2 * * *" // At minute 7 past every 2nd hour.
+	jobMaxDurationCacheEntries = 10 * time.Minute
+
+	cacheEntriesCleanupBatchSize = 100
+)
+
+type cacheEntriesCleanupJob struct {
+	retentionTime time.Duration
+
+	cacheEntryStore store.CacheEntryStore
+	blobStore       blob.Store
+}
+
+func newCacheEntriesCleanupJob(
+	retentionTime time.Duration,
+	cacheEntryStore store.CacheEntryStore,
+	blobStore blob.Store,
+) *cacheEntriesCleanupJob {
+	return &cacheEntriesCleanupJob{
+		retentionTime: retentionTime,
+
+		cacheEntryStore: cacheEntryStore,
+		blobStore:       blobStore,
+	}
+}
+
+// Handle purges build cache entries that weren't used within the retention time.
+func (j *cacheEntriesCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	unusedSince := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging build cache entries unused for %s (aka last used before %s)",
+		j.retentionTime,
+		unusedSince.Format(time.RFC3339Nano))
+
+	purged := 0
+	for {
+		entries, err := j.cacheEntryStore.ListUnusedSince(ctx, unusedSince.UnixMilli(), cacheEntriesCleanupBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list unused cache entries: %w", err)
+		}
+
+		for _, entry := range entries {
+			err = j.blobStore.Delete(ctx, entry.BlobPath)
+			if err != nil && !errors.Is(err, blob.ErrNotFound) {
+				return "", fmt.Errorf("failed to delete blob of cache entry %d: %w", entry.ID, err)
+			}
+
+			if err = j.cacheEntryStore.Delete(ctx, entry.ID); err != nil {
+				return "", fmt.Errorf("failed to delete cache entry %d: %w", entry.ID, err)
+			}
+
+			purged++
+		}
+
+		if len(entries) < cacheEntriesCleanupBatchSize {
+			break
+		}
+	}
+
+	result := "no unused build cache entries found"
+	if purged > 0 {
+		result = fmt.Sprintf("purged %d build cache entries", purged)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -22,12 +22,15 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	ArtifactsRetentionTime           time.Duration
+	CacheEntriesRetentionTime        time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +44,14 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.ArtifactsRetentionTime <= 0 {
+		return errors.New("config.ArtifactsRetentionTime has to be provided")
+	}
+
+	if c.CacheEntriesRetentionTime <= 0 {
+		return errors.New("config.CacheEntriesRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +64,10 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	artifactStore         store.ArtifactStore
+	cacheEntryStore       store.CacheEntryStore
+	quotaStore            store.QuotaStore
+	blobStore             blob.Store
 }
 
 func NewService(
@@ -63,6 +78,10 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	artifactStore store.ArtifactStore,
+	cacheEntryStore store.CacheEntryStore,
+	quotaStore store.QuotaStore,
+	blobStore blob.Store,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +96,10 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		artifactStore:         artifactStore,
+		cacheEntryStore:       cacheEntryStore,
+		quotaStore:            quotaStore,
+		blobStore:             blobStore,
 	}, nil
 }
 
@@ -126,6 +149,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeArtifacts,
+		jobTypeArtifacts,
+		jobCronArtifacts,
+		jobMaxDurationArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule artifacts cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeCacheEntries,
+		jobTypeCacheEntries,
+		jobCronCacheEntries,
+		jobMaxDurationCacheEntries,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule build cache cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +205,28 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeArtifacts,
+		newArtifactsCleanupJob(
+			s.config.ArtifactsRetentionTime,
+			s.artifactStore,
+			s.quotaStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for artifacts cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeCacheEntries,
+		newCacheEntriesCleanupJob(
+			s.config.CacheEntriesRetentionTime,
+			s.cacheEntryStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for build cache cleanup: %w", err)
+	}
 	return nil
 }
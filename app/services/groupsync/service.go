@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeSync        = "gitness:groupsync"
+	jobMaxDurationSync = 30 * time.Minute
+
+	syncPageSize = 100
+)
+
+// GroupSource is an external directory the group memberships of users are periodically synced from.
+type GroupSource interface {
+	// Enabled returns true if the source is configured.
+	Enabled() bool
+
+	// Provider returns the provider of the principal identities that are synced from the source.
+	Provider() string
+
+	// LookupGroups returns the groups of the user with the provided external id in the source.
+	// If the user doesn't exist in the source (anymore) an empty list is returned.
+	LookupGroups(ctx context.Context, externalID string) ([]string, error)
+
+	// GroupMappings returns the mappings of groups of the source to space memberships.
+	GroupMappings() []GroupMapping
+}
+
+type Config struct {
+	// Cron is the cron expression of the recurring group sync job.
+	Cron string
+}
+
+// Service periodically syncs the space memberships of all users linked to an identity of the source
+// with the groups they are part of in the source.
+// This ensures users that leave a group (or the organization) lose their access without manual cleanup.
+// Users are only synced via their linked identity, never by attributes they can change themselves (like the email).
+type Service struct {
+	config        Config
+	scheduler     *job.Scheduler
+	identityStore store.PrincipalIdentityStore
+	syncer        *Syncer
+	source        GroupSource
+}
+
+func NewService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	identityStore store.PrincipalIdentityStore,
+	syncer *Syncer,
+	source GroupSource,
+) (*Service, error) {
+	s := &Service{
+		config:        config,
+		scheduler:     scheduler,
+		identityStore: identityStore,
+		syncer:        syncer,
+		source:        source,
+	}
+
+	if err := executor.Register(jobTypeSync, s); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for group sync: %w", err)
+	}
+
+	return s, nil
+}
+
+// Register schedules the recurring group sync job in case the group source is configured.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.source.Enabled() || len(s.source.GroupMappings()) == 0 {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeSync, jobTypeSync, s.config.Cron, jobMaxDurationSync)
+	if err != nil {
+		return fmt.Errorf("failed to schedule group sync job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle syncs the space memberships of all users linked to an identity of the source with their groups.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.source.Enabled() {
+		return "group source is disabled", nil
+	}
+
+	mappings := s.source.GroupMappings()
+	if len(mappings) == 0 {
+		return "no group mappings configured", nil
+	}
+
+	var synced, failed int
+	var after string
+	for {
+		identities, err := s.identityStore.List(ctx, s.source.Provider(), after, syncPageSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list principal identities: %w", err)
+		}
+
+		for _, identity := range identities {
+			if err := s.syncIdentity(ctx, identity, mappings); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to sync groups of identity %q", identity.ExternalID)
+				failed++
+				continue
+			}
+			synced++
+		}
+
+		if len(identities) < syncPageSize {
+			break
+		}
+
+		after = identities[len(identities)-1].ExternalID
+	}
+
+	result := fmt.Sprintf("synced groups of %d users", synced)
+	if failed > 0 {
+		result += fmt.Sprintf(", failed for %d users", failed)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) syncIdentity(ctx context.Context, identity *types.PrincipalIdentity, mappings []GroupMapping) error {
+	// an error of the source must never result in removed memberships, hence the sync is skipped.
+	groups, err := s.source.LookupGroups(ctx, identity.ExternalID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup groups: %w", err)
+	}
+
+	return s.syncer.Sync(ctx, identity.PrincipalID, groups, mappings)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupsync
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeGroupSource struct {
+	groups map[string][]string
+	lookup []string
+}
+
+func (s *fakeGroupSource) Enabled() bool    { return true }
+func (s *fakeGroupSource) Provider() string { return types.PrincipalIdentityProviderLDAP }
+
+func (s *fakeGroupSource) LookupGroups(_ context.Context, externalID string) ([]string, error) {
+	s.lookup = append(s.lookup, externalID)
+	return s.groups[externalID], nil
+}
+
+func (s *fakeGroupSource) GroupMappings() []GroupMapping {
+	return []GroupMapping{{Group: "admins", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner}}
+}
+
+type fakeIdentityStore struct {
+	store.PrincipalIdentityStore
+	identities []*types.PrincipalIdentity
+}
+
+func (s *fakeIdentityStore) List(
+	_ context.Context,
+	provider string,
+	after string,
+	limit int,
+) ([]*types.PrincipalIdentity, error) {
+	var list []*types.PrincipalIdentity
+	for _, identity := range s.identities {
+		if identity.Provider == provider && identity.ExternalID > after && len(list) < limit {
+			list = append(list, identity)
+		}
+	}
+	return list, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (s *fakePrincipalStore) FindByUID(context.Context, string) (*types.Principal, error) {
+	return &types.Principal{ID: 1}, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (s *fakeSpaceStore) FindByRef(context.Context, string) (*types.Space, error) {
+	return &types.Space{ID: 10}, nil
+}
+
+type fakeMembershipStore struct {
+	store.MembershipStore
+	created []int64
+}
+
+func (s *fakeMembershipStore) Find(context.Context, types.MembershipKey) (*types.Membership, error) {
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeMembershipStore) Create(_ context.Context, membership *types.Membership) error {
+	s.created = append(s.created, membership.PrincipalID)
+	return nil
+}
+
+func TestService_Handle(t *testing.T) {
+	source := &fakeGroupSource{
+		groups: map[string][]string{
+			"uid=admin,dc=acme": {"admins"},
+			"uid=jdoe,dc=acme":  {"developers"},
+		},
+	}
+	identityStore := &fakeIdentityStore{
+		identities: []*types.PrincipalIdentity{
+			{Provider: types.PrincipalIdentityProviderLDAP, ExternalID: "uid=admin,dc=acme", PrincipalID: 2},
+			{Provider: types.PrincipalIdentityProviderLDAP, ExternalID: "uid=jdoe,dc=acme", PrincipalID: 3},
+			// identities of other providers must never be looked up in the source.
+			{Provider: types.PrincipalIdentityProviderOIDC, ExternalID: "uid=admin,dc=acme", PrincipalID: 4},
+		},
+	}
+	membershipStore := &fakeMembershipStore{}
+
+	s := &Service{
+		identityStore: identityStore,
+		syncer:        NewSyncer("system", &fakePrincipalStore{}, &fakeSpaceStore{}, membershipStore),
+		source:        source,
+	}
+
+	if _, err := s.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("failed to handle group sync: %v", err)
+	}
+
+	expLookup := []string{"uid=admin,dc=acme", "uid=jdoe,dc=acme"}
+	if !reflect.DeepEqual(expLookup, source.lookup) {
+		t.Errorf("looked up identities mismatch: want=%v got=%v", expLookup, source.lookup)
+	}
+
+	expCreated := []int64{2}
+	if !reflect.DeepEqual(expCreated, membershipStore.created) {
+		t.Errorf("created memberships mismatch: want=%v got=%v", expCreated, membershipStore.created)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GroupMapping maps the members of an external group (e.g. an OIDC group claim or an LDAP group)
+// to a membership role in a space.
+type GroupMapping struct {
+	Group     string
+	SpacePath string
+	Role      enum.MembershipRole
+}
+
+// ParseGroupMappings parses group mappings provided in the format "group:space_path:role".
+// The group itself is allowed to contain ":" as the space path and the role are separated from the right.
+func ParseGroupMappings(raw []string) ([]GroupMapping, error) {
+	mappings := make([]GroupMapping, 0, len(raw))
+	for _, s := range raw {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		idxRole := strings.LastIndex(s, ":")
+		if idxRole <= 0 {
+			return nil, fmt.Errorf("group mapping %q isn't in the format group:space_path:role", s)
+		}
+
+		idxSpace := strings.LastIndex(s[:idxRole], ":")
+		if idxSpace <= 0 {
+			return nil, fmt.Errorf("group mapping %q isn't in the format group:space_path:role", s)
+		}
+
+		role, ok := enum.MembershipRole(s[idxRole+1:]).Sanitize()
+		if !ok || role == enum.MembershipRoleCustom {
+			return nil, fmt.Errorf("group mapping %q has an unsupported role", s)
+		}
+
+		spacePath := strings.Trim(s[idxSpace+1:idxRole], types.PathSeparator)
+		if spacePath == "" {
+			return nil, fmt.Errorf("group mapping %q is missing the space path", s)
+		}
+
+		mappings = append(mappings, GroupMapping{
+			Group:     s[:idxSpace],
+			SpacePath: spacePath,
+			Role:      role,
+		})
+	}
+
+	return mappings, nil
+}
+
+// Syncer keeps the space memberships of users in line with the groups they are part of.
+type Syncer struct {
+	systemPrincipalUID string
+	principalStore     store.PrincipalStore
+	spaceStore         store.SpaceStore
+	membershipStore    store.MembershipStore
+
+	systemPrincipalMx sync.Mutex
+	systemPrincipalID int64
+}
+
+func NewSyncer(
+	systemPrincipalUID string,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+) *Syncer {
+	return &Syncer{
+		systemPrincipalUID: systemPrincipalUID,
+		principalStore:     principalStore,
+		spaceStore:         spaceStore,
+		membershipStore:    membershipStore,
+	}
+}
+
+// Sync brings the space memberships of the principal in line with the group mappings:
+// Memberships of the spaces mapped to the groups of the principal are created or updated to the mapped role,
+// memberships that were created by a previous sync are removed once the principal isn't part of the group anymore.
+// Memberships that were created manually are never changed.
+// If multiple mappings of the principal's groups target the same space, the first one wins.
+func (s *Syncer) Sync(
+	ctx context.Context,
+	principalID int64,
+	groups []string,
+	mappings []GroupMapping,
+) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	systemPrincipalID, err := s.getSystemPrincipalID(ctx)
+	if err != nil {
+		return err
+	}
+
+	spaceIDs := make([]int64, 0, len(mappings))
+	desired := make(map[int64]enum.MembershipRole)
+	for _, mapping := range mappings {
+		space, err := s.spaceStore.FindByRef(ctx, mapping.SpacePath)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Ctx(ctx).Warn().Msgf("space %q of group mapping for group %q not found",
+				mapping.SpacePath, mapping.Group)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find space %q of group mapping: %w", mapping.SpacePath, err)
+		}
+
+		role, seen := desired[space.ID]
+		if !seen {
+			spaceIDs = append(spaceIDs, space.ID)
+		}
+
+		if role == "" && containsGroup(groups, mapping.Group) {
+			role = mapping.Role
+		}
+
+		desired[space.ID] = role
+	}
+
+	for _, spaceID := range spaceIDs {
+		if err := s.syncSpace(ctx, systemPrincipalID, principalID, spaceID, desired[spaceID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncSpace ensures the principal has the provided role in the space.
+// An empty role means the principal shouldn't have a synced membership of the space.
+func (s *Syncer) syncSpace(
+	ctx context.Context,
+	systemPrincipalID int64,
+	principalID int64,
+	spaceID int64,
+	role enum.MembershipRole,
+) error {
+	key := types.MembershipKey{
+		SpaceID:     spaceID,
+		PrincipalID: principalID,
+	}
+
+	membership, err := s.membershipStore.Find(ctx, key)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership of space %d: %w", spaceID, err)
+	}
+
+	now := time.Now().UnixMilli()
+
+	switch {
+	case role != "" && membership == nil:
+		err = s.membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: key,
+			CreatedBy:     systemPrincipalID,
+			Created:       now,
+			Updated:       now,
+			Role:          role,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create membership of space %d: %w", spaceID, err)
+		}
+
+	case membership == nil || membership.CreatedBy != systemPrincipalID:
+		// nothing to do, or the membership was created manually
+
+	case role != "" && membership.Role != role:
+		membership.Role = role
+		membership.Updated = now
+		if err = s.membershipStore.Update(ctx, membership); err != nil {
+			return fmt.Errorf("failed to update membership of space %d: %w", spaceID, err)
+		}
+
+	case role == "":
+		if err = s.membershipStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete membership of space %d: %w", spaceID, err)
+		}
+	}
+
+	return nil
+}
+
+// getSystemPrincipalID returns the ID of the system principal, which is used as the creator of synced memberships.
+func (s *Syncer) getSystemPrincipalID(ctx context.Context) (int64, error) {
+	s.systemPrincipalMx.Lock()
+	defer s.systemPrincipalMx.Unlock()
+
+	if s.systemPrincipalID != 0 {
+		return s.systemPrincipalID, nil
+	}
+
+	principal, err := s.principalStore.FindByUID(ctx, s.systemPrincipalUID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find system principal: %w", err)
+	}
+
+	s.systemPrincipalID = principal.ID
+
+	return s.systemPrincipalID, nil
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupsync
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseGroupMappings(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    []string
+		exp    []GroupMapping
+		expErr bool
+	}{
+		{
+			name: "empty",
+			raw:  []string{"", " "},
+			exp:  []GroupMapping{},
+		},
+		{
+			name: "simple",
+			raw:  []string{"developers:acme/backend:contributor", "admins:acme:space_owner"},
+			exp: []GroupMapping{
+				{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+				{Group: "admins", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+			},
+		},
+		{
+			name: "group-with-colon",
+			raw:  []string{"cn=dev:ops,dc=acme:/acme/:reader"},
+			exp: []GroupMapping{
+				{Group: "cn=dev:ops,dc=acme", SpacePath: "acme", Role: enum.MembershipRoleReader},
+			},
+		},
+		{
+			name:   "missing-role",
+			raw:    []string{"developers:acme"},
+			expErr: true,
+		},
+		{
+			name:   "invalid-role",
+			raw:    []string{"developers:acme:owner"},
+			expErr: true,
+		},
+		{
+			name:   "custom-role",
+			raw:    []string{"developers:acme:custom"},
+			expErr: true,
+		},
+		{
+			name:   "missing-space",
+			raw:    []string{"developers::reader"},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mappings, err := ParseGroupMappings(test.raw)
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if !reflect.DeepEqual(test.exp, mappings) {
+				t.Errorf("mappings mismatch: want=%+v got=%+v", test.exp, mappings)
+			}
+		})
+	}
+}
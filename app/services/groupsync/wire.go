@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupsync
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideSyncer,
+	ProvideService,
+)
+
+func ProvideSyncer(
+	config *types.Config,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+) *Syncer {
+	return NewSyncer(config.Principal.System.UID, principalStore, spaceStore, membershipStore)
+}
+
+func ProvideService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	identityStore store.PrincipalIdentityStore,
+	syncer *Syncer,
+	source GroupSource,
+) (*Service, error) {
+	return NewService(config, scheduler, executor, identityStore, syncer, source)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TemplateApprovalRequested = "approval_requested.html"
+
+	subjectApprovalRequested = "[%s] Approval required for %s #%d"
+)
+
+type ApprovalRequestedPayload struct {
+	Recipient    *types.PrincipalInfo
+	Repo         *types.Repository
+	Pipeline     *types.Pipeline
+	Execution    *types.Execution
+	Approval     *types.StageApproval
+	ExecutionURL string
+}
+
+// ApprovalNotifier informs the approvers of a pipeline stage that it's waiting for their approval.
+// The approvers get an email and a notification in their inbox, which is delivered live to their event stream.
+type ApprovalNotifier struct {
+	mailer             mailer.Mailer
+	principalInfoCache store.PrincipalInfoCache
+	notificationStore  store.NotificationStore
+	sseStreamer        sse.Streamer
+	urlProvider        url.Provider
+}
+
+func NewApprovalNotifier(
+	mailer mailer.Mailer,
+	principalInfoCache store.PrincipalInfoCache,
+	notificationStore store.NotificationStore,
+	sseStreamer sse.Streamer,
+	urlProvider url.Provider,
+) *ApprovalNotifier {
+	return &ApprovalNotifier{
+		mailer:             mailer,
+		principalInfoCache: principalInfoCache,
+		notificationStore:  notificationStore,
+		sseStreamer:        sseStreamer,
+		urlProvider:        urlProvider,
+	}
+}
+
+// SendApprovalRequested notifies the recipients about the stage waiting for their approval.
+func (n *ApprovalNotifier) SendApprovalRequested(
+	ctx context.Context,
+	recipientIDs []int64,
+	repo *types.Repository,
+	pipeline *types.Pipeline,
+	execution *types.Execution,
+	approval *types.StageApproval,
+) error {
+	executionURL := n.urlProvider.GenerateUIBuildURL(repo.Path, pipeline.Identifier, execution.Number)
+	now := time.Now().UnixMilli()
+
+	for _, recipientID := range recipientIDs {
+		recipient, err := n.principalInfoCache.Get(ctx, recipientID)
+		if err != nil {
+			return fmt.Errorf("failed to find approver %d: %w", recipientID, err)
+		}
+
+		notification := &types.Notification{
+			PrincipalID: recipient.ID,
+			Type:        enum.NotificationTypeApprovalRequested,
+			RepoID:      repo.ID,
+			Title:       fmt.Sprintf("%s #%d", pipeline.Identifier, execution.Number),
+			URL:         executionURL,
+			Text:        approval.StageName,
+			ActorID:     execution.CreatedBy,
+			Read:        false,
+			Created:     now,
+			Updated:     now,
+		}
+
+		err = n.notificationStore.Create(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("failed to create approval requested notification for principal %d: %w",
+				recipient.ID, err)
+		}
+
+		err = n.sseStreamer.PublishPrincipal(ctx, recipient.ID, enum.SSETypeNotificationCreated, notification)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish notification created event for principal %d",
+				recipient.ID)
+		}
+
+		body, err := GetHTMLBody(TemplateApprovalRequested, &ApprovalRequestedPayload{
+			Recipient:    recipient,
+			Repo:         repo,
+			Pipeline:     pipeline,
+			Execution:    execution,
+			Approval:     approval,
+			ExecutionURL: executionURL,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = n.mailer.Send(ctx, mailer.Payload{
+			ToRecipients: []string{recipient.Email},
+			Subject:      fmt.Sprintf(subjectApprovalRequested, repo.Identifier, pipeline.Identifier, execution.Number),
+			Body:         string(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send approval requested email: %w", err)
+		}
+	}
+
+	return nil
+}
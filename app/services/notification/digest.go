@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TemplateDigest = "digest.html"
+
+	jobTypeDigest        = "gitness:notification:digest"
+	jobMaxDurationDigest = 30 * time.Minute
+
+	subjectDigest = "Your digest: %d new notifications"
+)
+
+type DigestPayload struct {
+	Recipient *types.PrincipalInfo
+	Items     []*types.NotificationDigestItem
+}
+
+// DigestService periodically batches the pending notifications of users
+// that prefer a digest into a single email per user.
+type DigestService struct {
+	config             Config
+	scheduler          *job.Scheduler
+	mailer             mailer.Mailer
+	digestStore        store.NotificationDigestStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewDigestService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mailer mailer.Mailer,
+	digestStore store.NotificationDigestStore,
+	principalInfoCache store.PrincipalInfoCache,
+) (*DigestService, error) {
+	s := &DigestService{
+		config:             config,
+		scheduler:          scheduler,
+		mailer:             mailer,
+		digestStore:        digestStore,
+		principalInfoCache: principalInfoCache,
+	}
+
+	if err := executor.Register(jobTypeDigest, s); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for notification digest: %w", err)
+	}
+
+	return s, nil
+}
+
+// Register schedules the recurring notification digest job.
+func (s *DigestService) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeDigest, jobTypeDigest, s.config.DigestCron, jobMaxDurationDigest)
+	if err != nil {
+		return fmt.Errorf("failed to schedule notification digest job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle sends the digest email to every user with pending digest items.
+func (s *DigestService) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	principalIDs, err := s.digestStore.ListPrincipalIDs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list principals with pending digest items: %w", err)
+	}
+
+	var sent, failed int
+	for _, principalID := range principalIDs {
+		if err := s.sendDigest(ctx, principalID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to send notification digest to principal %d", principalID)
+			failed++
+			continue
+		}
+		sent++
+	}
+
+	result := fmt.Sprintf("sent %d digests (%d failed)", sent, failed)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *DigestService) sendDigest(ctx context.Context, principalID int64) error {
+	items, err := s.digestStore.List(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to list digest items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	recipient, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	body, err := GetHTMLBody(TemplateDigest, &DigestPayload{
+		Recipient: recipient,
+		Items:     items,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{recipient.Email},
+		Subject:      fmt.Sprintf(subjectDigest, len(items)),
+		Body:         string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send digest email: %w", err)
+	}
+
+	// only delete the items that were part of the email, new ones might have been added in the meantime.
+	if err = s.digestStore.DeleteUpTo(ctx, principalID, items[len(items)-1].ID); err != nil {
+		return fmt.Errorf("failed to delete sent digest items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ Client = (*InboxClient)(nil)
+
+// InboxClient is a Client that stores the notifications in the inbox of the recipients
+// and delivers them live to the event stream of the recipients.
+type InboxClient struct {
+	notificationStore store.NotificationStore
+	sseStreamer       sse.Streamer
+}
+
+func NewInboxClient(
+	notificationStore store.NotificationStore,
+	sseStreamer sse.Streamer,
+) *InboxClient {
+	return &InboxClient{
+		notificationStore: notificationStore,
+		sseStreamer:       sseStreamer,
+	}
+}
+
+func (c *InboxClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypeCommentCreated, payload.Text)
+}
+
+func (c *InboxClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypeCommentMention, payload.Text)
+}
+
+func (c *InboxClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypeCommentReply, payload.Text)
+}
+
+func (c *InboxClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	// the reviewer is asked for a review, everyone else is only informed about the new reviewer.
+	var others []*types.PrincipalInfo
+	for _, recipient := range recipients {
+		if recipient.ID == payload.Reviewer.ID {
+			continue
+		}
+		others = append(others, recipient)
+	}
+
+	err := c.send(ctx, []*types.PrincipalInfo{payload.Reviewer}, payload.Base,
+		enum.NotificationTypeReviewRequested, "")
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, others, payload.Base, enum.NotificationTypeReviewerAdded, payload.Reviewer.DisplayName)
+}
+
+func (c *InboxClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypePullReqBranchUpdated, payload.NewSHA)
+}
+
+func (c *InboxClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypeReviewSubmitted, string(payload.Decision))
+}
+
+func (c *InboxClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base, enum.NotificationTypePullReqStateChanged, string(payload.State))
+}
+
+// send stores a notification for every recipient, except for the principal that triggered it.
+func (c *InboxClient) send(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	notificationType enum.NotificationType,
+	text string,
+) error {
+	now := time.Now().UnixMilli()
+
+	for _, recipient := range recipients {
+		if recipient.ID == base.ActorID {
+			continue
+		}
+
+		notification := &types.Notification{
+			PrincipalID:   recipient.ID,
+			Type:          notificationType,
+			RepoID:        base.Repo.ID,
+			PullReqID:     base.PullReq.ID,
+			PullReqNumber: base.PullReq.Number,
+			Title:         base.PullReq.Title,
+			URL:           base.PullReqURL,
+			Text:          text,
+			ActorID:       base.ActorID,
+			Read:          false,
+			Created:       now,
+			Updated:       now,
+		}
+
+		err := c.notificationStore.Create(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("failed to create %s notification for principal %d: %w",
+				notificationType, recipient.ID, err)
+		}
+
+		err = c.sseStreamer.PublishPrincipal(ctx, recipient.ID, enum.SSETypeNotificationCreated, notification)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish notification created event for principal %d",
+				recipient.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// digestSummaryMaxLength is the maximum length of the comment text shown in a digest email.
+	digestSummaryMaxLength = 200
+)
+
+var _ Client = (*PreferenceClient)(nil)
+
+// PreferenceClient is a Client that applies the notification preferences of the recipients
+// before forwarding the notification to the wrapped client.
+// Notifications of recipients that prefer a digest are stored and sent as part of the next digest email.
+type PreferenceClient struct {
+	client          Client
+	preferenceStore store.NotificationPreferenceStore
+	digestStore     store.NotificationDigestStore
+}
+
+func NewPreferenceClient(
+	client Client,
+	preferenceStore store.NotificationPreferenceStore,
+	digestStore store.NotificationDigestStore,
+) *PreferenceClient {
+	return &PreferenceClient{
+		client:          client,
+		preferenceStore: preferenceStore,
+		digestStore:     digestStore,
+	}
+}
+
+func (c *PreferenceClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	summary := fmt.Sprintf("%s commented: %s", payload.Commenter.DisplayName, truncate(payload.Text))
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypeCommentCreated, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentPRAuthor(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	summary := fmt.Sprintf("%s mentioned you: %s", payload.Commenter.DisplayName, truncate(payload.Text))
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypeCommentMention, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentMentions(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	summary := fmt.Sprintf("%s replied: %s", payload.Commenter.DisplayName, truncate(payload.Text))
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypeCommentReply, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendCommentParticipants(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	// the reviewer is asked for a review, everyone else is only informed about the new reviewer.
+	var reviewers, others []*types.PrincipalInfo
+	for _, recipient := range recipients {
+		if recipient.ID == payload.Reviewer.ID {
+			reviewers = append(reviewers, recipient)
+			continue
+		}
+		others = append(others, recipient)
+	}
+
+	reviewers, err := c.filter(ctx, reviewers, payload.Base, enum.NotificationTypeReviewRequested,
+		"You were added as a reviewer")
+	if err != nil {
+		return err
+	}
+
+	others, err = c.filter(ctx, others, payload.Base, enum.NotificationTypeReviewerAdded,
+		fmt.Sprintf("%s was added as a reviewer", payload.Reviewer.DisplayName))
+	if err != nil {
+		return err
+	}
+
+	recipients = append(reviewers, others...)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	return c.client.SendReviewerAdded(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	summary := fmt.Sprintf("%s updated the source branch to %s", payload.Committer.DisplayName, payload.NewSHA)
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypePullReqBranchUpdated, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendPullReqBranchUpdated(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	summary := fmt.Sprintf("%s submitted a review: %s", payload.Reviewer.DisplayName, payload.Decision)
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypeReviewSubmitted, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendReviewSubmitted(ctx, recipients, payload)
+}
+
+func (c *PreferenceClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	summary := fmt.Sprintf("The pull request was %s by %s", payload.State, payload.ChangedBy.DisplayName)
+	recipients, err := c.filter(ctx, recipients, payload.Base, enum.NotificationTypePullReqStateChanged, summary)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+
+	return c.client.SendPullReqStateChanged(ctx, recipients, payload)
+}
+
+// filter returns the recipients that want to be notified instantly.
+// Recipients that prefer a digest get a digest item stored instead, recipients that opted out are dropped.
+func (c *PreferenceClient) filter(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	base *BasePullReqPayload,
+	notificationType enum.NotificationType,
+	summary string,
+) ([]*types.PrincipalInfo, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	deliveries, err := c.preferenceStore.ListDeliveries(ctx, principalIDs, base.Repo.ID, notificationType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification deliveries: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	subject := GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title)
+
+	instant := make([]*types.PrincipalInfo, 0, len(recipients))
+	for _, recipient := range recipients {
+		delivery, ok := deliveries[recipient.ID]
+		if !ok {
+			delivery = enum.NotificationDeliveryInstant
+		}
+
+		switch delivery {
+		case enum.NotificationDeliveryOff:
+			continue
+		case enum.NotificationDeliveryDigest:
+			err = c.digestStore.Create(ctx, &types.NotificationDigestItem{
+				PrincipalID: recipient.ID,
+				Type:        notificationType,
+				Subject:     subject,
+				Summary:     summary,
+				URL:         base.PullReqURL,
+				Created:     now,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create notification digest item for principal %d: %w",
+					recipient.ID, err)
+			}
+		case enum.NotificationDeliveryInstant:
+			instant = append(instant, recipient)
+		}
+	}
+
+	return instant, nil
+}
+
+func truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) <= digestSummaryMaxLength {
+		return text
+	}
+
+	return string(runes[:digestSummaryMaxLength]) + "..."
+}
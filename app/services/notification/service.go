@@ -31,9 +31,10 @@ import (
 )
 
 const (
-	eventReaderGroupName = "gitness:notification"
-	templatesDir         = "templates"
-	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+	eventReaderGroupName      = "gitness:notification"
+	eventReaderGroupNameInbox = "gitness:notification:inbox"
+	templatesDir              = "templates"
+	subjectPullReqEvent       = "[%s] %s (PR #%d)"
 )
 
 var (
@@ -76,12 +77,18 @@ type BasePullReqPayload struct {
 	PullReq    *types.PullReq
 	Author     *types.PrincipalInfo
 	PullReqURL string
+
+	// ActorID is the ID of the principal that triggered the event.
+	ActorID int64
 }
 
 type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// DigestCron is the cron expression of the recurring notification digest job.
+	DigestCron string
 }
 
 type Service struct {
@@ -98,6 +105,13 @@ type Service struct {
 	urlProvider           url.Provider
 }
 
+// InboxService delivers pull request notifications to the in-app inbox of users.
+// It reads the events with its own reader group, hence sending emails and
+// delivering to the inbox are retried independently of each other.
+type InboxService struct {
+	*Service
+}
+
 func NewService(
 	ctx context.Context,
 	config Config,
@@ -111,6 +125,50 @@ func NewService(
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
 	urlProvider url.Provider,
+) (*Service, error) {
+	return newService(ctx, eventReaderGroupName, config, notificationClient, prReaderFactory,
+		pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewersStore,
+		pullReqActivityStore, spacePathStore, urlProvider)
+}
+
+func NewInboxService(
+	ctx context.Context,
+	config Config,
+	inboxClient *InboxClient,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalInfoView store.PrincipalInfoView,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqReviewersStore store.PullReqReviewerStore,
+	pullReqActivityStore store.PullReqActivityStore,
+	spacePathStore store.SpacePathStore,
+	urlProvider url.Provider,
+) (*InboxService, error) {
+	service, err := newService(ctx, eventReaderGroupNameInbox, config, inboxClient, prReaderFactory,
+		pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewersStore,
+		pullReqActivityStore, spacePathStore, urlProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InboxService{Service: service}, nil
+}
+
+func newService(
+	ctx context.Context,
+	groupName string,
+	config Config,
+	notificationClient Client,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalInfoView store.PrincipalInfoView,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqReviewersStore store.PullReqReviewerStore,
+	pullReqActivityStore store.PullReqActivityStore,
+	spacePathStore store.SpacePathStore,
+	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
@@ -128,7 +186,7 @@ func NewService(
 
 	_, err := service.prReaderFactory.Launch(
 		ctx,
-		eventReaderGroupName,
+		groupName,
 		config.EventReaderName,
 		func(r *pullreqevents.Reader,
 		) error {
@@ -150,7 +208,7 @@ func NewService(
 			return nil
 		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
+		return nil, fmt.Errorf("failed to launch event reader for %s: %w", groupName, err)
 	}
 
 	return service, nil
@@ -181,5 +239,6 @@ func (s *Service) getBasePayload(
 		PullReq:    pullReq,
 		Author:     author,
 		PullReqURL: s.urlProvider.GenerateUIPRURL(repo.Path, pullReq.Number),
+		ActorID:    base.PrincipalID,
 	}, nil
 }
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	TemplateWebhookDisabled = "webhook_disabled.html"
+
+	subjectWebhookDisabled = "Webhook %s got disabled"
+)
+
+type WebhookDisabledPayload struct {
+	Recipient *types.PrincipalInfo
+	Webhook   *types.Webhook
+	Reason    string
+}
+
+// WebhookNotifier informs the creator of a webhook about the webhook getting disabled by the system.
+type WebhookNotifier struct {
+	mailer             mailer.Mailer
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewWebhookNotifier(
+	mailer mailer.Mailer,
+	principalInfoCache store.PrincipalInfoCache,
+) *WebhookNotifier {
+	return &WebhookNotifier{
+		mailer:             mailer,
+		principalInfoCache: principalInfoCache,
+	}
+}
+
+// SendWebhookDisabled sends an email to the creator of the webhook explaining why it got disabled.
+func (n *WebhookNotifier) SendWebhookDisabled(ctx context.Context, webhook *types.Webhook, reason string) error {
+	recipient, err := n.principalInfoCache.Get(ctx, webhook.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook creator: %w", err)
+	}
+
+	body, err := GetHTMLBody(TemplateWebhookDisabled, &WebhookDisabledPayload{
+		Recipient: recipient,
+		Webhook:   webhook,
+		Reason:    reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = n.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{recipient.Email},
+		Subject:      fmt.Sprintf(subjectWebhookDisabled, webhook.Identifier),
+		Body:         string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send webhook disabled email: %w", err)
+	}
+
+	return nil
+}
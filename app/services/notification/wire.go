@@ -19,9 +19,11 @@ import (
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -29,6 +31,10 @@ import (
 var WireSet = wire.NewSet(
 	ProvideMailClient,
 	ProvideNotificationService,
+	ProvideInboxService,
+	ProvideDigestService,
+	ProvideWebhookNotifier,
+	ProvideApprovalNotifier,
 )
 
 func ProvideNotificationService(
@@ -61,6 +67,69 @@ func ProvideNotificationService(
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
-	return NewMailClient(mailer)
+func ProvideInboxService(
+	ctx context.Context,
+	config Config,
+	notificationStore store.NotificationStore,
+	sseStreamer sse.Streamer,
+	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalInfoView store.PrincipalInfoView,
+	principalInfoCache store.PrincipalInfoCache,
+	pullReqReviewersStore store.PullReqReviewerStore,
+	pullReqActivityStore store.PullReqActivityStore,
+	spacePathStore store.SpacePathStore,
+	urlProvider url.Provider,
+) (*InboxService, error) {
+	return NewInboxService(
+		ctx,
+		config,
+		NewInboxClient(notificationStore, sseStreamer),
+		prReaderFactory,
+		pullReqStore,
+		repoStore,
+		principalInfoView,
+		principalInfoCache,
+		pullReqReviewersStore,
+		pullReqActivityStore,
+		spacePathStore,
+		urlProvider,
+	)
+}
+
+func ProvideDigestService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mailer mailer.Mailer,
+	digestStore store.NotificationDigestStore,
+	principalInfoCache store.PrincipalInfoCache,
+) (*DigestService, error) {
+	return NewDigestService(config, scheduler, executor, mailer, digestStore, principalInfoCache)
+}
+
+func ProvideWebhookNotifier(
+	mailer mailer.Mailer,
+	principalInfoCache store.PrincipalInfoCache,
+) *WebhookNotifier {
+	return NewWebhookNotifier(mailer, principalInfoCache)
+}
+
+func ProvideApprovalNotifier(
+	mailer mailer.Mailer,
+	principalInfoCache store.PrincipalInfoCache,
+	notificationStore store.NotificationStore,
+	sseStreamer sse.Streamer,
+	urlProvider url.Provider,
+) *ApprovalNotifier {
+	return NewApprovalNotifier(mailer, principalInfoCache, notificationStore, sseStreamer, urlProvider)
+}
+
+func ProvideMailClient(
+	mailer mailer.Mailer,
+	preferenceStore store.NotificationPreferenceStore,
+	digestStore store.NotificationDigestStore,
+) Client {
+	return NewPreferenceClient(NewMailClient(mailer), preferenceStore, digestStore)
 }
@@ -28,6 +28,7 @@ type Branch struct {
 	Bypass    DefBypass    `json:"bypass"`
 	PullReq   DefPullReq   `json:"pullreq"`
 	Lifecycle DefLifecycle `json:"lifecycle"`
+	Freeze    DefFreeze    `json:"freeze"`
 }
 
 var (
@@ -44,6 +45,13 @@ func (v *Branch) MergeVerify(
 		return
 	}
 
+	_, freezeViolations, err := v.Freeze.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = combineViolations(violations, freezeViolations)
+
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
@@ -94,6 +102,16 @@ func (v *Branch) RefChangeVerify(
 	}
 
 	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	freezeViolations, err := v.Freeze.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = combineViolations(violations, freezeViolations)
 
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
@@ -122,5 +140,25 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Freeze.Sanitize(); err != nil {
+		return fmt.Errorf("freeze: %w", err)
+	}
+
 	return nil
 }
+
+// combineViolations merges violations reported by different sections of a rule into a single RuleViolations.
+func combineViolations(sections ...[]types.RuleViolations) []types.RuleViolations {
+	var combined types.RuleViolations
+	for _, section := range sections {
+		for i := range section {
+			combined.Violations = append(combined.Violations, section[i].Violations...)
+		}
+	}
+
+	if len(combined.Violations) == 0 {
+		return nil
+	}
+
+	return []types.RuleViolations{combined}
+}
@@ -477,6 +477,34 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "freeze-owner-bypass",
+			branch: Branch{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefLifecycle{UpdateForbidden: true},
+				Freeze: DefFreeze{Windows: []FreezeWindow{
+					{Start: "2000-01-01T00:00", End: "2100-01-01T00:00"},
+				}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				IsRepoOwner: true,
+				RefAction:   RefActionUpdate,
+				RefType:     RefTypeBranch,
+				RefNames:    []string{"abc"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeLifecycleUpdate},
+						{Code: codeFreezeActive},
+					},
+				},
+			},
+		},
 	}
 
 	ctx := context.Background()
@@ -0,0 +1,238 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// FreezeTimeLayout is the layout of the absolute start and end times of a freeze window.
+// The times are interpreted in the time zone of the window.
+const FreezeTimeLayout = "2006-01-02T15:04"
+
+const freezeDefaultTimezone = "UTC"
+
+type (
+	// DefFreeze holds the time windows during which all changes to the matching branches are forbidden.
+	DefFreeze struct {
+		Windows []FreezeWindow `json:"windows,omitempty"`
+	}
+
+	// FreezeWindow is a single freeze period. It is either recurring, defined with a cron expression
+	// that marks the start of the window and a duration, or absolute, defined with a start and an end time.
+	FreezeWindow struct {
+		Cron     string `json:"cron,omitempty"`
+		Duration string `json:"duration,omitempty"`
+		Start    string `json:"start,omitempty"`
+		End      string `json:"end,omitempty"`
+		Timezone string `json:"timezone,omitempty"`
+		Reason   string `json:"reason,omitempty"`
+	}
+)
+
+// ensures that the DefFreeze type implements Sanitizer, MergeVerifier and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefFreeze)(nil)
+	_ MergeVerifier     = (*DefFreeze)(nil)
+	_ RefChangeVerifier = (*DefFreeze)(nil)
+)
+
+const (
+	codeFreezeActive = "freeze.active"
+)
+
+func (v *DefFreeze) MergeVerify(
+	_ context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	if len(v.Windows) == 0 {
+		return MergeVerifyOutput{}, nil, nil
+	}
+
+	violations, err := v.verify(time.Now(), in.PullReq.TargetBranch)
+	if err != nil {
+		return MergeVerifyOutput{}, nil, err
+	}
+
+	return MergeVerifyOutput{}, violations, nil
+}
+
+func (v *DefFreeze) RequiredChecks(
+	_ context.Context,
+	_ RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+func (v *DefFreeze) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if len(v.Windows) == 0 {
+		return nil, nil
+	}
+
+	return v.verify(time.Now(), in.RefNames[0])
+}
+
+func (v *DefFreeze) verify(now time.Time, branch string) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	for i := range v.Windows {
+		w := &v.Windows[i]
+
+		until, active, err := w.activeAt(now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate freeze window %d: %w", i, err)
+		}
+		if !active {
+			continue
+		}
+
+		if w.Reason != "" {
+			violations.Addf(codeFreezeActive,
+				"Branch %q is frozen until %s: %s", branch, until.Format(time.RFC3339), w.Reason)
+		} else {
+			violations.Addf(codeFreezeActive,
+				"Branch %q is frozen until %s.", branch, until.Format(time.RFC3339))
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefFreeze) Sanitize() error {
+	if len(v.Windows) > maxElements {
+		return errors.New("too many windows provided")
+	}
+
+	for i := range v.Windows {
+		if err := v.Windows[i].Sanitize(); err != nil {
+			return fmt.Errorf("window %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// activeAt returns true if the window is active at the provided time.
+// If it is, it also returns the time when the window ends.
+func (w *FreezeWindow) activeAt(now time.Time) (time.Time, bool, error) {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	now = now.In(loc)
+
+	if w.Cron != "" {
+		exp, err := cronexpr.Parse(w.Cron)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid cron expression: %w", err)
+		}
+
+		dur, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid duration: %w", err)
+		}
+
+		// The earliest window start after (now - duration) is the only candidate for a window
+		// that covers the provided time. If that start is already in the past, the window is active.
+		start := exp.Next(now.Add(-dur))
+		if start.IsZero() || start.After(now) {
+			return time.Time{}, false, nil
+		}
+
+		return start.Add(dur), true, nil
+	}
+
+	start, err := time.ParseInLocation(FreezeTimeLayout, w.Start, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	end, err := time.ParseInLocation(FreezeTimeLayout, w.End, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	if now.Before(start) || !now.Before(end) {
+		return time.Time{}, false, nil
+	}
+
+	return end, true, nil
+}
+
+func (w *FreezeWindow) Sanitize() error {
+	if w.Timezone == "" {
+		w.Timezone = freezeDefaultTimezone
+	}
+
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q", w.Timezone)
+	}
+
+	isRecurring := w.Cron != "" || w.Duration != ""
+	isAbsolute := w.Start != "" || w.End != ""
+
+	switch {
+	case isRecurring && isAbsolute:
+		return errors.New("window must be either recurring (cron and duration) or absolute (start and end)")
+
+	case isRecurring:
+		if _, err := cronexpr.Parse(w.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression %q", w.Cron)
+		}
+
+		dur, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", w.Duration)
+		}
+		if dur <= 0 {
+			return errors.New("duration must be positive")
+		}
+
+		w.Duration = dur.String()
+
+	case isAbsolute:
+		start, err := time.ParseInLocation(FreezeTimeLayout, w.Start, loc)
+		if err != nil {
+			return fmt.Errorf("invalid start time %q, expected format is %s", w.Start, FreezeTimeLayout)
+		}
+
+		end, err := time.ParseInLocation(FreezeTimeLayout, w.End, loc)
+		if err != nil {
+			return fmt.Errorf("invalid end time %q, expected format is %s", w.End, FreezeTimeLayout)
+		}
+
+		if !end.After(start) {
+			return errors.New("end time must be after the start time")
+		}
+
+	default:
+		return errors.New("window must define either cron and duration or start and end")
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefFreeze_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		window FreezeWindow
+		expErr bool
+		expTZ  string
+	}{
+		{
+			name:   "recurring",
+			window: FreezeWindow{Cron: "0 18 * * 5", Duration: "62h"},
+			expTZ:  "UTC",
+		},
+		{
+			name:   "absolute",
+			window: FreezeWindow{Start: "2023-12-20T00:00", End: "2024-01-02T00:00", Timezone: "Europe/Berlin"},
+			expTZ:  "Europe/Berlin",
+		},
+		{
+			name:   "empty",
+			window: FreezeWindow{},
+			expErr: true,
+		},
+		{
+			name:   "mixed",
+			window: FreezeWindow{Cron: "0 18 * * 5", Duration: "62h", Start: "2023-12-20T00:00"},
+			expErr: true,
+		},
+		{
+			name:   "invalid-cron",
+			window: FreezeWindow{Cron: "every friday", Duration: "1h"},
+			expErr: true,
+		},
+		{
+			name:   "negative-duration",
+			window: FreezeWindow{Cron: "0 18 * * 5", Duration: "-1h"},
+			expErr: true,
+		},
+		{
+			name:   "end-before-start",
+			window: FreezeWindow{Start: "2024-01-02T00:00", End: "2023-12-20T00:00"},
+			expErr: true,
+		},
+		{
+			name:   "invalid-timezone",
+			window: FreezeWindow{Start: "2023-12-20T00:00", End: "2024-01-02T00:00", Timezone: "Mars/Olympus"},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def := DefFreeze{Windows: []FreezeWindow{test.window}}
+
+			err := def.Sanitize()
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := test.expTZ, def.Windows[0].Timezone; want != got {
+				t.Errorf("timezone mismatch: want=%s got=%s", want, got)
+			}
+		})
+	}
+}
+
+func TestDefFreeze_verify(t *testing.T) {
+	const branch = "main"
+
+	// Friday, 2023-12-22 20:00 UTC
+	now := time.Date(2023, 12, 22, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		def       DefFreeze
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "recurring-active",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Cron: "0 18 * * 5", Duration: "62h"},
+			}},
+			expCodes:  []string{codeFreezeActive},
+			expParams: [][]any{{branch, "2023-12-25T08:00:00Z"}},
+		},
+		{
+			name: "recurring-inactive",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Cron: "0 18 * * 5", Duration: "1h"},
+			}},
+		},
+		{
+			name: "recurring-timezone",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Cron: "0 20 * * 5", Duration: "1h", Timezone: "America/New_York"},
+			}},
+		},
+		{
+			name: "absolute-active",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: "2023-12-20T00:00", End: "2024-01-02T00:00", Reason: "holidays"},
+			}},
+			expCodes:  []string{codeFreezeActive},
+			expParams: [][]any{{branch, "2024-01-02T00:00:00Z", "holidays"}},
+		},
+		{
+			name: "absolute-timezone",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: "2023-12-22T21:00", End: "2023-12-23T00:00", Timezone: "Europe/Berlin"},
+			}},
+			expCodes:  []string{codeFreezeActive},
+			expParams: [][]any{{branch, "2023-12-23T00:00:00+01:00"}},
+		},
+		{
+			name: "absolute-ended",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: "2023-12-01T00:00", End: "2023-12-22T20:00"},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.verify(now, branch)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
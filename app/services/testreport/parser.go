@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxMessageLength is the maximum length of the failure message of a test case.
+	maxMessageLength = 1 << 10 // 1 KB
+
+	// maxDetailsLength is the maximum length of the failure details (e.g. stack trace) of a test case.
+	maxDetailsLength = 16 << 10 // 16 KB
+)
+
+var errUnknownFormat = errors.New("unknown test report format, expected JUnit or xUnit.net XML")
+
+// junitSuite is a JUnit <testsuite> element. Some tools nest test suites, hence the recursion.
+type junitSuite struct {
+	Name      string       `xml:"name,attr"`
+	Suites    []junitSuite `xml:"testsuite"`
+	TestCases []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *junitFailure `xml:"skipped"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// xunitAssembly is a xUnit.net v2 <assembly> element.
+type xunitAssembly struct {
+	Name        string            `xml:"name,attr"`
+	Collections []xunitCollection `xml:"collection"`
+}
+
+type xunitCollection struct {
+	Name  string      `xml:"name,attr"`
+	Tests []xunitTest `xml:"test"`
+}
+
+type xunitTest struct {
+	Name    string        `xml:"name,attr"`
+	Type    string        `xml:"type,attr"`
+	Method  string        `xml:"method,attr"`
+	Time    string        `xml:"time,attr"`
+	Result  string        `xml:"result,attr"`
+	Failure *xunitFailure `xml:"failure"`
+	Reason  string        `xml:"reason"`
+}
+
+type xunitFailure struct {
+	ExceptionType string `xml:"exception-type,attr"`
+	Message       string `xml:"message"`
+	StackTrace    string `xml:"stack-trace"`
+}
+
+// Parse parses a JUnit or xUnit.net (v2) XML test report. The format is detected by the root element.
+func Parse(r io.Reader) (enum.TestReportFormat, []*types.TestCase, error) {
+	decoder := xml.NewDecoder(r)
+
+	var root xml.StartElement
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return "", nil, errors.New("test report is empty")
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read test report: %w", err)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			root = start
+			break
+		}
+	}
+
+	switch root.Name.Local {
+	case "testsuites":
+		var suites struct {
+			Suites []junitSuite `xml:"testsuite"`
+		}
+		if err := decoder.DecodeElement(&suites, &root); err != nil {
+			return "", nil, fmt.Errorf("failed to decode JUnit test report: %w", err)
+		}
+		return enum.TestReportFormatJUnit, junitTestCases(suites.Suites), nil
+
+	case "testsuite":
+		var suite junitSuite
+		if err := decoder.DecodeElement(&suite, &root); err != nil {
+			return "", nil, fmt.Errorf("failed to decode JUnit test report: %w", err)
+		}
+		return enum.TestReportFormatJUnit, junitTestCases([]junitSuite{suite}), nil
+
+	case "assemblies":
+		var assemblies struct {
+			Assemblies []xunitAssembly `xml:"assembly"`
+		}
+		if err := decoder.DecodeElement(&assemblies, &root); err != nil {
+			return "", nil, fmt.Errorf("failed to decode xUnit test report: %w", err)
+		}
+		return enum.TestReportFormatXUnit, xunitTestCases(assemblies.Assemblies), nil
+
+	case "assembly":
+		var assembly xunitAssembly
+		if err := decoder.DecodeElement(&assembly, &root); err != nil {
+			return "", nil, fmt.Errorf("failed to decode xUnit test report: %w", err)
+		}
+		return enum.TestReportFormatXUnit, xunitTestCases([]xunitAssembly{assembly}), nil
+
+	default:
+		return "", nil, errUnknownFormat
+	}
+}
+
+func junitTestCases(suites []junitSuite) []*types.TestCase {
+	var testCases []*types.TestCase
+
+	for _, suite := range suites {
+		for _, tc := range suite.TestCases {
+			testCase := &types.TestCase{
+				Suite:     suite.Name,
+				ClassName: tc.ClassName,
+				Name:      tc.Name,
+				Status:    enum.TestStatusPassed,
+				Duration:  parseSeconds(tc.Time),
+			}
+
+			// a test case with both, a failure and an error, is reported as failed.
+			switch {
+			case tc.Failure != nil:
+				testCase.Status = enum.TestStatusFailed
+				setFailure(testCase, tc.Failure.Message, tc.Failure.Type, tc.Failure.Text)
+			case tc.Error != nil:
+				testCase.Status = enum.TestStatusError
+				setFailure(testCase, tc.Error.Message, tc.Error.Type, tc.Error.Text)
+			case tc.Skipped != nil:
+				testCase.Status = enum.TestStatusSkipped
+				setFailure(testCase, tc.Skipped.Message, "", tc.Skipped.Text)
+			}
+
+			testCases = append(testCases, testCase)
+		}
+
+		testCases = append(testCases, junitTestCases(suite.Suites)...)
+	}
+
+	return testCases
+}
+
+func xunitTestCases(assemblies []xunitAssembly) []*types.TestCase {
+	var testCases []*types.TestCase
+
+	for _, assembly := range assemblies {
+		for _, collection := range assembly.Collections {
+			suite := collection.Name
+			if suite == "" {
+				suite = assembly.Name
+			}
+
+			for _, test := range collection.Tests {
+				name := test.Method
+				if name == "" {
+					name = test.Name
+				}
+
+				testCase := &types.TestCase{
+					Suite:     suite,
+					ClassName: test.Type,
+					Name:      name,
+					Duration:  parseSeconds(test.Time),
+				}
+
+				switch test.Result {
+				case "Pass":
+					testCase.Status = enum.TestStatusPassed
+				case "Fail":
+					testCase.Status = enum.TestStatusFailed
+					if test.Failure != nil {
+						setFailure(testCase, test.Failure.Message, test.Failure.ExceptionType, test.Failure.StackTrace)
+					}
+				default: // "Skip" and "NotRun"
+					testCase.Status = enum.TestStatusSkipped
+					setFailure(testCase, test.Reason, "", "")
+				}
+
+				testCases = append(testCases, testCase)
+			}
+		}
+	}
+
+	return testCases
+}
+
+// setFailure sets the failure message and details of the test case.
+// The type of the failure (e.g. the exception) is used as message if no message was provided.
+func setFailure(testCase *types.TestCase, message, failureType, details string) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = strings.TrimSpace(failureType)
+	}
+
+	testCase.Message = truncate(message, maxMessageLength)
+	testCase.Details = truncate(strings.TrimSpace(details), maxDetailsLength)
+}
+
+// parseSeconds converts the time attribute (in seconds) of a test case to milliseconds.
+// Some tools format the value with thousands separators, invalid values are ignored.
+func parseSeconds(s string) int64 {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) {
+		return 0
+	}
+
+	return int64(math.Round(seconds * 1000))
+}
+
+// truncate cuts the string to at most maxLength bytes without splitting a multi-byte character.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut]
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		report     string
+		wantFormat enum.TestReportFormat
+		want       []*types.TestCase
+		wantErr    bool
+	}{
+		{
+			name: "junit testsuites",
+			report: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pkg" time="1.5">
+    <testcase name="TestOK" classname="pkg.A" time="0.25"></testcase>
+    <testcase name="TestFail" classname="pkg.A" time="1,000.5">
+      <failure message="expected 1, got 2" type="assert">a_test.go:10</failure>
+    </testcase>
+    <testcase name="TestErr" classname="pkg.A"><error type="panic">stack</error></testcase>
+    <testcase name="TestSkip" classname="pkg.A"><skipped message="flaky"/></testcase>
+    <testsuite name="nested">
+      <testcase name="TestNested" classname="pkg.B" time="x"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+			wantFormat: enum.TestReportFormatJUnit,
+			want: []*types.TestCase{
+				{Suite: "pkg", ClassName: "pkg.A", Name: "TestOK", Status: enum.TestStatusPassed, Duration: 250},
+				{Suite: "pkg", ClassName: "pkg.A", Name: "TestFail", Status: enum.TestStatusFailed, Duration: 1000500,
+					Message: "expected 1, got 2", Details: "a_test.go:10"},
+				{Suite: "pkg", ClassName: "pkg.A", Name: "TestErr", Status: enum.TestStatusError,
+					Message: "panic", Details: "stack"},
+				{Suite: "pkg", ClassName: "pkg.A", Name: "TestSkip", Status: enum.TestStatusSkipped,
+					Message: "flaky"},
+				{Suite: "nested", ClassName: "pkg.B", Name: "TestNested", Status: enum.TestStatusPassed},
+			},
+		},
+		{
+			name:       "junit single testsuite",
+			report:     `<testsuite name="s"><testcase name="t" classname="c" time="2"/></testsuite>`,
+			wantFormat: enum.TestReportFormatJUnit,
+			want: []*types.TestCase{
+				{Suite: "s", ClassName: "c", Name: "t", Status: enum.TestStatusPassed, Duration: 2000},
+			},
+		},
+		{
+			name: "xunit",
+			report: `<assemblies>
+  <assembly name="Tests.dll">
+    <collection name="Collection A">
+      <test name="Ns.C.Ok" type="Ns.C" method="Ok" time="0.001" result="Pass"/>
+      <test name="Ns.C.Fail" type="Ns.C" method="Fail" time="0.5" result="Fail">
+        <failure exception-type="Xunit.Sdk.EqualException">
+          <message>Assert.Equal() Failure</message>
+          <stack-trace>at Ns.C.Fail()</stack-trace>
+        </failure>
+      </test>
+      <test name="Ns.C.Skip" type="Ns.C" method="Skip" time="0" result="Skip"><reason>todo</reason></test>
+    </collection>
+  </assembly>
+</assemblies>`,
+			wantFormat: enum.TestReportFormatXUnit,
+			want: []*types.TestCase{
+				{Suite: "Collection A", ClassName: "Ns.C", Name: "Ok", Status: enum.TestStatusPassed, Duration: 1},
+				{Suite: "Collection A", ClassName: "Ns.C", Name: "Fail", Status: enum.TestStatusFailed, Duration: 500,
+					Message: "Assert.Equal() Failure", Details: "at Ns.C.Fail()"},
+				{Suite: "Collection A", ClassName: "Ns.C", Name: "Skip", Status: enum.TestStatusSkipped,
+					Message: "todo"},
+			},
+		},
+		{
+			name:    "unknown root element",
+			report:  `<coverage/>`,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			report:  ``,
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			report:  `<testsuites><testsuite>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, got, err := Parse(strings.NewReader(tt.report))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if format != tt.wantFormat {
+				t.Errorf("Parse() format = %v, want %v", format, tt.wantFormat)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				for i := range got {
+					t.Logf("got[%d] = %+v", i, got[i])
+				}
+				t.Errorf("Parse() returned unexpected test cases")
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("abc", 5); got != "abc" {
+		t.Errorf("truncate() = %q, want %q", got, "abc")
+	}
+	// "ä" is encoded as two bytes and mustn't be split.
+	if got := truncate("aäb", 2); got != "a" {
+		t.Errorf("truncate() = %q, want %q", got, "a")
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// MaxReportSize is the maximum size of an uploaded test report.
+	MaxReportSize = 32 << 20 // 32 MB
+
+	// maxTestCases is the maximum number of test cases of a single test report.
+	maxTestCases = 50000
+
+	// flakyTestWindow is the time span in which test reports are considered for the detection of flaky tests.
+	flakyTestWindow = 14 * 24 * time.Hour
+
+	// maxFlakyTests is the maximum number of flaky tests that are considered for a pull request summary.
+	maxFlakyTests = 1000
+
+	// maxFailures is the maximum number of failing tests per commit that are considered for a pull request summary.
+	maxFailures = 1000
+)
+
+// Service ingests test reports and summarizes them.
+type Service struct {
+	tx              dbtx.Transactor
+	testReportStore store.TestReportStore
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	testReportStore store.TestReportStore,
+) *Service {
+	return &Service{
+		tx:              tx,
+		testReportStore: testReportStore,
+	}
+}
+
+// Ingest parses the JUnit or xUnit.net XML test report and stores it with all its test cases.
+// The repo, commit, check identifier, branch and creator of the report have to be set by the caller.
+func (s *Service) Ingest(ctx context.Context, report *types.TestReport, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, MaxReportSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read test report: %w", err)
+	}
+	if len(data) > MaxReportSize {
+		return usererror.BadRequestf("Test report can't be larger than %d bytes.", MaxReportSize)
+	}
+
+	format, testCases, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return usererror.BadRequestf("Invalid test report: %s.", err)
+	}
+
+	if len(testCases) > maxTestCases {
+		return usererror.BadRequestf("Test report can't contain more than %d test cases.", maxTestCases)
+	}
+
+	report.Format = format
+	report.Created = time.Now().UnixMilli()
+	report.TestCounts = types.TestCounts{}
+	for _, testCase := range testCases {
+		report.Add(testCase)
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.testReportStore.Create(ctx, report); err != nil {
+			return fmt.Errorf("failed to create test report: %w", err)
+		}
+
+		for _, testCase := range testCases {
+			testCase.ReportID = report.ID
+			if err := s.testReportStore.CreateCase(ctx, testCase); err != nil {
+				return fmt.Errorf("failed to create test case: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PullReqSummary summarizes the test reports of the pull request's source commit.
+// Failing tests are compared with the latest report of the same check on the target branch
+// and matched against the tests that recently were flaky in the repository.
+func (s *Service) PullReqSummary(
+	ctx context.Context,
+	repoID int64,
+	pr *types.PullReq,
+) (types.PullReqTestSummary, error) {
+	summary := types.PullReqTestSummary{
+		CommitSHA:   pr.SourceSHA,
+		Checks:      []types.TestSummary{},
+		NewFailures: []*types.TestCase{},
+		Flaky:       []types.FlakyTest{},
+	}
+
+	var err error
+
+	summary.Checks, err = s.testReportStore.Summarize(ctx, repoID, pr.SourceSHA)
+	if err != nil {
+		return types.PullReqTestSummary{}, fmt.Errorf("failed to summarize test reports: %w", err)
+	}
+
+	failures, err := s.listFailures(ctx, repoID, pr.SourceSHA, "")
+	if err != nil {
+		return types.PullReqTestSummary{}, err
+	}
+	if len(failures) == 0 {
+		return summary, nil
+	}
+
+	// failures of the target branch, per check identifier.
+	targetFailures := map[string]map[testKey]struct{}{}
+
+	for _, failure := range failures {
+		checkFailures, ok := targetFailures[failure.CheckIdentifier]
+		if !ok {
+			checkFailures, err = s.listTargetFailures(ctx, repoID, pr.TargetBranch, failure.CheckIdentifier)
+			if err != nil {
+				return types.PullReqTestSummary{}, err
+			}
+			targetFailures[failure.CheckIdentifier] = checkFailures
+		}
+
+		if _, ok := checkFailures[keyOf(failure)]; !ok {
+			summary.NewFailures = append(summary.NewFailures, failure)
+		}
+	}
+
+	since := time.Now().Add(-flakyTestWindow).UnixMilli()
+	flakyTests, err := s.testReportStore.ListFlaky(ctx, repoID, since, maxFlakyTests)
+	if err != nil {
+		return types.PullReqTestSummary{}, fmt.Errorf("failed to list flaky tests: %w", err)
+	}
+
+	failing := make(map[testKey]struct{}, len(failures))
+	for _, failure := range failures {
+		failing[keyOf(failure)] = struct{}{}
+	}
+
+	for _, flaky := range flakyTests {
+		key := testKey{
+			checkIdentifier: flaky.CheckIdentifier,
+			suite:           flaky.Suite,
+			className:       flaky.ClassName,
+			name:            flaky.Name,
+		}
+		if _, ok := failing[key]; ok {
+			summary.Flaky = append(summary.Flaky, flaky)
+		}
+	}
+
+	return summary, nil
+}
+
+// testKey identifies a test across test reports.
+type testKey struct {
+	checkIdentifier string
+	suite           string
+	className       string
+	name            string
+}
+
+func keyOf(testCase *types.TestCase) testKey {
+	return testKey{
+		checkIdentifier: testCase.CheckIdentifier,
+		suite:           testCase.Suite,
+		className:       testCase.ClassName,
+		name:            testCase.Name,
+	}
+}
+
+// listFailures lists the failed tests of the commit, each test only once even if it failed in multiple reports.
+func (s *Service) listFailures(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	checkIdentifier string,
+) ([]*types.TestCase, error) {
+	testCases, err := s.testReportStore.ListCases(ctx, repoID, commitSHA, types.TestCaseListOptions{
+		Pagination:      types.Pagination{Page: 1, Size: maxFailures},
+		CheckIdentifier: checkIdentifier,
+		Statuses:        []enum.TestStatus{enum.TestStatusFailed, enum.TestStatusError},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed test cases: %w", err)
+	}
+
+	seen := make(map[testKey]struct{}, len(testCases))
+	failures := make([]*types.TestCase, 0, len(testCases))
+	for _, testCase := range testCases {
+		key := keyOf(testCase)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		failures = append(failures, testCase)
+	}
+
+	return failures, nil
+}
+
+// listTargetFailures returns the failed tests of the latest test report of the check on the target branch.
+func (s *Service) listTargetFailures(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+	checkIdentifier string,
+) (map[testKey]struct{}, error) {
+	commitSHA, err := s.testReportStore.FindLatestCommitOnBranch(ctx, repoID, targetBranch, checkIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return map[testKey]struct{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest test report on target branch: %w", err)
+	}
+
+	failures, err := s.listFailures(ctx, repoID, commitSHA, checkIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[testKey]struct{}, len(failures))
+	for _, failure := range failures {
+		keys[keyOf(failure)] = struct{}{}
+	}
+
+	return keys, nil
+}
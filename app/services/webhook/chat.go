@@ -0,0 +1,324 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// chatMessage is the provider independent representation of a chat notification.
+type chatMessage struct {
+	Title string
+	Text  string
+	URL   string
+}
+
+// slackMessage is the payload of a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// teamsMessage is the payload of a Microsoft Teams incoming webhook (legacy actionable message card).
+type teamsMessage struct {
+	Type            string        `json:"@type"`
+	Context         string        `json:"@context"`
+	Summary         string        `json:"summary"`
+	Title           string        `json:"title"`
+	Text            string        `json:"text"`
+	PotentialAction []teamsAction `json:"potentialAction,omitempty"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+// The following accessors allow to detect the segments embedded in a payload.
+
+func (s BaseSegment) baseSegment() BaseSegment { return s }
+
+func (s ReferenceSegment) referenceSegment() ReferenceSegment { return s }
+
+func (s PullReqSegment) pullReqSegment() PullReqSegment { return s }
+
+func (s PullReqCommentSegment) pullReqCommentSegment() PullReqCommentSegment { return s }
+
+func (s PullReqReviewerSegment) pullReqReviewerSegment() PullReqReviewerSegment { return s }
+
+type withBaseSegment interface {
+	baseSegment() BaseSegment
+}
+
+type withReferenceSegment interface {
+	referenceSegment() ReferenceSegment
+}
+
+type withPullReqSegment interface {
+	pullReqSegment() PullReqSegment
+}
+
+type withPullReqCommentSegment interface {
+	pullReqCommentSegment() PullReqCommentSegment
+}
+
+type withPullReqReviewerSegment interface {
+	pullReqReviewerSegment() PullReqReviewerSegment
+}
+
+// chatFormatter renders the provider specific parts of a chat message.
+type chatFormatter struct {
+	// escape escapes user provided text.
+	escape func(string) string
+	// mention renders a principal, notifying the mapped chat user if there is one.
+	mention func(PrincipalInfo) string
+}
+
+func newChatFormatter(webhook *types.Webhook) chatFormatter {
+	escape := func(s string) string { return s }
+	if webhook.Type == enum.WebhookTypeSlack {
+		escape = escapeSlack
+	}
+
+	return chatFormatter{
+		escape: escape,
+		mention: func(principal PrincipalInfo) string {
+			handle, ok := webhook.ChatMentions[principal.UID]
+			switch {
+			case ok && webhook.Type == enum.WebhookTypeSlack:
+				return "<@" + handle + ">"
+			case ok:
+				return handle
+			default:
+				return escape(principal.DisplayName)
+			}
+		},
+	}
+}
+
+// chatMessageFor converts the webhook payload into the message format of the chat provider of the webhook.
+func (s *Service) chatMessageFor(webhook *types.Webhook, triggerType enum.WebhookTrigger, body any) any {
+	msg := s.buildChatMessage(triggerType, body, newChatFormatter(webhook))
+
+	switch webhook.Type {
+	case enum.WebhookTypeSlack:
+		text := msg.Title
+		if msg.URL != "" {
+			text = "<" + msg.URL + "|" + text + ">"
+		}
+		if msg.Text != "" {
+			text += "\n" + msg.Text
+		}
+		return &slackMessage{Text: text}
+	case enum.WebhookTypeMSTeams:
+		card := &teamsMessage{
+			Type:    "MessageCard",
+			Context: "https://schema.org/extensions",
+			Summary: msg.Title,
+			Title:   msg.Title,
+			Text:    msg.Text,
+		}
+		if msg.URL != "" {
+			card.PotentialAction = []teamsAction{{
+				Type:    "OpenUri",
+				Name:    "View",
+				Targets: []teamsTarget{{OS: "default", URI: msg.URL}},
+			}}
+		}
+		return card
+	case enum.WebhookTypeGeneric:
+		return body
+	}
+
+	return body
+}
+
+// buildChatMessage builds the chat message for the provided payload.
+//
+//nolint:gocognit,cyclop,funlen // it's a simple mapping of triggers to messages.
+func (s *Service) buildChatMessage(
+	triggerType enum.WebhookTrigger,
+	body any,
+	f chatFormatter,
+) chatMessage {
+	var (
+		base          BaseSegment
+		ref           ReferenceInfo
+		pr            PullReqInfo
+		comment       CommentInfo
+		reviewer      PrincipalInfo
+		decision      enum.PullReqReviewDecision
+		commentStatus enum.PullReqCommentStatus
+		check         CheckInfo
+		execution     ExecutionInfo
+	)
+	if v, ok := body.(withBaseSegment); ok {
+		base = v.baseSegment()
+	}
+	if v, ok := body.(withReferenceSegment); ok {
+		ref = v.referenceSegment().Ref
+	}
+	if v, ok := body.(withPullReqSegment); ok {
+		pr = v.pullReqSegment().PullReq
+	}
+	if v, ok := body.(withPullReqCommentSegment); ok {
+		comment = v.pullReqCommentSegment().CommentInfo
+	}
+	if v, ok := body.(withPullReqReviewerSegment); ok {
+		reviewer = v.pullReqReviewerSegment().Reviewer
+	}
+	switch v := body.(type) {
+	case *PullReqReviewSubmittedPayload:
+		decision = v.Decision
+	case *PullReqCommentStatusPayload:
+		commentStatus = v.Status
+	case *CheckPayload:
+		check = v.Check
+	case *ExecutionPayload:
+		execution = v.Execution
+	}
+
+	repoPath := f.escape(base.Repo.Path)
+	actor := f.mention(base.Principal)
+	branch := f.escape(strings.TrimPrefix(ref.Name, "refs/heads/"))
+	tag := f.escape(strings.TrimPrefix(ref.Name, "refs/tags/"))
+	prTitle := fmt.Sprintf("[%s] Pull request #%d: %s", repoPath, pr.Number, f.escape(pr.Title))
+
+	msg := chatMessage{
+		URL: s.urlProvider.GenerateUIRepoURL(base.Repo.Path),
+	}
+
+	switch triggerType {
+	case enum.WebhookTriggerBranchCreated:
+		msg.Title = fmt.Sprintf("[%s] Branch %s created", repoPath, branch)
+		msg.Text = fmt.Sprintf("%s created branch %s.", actor, branch)
+	case enum.WebhookTriggerBranchUpdated:
+		msg.Title = fmt.Sprintf("[%s] Branch %s updated", repoPath, branch)
+		msg.Text = fmt.Sprintf("%s pushed to branch %s.", actor, branch)
+	case enum.WebhookTriggerBranchDeleted:
+		msg.Title = fmt.Sprintf("[%s] Branch %s deleted", repoPath, branch)
+		msg.Text = fmt.Sprintf("%s deleted branch %s.", actor, branch)
+	case enum.WebhookTriggerTagCreated:
+		msg.Title = fmt.Sprintf("[%s] Tag %s created", repoPath, tag)
+		msg.Text = fmt.Sprintf("%s created tag %s.", actor, tag)
+	case enum.WebhookTriggerTagUpdated:
+		msg.Title = fmt.Sprintf("[%s] Tag %s updated", repoPath, tag)
+		msg.Text = fmt.Sprintf("%s updated tag %s.", actor, tag)
+	case enum.WebhookTriggerTagDeleted:
+		msg.Title = fmt.Sprintf("[%s] Tag %s deleted", repoPath, tag)
+		msg.Text = fmt.Sprintf("%s deleted tag %s.", actor, tag)
+	case enum.WebhookTriggerPullReqCreated:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s opened a pull request from %s into %s.",
+			actor, f.escape(pr.SourceBranch), f.escape(pr.TargetBranch))
+	case enum.WebhookTriggerPullReqReopened:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s reopened the pull request.", actor)
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s pushed new commits to %s.", actor, f.escape(pr.SourceBranch))
+	case enum.WebhookTriggerPullReqClosed:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s closed the pull request.", actor)
+	case enum.WebhookTriggerPullReqMerged:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s merged the pull request into %s.", actor, f.escape(pr.TargetBranch))
+	case enum.WebhookTriggerPullReqCommentCreated:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s commented:\n> %s", actor,
+			strings.ReplaceAll(f.escape(comment.Text), "\n", "\n> "))
+	case enum.WebhookTriggerPullReqCommentUpdated:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s edited a comment:\n> %s", actor,
+			strings.ReplaceAll(f.escape(comment.Text), "\n", "\n> "))
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		if commentStatus == enum.PullReqCommentStatusResolved {
+			msg.Text = fmt.Sprintf("%s resolved a comment.", actor)
+		} else {
+			msg.Text = fmt.Sprintf("%s reactivated a comment.", actor)
+		}
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		switch decision {
+		case enum.PullReqReviewDecisionApproved:
+			msg.Text = fmt.Sprintf("%s approved the pull request.", f.mention(reviewer))
+		case enum.PullReqReviewDecisionChangeReq:
+			msg.Text = fmt.Sprintf("%s requested changes.", f.mention(reviewer))
+		case enum.PullReqReviewDecisionPending, enum.PullReqReviewDecisionReviewed:
+			msg.Text = fmt.Sprintf("%s reviewed the pull request.", f.mention(reviewer))
+		}
+	case enum.WebhookTriggerPullReqReviewerAdded:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s requested a review from %s.", actor, f.mention(reviewer))
+	case enum.WebhookTriggerPullReqReviewerRemoved:
+		msg.Title, msg.URL = prTitle, pr.PrURL
+		msg.Text = fmt.Sprintf("%s removed %s from the reviewers.", actor, f.mention(reviewer))
+	case enum.WebhookTriggerCheckStatusUpdated:
+		msg.Title = fmt.Sprintf("[%s] Check %s is %s", repoPath, f.escape(check.Identifier), check.Status)
+		msg.Text = fmt.Sprintf("%s reported %s for commit %s.",
+			actor, check.Status, shortSHA(check.CommitSHA))
+		if check.Summary != "" {
+			msg.Text += "\n" + f.escape(check.Summary)
+		}
+		if check.Link != "" {
+			msg.URL = check.Link
+		}
+	case enum.WebhookTriggerExecutionCompleted:
+		msg.Title = fmt.Sprintf("[%s] Pipeline %s #%d: %s",
+			repoPath, f.escape(execution.PipelineIdentifier), execution.Number, execution.Status)
+		msg.Text = fmt.Sprintf("Execution #%d triggered by %s completed with status %s.",
+			execution.Number, actor, execution.Status)
+		if execution.Error != "" {
+			msg.Text += "\n" + f.escape(execution.Error)
+		}
+		msg.URL = execution.URL
+	case enum.WebhookTriggerRepoCreated:
+		msg.Title = fmt.Sprintf("[%s] Repository created", repoPath)
+		msg.Text = fmt.Sprintf("%s created the repository.", actor)
+	case enum.WebhookTriggerRepoDeleted:
+		// the repository can't be viewed anymore
+		msg.Title, msg.URL = fmt.Sprintf("[%s] Repository deleted", repoPath), ""
+		msg.Text = fmt.Sprintf("%s deleted the repository.", actor)
+	default:
+		msg.Title = fmt.Sprintf("[%s] %s", repoPath, triggerType)
+		msg.Text = fmt.Sprintf("%s triggered %s.", actor, triggerType)
+	}
+
+	return msg
+}
+
+// shortSHA returns the abbreviated form of the commit SHA.
+func shortSHA(sha string) string {
+	const shortSHALength = 7
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+// escapeSlack escapes the control characters of the Slack message format.
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
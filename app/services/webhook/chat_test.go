@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestService_ChatMessageFor(t *testing.T) {
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://gitness:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+	s := &Service{urlProvider: urlProvider}
+
+	body := &PullReqCommentPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPullReqCommentCreated,
+			Repo:      RepositoryInfo{Path: "space/repo"},
+			Principal: PrincipalInfo{UID: "jdoe", DisplayName: "John Doe"},
+		},
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: "Fix <bug>", PrURL: "http://localhost:3000/pr/7"},
+		},
+		PullReqCommentSegment: PullReqCommentSegment{
+			CommentInfo: CommentInfo{Text: "a & b"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		webhook *types.Webhook
+		want    any
+	}{
+		{
+			name:    "generic",
+			webhook: &types.Webhook{Type: enum.WebhookTypeGeneric},
+			want:    body,
+		},
+		{
+			name: "slack with mention",
+			webhook: &types.Webhook{
+				Type:         enum.WebhookTypeSlack,
+				ChatMentions: map[string]string{"jdoe": "U123"},
+			},
+			want: &slackMessage{
+				Text: "<http://localhost:3000/pr/7|[space/repo] Pull request #7: Fix &lt;bug&gt;>\n" +
+					"<@U123> commented:\n> a &amp; b",
+			},
+		},
+		{
+			name:    "teams without mention",
+			webhook: &types.Webhook{Type: enum.WebhookTypeMSTeams},
+			want: &teamsMessage{
+				Type:    "MessageCard",
+				Context: "https://schema.org/extensions",
+				Summary: "[space/repo] Pull request #7: Fix <bug>",
+				Title:   "[space/repo] Pull request #7: Fix <bug>",
+				Text:    "John Doe commented:\n> a & b",
+				PotentialAction: []teamsAction{{
+					Type:    "OpenUri",
+					Name:    "View",
+					Targets: []teamsTarget{{OS: "default", URI: "http://localhost:3000/pr/7"}},
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.chatMessageFor(test.webhook, enum.WebhookTriggerPullReqCommentCreated, body)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestService_ChatMessageFor_Reviewer(t *testing.T) {
+	urlProvider, err := url.NewProvider("http://localhost:3000", "http://gitness:3000",
+		"http://localhost:3000/api", "http://localhost:3000/git", "http://localhost:3000")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %v", err)
+	}
+	s := &Service{urlProvider: urlProvider}
+
+	body := &PullReqReviewerPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPullReqReviewerAdded,
+			Repo:      RepositoryInfo{Path: "space/repo"},
+			Principal: PrincipalInfo{UID: "jdoe", DisplayName: "John Doe"},
+		},
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{Number: 7, Title: "Fix", PrURL: "http://localhost:3000/pr/7"},
+		},
+		PullReqReviewerSegment: PullReqReviewerSegment{
+			Reviewer: PrincipalInfo{UID: "asmith", DisplayName: "Anna Smith"},
+		},
+	}
+	webhook := &types.Webhook{
+		Type:         enum.WebhookTypeSlack,
+		ChatMentions: map[string]string{"asmith": "U456"},
+	}
+
+	got := s.chatMessageFor(webhook, enum.WebhookTriggerPullReqReviewerAdded, body)
+
+	want := &slackMessage{
+		Text: "<http://localhost:3000/pr/7|[space/repo] Pull request #7: Fix>\n" +
+			"John Doe requested a review from <@U456>.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
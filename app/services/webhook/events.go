@@ -55,7 +55,7 @@ func (s *Service) triggerForEventWithRepo(ctx context.Context,
 		return fmt.Errorf("body creation function failed: %w", err)
 	}
 
-	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
+	return s.triggerForEvent(ctx, eventID, repo, triggerType, body)
 }
 
 // triggerForEventWithPullReq triggers all webhooks for the given repo and triggerType
@@ -96,7 +96,7 @@ func (s *Service) triggerForEventWithPullReq(ctx context.Context,
 		return fmt.Errorf("body creation function failed: %w", err)
 	}
 
-	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, targetRepo.ID, triggerType, body)
+	return s.triggerForEvent(ctx, eventID, targetRepo, triggerType, body)
 }
 
 // findRepositoryForEvent finds the repository for the provided repoID.
@@ -147,21 +147,21 @@ func (s *Service) findPrincipalForEvent(ctx context.Context, principalID int64)
 	return principal, nil
 }
 
-// triggerForEvent triggers all webhooks for the given parentType/ID and triggerType
+// triggerForEvent triggers all webhooks for the given repo and triggerType
 // using the eventID to generate a deterministic triggerID and sending the provided body as payload.
 func (s *Service) triggerForEvent(ctx context.Context, eventID string,
-	parentType enum.WebhookParent, parentID int64, triggerType enum.WebhookTrigger, body any) error {
+	repo *types.Repository, triggerType enum.WebhookTrigger, body any) error {
 	triggerID := generateTriggerIDFromEventID(eventID)
 
-	results, err := s.triggerWebhooksFor(ctx, parentType, parentID, triggerID, triggerType, body)
+	results, err := s.triggerWebhooksFor(ctx, repo, triggerID, triggerType, body)
 
 	// return all errors and force the event to be reprocessed (it's not webhook execution specific!)
 	if err != nil {
-		return fmt.Errorf("failed to trigger %s (id: '%s') for webhooks of %s %d: %w",
-			triggerType, triggerID, parentType, parentID, err)
+		return fmt.Errorf("failed to trigger %s (id: '%s') for webhooks of repo %d: %w",
+			triggerType, triggerID, repo.ID, err)
 	}
 
-	// go through all events and figure out if we need to retry the event.
+	// go through all events and queue the executions that have to be retried.
 	// Combine all errors into a single error to log (to reduce number of logs)
 	retryRequired := false
 	var errs error
@@ -176,19 +176,25 @@ func (s *Service) triggerForEvent(ctx context.Context, eventID string,
 				result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
 		}
 
-		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		if result.Execution.Result != enum.WebhookExecutionResultRetriableError || s.config.RetryMaxAttempts == 0 {
+			continue
+		}
+
+		// retries are processed by the retry job with backoff - only fall back to reprocessing the event on failure.
+		if err = s.queueRetry(ctx, result.Execution); err != nil {
+			errs = multierr.Append(errs, err)
 			retryRequired = true
 		}
 	}
 
 	// in case there was at least one error, log error details in single log to reduce log flooding
 	if errs != nil {
-		log.Ctx(ctx).Warn().Err(errs).Msgf("webhook execution for %s %d had errors", parentType, parentID)
+		log.Ctx(ctx).Warn().Err(errs).Msgf("webhook execution for repo %d had errors", repo.ID)
 	}
 
-	// in case at least one webhook has to be retried, return an error to the event framework to have it reprocessed
+	// in case a retry couldn't be queued, return an error to the event framework to have it reprocessed
 	if retryRequired {
-		return fmt.Errorf("at least one webhook execution resulted in a retry for %s %d", parentType, parentID)
+		return fmt.Errorf("failed to queue the retry of at least one webhook execution for repo %d", repo.ID)
 	}
 
 	return nil
@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/antonmedv/expr"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// gitReferenceNamePrefixTag is the prefix of references of type tag.
+	gitReferenceNamePrefixTag = "refs/tags/"
+)
+
+// ValidatePayloadFilterExpression validates the syntax of a payload filter expression.
+func ValidatePayloadFilterExpression(expression string) error {
+	_, err := expr.Compile(expression, expr.AsBool())
+	return err
+}
+
+// payloadMatcher evaluates webhook payload filters against the payload of a single trigger.
+type payloadMatcher struct {
+	body any
+
+	// env is the JSON representation of the body, lazily created for expression evaluation.
+	env    map[string]any
+	envErr error
+}
+
+func newPayloadMatcher(body any) *payloadMatcher {
+	return &payloadMatcher{body: body}
+}
+
+// match checks whether the payload satisfies the provided filter.
+// In case it doesn't, the reason is returned.
+// NOTE: conditions only apply to payloads containing the respective information,
+// e.g. branch patterns are ignored for tag triggers.
+func (m *payloadMatcher) match(filter *types.WebhookPayloadFilter) (bool, string) {
+	if filter.IsEmpty() {
+		return true, ""
+	}
+
+	var refName, targetRefName string
+	if v, ok := m.body.(withReferenceSegment); ok {
+		refName = v.referenceSegment().Ref.Name
+	}
+	if v, ok := m.body.(withPullReqSegment); ok {
+		targetRefName = gitReferenceNamePrefixBranch + v.pullReqSegment().PullReq.TargetBranch
+	}
+
+	if branch, ok := strings.CutPrefix(refName, gitReferenceNamePrefixBranch); ok &&
+		!matchesAnyPattern(filter.Branches, branch) {
+		return false, fmt.Sprintf("branch %q doesn't match any of the branch patterns", branch)
+	}
+
+	if tag, ok := strings.CutPrefix(refName, gitReferenceNamePrefixTag); ok &&
+		!matchesAnyPattern(filter.Tags, tag) {
+		return false, fmt.Sprintf("tag %q doesn't match any of the tag patterns", tag)
+	}
+
+	if target, ok := strings.CutPrefix(targetRefName, gitReferenceNamePrefixBranch); ok &&
+		!matchesAnyPattern(filter.TargetBranches, target) {
+		return false, fmt.Sprintf("target branch %q doesn't match any of the target branch patterns", target)
+	}
+
+	if filter.Expression != "" {
+		matches, err := m.evaluate(filter.Expression)
+		if err != nil {
+			return false, fmt.Sprintf("failed to evaluate expression: %s", err)
+		}
+		if !matches {
+			return false, "payload doesn't satisfy the expression"
+		}
+	}
+
+	return true, ""
+}
+
+func (m *payloadMatcher) evaluate(expression string) (bool, error) {
+	if m.env == nil && m.envErr == nil {
+		m.env, m.envErr = payloadToEnv(m.body)
+	}
+	if m.envErr != nil {
+		return false, m.envErr
+	}
+
+	out, err := expr.Eval(expression, m.env)
+	if err != nil {
+		return false, err
+	}
+
+	matches, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %T instead of bool", out)
+	}
+
+	return matches, nil
+}
+
+// payloadToEnv converts the payload into the generic map the expressions are evaluated against.
+// This ensures the expressions use the same field names as the JSON payload.
+func payloadToEnv(body any) (map[string]any, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	env := map[string]any{}
+	if err = json.Unmarshal(raw, &env); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return env, nil
+}
+
+// matchesAnyPattern returns true if there are no patterns or if the name matches at least one of the patterns.
+func matchesAnyPattern(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
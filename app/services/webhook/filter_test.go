@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestPayloadMatcher_Match(t *testing.T) {
+	branchPayload := &ReferencePayload{
+		BaseSegment: BaseSegment{
+			Principal: PrincipalInfo{UID: "jdoe"},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/release/1.0"},
+		},
+	}
+	tagPayload := &ReferencePayload{
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/tags/v1.0"},
+		},
+	}
+	pullReqPayload := &PullReqCreatedPayload{
+		PullReqSegment: PullReqSegment{
+			PullReq: PullReqInfo{TargetBranch: "main", IsDraft: true},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/feature"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		body   any
+		filter *types.WebhookPayloadFilter
+		want   bool
+	}{
+		{
+			name:   "no filter",
+			body:   branchPayload,
+			filter: nil,
+			want:   true,
+		},
+		{
+			name:   "branch matches",
+			body:   branchPayload,
+			filter: &types.WebhookPayloadFilter{Branches: []string{"release/*"}},
+			want:   true,
+		},
+		{
+			name:   "branch doesn't match",
+			body:   branchPayload,
+			filter: &types.WebhookPayloadFilter{Branches: []string{"main"}},
+			want:   false,
+		},
+		{
+			name:   "branch patterns are ignored for tags",
+			body:   tagPayload,
+			filter: &types.WebhookPayloadFilter{Branches: []string{"main"}},
+			want:   true,
+		},
+		{
+			name:   "tag doesn't match",
+			body:   tagPayload,
+			filter: &types.WebhookPayloadFilter{Tags: []string{"v2.*"}},
+			want:   false,
+		},
+		{
+			name:   "target branch matches",
+			body:   pullReqPayload,
+			filter: &types.WebhookPayloadFilter{TargetBranches: []string{"main", "release/**"}},
+			want:   true,
+		},
+		{
+			name:   "target branch doesn't match",
+			body:   pullReqPayload,
+			filter: &types.WebhookPayloadFilter{TargetBranches: []string{"release/**"}},
+			want:   false,
+		},
+		{
+			name:   "expression matches",
+			body:   branchPayload,
+			filter: &types.WebhookPayloadFilter{Expression: `principal.uid == "jdoe"`},
+			want:   true,
+		},
+		{
+			name:   "expression doesn't match",
+			body:   pullReqPayload,
+			filter: &types.WebhookPayloadFilter{Expression: `pull_req.is_draft == false`},
+			want:   false,
+		},
+		{
+			name:   "expression with non bool result",
+			body:   branchPayload,
+			filter: &types.WebhookPayloadFilter{Expression: `principal.uid`},
+			want:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, reason := newPayloadMatcher(test.body).match(test.filter)
+			if got != test.want {
+				t.Errorf("want=%t got=%t (reason: %s)", test.want, got, reason)
+			}
+			if !got && reason == "" {
+				t.Error("expected a reason for the skipped payload")
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckInfo describes the status check related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CheckInfo struct {
+	Identifier string           `json:"identifier"`
+	CommitSHA  string           `json:"commit_sha"`
+	Status     enum.CheckStatus `json:"status"`
+	OldStatus  enum.CheckStatus `json:"old_status,omitempty"`
+	Summary    string           `json:"summary,omitempty"`
+	Link       string           `json:"link,omitempty"`
+}
+
+// CheckPayload describes the body of the check status updated trigger.
+type CheckPayload struct {
+	BaseSegment
+	Check CheckInfo `json:"check"`
+}
+
+// handleEventCheckStatusUpdated handles check status updated events
+// and triggers check status updated webhooks for the repo.
+func (s *Service) handleEventCheckStatusUpdated(ctx context.Context,
+	event *events.Event[*checkevents.StatusUpdatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckStatusUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &CheckPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckStatusUpdated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				Check: CheckInfo{
+					Identifier: event.Payload.Identifier,
+					CommitSHA:  event.Payload.CommitSHA,
+					Status:     event.Payload.Status,
+					OldStatus:  event.Payload.OldStatus,
+					Summary:    event.Payload.Summary,
+					Link:       event.Payload.Link,
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExecutionInfo describes the pipeline execution related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	Number             int64         `json:"number"`
+	PipelineIdentifier string        `json:"pipeline_identifier"`
+	Status             enum.CIStatus `json:"status"`
+	Error              string        `json:"error,omitempty"`
+	Event              string        `json:"event,omitempty"`
+	Ref                string        `json:"ref,omitempty"`
+	Source             string        `json:"source,omitempty"`
+	Target             string        `json:"target,omitempty"`
+	After              string        `json:"after,omitempty"`
+	Started            int64         `json:"started,omitempty"`
+	Finished           int64         `json:"finished,omitempty"`
+	URL                string        `json:"url"`
+}
+
+// ExecutionPayload describes the body of the execution completed trigger.
+type ExecutionPayload struct {
+	BaseSegment
+	Execution ExecutionInfo `json:"execution"`
+}
+
+// handleEventExecutionCompleted handles execution completed events
+// and triggers execution completed webhooks for the repo.
+func (s *Service) handleEventExecutionCompleted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutionCompletedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerExecutionCompleted,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			execution, err := s.executionStore.Find(ctx, event.Payload.ExecutionID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find execution %d: %w", event.Payload.ExecutionID, err)
+			}
+			pipeline, err := s.pipelineStore.Find(ctx, event.Payload.PipelineID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find pipeline %d: %w", event.Payload.PipelineID, err)
+			}
+
+			return &ExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerExecutionCompleted,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				Execution: ExecutionInfo{
+					Number:             execution.Number,
+					PipelineIdentifier: pipeline.Identifier,
+					// status of the event is the status at completion time
+					Status:   event.Payload.Status,
+					Error:    execution.Error,
+					Event:    execution.Event,
+					Ref:      execution.Ref,
+					Source:   execution.Source,
+					Target:   execution.Target,
+					After:    execution.After,
+					Started:  execution.Started,
+					Finished: execution.Finished,
+					URL:      s.urlProvider.GenerateUIBuildURL(repo.Path, pipeline.Identifier, execution.Number),
+				},
+			}, nil
+		})
+}
@@ -314,49 +314,104 @@ func (s *Service) handleEventPullReqComment(
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCommentCreated,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
-			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
-			activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get activity by id for acitivity id %d: %w", event.Payload.ActivityID, err)
-			}
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			return s.pullReqCommentPayloadFrom(ctx, enum.WebhookTriggerPullReqCommentCreated,
+				principal, pr, targetRepo, sourceRepo, event.Payload.ActivityID, event.Payload.SourceSHA)
+		})
+}
+
+// handleEventPullReqCommentUpdated handles updated events for pull request comments.
+func (s *Service) handleEventPullReqCommentUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentUpdatedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCommentUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			return s.pullReqCommentPayloadFrom(ctx, enum.WebhookTriggerPullReqCommentUpdated,
+				principal, pr, targetRepo, sourceRepo, event.Payload.ActivityID, event.Payload.SourceSHA)
+		})
+}
+
+// PullReqCommentStatusPayload describes the body of the pullreq comment status updated trigger.
+type PullReqCommentStatusPayload struct {
+	PullReqCommentPayload
+	Status enum.PullReqCommentStatus `json:"status"`
+}
+
+// handleEventPullReqCommentStatusUpdated handles status updated events for pull request comments.
+func (s *Service) handleEventPullReqCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCommentStatusUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			payload, err := s.pullReqCommentPayloadFrom(ctx, enum.WebhookTriggerPullReqCommentStatusUpdated,
+				principal, pr, targetRepo, sourceRepo, event.Payload.ActivityID, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
-			return &PullReqCommentPayload{
-				BaseSegment: BaseSegment{
-					Trigger:   enum.WebhookTriggerPullReqCommentCreated,
-					Repo:      targetRepoInfo,
-					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
-				},
-				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
-				},
-				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
-					TargetRef: ReferenceInfo{
-						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
-						Repo: targetRepoInfo,
-					},
-				},
-				ReferenceSegment: ReferenceSegment{
-					Ref: ReferenceInfo{
-						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
-						Repo: sourceRepoInfo,
-					},
-				},
-				ReferenceDetailsSegment: ReferenceDetailsSegment{
-					SHA:        event.Payload.SourceSHA,
-					Commit:     &commitInfo,
-					HeadCommit: &commitInfo,
-				},
-				PullReqCommentSegment: PullReqCommentSegment{
-					CommentInfo: CommentInfo{
-						Text:     activity.Text,
-						ID:       activity.ID,
-						ParentID: activity.ParentID,
-					},
-				},
+
+			return &PullReqCommentStatusPayload{
+				PullReqCommentPayload: *payload,
+				Status:                event.Payload.Status,
 			}, nil
 		})
 }
+
+// pullReqCommentPayloadFrom creates the payload for pull request comment related triggers.
+func (s *Service) pullReqCommentPayloadFrom(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	principal *types.Principal,
+	pr *types.PullReq,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	activityID int64,
+	sourceSHA string,
+) (*PullReqCommentPayload, error) {
+	targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+	sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+	activity, err := s.activityStore.Find(ctx, activityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity by id for acitivity id %d: %w", activityID, err)
+	}
+	commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, sourceSHA)
+	if err != nil {
+		return nil, err
+	}
+	return &PullReqCommentPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   triggerType,
+			Repo:      targetRepoInfo,
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+		PullReqSegment: PullReqSegment{
+			PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+		},
+		PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+			TargetRef: ReferenceInfo{
+				Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+				Repo: targetRepoInfo,
+			},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{
+				Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+				Repo: sourceRepoInfo,
+			},
+		},
+		ReferenceDetailsSegment: ReferenceDetailsSegment{
+			SHA:        sourceSHA,
+			Commit:     &commitInfo,
+			HeadCommit: &commitInfo,
+		},
+		PullReqCommentSegment: PullReqCommentSegment{
+			CommentInfo: CommentInfo{
+				Text:     activity.Text,
+				ID:       activity.ID,
+				ParentID: activity.ParentID,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PullReqReviewerSegment contains the reviewer for pull request review related payloads for webhooks.
+type PullReqReviewerSegment struct {
+	Reviewer PrincipalInfo `json:"reviewer"`
+}
+
+// PullReqReviewSubmittedPayload describes the body of the pullreq review submitted trigger.
+type PullReqReviewSubmittedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqReviewerSegment
+	Decision enum.PullReqReviewDecision `json:"decision"`
+}
+
+// handleEventPullReqReviewSubmitted handles review submitted events for pull requests
+// and triggers pullreq review submitted webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReviewSubmitted,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			reviewer, err := s.findPrincipalForEvent(ctx, event.Payload.ReviewerID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PullReqReviewSubmittedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
+					Repo:      repositoryInfoFrom(targetRepo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: s.pullReqTargetReferenceSegmentFrom(pr, targetRepo),
+				ReferenceSegment:              s.pullReqReferenceSegmentFrom(pr, sourceRepo),
+				PullReqReviewerSegment: PullReqReviewerSegment{
+					Reviewer: principalInfoFrom(reviewer.ToPrincipalInfo()),
+				},
+				Decision: event.Payload.Decision,
+			}, nil
+		})
+}
+
+// PullReqReviewerPayload describes the body of the pullreq reviewer added and removed triggers.
+type PullReqReviewerPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	PullReqReviewerSegment
+}
+
+// handleEventPullReqReviewerAdded handles reviewer added events for pull requests
+// and triggers pullreq reviewer added webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewerAdded(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload],
+) error {
+	return s.triggerForReviewerEvent(ctx, enum.WebhookTriggerPullReqReviewerAdded,
+		event.ID, event.Payload.Base, event.Payload.ReviewerID)
+}
+
+// handleEventPullReqReviewerRemoved handles reviewer removed events for pull requests
+// and triggers pullreq reviewer removed webhooks for the target repo.
+func (s *Service) handleEventPullReqReviewerRemoved(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerRemovedPayload],
+) error {
+	return s.triggerForReviewerEvent(ctx, enum.WebhookTriggerPullReqReviewerRemoved,
+		event.ID, event.Payload.Base, event.Payload.ReviewerID)
+}
+
+func (s *Service) triggerForReviewerEvent(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	base pullreqevents.Base,
+	reviewerID int64,
+) error {
+	return s.triggerForEventWithPullReq(ctx, triggerType,
+		eventID, base.PrincipalID, base.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			reviewer, err := s.findPrincipalForEvent(ctx, reviewerID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PullReqReviewerPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(targetRepo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: s.pullReqTargetReferenceSegmentFrom(pr, targetRepo),
+				ReferenceSegment:              s.pullReqReferenceSegmentFrom(pr, sourceRepo),
+				PullReqReviewerSegment: PullReqReviewerSegment{
+					Reviewer: principalInfoFrom(reviewer.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+func (s *Service) pullReqTargetReferenceSegmentFrom(
+	pr *types.PullReq,
+	targetRepo *types.Repository,
+) PullReqTargetReferenceSegment {
+	return PullReqTargetReferenceSegment{
+		TargetRef: ReferenceInfo{
+			Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+			Repo: repositoryInfoFrom(targetRepo, s.urlProvider),
+		},
+	}
+}
+
+func (s *Service) pullReqReferenceSegmentFrom(
+	pr *types.PullReq,
+	sourceRepo *types.Repository,
+) ReferenceSegment {
+	return ReferenceSegment{
+		Ref: ReferenceInfo{
+			Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+			Repo: repositoryInfoFrom(sourceRepo, s.urlProvider),
+		},
+	}
+}
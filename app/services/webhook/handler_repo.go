@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoPayload describes the body of the repo created and deleted triggers.
+type RepoPayload struct {
+	BaseSegment
+}
+
+// handleEventRepoCreated handles repo created events
+// and triggers repo created webhooks for the repo and its spaces.
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoSoftDeleted handles repo soft deleted events
+// and triggers repo deleted webhooks for the repo and its spaces.
+func (s *Service) handleEventRepoSoftDeleted(ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload]) error {
+	principal, err := s.findPrincipalForEvent(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := s.repoStore.FindByRefAndDeletedAt(ctx,
+		strconv.FormatInt(event.Payload.RepoID, 10), event.Payload.DeletedAt)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// repo got restored or purged in the meantime
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	return s.triggerForEvent(ctx, event.ID, repo, enum.WebhookTriggerRepoDeleted, &RepoPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerRepoDeleted,
+			Repo:      repositoryInfoFrom(repo, s.urlProvider),
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maskedHeaderValue replaces header values containing secrets in the stored webhook executions.
+const maskedHeaderValue = "******"
+
+// secretReferenceRegex matches secret references in header values, e.g. `${{ secrets.get("token") }}`.
+var secretReferenceRegex = regexp.MustCompile(`\$\{\{\s*secrets\.get\(\s*"([^"]+)"\s*\)\s*}}`)
+
+// SecretReferences returns the identifiers of all secrets referenced by the header value.
+// An error is returned in case the value contains an invalid secret reference.
+func SecretReferences(value string) ([]string, error) {
+	matches := secretReferenceRegex.FindAllStringSubmatch(value, -1)
+
+	// any leftover expression is an invalid secret reference
+	if strings.Contains(secretReferenceRegex.ReplaceAllString(value, ""), "${{") {
+		return nil, errors.New(`secrets have to be referenced as ${{ secrets.get("identifier") }}`)
+	}
+
+	identifiers := make([]string, len(matches))
+	for i, match := range matches {
+		identifiers[i] = match[1]
+	}
+
+	return identifiers, nil
+}
+
+// sortedHeaderNames returns the names of the headers in a deterministic order.
+func sortedHeaderNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveHeaderValue replaces all secret references in the header value with the value of the secret.
+// The secrets are looked up in the space the webhook belongs to (the parent space for repo webhooks).
+// It returns whether the value contains secrets.
+func (s *Service) resolveHeaderValue(ctx context.Context, webhook *types.Webhook,
+	value string) (string, bool, error) {
+	identifiers, err := SecretReferences(value)
+	if err != nil {
+		return "", false, err
+	}
+	if len(identifiers) == 0 {
+		return value, false, nil
+	}
+
+	spaceID := webhook.ParentID
+	if webhook.ParentType == enum.WebhookParentRepo {
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to find repo of webhook: %w", err)
+		}
+		spaceID = repo.ParentID
+	}
+
+	secrets := make(map[string]string, len(identifiers))
+	for _, identifier := range identifiers {
+		if _, ok := secrets[identifier]; ok {
+			continue
+		}
+
+		secret, err := s.secretStore.FindByIdentifier(ctx, spaceID, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return "", false, fmt.Errorf("secret '%s' doesn't exist", identifier)
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to find secret '%s': %w", identifier, err)
+		}
+
+		data, err := s.encrypter.Decrypt([]byte(secret.Data))
+		if err != nil {
+			return "", false, fmt.Errorf("failed to decrypt secret '%s': %w", identifier, err)
+		}
+		secrets[identifier] = data
+	}
+
+	resolved := secretReferenceRegex.ReplaceAllStringFunc(value, func(reference string) string {
+		return secrets[secretReferenceRegex.FindStringSubmatch(reference)[1]]
+	})
+
+	if strings.ContainsAny(resolved, "\r\n") {
+		return "", false, errors.New("secrets used in headers can't contain line breaks")
+	}
+
+	return resolved, true, nil
+}
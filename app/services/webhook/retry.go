@@ -0,0 +1,272 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	jobTypeRetry        = "gitness:webhook:retry"
+	jobCronRetry        = "* * * * *" // every minute
+	jobMaxDurationRetry = 20 * time.Minute
+
+	// retryBatchSize is the number of due retries loaded at once by the retry job.
+	retryBatchSize = 100
+
+	// retryRunDuration is the time after which a run of the retry job doesn't start another batch of retries,
+	// leaving time for the last batch to complete within the maximum duration of the job.
+	retryRunDuration = jobMaxDurationRetry / 2
+)
+
+// OwnerNotifier informs the owner of a webhook about changes the system made to the webhook.
+type OwnerNotifier interface {
+	SendWebhookDisabled(ctx context.Context, webhook *types.Webhook, reason string) error
+}
+
+// RetryService re-attempts failed webhook deliveries with exponential backoff.
+// Webhooks with deliveries that still fail after all attempts are disabled and their owner gets notified.
+type RetryService struct {
+	config            Config
+	scheduler         *job.Scheduler
+	webhookService    *Service
+	webhookStore      store.WebhookStore
+	webhookRetryStore store.WebhookRetryStore
+	notifier          OwnerNotifier
+}
+
+func NewRetryService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookService *Service,
+	webhookStore store.WebhookStore,
+	webhookRetryStore store.WebhookRetryStore,
+	notifier OwnerNotifier,
+) (*RetryService, error) {
+	s := &RetryService{
+		config:            config,
+		scheduler:         scheduler,
+		webhookService:    webhookService,
+		webhookStore:      webhookStore,
+		webhookRetryStore: webhookRetryStore,
+		notifier:          notifier,
+	}
+
+	if err := executor.Register(jobTypeRetry, s); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for webhook retries: %w", err)
+	}
+
+	return s, nil
+}
+
+// Register schedules the recurring webhook retry job.
+func (s *RetryService) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeRetry, jobTypeRetry, jobCronRetry, jobMaxDurationRetry)
+	if err != nil {
+		return fmt.Errorf("failed to schedule webhook retry job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle re-attempts all webhook deliveries that are due.
+// Deliveries are re-attempted concurrently, batch by batch, as long as due deliveries remain.
+func (s *RetryService) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	start := time.Now()
+
+	var processed, failed int
+	for time.Since(start) < retryRunDuration {
+		retries, err := s.webhookRetryStore.ListDue(ctx, time.Now().UnixMilli(), retryBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list due webhook retries: %w", err)
+		}
+
+		batchProcessed, batchFailed := s.retryBatch(ctx, retries)
+		processed += batchProcessed
+		failed += batchFailed
+
+		// failed retries stay due, stop in case the batch didn't make any progress.
+		if len(retries) < retryBatchSize || batchProcessed == 0 {
+			break
+		}
+	}
+
+	result := fmt.Sprintf("processed %d webhook retries (%d failed)", processed, failed)
+
+	if processed > 0 || failed > 0 {
+		log.Ctx(ctx).Info().Msg(result)
+	}
+
+	return result, nil
+}
+
+// retryBatch re-attempts the deliveries concurrently and returns the number of processed and failed retries.
+func (s *RetryService) retryBatch(ctx context.Context, retries []*types.WebhookRetry) (int, int) {
+	var processed, failed atomic.Int32
+
+	g := errgroup.Group{}
+	g.SetLimit(s.config.Concurrency)
+
+	for _, retry := range retries {
+		retry := retry
+		g.Go(func() error {
+			if err := s.retry(ctx, retry); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to retry execution %d of webhook %d",
+					retry.ExecutionID, retry.WebhookID)
+				failed.Add(1)
+				return nil
+			}
+			processed.Add(1)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	return int(processed.Load()), int(failed.Load())
+}
+
+// retry re-attempts the delivery and either removes it from the queue or schedules the next attempt.
+func (s *RetryService) retry(ctx context.Context, retry *types.WebhookRetry) error {
+	webhook, err := s.webhookStore.Find(ctx, retry.WebhookID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return s.webhookRetryStore.Delete(ctx, retry.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	// the webhook got disabled in the meantime - drop the delivery, it can be redelivered once enabled again.
+	if !webhook.Enabled {
+		return s.webhookRetryStore.Delete(ctx, retry.ID)
+	}
+
+	result, err := s.webhookService.RetriggerWebhookExecution(ctx, retry.ExecutionID)
+	if errors.Is(err, ErrWebhookNotRetriggerable) || errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return s.webhookRetryStore.Delete(ctx, retry.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrigger execution: %w", err)
+	}
+
+	// the delivery either succeeded or failed with an error that isn't worth retrying.
+	if result.Execution.Result != enum.WebhookExecutionResultRetriableError {
+		return s.webhookRetryStore.Delete(ctx, retry.ID)
+	}
+
+	retry.Attempts++
+	if retry.Attempts >= s.config.RetryMaxAttempts {
+		if err = s.webhookRetryStore.Delete(ctx, retry.ID); err != nil {
+			return err
+		}
+
+		if s.config.AutoDisable && s.config.RetryMaxAttempts > 0 {
+			s.disableWebhook(ctx, result.Webhook, result.Execution, retry.Attempts)
+		}
+
+		return nil
+	}
+
+	// the next attempt retriggers the latest execution to keep the chain of executions visible to the user.
+	if result.Execution.ID != 0 {
+		retry.ExecutionID = result.Execution.ID
+	}
+	retry.NextAttempt = time.Now().Add(s.config.retryBackoff(retry.Attempts)).UnixMilli()
+
+	return s.webhookRetryStore.Update(ctx, retry)
+}
+
+// disableWebhook disables the webhook and notifies its owner (best effort).
+func (s *RetryService) disableWebhook(ctx context.Context, webhook *types.Webhook,
+	execution *types.WebhookExecution, attempts int) {
+	_, err := s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		hook.Enabled = false
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to disable failing webhook %d", webhook.ID)
+		return
+	}
+
+	log.Ctx(ctx).Info().Msgf("disabled webhook %d after %d failed retries of execution %d",
+		webhook.ID, attempts, execution.ID)
+
+	reason := fmt.Sprintf("The delivery of a %s event still failed after %d retries: %s",
+		execution.TriggerType, attempts, execution.Error)
+
+	if err = s.notifier.SendWebhookDisabled(ctx, webhook, reason); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to notify owner of disabled webhook %d", webhook.ID)
+	}
+}
+
+// queueRetry queues the failed execution to be re-attempted by the retry job.
+func (s *Service) queueRetry(ctx context.Context, execution *types.WebhookExecution) error {
+	if execution.ID == 0 {
+		return fmt.Errorf("execution of webhook %d wasn't stored", execution.WebhookID)
+	}
+
+	now := time.Now()
+	err := s.webhookRetryStore.Create(ctx, &types.WebhookRetry{
+		WebhookID:   execution.WebhookID,
+		TriggerID:   execution.TriggerID,
+		ExecutionID: execution.ID,
+		NextAttempt: now.Add(s.config.retryBackoff(0)).UnixMilli(),
+		Created:     now.UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue retry of execution %d: %w", execution.ID, err)
+	}
+
+	return nil
+}
+
+// RedeliverFailedSince queues every delivery that failed since the provided time and never succeeded
+// to be re-attempted by the retry job. It returns the number of queued deliveries.
+func (s *Service) RedeliverFailedSince(ctx context.Context, since time.Time) (int64, error) {
+	n, err := s.webhookRetryStore.CreateForFailedSince(ctx, since.UnixMilli(), time.Now().UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue failed webhook deliveries: %w", err)
+	}
+
+	return n, nil
+}
+
+// retryBackoff returns the delay before the next attempt after the provided number of failed attempts.
+func (c *Config) retryBackoff(attempts int) time.Duration {
+	backoff := c.RetryBackoff
+	for i := 0; i < attempts && backoff < c.RetryMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > c.RetryMaxBackoff {
+		return c.RetryMaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// fakeWebhookRetryStore tracks the number of concurrently deleted retries.
+type fakeWebhookRetryStore struct {
+	store.WebhookRetryStore
+
+	mx             sync.Mutex
+	retries        []*types.WebhookRetry
+	inFlight       int
+	maxInFlight    int
+	listDueInvokes int
+}
+
+func (f *fakeWebhookRetryStore) ListDue(_ context.Context, _ int64, limit int) ([]*types.WebhookRetry, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.listDueInvokes++
+
+	if limit > len(f.retries) {
+		limit = len(f.retries)
+	}
+	return append([]*types.WebhookRetry(nil), f.retries[:limit]...), nil
+}
+
+func (f *fakeWebhookRetryStore) Delete(_ context.Context, id int64) error {
+	f.mx.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mx.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.inFlight--
+	for i, retry := range f.retries {
+		if retry.ID == id {
+			f.retries = append(f.retries[:i], f.retries[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func TestRetryService_Handle(t *testing.T) {
+	const count = 2*retryBatchSize + 10
+	const concurrency = 3
+
+	retryStore := &fakeWebhookRetryStore{}
+	for i := 1; i <= count; i++ {
+		// the webhooks don't exist anymore, so their retries get dropped.
+		retryStore.retries = append(retryStore.retries, &types.WebhookRetry{ID: int64(i), WebhookID: int64(i)})
+	}
+
+	s := &RetryService{
+		config:            Config{Concurrency: concurrency},
+		webhookStore:      &fakeWebhookStore{},
+		webhookRetryStore: retryStore,
+	}
+
+	if _, err := s.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(retryStore.retries) != 0 {
+		t.Errorf("expected all due retries to be processed, %d left", len(retryStore.retries))
+	}
+	if retryStore.listDueInvokes != 3 {
+		t.Errorf("expected due retries to be listed 3 times, got %d", retryStore.listDueInvokes)
+	}
+	if retryStore.maxInFlight < 2 || retryStore.maxInFlight > concurrency {
+		t.Errorf("expected concurrent retries bounded by %d, got %d", concurrency, retryStore.maxInFlight)
+	}
+}
+
+func TestConfig_RetryBackoff(t *testing.T) {
+	config := Config{
+		RetryBackoff:    time.Minute,
+		RetryMaxBackoff: 10 * time.Minute,
+	}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Minute},
+		{attempts: 1, want: 2 * time.Minute},
+		{attempts: 3, want: 8 * time.Minute},
+		{attempts: 4, want: 10 * time.Minute},
+		{attempts: 1000, want: 10 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := config.retryBackoff(test.attempts); got != test.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", test.attempts, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	sampleSHA    = "2b80a8b3f40f4d5cd4a6ef0b4e8a9e1c8b0e5a5d"
+	sampleOldSHA = "9d1f2b6f6b0f4b1e8e0a5c4d3b2a1f0e9d8c7b6a"
+)
+
+// samplePayload returns the payload of the trigger filled with sample data, which is used for test deliveries.
+// The repo and principal are used as is to allow the receiver to verify the mapping of real values.
+//
+//nolint:funlen // one case per trigger
+func samplePayload(
+	triggerType enum.WebhookTrigger,
+	repo RepositoryInfo,
+	principal PrincipalInfo,
+	prURL string,
+) any {
+	base := BaseSegment{
+		Trigger:   triggerType,
+		Repo:      repo,
+		Principal: principal,
+	}
+
+	commit := CommitInfo{
+		SHA:     sampleSHA,
+		Message: "Sample commit",
+		Author: SignatureInfo{
+			Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+			When:     time.Now().UTC().Truncate(time.Second),
+		},
+		Added:    []string{"README.md"},
+		Removed:  []string{},
+		Modified: []string{},
+	}
+	commit.Committer = commit.Author
+
+	details := ReferenceDetailsSegment{
+		SHA:               sampleSHA,
+		HeadCommit:        &commit,
+		Commits:           &[]CommitInfo{commit},
+		TotalCommitsCount: 1,
+		Commit:            &commit,
+	}
+
+	pullReq := PullReqSegment{
+		PullReq: PullReqInfo{
+			Number:       1,
+			State:        enum.PullReqStateOpen,
+			Title:        "Sample pull request",
+			Description:  "This is a test delivery.",
+			SourceRepoID: repo.ID,
+			SourceBranch: "feature",
+			TargetRepoID: repo.ID,
+			TargetBranch: repo.DefaultBranch,
+			Author:       principal,
+			PrURL:        prURL,
+		},
+	}
+	pullReqRefs := struct {
+		PullReqTargetReferenceSegment
+		ReferenceSegment
+	}{
+		PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+			TargetRef: ReferenceInfo{Name: "refs/heads/" + repo.DefaultBranch, Repo: repo},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/feature", Repo: repo},
+		},
+	}
+	comment := PullReqCommentPayload{
+		BaseSegment:                   base,
+		PullReqSegment:                pullReq,
+		PullReqTargetReferenceSegment: pullReqRefs.PullReqTargetReferenceSegment,
+		ReferenceSegment:              pullReqRefs.ReferenceSegment,
+		ReferenceDetailsSegment:       details,
+		PullReqCommentSegment: PullReqCommentSegment{
+			CommentInfo: CommentInfo{ID: 1, Text: "Sample comment"},
+		},
+	}
+
+	switch triggerType {
+	case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerBranchDeleted:
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: ReferenceInfo{Name: "refs/heads/feature", Repo: repo}},
+			ReferenceDetailsSegment: details,
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+
+	case enum.WebhookTriggerTagCreated, enum.WebhookTriggerTagUpdated, enum.WebhookTriggerTagDeleted:
+		return &ReferencePayload{
+			BaseSegment:             base,
+			ReferenceSegment:        ReferenceSegment{Ref: ReferenceInfo{Name: "refs/tags/v1.0.0", Repo: repo}},
+			ReferenceDetailsSegment: details,
+			ReferenceUpdateSegment:  ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+
+	case enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerPullReqCommentUpdated:
+		return &comment
+
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		return &PullReqCommentStatusPayload{
+			PullReqCommentPayload: comment,
+			Status:                enum.PullReqCommentStatusResolved,
+		}
+
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		return &PullReqReviewSubmittedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: pullReqRefs.PullReqTargetReferenceSegment,
+			ReferenceSegment:              pullReqRefs.ReferenceSegment,
+			PullReqReviewerSegment:        PullReqReviewerSegment{Reviewer: principal},
+			Decision:                      enum.PullReqReviewDecisionApproved,
+		}
+
+	case enum.WebhookTriggerPullReqReviewerAdded, enum.WebhookTriggerPullReqReviewerRemoved:
+		return &PullReqReviewerPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: pullReqRefs.PullReqTargetReferenceSegment,
+			ReferenceSegment:              pullReqRefs.ReferenceSegment,
+			PullReqReviewerSegment:        PullReqReviewerSegment{Reviewer: principal},
+		}
+
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: pullReqRefs.PullReqTargetReferenceSegment,
+			ReferenceSegment:              pullReqRefs.ReferenceSegment,
+			ReferenceDetailsSegment:       details,
+			ReferenceUpdateSegment:        ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+
+	case enum.WebhookTriggerPullReqCreated, enum.WebhookTriggerPullReqReopened,
+		enum.WebhookTriggerPullReqClosed, enum.WebhookTriggerPullReqMerged:
+		return &PullReqCreatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: pullReqRefs.PullReqTargetReferenceSegment,
+			ReferenceSegment:              pullReqRefs.ReferenceSegment,
+			ReferenceDetailsSegment:       details,
+		}
+
+	case enum.WebhookTriggerCheckStatusUpdated:
+		return &CheckPayload{
+			BaseSegment: base,
+			Check: CheckInfo{
+				Identifier: "sample-check",
+				CommitSHA:  sampleSHA,
+				Status:     enum.CheckStatusSuccess,
+				OldStatus:  enum.CheckStatusRunning,
+				Summary:    "Sample check succeeded",
+			},
+		}
+
+	case enum.WebhookTriggerExecutionCompleted:
+		return &ExecutionPayload{
+			BaseSegment: base,
+			Execution: ExecutionInfo{
+				Number:             1,
+				PipelineIdentifier: "sample-pipeline",
+				Status:             enum.CIStatusSuccess,
+				Event:              "manual",
+				Ref:                "refs/heads/" + repo.DefaultBranch,
+				After:              sampleSHA,
+			},
+		}
+
+	default:
+		// repo triggers only contain the base segment
+		return &RepoPayload{BaseSegment: base}
+	}
+}
+
+// sampleRepositoryInfo returns the repository info of an imaginary repository in the provided space,
+// which is used for test deliveries of space webhooks.
+func (s *Service) sampleRepositoryInfo(spacePath string) RepositoryInfo {
+	const identifier = "sample-repo"
+	path := spacePath + "/" + identifier
+	return RepositoryInfo{
+		Path:          path,
+		Identifier:    identifier,
+		DefaultBranch: "main",
+		GitURL:        s.urlProvider.GenerateGITCloneURL(path),
+	}
+}
+
+// TestWebhook delivers a sample payload of the trigger to the webhook.
+// The repo is used for the payload of repo webhooks, space webhooks use an imaginary repo in the space.
+// In case of a dry run the request is only rendered but not sent, and the execution isn't stored.
+func (s *Service) TestWebhook(
+	ctx context.Context,
+	webhook *types.Webhook,
+	triggerType enum.WebhookTrigger,
+	repo *types.Repository,
+	spacePath string,
+	principal *types.Principal,
+	dryRun bool,
+) (*types.WebhookExecution, error) {
+	repoInfo := s.sampleRepositoryInfo(spacePath)
+	if repo != nil {
+		repoInfo = repositoryInfoFrom(repo, s.urlProvider)
+	}
+
+	var body any = samplePayload(triggerType, repoInfo, principalInfoFrom(principal.ToPrincipalInfo()),
+		s.urlProvider.GenerateUIPRURL(repoInfo.Path, 1))
+
+	// chat integrations expect a message in the format of the chat provider instead of the raw payload
+	if webhook.Type.IsChat() {
+		body = s.chatMessageFor(webhook, triggerType, body)
+	}
+
+	if dryRun {
+		execution := &types.WebhookExecution{
+			WebhookID:   webhook.ID,
+			TriggerType: triggerType,
+			Result:      enum.WebhookExecutionResultSkipped,
+			Error:       "Dry run, the request wasn't sent.",
+			Created:     time.Now().UnixMilli(),
+		}
+		// rendering errors are reported via the error and result of the execution.
+		_, _ = s.prepareHTTPRequest(ctx, execution, triggerType, webhook, body)
+		return execution, nil
+	}
+
+	triggerID := fmt.Sprintf("test-%d-%d", webhook.ID, time.Now().UnixNano())
+
+	// errors of the delivery are reported via the error and result of the execution.
+	execution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msgf("test delivery of webhook %d failed", webhook.ID)
+	}
+
+	return execution, nil
+}
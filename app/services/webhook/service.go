@@ -21,8 +21,11 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -47,6 +50,15 @@ type Config struct {
 	MaxRetries          int
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
+
+	// RetryMaxAttempts is the number of times a delivery that failed with a retriable error is re-attempted.
+	RetryMaxAttempts int
+	// RetryBackoff is the delay before the first re-attempt of a delivery, it doubles with every attempt.
+	RetryBackoff time.Duration
+	// RetryMaxBackoff is the maximum delay between two re-attempts of a delivery.
+	RetryMaxBackoff time.Duration
+	// AutoDisable disables webhooks with deliveries that still fail after all re-attempts.
+	AutoDisable bool
 }
 
 func (c *Config) Prepare() error {
@@ -65,6 +77,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.RetryMaxAttempts < 0 {
+		return errors.New("config.RetryMaxAttempts can't be negative")
+	}
+	if c.RetryMaxAttempts > 0 && (c.RetryBackoff <= 0 || c.RetryMaxBackoff < c.RetryBackoff) {
+		return errors.New("config.RetryBackoff has to be positive and can't exceed config.RetryMaxBackoff")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -78,12 +96,17 @@ func (c *Config) Prepare() error {
 type Service struct {
 	webhookStore          store.WebhookStore
 	webhookExecutionStore store.WebhookExecutionStore
+	webhookRetryStore     store.WebhookRetryStore
 	urlProvider           url.Provider
 	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
 	pullreqStore          store.PullReqStore
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
+	executionStore        store.ExecutionStore
+	pipelineStore         store.PipelineStore
+	secretStore           store.SecretStore
 	encrypter             encrypt.Encrypter
 
 	secureHTTPClient   *http.Client
@@ -100,11 +123,19 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
+	webhookRetryStore store.WebhookRetryStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	secretStore store.SecretStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
@@ -116,9 +147,14 @@ func NewService(
 	service := &Service{
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
+		webhookRetryStore:     webhookRetryStore,
 		repoStore:             repoStore,
+		spaceStore:            spaceStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
+		executionStore:        executionStore,
+		pipelineStore:         pipelineStore,
+		secretStore:           secretStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
@@ -175,6 +211,11 @@ func NewService(
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterCommentUpdated(service.handleEventPullReqCommentUpdated)
+			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterReviewerAdded(service.handleEventPullReqReviewerAdded)
+			_ = r.RegisterReviewerRemoved(service.handleEventPullReqReviewerRemoved)
 
 			return nil
 		})
@@ -182,5 +223,63 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoSoftDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStatusUpdated(service.handleEventCheckStatusUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterExecutionCompleted(service.handleEventExecutionCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// payloadTemplateFuncs are available in payload templates in addition to the text/template builtins.
+var payloadTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		raw, err := json.Marshal(v)
+		return string(raw), err
+	},
+}
+
+// ValidatePayloadTemplate validates the syntax of the template or JSONPath mapping of a payload template.
+func ValidatePayloadTemplate(payloadTemplate *types.WebhookPayloadTemplate) error {
+	if payloadTemplate.Template != "" && len(payloadTemplate.Mapping) > 0 {
+		return errors.New("template and mapping can't be used together")
+	}
+
+	if payloadTemplate.Template != "" {
+		if _, err := parsePayloadTemplate(payloadTemplate.Template); err != nil {
+			return err
+		}
+	}
+
+	for field, path := range payloadTemplate.Mapping {
+		if _, err := parseJSONPath(path); err != nil {
+			return fmt.Errorf("invalid JSONPath of field '%s': %w", field, err)
+		}
+	}
+
+	return nil
+}
+
+func parsePayloadTemplate(text string) (*template.Template, error) {
+	return template.New("payload").Funcs(payloadTemplateFuncs).Parse(text)
+}
+
+// renderBody generates the request body of the webhook from the payload,
+// using the payload template and content type of the webhook.
+func renderBody(webhook *types.Webhook, body any) ([]byte, error) {
+	payloadTemplate := webhook.PayloadTemplate
+	if payloadTemplate.IsEmpty() && webhook.ContentType != enum.WebhookContentTypeForm {
+		bBuff := &bytes.Buffer{}
+		if err := json.NewEncoder(bBuff).Encode(body); err != nil {
+			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+		}
+		return bBuff.Bytes(), nil
+	}
+
+	// templates and form encoding operate on the JSON representation of the payload
+	data, err := payloadToEnv(body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !payloadTemplate.IsEmpty() && payloadTemplate.Template != "":
+		tmpl, err := parsePayloadTemplate(payloadTemplate.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse payload template: %w", err)
+		}
+
+		bBuff := &bytes.Buffer{}
+		if err = tmpl.Execute(bBuff, data); err != nil {
+			return nil, fmt.Errorf("failed to render payload template: %w", err)
+		}
+
+		// the template output is the body as is, independent of the content type.
+		return bBuff.Bytes(), nil
+
+	case !payloadTemplate.IsEmpty():
+		mapped := make(map[string]any, len(payloadTemplate.Mapping))
+		for field, rawPath := range payloadTemplate.Mapping {
+			path, err := parseJSONPath(rawPath)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSONPath of field '%s': %w", field, err)
+			}
+			mapped[field] = path.evaluate(data)
+		}
+		data = mapped
+	}
+
+	if webhook.ContentType == enum.WebhookContentTypeForm {
+		return formEncode(data)
+	}
+
+	return json.Marshal(data)
+}
+
+// formEncode encodes the fields as form values. Values that aren't strings are encoded as JSON.
+func formEncode(fields map[string]any) ([]byte, error) {
+	values := url.Values{}
+	for field, value := range fields {
+		switch v := value.(type) {
+		case nil:
+			values.Set(field, "")
+		case string:
+			values.Set(field, v)
+		default:
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode form value of field '%s': %w", field, err)
+			}
+			values.Set(field, string(raw))
+		}
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+// jsonPath is a parsed JSONPath expression.
+// Only the root `$` followed by child (`.name`, `['name']`) and index (`[0]`) selectors are supported.
+type jsonPath []jsonPathSelector
+
+type jsonPathSelector struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+//nolint:gocognit // it's a parser
+func parseJSONPath(path string) (jsonPath, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.New("a JSONPath has to start with '$'")
+	}
+
+	var selectors jsonPath
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, errors.New("field names can't be empty")
+			}
+			selectors = append(selectors, jsonPathSelector{name: rest[:end]})
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, errors.New("missing closing bracket")
+			}
+			subscript := rest[1:end]
+			rest = rest[end+1:]
+
+			if l := len(subscript); l >= 2 && (subscript[0] == '\'' && subscript[l-1] == '\'' ||
+				subscript[0] == '"' && subscript[l-1] == '"') {
+				selectors = append(selectors, jsonPathSelector{name: subscript[1 : l-1]})
+				continue
+			}
+
+			index, err := strconv.Atoi(subscript)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("unsupported subscript '[%s]', only names and indices are supported", subscript)
+			}
+			selectors = append(selectors, jsonPathSelector{index: index, isIndex: true})
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c'", rest[0])
+		}
+	}
+
+	return selectors, nil
+}
+
+// evaluate returns the value the path points to, or nil if it doesn't exist.
+func (p jsonPath) evaluate(data any) any {
+	for _, selector := range p {
+		if selector.isIndex {
+			arr, ok := data.([]any)
+			if !ok || selector.index >= len(arr) {
+				return nil
+			}
+			data = arr[selector.index]
+			continue
+		}
+
+		obj, ok := data.(map[string]any)
+		if !ok {
+			return nil
+		}
+		data = obj[selector.name]
+	}
+
+	return data
+}
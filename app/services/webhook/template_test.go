@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRenderBody(t *testing.T) {
+	payload := &ReferencePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerBranchCreated,
+			Principal: PrincipalInfo{UID: "jdoe"},
+		},
+		ReferenceSegment: ReferenceSegment{
+			Ref: ReferenceInfo{Name: "refs/heads/main"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		webhook *types.Webhook
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "template",
+			webhook: &types.Webhook{
+				PayloadTemplate: &types.WebhookPayloadTemplate{
+					Template: `{"text": {{ json (printf "%s pushed %s" .principal.uid .ref.name) }}}`,
+				},
+			},
+			want: `{"text": "jdoe pushed refs/heads/main"}`,
+		},
+		{
+			name: "template ignores content type",
+			webhook: &types.Webhook{
+				ContentType: enum.WebhookContentTypeForm,
+				PayloadTemplate: &types.WebhookPayloadTemplate{
+					Template: `{{ .trigger }}`,
+				},
+			},
+			want: `branch_created`,
+		},
+		{
+			name: "mapping",
+			webhook: &types.Webhook{
+				ContentType: enum.WebhookContentTypeJSON,
+				PayloadTemplate: &types.WebhookPayloadTemplate{
+					Mapping: map[string]string{
+						"user":    "$.principal.uid",
+						"ref":     "$['ref']['name']",
+						"missing": "$.repo.nothing",
+					},
+				},
+			},
+			want: `{"missing":null,"ref":"refs/heads/main","user":"jdoe"}`,
+		},
+		{
+			name: "mapping with form encoding",
+			webhook: &types.Webhook{
+				ContentType: enum.WebhookContentTypeForm,
+				PayloadTemplate: &types.WebhookPayloadTemplate{
+					Mapping: map[string]string{
+						"user":    "$.principal.uid",
+						"ref":     "$.ref.name",
+						"repo_id": "$.ref.repo.id",
+					},
+				},
+			},
+			want: `ref=refs%2Fheads%2Fmain&repo_id=0&user=jdoe`,
+		},
+		{
+			name: "failing template",
+			webhook: &types.Webhook{
+				PayloadTemplate: &types.WebhookPayloadTemplate{
+					Template: `{{ index .principal.uid 100 }}`,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderBody(test.webhook, payload)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("want=%s, got=%s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	data := map[string]any{
+		"commits": []any{
+			map[string]any{"sha": "abc"},
+		},
+		"a.b": "dotted",
+	}
+
+	tests := []struct {
+		path    string
+		want    any
+		wantErr bool
+	}{
+		{path: "$", want: data},
+		{path: "$.commits[0].sha", want: "abc"},
+		{path: `$["a.b"]`, want: "dotted"},
+		{path: "$.commits[1].sha", want: nil},
+		{path: "$.commits.sha", want: nil},
+		{path: "commits", wantErr: true},
+		{path: "$.", wantErr: true},
+		{path: "$.commits[*]", wantErr: true},
+		{path: "$.commits[0", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := parseJSONPath(test.path)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := path.evaluate(data); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v, got=%v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSecretReferences(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{value: "plain", want: []string{}},
+		{value: `Bearer ${{ secrets.get("token") }}`, want: []string{"token"}},
+		{value: `${{secrets.get("user")}}:${{ secrets.get( "pass" ) }}`, want: []string{"user", "pass"}},
+		{value: `${{ secrets.token }}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := SecretReferences(test.value)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v, got=%v", test.want, got)
+			}
+		})
+	}
+}
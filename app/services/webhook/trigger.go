@@ -20,7 +20,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -46,6 +45,9 @@ const (
 
 	// responseBodyBytesLimit defines the maximum number of bytes processed from the webhook response body.
 	responseBodyBytesLimit = 1024
+
+	// webhookListPageSize defines the number of webhooks loaded at once when collecting the webhooks to trigger.
+	webhookListPageSize = 100
 )
 
 var (
@@ -63,22 +65,69 @@ type TriggerResult struct {
 }
 
 func (r *TriggerResult) Skipped() bool {
-	return r.Execution == nil
+	return r.Execution == nil || r.Execution.Result == enum.WebhookExecutionResultSkipped
 }
 
-func (s *Service) triggerWebhooksFor(ctx context.Context, parentType enum.WebhookParent, parentID int64,
+// triggerWebhooksFor triggers the webhooks of the repo as well as the webhooks of all spaces the repo is located in.
+func (s *Service) triggerWebhooksFor(ctx context.Context, repo *types.Repository,
 	triggerID string, triggerType enum.WebhookTrigger, body any) ([]TriggerResult, error) {
-	// get all webhooks for the given parent
-	// NOTE: there never should be even close to 1000 webhooks for a repo (that should be blocked in the future).
-	// We just use 1000 as a safe number to get all hooks
-	webhooks, err := s.webhookStore.List(ctx, parentType, parentID, &types.WebhookFilter{Size: 1000, Order: enum.OrderAsc})
+	webhooks, err := s.listAllWebhooks(ctx, enum.WebhookParentRepo, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks for repo %d: %w", repo.ID, err)
+	}
+
+	spaceWebhooks, err := s.listAncestorSpaceWebhooks(ctx, repo.ParentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list webhooks for %s %d: %w", parentType, parentID, err)
+		return nil, err
 	}
+	webhooks = append(webhooks, spaceWebhooks...)
 
 	return s.triggerWebhooks(ctx, webhooks, triggerID, triggerType, body)
 }
 
+// listAncestorSpaceWebhooks lists the webhooks of the provided space and all its ancestors.
+func (s *Service) listAncestorSpaceWebhooks(ctx context.Context, spaceID int64) ([]*types.Webhook, error) {
+	var webhooks []*types.Webhook
+	for spaceID > 0 {
+		spaceWebhooks, err := s.listAllWebhooks(ctx, enum.WebhookParentSpace, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks for space %d: %w", spaceID, err)
+		}
+		webhooks = append(webhooks, spaceWebhooks...)
+
+		space, err := s.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+		spaceID = space.ParentID
+	}
+
+	return webhooks, nil
+}
+
+// listAllWebhooks lists all webhooks of the provided parent, page by page.
+func (s *Service) listAllWebhooks(ctx context.Context, parentType enum.WebhookParent,
+	parentID int64) ([]*types.Webhook, error) {
+	var webhooks []*types.Webhook
+	for page := 1; ; page++ {
+		webhooksPage, err := s.webhookStore.List(ctx, parentType, parentID, &types.WebhookFilter{
+			Page:  page,
+			Size:  webhookListPageSize,
+			Sort:  enum.WebhookAttrID,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhooksPage...)
+
+		if len(webhooksPage) < webhookListPageSize {
+			return webhooks, nil
+		}
+	}
+}
+
 //nolint:gocognit // refactor if needed
 func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook,
 	triggerID string, triggerType enum.WebhookTrigger, body any) ([]TriggerResult, error) {
@@ -96,13 +145,15 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 	// precalculate whether a webhook should be executed
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
-		// skip execution in case of success or unrecoverable error
+		// skip execution in case of success, unrecoverable error or if it was skipped by the payload filter
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
-			execution.Result == enum.WebhookExecutionResultFatalError {
+			execution.Result == enum.WebhookExecutionResultFatalError ||
+			execution.Result == enum.WebhookExecutionResultSkipped {
 			skipExecution[execution.WebhookID] = true
 		}
 	}
 
+	matcher := newPayloadMatcher(body)
 	results := make([]TriggerResult, len(webhooks))
 	for i, webhook := range webhooks {
 		results[i] = TriggerResult{
@@ -133,8 +184,20 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
+		// check if payload matches the payload filter - skipped executions are stored to be visible to the user
+		if matches, reason := matcher.match(webhook.PayloadFilter); !matches {
+			results[i].Execution, results[i].Err = s.skipWebhook(ctx, webhook, triggerID, triggerType, body, reason)
+			continue
+		}
+
+		// chat integrations expect a message in the format of the chat provider instead of the raw payload
+		webhookBody := body
+		if webhook.Type.IsChat() {
+			webhookBody = s.chatMessageFor(webhook, triggerType, body)
+		}
+
 		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, webhookBody, nil)
 	}
 
 	return results, nil
@@ -177,6 +240,39 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 	}, nil
 }
 
+// skipWebhook stores a skipped execution of the webhook for the provided trigger.
+// The body is stored as well, which allows the user to retrigger the execution manually.
+func (s *Service) skipWebhook(ctx context.Context, webhook *types.Webhook, triggerID string,
+	triggerType enum.WebhookTrigger, body any, reason string) (*types.WebhookExecution, error) {
+	execution := &types.WebhookExecution{
+		WebhookID:   webhook.ID,
+		TriggerID:   triggerID,
+		TriggerType: triggerType,
+		Result:      enum.WebhookExecutionResultSkipped,
+		Error:       "Skipped by payload filter: " + reason,
+		Created:     time.Now().UnixMilli(),
+		Request: types.WebhookExecutionRequest{
+			URL: webhook.URL,
+		},
+	}
+
+	// chat integrations expect a message in the format of the chat provider instead of the raw payload
+	if webhook.Type.IsChat() {
+		body = s.chatMessageFor(webhook, triggerType, body)
+	}
+
+	if bBytes, err := renderBody(webhook, body); err == nil {
+		execution.Request.Body = string(bBytes)
+		execution.Retriggerable = true
+	}
+
+	if err := s.webhookExecutionStore.Create(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to store skipped execution of webhook %d: %w", webhook.ID, err)
+	}
+
+	return execution, nil
+}
+
 //nolint:gocognit // refactor into smaller chunks if necessary.
 func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, triggerID string,
 	triggerType enum.WebhookTrigger, body any, rerunOfID *int64) (*types.WebhookExecution, error) {
@@ -309,14 +405,18 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		bBuff.Write(bBytes)
 
 	default:
-		// all other types we json serialize
-		err := json.NewEncoder(bBuff).Encode(body)
+		// all other types we render using the payload template and content type of the webhook
+		bBytes, err := renderBody(webhook, body)
 		if err != nil {
-			// this is an internal issue, nothing the user can do - don't expose error details
-			execution.Error = "an error occurred preparing the request body"
+			// ASSUMPTION: there was an issue with the static user input (template), not retriable
+			tErr := fmt.Errorf("failed to generate request body: %w", err)
+			execution.Error = tErr.Error()
 			execution.Result = enum.WebhookExecutionResultFatalError
-			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
+			return nil, tErr
 		}
+
+		// NOTE: bBuff.Write(v) will always return (len(v), nil) - no need to error handle
+		bBuff.Write(bBytes)
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
@@ -334,7 +434,7 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 
 	// setup headers
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", webhook.ContentType.MIMEType())
 	req.Header.Add(s.toXHeader("Trigger"), string(triggerType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -356,8 +456,32 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 		req.Header.Add(s.toXHeader("Signature"), hmac)
 	}
 
+	// add custom headers - values referencing secrets are masked in the stored execution
+	maskedHeader := req.Header.Clone()
+	for _, name := range sortedHeaderNames(webhook.Headers) {
+		// headers set by the system can't be overwritten
+		if req.Header.Get(name) != "" {
+			continue
+		}
+
+		value, hasSecrets, err := s.resolveHeaderValue(ctx, webhook, webhook.Headers[name])
+		if err != nil {
+			tErr := fmt.Errorf("failed to resolve value of header '%s': %w", name, err)
+			execution.Error = tErr.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return nil, tErr
+		}
+
+		req.Header.Set(name, value)
+		if hasSecrets {
+			maskedHeader.Set(name, maskedHeaderValue)
+		} else {
+			maskedHeader.Set(name, value)
+		}
+	}
+
 	hBuffer := &bytes.Buffer{}
-	err = req.Header.Write(hBuffer)
+	err = maskedHeader.Write(hBuffer)
 	if err != nil {
 		tErr := fmt.Errorf("failed to write request headers: %w", err)
 		execution.Error = tErr.Error()
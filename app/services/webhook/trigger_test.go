@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeWebhookStore struct {
+	store.WebhookStore
+	webhooks []*types.Webhook
+}
+
+func (f *fakeWebhookStore) List(
+	_ context.Context,
+	_ enum.WebhookParent,
+	_ int64,
+	opts *types.WebhookFilter,
+) ([]*types.Webhook, error) {
+	start := (opts.Page - 1) * opts.Size
+	if start >= len(f.webhooks) {
+		return nil, nil
+	}
+	end := start + opts.Size
+	if end > len(f.webhooks) {
+		end = len(f.webhooks)
+	}
+	return f.webhooks[start:end], nil
+}
+
+func (f *fakeWebhookStore) Find(_ context.Context, id int64) (*types.Webhook, error) {
+	for _, webhook := range f.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestService_ListAllWebhooks(t *testing.T) {
+	for _, count := range []int{0, 1, webhookListPageSize, 2*webhookListPageSize + 1} {
+		webhooks := make([]*types.Webhook, count)
+		for i := range webhooks {
+			webhooks[i] = &types.Webhook{ID: int64(i + 1)}
+		}
+
+		s := &Service{webhookStore: &fakeWebhookStore{webhooks: webhooks}}
+
+		got, err := s.listAllWebhooks(context.Background(), enum.WebhookParentRepo, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != count {
+			t.Errorf("expected %d webhooks, got %d", count, len(got))
+		}
+	}
+}
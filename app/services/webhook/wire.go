@@ -17,13 +17,17 @@ package webhook
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -31,23 +35,45 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideRetryService,
 )
 
 func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
+	webhookRetryStore store.WebhookRetryStore,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	secretStore store.SecretStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
 ) (*Service, error) {
 	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+		repoReaderFactory, checkReaderFactory, pipelineReaderFactory,
+		webhookStore, webhookExecutionStore, webhookRetryStore, repoStore, spaceStore, pullreqStore, activityStore,
+		executionStore, pipelineStore, secretStore, urlProvider, principalStore, git, encrypter)
+}
+
+func ProvideRetryService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	webhookService *Service,
+	webhookStore store.WebhookStore,
+	webhookRetryStore store.WebhookRetryStore,
+	notifier OwnerNotifier,
+) (*RetryService, error) {
+	return NewRetryService(config, scheduler, executor, webhookService, webhookStore, webhookRetryStore, notifier)
 }
@@ -15,7 +15,10 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/pipeline/approval"
+	"github.com/harness/gitness/app/pipeline/reclaimer"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
@@ -34,6 +37,7 @@ var WireSet = wire.NewSet(
 
 type Services struct {
 	Webhook            *webhook.Service
+	WebhookRetry       *webhook.RetryService
 	PullReq            *pullreq.Service
 	Trigger            *trigger.Service
 	JobScheduler       *job.Scheduler
@@ -42,11 +46,17 @@ type Services struct {
 	Repo               *repo.Service
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
+	NotificationInbox  *notification.InboxService
+	NotificationDigest *notification.DigestService
 	Keywordsearch      *keywordsearch.Service
+	GroupSync          *groupsync.Service
+	PipelineReclaimer  *reclaimer.Reclaimer
+	PipelineApproval   *approval.Service
 }
 
 func ProvideServices(
 	webhooksSvc *webhook.Service,
+	webhookRetrySvc *webhook.RetryService,
 	pullReqSvc *pullreq.Service,
 	triggerSvc *trigger.Service,
 	jobScheduler *job.Scheduler,
@@ -55,10 +65,16 @@ func ProvideServices(
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
+	notificationInboxSvc *notification.InboxService,
+	notificationDigestSvc *notification.DigestService,
 	keywordsearchSvc *keywordsearch.Service,
+	groupSyncSvc *groupsync.Service,
+	pipelineReclaimer *reclaimer.Reclaimer,
+	pipelineApprovalSvc *approval.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
+		WebhookRetry:       webhookRetrySvc,
 		PullReq:            pullReqSvc,
 		Trigger:            triggerSvc,
 		JobScheduler:       jobScheduler,
@@ -67,6 +83,11 @@ func ProvideServices(
 		Repo:               repo,
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
+		NotificationInbox:  notificationInboxSvc,
+		NotificationDigest: notificationDigestSvc,
 		Keywordsearch:      keywordsearchSvc,
+		GroupSync:          groupSyncSvc,
+		PipelineReclaimer:  pipelineReclaimer,
+		PipelineApproval:   pipelineApprovalSvc,
 	}
 }
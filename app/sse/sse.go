@@ -36,6 +36,12 @@ type Streamer interface {
 
 	// Stream streams the events on a space ID.
 	Stream(ctx context.Context, spaceID int64) (<-chan *Event, <-chan error, func(context.Context) error)
+
+	// PublishPrincipal publishes an event to a given principal ID.
+	PublishPrincipal(ctx context.Context, principalID int64, eventType enum.SSEType, data any) error
+
+	// StreamPrincipal streams the events on a principal ID.
+	StreamPrincipal(ctx context.Context, principalID int64) (<-chan *Event, <-chan error, func(context.Context) error)
 }
 
 type pubsubStreamer struct {
@@ -51,6 +57,19 @@ func NewStreamer(pubsub pubsub.PubSub, namespace string) Streamer {
 }
 
 func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType enum.SSEType, data any) error {
+	return e.publish(ctx, getSpaceTopic(spaceID), eventType, data)
+}
+
+func (e *pubsubStreamer) PublishPrincipal(
+	ctx context.Context,
+	principalID int64,
+	eventType enum.SSEType,
+	data any,
+) error {
+	return e.publish(ctx, getPrincipalTopic(principalID), eventType, data)
+}
+
+func (e *pubsubStreamer) publish(ctx context.Context, topic string, eventType enum.SSEType, data any) error {
 	dataSerialized, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to serialize data: %w", err)
@@ -64,7 +83,6 @@ func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType e
 		return fmt.Errorf("failed to serialize event: %w", err)
 	}
 	namespaceOption := pubsub.WithPublishNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	err = e.pubsub.Publish(ctx, topic, serializedEvent, namespaceOption)
 	if err != nil {
 		return fmt.Errorf("failed to publish event on pubsub: %w", err)
@@ -76,6 +94,20 @@ func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType e
 func (e *pubsubStreamer) Stream(
 	ctx context.Context,
 	spaceID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getSpaceTopic(spaceID))
+}
+
+func (e *pubsubStreamer) StreamPrincipal(
+	ctx context.Context,
+	principalID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getPrincipalTopic(principalID))
+}
+
+func (e *pubsubStreamer) stream(
+	ctx context.Context,
+	topic string,
 ) (<-chan *Event, <-chan error, func(context.Context) error) {
 	chEvent := make(chan *Event, 100) // TODO: check best size here
 	chErr := make(chan error)
@@ -94,7 +126,6 @@ func (e *pubsubStreamer) Stream(
 		return nil
 	}
 	namespaceOption := pubsub.WithChannelNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	consumer := e.pubsub.Subscribe(ctx, topic, g, namespaceOption)
 	cleanupFN := func(_ context.Context) error {
 		return consumer.Close()
@@ -107,3 +138,8 @@ func (e *pubsubStreamer) Stream(
 func getSpaceTopic(spaceID int64) string {
 	return "spaces:" + strconv.Itoa(int(spaceID))
 }
+
+// getPrincipalTopic creates the namespace name which will be `principals:<id>`.
+func getPrincipalTopic(principalID int64) string {
+	return "principals:" + strconv.Itoa(int(principalID))
+}
@@ -288,6 +288,171 @@ type (
 		ListUsers(ctx context.Context, spaceID int64, filter types.MembershipUserFilter) ([]types.MembershipUser, error)
 		CountSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) (int64, error)
 		ListSpaces(ctx context.Context, userID int64, filter types.MembershipSpaceFilter) ([]types.MembershipSpace, error)
+		CountByRoleID(ctx context.Context, roleID int64) (int64, error)
+	}
+
+	// RepoMembershipStore defines the repository membership data storage.
+	RepoMembershipStore interface {
+		Find(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembership, error)
+		FindUser(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembershipUser, error)
+		Create(ctx context.Context, membership *types.RepoMembership) error
+		Update(ctx context.Context, membership *types.RepoMembership) error
+		Delete(ctx context.Context, key types.RepoMembershipKey) error
+		CountUsers(ctx context.Context, repoID int64, filter types.MembershipUserFilter) (int64, error)
+		ListUsers(ctx context.Context, repoID int64, filter types.MembershipUserFilter) ([]types.RepoMembershipUser, error)
+		CountByRoleID(ctx context.Context, roleID int64) (int64, error)
+	}
+
+	// RoleStore defines the custom membership role data storage.
+	RoleStore interface {
+		// Find finds the role by id.
+		Find(ctx context.Context, id int64) (*types.Role, error)
+
+		// FindByIdentifier finds the role by space id and identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Role, error)
+
+		// Create creates a new role.
+		Create(ctx context.Context, role *types.Role) error
+
+		// Update updates the role.
+		Update(ctx context.Context, role *types.Role) error
+
+		// Delete deletes the role with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// Count returns the number of roles in a space.
+		Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error)
+
+		// List returns a list of roles in a space.
+		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Role, error)
+	}
+
+	// AuditStore defines the storage of the audit log.
+	AuditStore interface {
+		// Create saves the audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// List returns the audit events matching the filter, ordered by timestamp.
+		List(ctx context.Context, filter *types.AuditFilter) ([]*types.AuditEvent, error)
+
+		// Count returns the number of audit events matching the filter.
+		Count(ctx context.Context, filter *types.AuditFilter) (int64, error)
+	}
+
+	// QuotaStore defines the storage of space quotas and the resource usage they limit.
+	QuotaStore interface {
+		// Find finds the quota of the space.
+		Find(ctx context.Context, spaceID int64) (*types.SpaceQuota, error)
+
+		// Upsert creates the quota of the space or replaces the existing one.
+		Upsert(ctx context.Context, quota *types.SpaceQuota) error
+
+		// Delete deletes the quota of the space.
+		Delete(ctx context.Context, spaceID int64) error
+
+		// Usage returns the resource consumption of the space together with all of its descendants.
+		// Only pipeline executions started at or after since (unix millis) count towards pipeline minutes.
+		Usage(ctx context.Context, spaceID int64, since int64) (*types.ResourceUsage, error)
+
+		// AddBlobSize adds the size (in bytes) of an uploaded blob to the blob storage used by the repository.
+		AddBlobSize(ctx context.Context, repoID int64, size int64) error
+	}
+
+	// NotificationStore defines the storage of the notification inbox of users.
+	NotificationStore interface {
+		// Find finds the notification of the principal by id.
+		Find(ctx context.Context, principalID, id int64) (*types.Notification, error)
+
+		// Create saves the notification.
+		Create(ctx context.Context, notification *types.Notification) error
+
+		// List returns the notifications of the principal matching the filter, newest first.
+		List(ctx context.Context, principalID int64, filter *types.NotificationFilter) ([]*types.Notification, error)
+
+		// Count returns the number of notifications of the principal matching the filter.
+		Count(ctx context.Context, principalID int64, filter *types.NotificationFilter) (int64, error)
+
+		// UpdateRead marks the notification of the principal as read or unread.
+		UpdateRead(ctx context.Context, principalID, id int64, read bool) error
+
+		// UpdateAllRead marks all unread notifications of the principal as read and returns their number.
+		UpdateAllRead(ctx context.Context, principalID int64) (int64, error)
+	}
+
+	// NotificationPreferenceStore defines the storage of the notification preferences of users.
+	NotificationPreferenceStore interface {
+		// List returns all notification preferences of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationPreference, error)
+
+		// Upsert creates or updates the notification preference.
+		Upsert(ctx context.Context, pref *types.NotificationPreference) error
+
+		// Delete deletes the notification preference of the principal for the (optional) repository.
+		Delete(ctx context.Context, principalID int64, repoID *int64, notificationType enum.NotificationType) error
+
+		// ListDeliveries returns the configured deliveries of the notification type in the repository
+		// for the provided principals. A repository preference takes precedence over the global one.
+		// Principals without any preference are not part of the result.
+		ListDeliveries(
+			ctx context.Context,
+			principalIDs []int64,
+			repoID int64,
+			notificationType enum.NotificationType,
+		) (map[int64]enum.NotificationDelivery, error)
+	}
+
+	// NotificationDigestStore defines the storage of notifications waiting for the next digest email.
+	NotificationDigestStore interface {
+		// Create saves the digest item.
+		Create(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns the IDs of all principals with pending digest items.
+		ListPrincipalIDs(ctx context.Context) ([]int64, error)
+
+		// List returns the pending digest items of the principal, oldest first.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationDigestItem, error)
+
+		// DeleteUpTo deletes all digest items of the principal with an ID up to (including) the provided one.
+		DeleteUpTo(ctx context.Context, principalID int64, id int64) error
+	}
+
+	// TOTPStore defines the storage of the TOTP configuration of users.
+	TOTPStore interface {
+		// Find finds the TOTP configuration of the principal.
+		Find(ctx context.Context, principalID int64) (*types.TOTP, error)
+
+		// Upsert creates the TOTP configuration of the principal or replaces the existing one.
+		Upsert(ctx context.Context, totp *types.TOTP) error
+
+		// Update updates the enabled state and the recovery codes of the TOTP configuration.
+		Update(ctx context.Context, totp *types.TOTP) error
+
+		// UpdateLastUsedStep stores the time step of an accepted code.
+		// It returns false if a code of the same or a later time step was already accepted.
+		UpdateLastUsedStep(ctx context.Context, principalID int64, step int64) (bool, error)
+
+		// UpdateRecoveryCodes replaces the recovery codes of the TOTP configuration.
+		// It returns false if the stored recovery codes don't match the expected ones anymore.
+		UpdateRecoveryCodes(ctx context.Context, principalID int64, expected []string, recoveryCodes []string) (bool, error)
+
+		// Delete deletes the TOTP configuration of the principal.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	// PrincipalIdentityStore defines the storage of the links of principals to external identities.
+	PrincipalIdentityStore interface {
+		// Find finds the identity of the external provider.
+		Find(ctx context.Context, provider string, externalID string) (*types.PrincipalIdentity, error)
+
+		// Create links the principal to the identity of the external provider.
+		Create(ctx context.Context, identity *types.PrincipalIdentity) error
+
+		// List lists the identities of the external provider ordered by their external id,
+		// starting after the provided external id.
+		List(ctx context.Context, provider string, afterExternalID string, limit int) ([]*types.PrincipalIdentity, error)
+
+		// DeleteByPrincipal unlinks the principal from all its identities of the external provider.
+		DeleteByPrincipal(ctx context.Context, provider string, principalID int64) error
 	}
 
 	// TokenStore defines the token data storage.
@@ -301,6 +466,9 @@ type (
 		// Create saves the token details.
 		Create(ctx context.Context, token *types.Token) error
 
+		// UpdateLastUsed updates the time the token was last used for authentication.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
@@ -534,6 +702,26 @@ type (
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
 	}
 
+	// WebhookRetryStore defines the storage of failed webhook deliveries waiting to be re-attempted.
+	WebhookRetryStore interface {
+		// Create queues a retry. Nothing happens if a retry for the same webhook and trigger is queued already.
+		Create(ctx context.Context, retry *types.WebhookRetry) error
+
+		// Update updates the execution, attempts and next attempt of a queued retry.
+		Update(ctx context.Context, retry *types.WebhookRetry) error
+
+		// Delete removes a retry from the queue.
+		Delete(ctx context.Context, id int64) error
+
+		// ListDue lists the retries that are due at the provided time, the longest overdue first.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.WebhookRetry, error)
+
+		// CreateForFailedSince queues a retry for every delivery of an enabled webhook
+		// that failed since the provided time and never succeeded, using the latest failed execution.
+		// It returns the number of queued retries.
+		CreateForFailedSince(ctx context.Context, since int64, now int64) (int64, error)
+	}
+
 	CheckStore interface {
 		// FindByIdentifier returns status check result for given unique key.
 		FindByIdentifier(ctx context.Context, repoID int64, commitSHA string, identifier string) (types.Check, error)
@@ -645,6 +833,10 @@ type (
 
 		// Count the number of executions in a space
 		Count(ctx context.Context, parentID int64) (int64, error)
+
+		// ListIncompleteInConcurrencyGroup lists the pending and running executions
+		// of the repo's concurrency group, oldest first.
+		ListIncompleteInConcurrencyGroup(ctx context.Context, repoID int64, group string) ([]*types.Execution, error)
 	}
 
 	StageStore interface {
@@ -674,6 +866,9 @@ type (
 	}
 
 	StepStore interface {
+		// Find returns a step from the datastore by ID.
+		Find(ctx context.Context, stepID int64) (*types.Step, error)
+
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
 
@@ -683,6 +878,180 @@ type (
 		// Update tries to update a step and returns an optimistic locking error if it was
 		// unable to do so.
 		Update(ctx context.Context, e *types.Step) error
+
+		// DeleteByStageID deletes all steps of a stage.
+		DeleteByStageID(ctx context.Context, stageID int64) error
+	}
+
+	// RunnerStore defines the remote runner data storage.
+	RunnerStore interface {
+		// Find finds the runner by id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByIdentifier finds the runner by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error)
+
+		// FindByTokenHash finds the runner by the hash of its token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create saves the runner details.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// Update updates the runner details.
+		Update(ctx context.Context, runner *types.Runner) error
+
+		// Delete deletes the runner.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists all runners.
+		List(ctx context.Context) ([]*types.Runner, error)
+	}
+
+	// EnvironmentStore defines the deployment environment data storage.
+	EnvironmentStore interface {
+		// Find finds the environment by id.
+		Find(ctx context.Context, id int64) (*types.Environment, error)
+
+		// FindByIdentifier finds the environment with the given identifier for the given parent.
+		FindByIdentifier(
+			ctx context.Context,
+			parentType enum.EnvironmentParent,
+			parentID int64,
+			identifier string,
+		) (*types.Environment, error)
+
+		// Create creates a new environment.
+		Create(ctx context.Context, environment *types.Environment) error
+
+		// Update updates an existing environment.
+		Update(ctx context.Context, environment *types.Environment) error
+
+		// Delete deletes the environment with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// Count counts the environments of the given parent.
+		Count(
+			ctx context.Context,
+			parentType enum.EnvironmentParent,
+			parentID int64,
+			filter *types.ListQueryFilter,
+		) (int64, error)
+
+		// List lists the environments of the given parent.
+		List(
+			ctx context.Context,
+			parentType enum.EnvironmentParent,
+			parentID int64,
+			filter *types.ListQueryFilter,
+		) ([]*types.Environment, error)
+	}
+
+	// DeploymentStore defines the deployment data storage.
+	DeploymentStore interface {
+		// Create creates a new deployment.
+		Create(ctx context.Context, deployment *types.Deployment) error
+
+		// Count counts the deployments to the environment.
+		Count(ctx context.Context, environmentID int64, filter *types.DeploymentFilter) (int64, error)
+
+		// List lists the deployments to the environment, most recent first.
+		List(ctx context.Context, environmentID int64, filter *types.DeploymentFilter) ([]*types.Deployment, error)
+
+		// ListLatestByRepo lists the most recent successful deployment of the repo to each environment.
+		ListLatestByRepo(ctx context.Context, repoID int64) ([]*types.Deployment, error)
+	}
+
+	StageApprovalStore interface {
+		// Create creates the approval requirements of an approval stage.
+		Create(ctx context.Context, approval *types.StageApproval) error
+
+		// FindByStageID finds the approval requirements of the approval stage.
+		FindByStageID(ctx context.Context, stageID int64) (*types.StageApproval, error)
+
+		// ListByExecution lists the approval requirements of all approval stages of the execution.
+		ListByExecution(ctx context.Context, executionID int64) ([]*types.StageApproval, error)
+
+		// ListExpired lists the approvals of blocked stages whose timeout passed.
+		ListExpired(ctx context.Context, now int64) ([]*types.StageApproval, error)
+
+		// UpsertDecision creates or replaces the decision of a principal on an approval stage.
+		UpsertDecision(ctx context.Context, decision *types.ApprovalDecision) error
+
+		// ListDecisions lists the decisions on the approval stage, in the order they got made.
+		ListDecisions(ctx context.Context, stageID int64) ([]*types.ApprovalDecision, error)
+	}
+
+	ArtifactStore interface {
+		// Create creates a new artifact.
+		Create(ctx context.Context, artifact *types.Artifact) error
+
+		// Find returns an artifact given its ID.
+		Find(ctx context.Context, id int64) (*types.Artifact, error)
+
+		// FindByName returns the artifact of the execution with the given name.
+		FindByName(ctx context.Context, executionID int64, name string) (*types.Artifact, error)
+
+		// ListByExecution lists all artifacts of the execution, ordered by name.
+		ListByExecution(ctx context.Context, executionID int64) ([]*types.Artifact, error)
+
+		// ListCreatedBefore lists up to limit artifacts that were created before the provided time (unix millis).
+		ListCreatedBefore(ctx context.Context, before int64, limit int) ([]*types.Artifact, error)
+
+		// Delete deletes the artifact with the given ID.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	CacheEntryStore interface {
+		// Find returns the cache entry of the repo branch with the given key.
+		Find(ctx context.Context, repoID int64, branch string, key string) (*types.CacheEntry, error)
+
+		// FindByKeyPrefix returns the most recently created cache entry of the repo branch
+		// whose key starts with the given prefix.
+		FindByKeyPrefix(ctx context.Context, repoID int64, branch string, prefix string) (*types.CacheEntry, error)
+
+		// Upsert creates a new or replaces the existing cache entry of the repo branch with the same key.
+		Upsert(ctx context.Context, entry *types.CacheEntry) error
+
+		// UpdateLastUsed updates the time (unix millis) the cache entry was last restored.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+
+		// TotalSize returns the size of all cache entries of the repo.
+		TotalSize(ctx context.Context, repoID int64) (int64, error)
+
+		// ListLeastRecentlyUsed lists up to limit cache entries of the repo, least recently used first.
+		ListLeastRecentlyUsed(ctx context.Context, repoID int64, limit int) ([]*types.CacheEntry, error)
+
+		// ListUnusedSince lists up to limit cache entries of all repos that weren't used since the provided time.
+		ListUnusedSince(ctx context.Context, since int64, limit int) ([]*types.CacheEntry, error)
+
+		// Delete deletes the cache entry with the given ID.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	TestReportStore interface {
+		// Create creates a new test report.
+		Create(ctx context.Context, report *types.TestReport) error
+
+		// CreateCase creates a new test case of a test report.
+		CreateCase(ctx context.Context, testCase *types.TestCase) error
+
+		// ListCases lists the test cases of all test reports of a commit.
+		ListCases(ctx context.Context, repoID int64, commitSHA string,
+			opts types.TestCaseListOptions) ([]*types.TestCase, error)
+
+		// CountCases returns the number of test cases of all test reports of a commit.
+		CountCases(ctx context.Context, repoID int64, commitSHA string, opts types.TestCaseListOptions) (int, error)
+
+		// Summarize returns the aggregated test counts of the test reports of a commit, per status check.
+		Summarize(ctx context.Context, repoID int64, commitSHA string) ([]types.TestSummary, error)
+
+		// FindLatestCommitOnBranch returns the commit SHA of the most recent test report
+		// that was uploaded for the status check on the branch.
+		FindLatestCommitOnBranch(ctx context.Context, repoID int64, branch string, checkIdentifier string) (string, error)
+
+		// ListFlaky lists up to limit tests of the repo that both passed and failed
+		// for the same commit and status check in a test report created since the provided time (unix millis).
+		ListFlaky(ctx context.Context, repoID int64, since int64, limit int) ([]types.FlakyTest, error)
 	}
 
 	ConnectorStore interface {
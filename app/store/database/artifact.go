@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ArtifactStore = (*ArtifactStore)(nil)
+
+// NewArtifactStore returns a new ArtifactStore.
+func NewArtifactStore(db *sqlx.DB) *ArtifactStore {
+	return &ArtifactStore{
+		db: db,
+	}
+}
+
+// ArtifactStore implements store.ArtifactStore backed by a relational database.
+// Artifacts intentionally don't reference their execution or repository via foreign keys:
+// the rows have to outlive a deleted execution until the cleanup removed the blobs they point to.
+type ArtifactStore struct {
+	db *sqlx.DB
+}
+
+type artifact struct {
+	ID          int64  `db:"artifact_id"`
+	RepoID      int64  `db:"artifact_repo_id"`
+	ExecutionID int64  `db:"artifact_execution_id"`
+	StageID     int64  `db:"artifact_stage_id"`
+	Name        string `db:"artifact_name"`
+	ContentType string `db:"artifact_content_type"`
+	Size        int64  `db:"artifact_size"`
+	BlobPath    string `db:"artifact_blob_path"`
+	Created     int64  `db:"artifact_created"`
+}
+
+const (
+	artifactColumns = `
+		 artifact_id
+		,artifact_repo_id
+		,artifact_execution_id
+		,artifact_stage_id
+		,artifact_name
+		,artifact_content_type
+		,artifact_size
+		,artifact_blob_path
+		,artifact_created`
+
+	artifactSelectBase = `
+	SELECT` + artifactColumns + `
+	FROM artifacts`
+)
+
+// Create creates a new artifact.
+func (s *ArtifactStore) Create(ctx context.Context, a *types.Artifact) error {
+	const sqlQuery = `
+	INSERT INTO artifacts (
+		 artifact_repo_id
+		,artifact_execution_id
+		,artifact_stage_id
+		,artifact_name
+		,artifact_content_type
+		,artifact_size
+		,artifact_blob_path
+		,artifact_created
+	) values (
+		 :artifact_repo_id
+		,:artifact_execution_id
+		,:artifact_stage_id
+		,:artifact_name
+		,:artifact_content_type
+		,:artifact_size
+		,:artifact_blob_path
+		,:artifact_created
+	) RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalArtifact(a))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert artifact")
+	}
+
+	return nil
+}
+
+// Find returns an artifact given its ID.
+func (s *ArtifactStore) Find(ctx context.Context, id int64) (*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+	WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &artifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find artifact")
+	}
+
+	return mapToArtifact(dst), nil
+}
+
+// FindByName returns the artifact of the execution with the given name.
+func (s *ArtifactStore) FindByName(ctx context.Context, executionID int64, name string) (*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+	WHERE artifact_execution_id = $1 AND artifact_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &artifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, executionID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find artifact by name")
+	}
+
+	return mapToArtifact(dst), nil
+}
+
+// ListByExecution lists all artifacts of the execution, ordered by name.
+func (s *ArtifactStore) ListByExecution(ctx context.Context, executionID int64) ([]*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+	WHERE artifact_execution_id = $1
+	ORDER BY artifact_name`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*artifact{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list artifacts")
+	}
+
+	return mapToArtifacts(dst), nil
+}
+
+// ListCreatedBefore lists up to limit artifacts that were created before the provided time (unix millis).
+func (s *ArtifactStore) ListCreatedBefore(ctx context.Context, before int64, limit int) ([]*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+	WHERE artifact_created < $1
+	ORDER BY artifact_created
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*artifact{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, before, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list old artifacts")
+	}
+
+	return mapToArtifacts(dst), nil
+}
+
+// Delete deletes the artifact with the given ID.
+func (s *ArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM artifacts
+	WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete artifact")
+	}
+
+	return nil
+}
+
+func mapToInternalArtifact(in *types.Artifact) *artifact {
+	return &artifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		Name:        in.Name,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		BlobPath:    in.BlobPath,
+		Created:     in.Created,
+	}
+}
+
+func mapToArtifact(in *artifact) *types.Artifact {
+	return &types.Artifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		StageID:     in.StageID,
+		Name:        in.Name,
+		ContentType: in.ContentType,
+		Size:        in.Size,
+		BlobPath:    in.BlobPath,
+		Created:     in.Created,
+	}
+}
+
+func mapToArtifacts(in []*artifact) []*types.Artifact {
+	res := make([]*types.Artifact, len(in))
+	for i := range in {
+		res[i] = mapToArtifact(in[i])
+	}
+	return res
+}
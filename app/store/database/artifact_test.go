@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_Artifact(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	artifactStore := database.NewArtifactStore(db)
+
+	ctx := context.Background()
+
+	for i, name := range []string{"dist/app.tar.gz", "coverage.html"} {
+		a := &types.Artifact{
+			RepoID:      1,
+			ExecutionID: 1,
+			StageID:     1,
+			Name:        name,
+			ContentType: "application/octet-stream",
+			Size:        int64(10 * (i + 1)),
+			BlobPath:    "artifacts/1/1/" + name,
+			Created:     int64(i + 1),
+		}
+		if err := artifactStore.Create(ctx, a); err != nil {
+			t.Fatalf("failed to create artifact: %v", err)
+		}
+		if a.ID == 0 {
+			t.Fatalf("artifact id wasn't set")
+		}
+	}
+
+	duplicate := &types.Artifact{ExecutionID: 1, Name: "coverage.html", Created: 3}
+	if err := artifactStore.Create(ctx, duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got: %v", err)
+	}
+
+	list, err := artifactStore.ListByExecution(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list artifacts: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "coverage.html" || list[1].Name != "dist/app.tar.gz" {
+		t.Fatalf("unexpected artifacts: %+v", list)
+	}
+
+	found, err := artifactStore.FindByName(ctx, 1, "dist/app.tar.gz")
+	if err != nil {
+		t.Fatalf("failed to find artifact by name: %v", err)
+	}
+	if found.Size != 10 || found.BlobPath != "artifacts/1/1/dist/app.tar.gz" {
+		t.Errorf("unexpected artifact: %+v", found)
+	}
+
+	old, err := artifactStore.ListCreatedBefore(ctx, 2, 10)
+	if err != nil {
+		t.Fatalf("failed to list old artifacts: %v", err)
+	}
+	if len(old) != 1 || old[0].ID != found.ID {
+		t.Fatalf("unexpected old artifacts: %+v", old)
+	}
+
+	if err = artifactStore.Delete(ctx, found.ID); err != nil {
+		t.Fatalf("failed to delete artifact: %v", err)
+	}
+
+	if _, err = artifactStore.Find(ctx, found.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
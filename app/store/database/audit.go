@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.AuditStore = (*AuditStore)(nil)
+
+// NewAuditStore returns a new AuditStore.
+func NewAuditStore(db *sqlx.DB) *AuditStore {
+	return &AuditStore{
+		db: db,
+	}
+}
+
+// AuditStore implements store.AuditStore backed by a relational database.
+type AuditStore struct {
+	db *sqlx.DB
+}
+
+type auditEvent struct {
+	ID                 string `db:"audit_event_id"`
+	Timestamp          int64  `db:"audit_event_timestamp"`
+	Action             string `db:"audit_event_action"`
+	ResourceType       string `db:"audit_event_resource_type"`
+	ResourceIdentifier string `db:"audit_event_resource_identifier"`
+	SpacePath          string `db:"audit_event_space_path"`
+	PrincipalID        int64  `db:"audit_event_principal_id"`
+	PrincipalUID       string `db:"audit_event_principal_uid"`
+	PrincipalType      string `db:"audit_event_principal_type"`
+	ClientIP           string `db:"audit_event_client_ip"`
+	RequestMethod      string `db:"audit_event_request_method"`
+	OldObject          string `db:"audit_event_old_object"`
+	NewObject          string `db:"audit_event_new_object"`
+	Data               string `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_timestamp
+		,audit_event_action
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_space_path
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_type
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_data`
+)
+
+// Create saves the audit event.
+func (s *AuditStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+	INSERT INTO audit_events (` + auditEventColumns + `
+	) values (
+		 :audit_event_id
+		,:audit_event_timestamp
+		,:audit_event_action
+		,:audit_event_resource_type
+		,:audit_event_resource_identifier
+		,:audit_event_space_path
+		,:audit_event_principal_id
+		,:audit_event_principal_uid
+		,:audit_event_principal_type
+		,:audit_event_client_ip
+		,:audit_event_request_method
+		,:audit_event_old_object
+		,:audit_event_new_object
+		,:audit_event_data
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEvent, err := mapToInternalAuditEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to map audit event: %w", err)
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEvent)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert audit event")
+	}
+
+	return nil
+}
+
+// List returns the audit events matching the filter, ordered by timestamp.
+func (s *AuditStore) List(ctx context.Context, filter *types.AuditFilter) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	order := filter.Order.String()
+	stmt = stmt.OrderBy("audit_event_timestamp "+order, "audit_event_id "+order)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*auditEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing audit event list query")
+	}
+
+	return mapToAuditEvents(dst)
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditStore) Count(ctx context.Context, filter *types.AuditFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing audit event count query")
+	}
+
+	return count, nil
+}
+
+func (*AuditStore) applyFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditFilter,
+) squirrel.SelectBuilder {
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if filter.PrincipalUID != "" {
+		stmt = stmt.Where("audit_event_principal_uid = ?", filter.PrincipalUID)
+	}
+
+	if filter.SpacePath != "" {
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Eq{"audit_event_space_path": filter.SpacePath},
+			squirrel.Like{"audit_event_space_path": filter.SpacePath + "/%"},
+		})
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(audit_event_resource_identifier) LIKE ?",
+			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_timestamp < ?", filter.CreatedLt)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_timestamp > ?", filter.CreatedGt)
+	}
+
+	return stmt
+}
+
+func mapToInternalAuditEvent(event *types.AuditEvent) (*auditEvent, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	return &auditEvent{
+		ID:                 event.ID,
+		Timestamp:          event.Timestamp,
+		Action:             event.Action,
+		ResourceType:       event.ResourceType,
+		ResourceIdentifier: event.ResourceIdentifier,
+		SpacePath:          event.SpacePath,
+		PrincipalID:        event.PrincipalID,
+		PrincipalUID:       event.PrincipalUID,
+		PrincipalType:      string(event.PrincipalType),
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		OldObject:          string(event.OldObject),
+		NewObject:          string(event.NewObject),
+		Data:               string(data),
+	}, nil
+}
+
+func mapToAuditEvent(event *auditEvent) (*types.AuditEvent, error) {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event data: %w", err)
+	}
+
+	return &types.AuditEvent{
+		ID:                 event.ID,
+		Timestamp:          event.Timestamp,
+		Action:             event.Action,
+		ResourceType:       event.ResourceType,
+		ResourceIdentifier: event.ResourceIdentifier,
+		SpacePath:          event.SpacePath,
+		PrincipalID:        event.PrincipalID,
+		PrincipalUID:       event.PrincipalUID,
+		PrincipalType:      enum.PrincipalType(event.PrincipalType),
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		OldObject:          rawMessageOrNil(event.OldObject),
+		NewObject:          rawMessageOrNil(event.NewObject),
+		Data:               data,
+	}, nil
+}
+
+func mapToAuditEvents(events []*auditEvent) ([]*types.AuditEvent, error) {
+	res := make([]*types.AuditEvent, len(events))
+	for i := range events {
+		var err error
+		if res[i], err = mapToAuditEvent(events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func rawMessageOrNil(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
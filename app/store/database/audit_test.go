@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_Audit(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	auditStore := database.NewAuditStore(db)
+
+	ctx := context.Background()
+
+	events := []*types.AuditEvent{
+		{
+			ID:                 "1",
+			Timestamp:          100,
+			Action:             "created",
+			ResourceType:       "repository",
+			ResourceIdentifier: "repo1",
+			SpacePath:          "space1",
+			PrincipalID:        1,
+			PrincipalUID:       "admin",
+			PrincipalType:      enum.PrincipalTypeUser,
+			NewObject:          json.RawMessage(`{"identifier":"repo1"}`),
+			Data:               map[string]string{"request_id": "abc"},
+		},
+		{
+			ID:                 "2",
+			Timestamp:          200,
+			Action:             "deleted",
+			ResourceType:       "webhook",
+			ResourceIdentifier: "hook1",
+			SpacePath:          "space1/sub",
+			PrincipalID:        2,
+			PrincipalUID:       "user",
+			PrincipalType:      enum.PrincipalTypeUser,
+		},
+		{
+			ID:                 "3",
+			Timestamp:          300,
+			Action:             "login",
+			ResourceType:       "user",
+			ResourceIdentifier: "user",
+			PrincipalID:        2,
+			PrincipalUID:       "user",
+			PrincipalType:      enum.PrincipalTypeUser,
+		},
+		{
+			ID:                 "4",
+			Timestamp:          400,
+			Action:             "created",
+			ResourceType:       "secret",
+			ResourceIdentifier: "secret1",
+			SpacePath:          "space10",
+			PrincipalID:        1,
+			PrincipalUID:       "admin",
+			PrincipalType:      enum.PrincipalTypeUser,
+		},
+	}
+
+	for _, event := range events {
+		if err := auditStore.Create(ctx, event); err != nil {
+			t.Fatalf("failed to create audit event: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.AuditFilter
+		expIDs []string
+	}{
+		{
+			name:   "all",
+			filter: types.AuditFilter{},
+			expIDs: []string{"4", "3", "2", "1"},
+		},
+		{
+			name:   "ascending",
+			filter: types.AuditFilter{Order: enum.OrderAsc},
+			expIDs: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:   "action",
+			filter: types.AuditFilter{Actions: []string{"created"}},
+			expIDs: []string{"4", "1"},
+		},
+		{
+			name:   "resource type",
+			filter: types.AuditFilter{ResourceTypes: []string{"webhook", "user"}},
+			expIDs: []string{"3", "2"},
+		},
+		{
+			name:   "principal",
+			filter: types.AuditFilter{PrincipalUID: "admin"},
+			expIDs: []string{"4", "1"},
+		},
+		{
+			name:   "space path includes descendants",
+			filter: types.AuditFilter{SpacePath: "space1"},
+			expIDs: []string{"2", "1"},
+		},
+		{
+			name:   "created range",
+			filter: types.AuditFilter{CreatedFilter: types.CreatedFilter{CreatedGt: 100, CreatedLt: 400}},
+			expIDs: []string{"3", "2"},
+		},
+		{
+			name:   "query",
+			filter: types.AuditFilter{ListQueryFilter: types.ListQueryFilter{Query: "HOOK"}},
+			expIDs: []string{"2"},
+		},
+		{
+			name: "page",
+			filter: types.AuditFilter{ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: 2, Size: 3},
+			}},
+			expIDs: []string{"1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list audit events: %v", err)
+			}
+
+			ids := make([]string, len(list))
+			for i := range list {
+				ids[i] = list[i].ID
+			}
+			if !reflect.DeepEqual(test.expIDs, ids) {
+				t.Errorf("id mismatch: want=%v got=%v", test.expIDs, ids)
+			}
+
+			if test.filter.Page > 1 {
+				return
+			}
+
+			count, err := auditStore.Count(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to count audit events: %v", err)
+			}
+			if count != int64(len(test.expIDs)) {
+				t.Errorf("count mismatch: want=%d got=%d", len(test.expIDs), count)
+			}
+		})
+	}
+
+	list, err := auditStore.List(ctx, &types.AuditFilter{Order: enum.OrderAsc})
+	if err != nil {
+		t.Fatalf("failed to list audit events: %v", err)
+	}
+	if !reflect.DeepEqual(events[0], list[0]) {
+		t.Errorf("audit event mismatch: want=%+v got=%+v", events[0], list[0])
+	}
+}
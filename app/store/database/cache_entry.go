@@ -0,0 +1,277 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CacheEntryStore = (*CacheEntryStore)(nil)
+
+// NewCacheEntryStore returns a new CacheEntryStore.
+func NewCacheEntryStore(db *sqlx.DB) *CacheEntryStore {
+	return &CacheEntryStore{
+		db: db,
+	}
+}
+
+// CacheEntryStore implements store.CacheEntryStore backed by a relational database.
+// Like artifacts, cache entries don't reference their repository via a foreign key,
+// the rows have to outlive a purged repository until the cleanup removed the blobs they point to.
+type CacheEntryStore struct {
+	db *sqlx.DB
+}
+
+type cacheEntry struct {
+	ID       int64  `db:"cache_entry_id"`
+	RepoID   int64  `db:"cache_entry_repo_id"`
+	Branch   string `db:"cache_entry_branch"`
+	Key      string `db:"cache_entry_key"`
+	Size     int64  `db:"cache_entry_size"`
+	BlobPath string `db:"cache_entry_blob_path"`
+	Created  int64  `db:"cache_entry_created"`
+	LastUsed int64  `db:"cache_entry_last_used"`
+}
+
+const (
+	cacheEntryColumns = `
+		 cache_entry_id
+		,cache_entry_repo_id
+		,cache_entry_branch
+		,cache_entry_key
+		,cache_entry_size
+		,cache_entry_blob_path
+		,cache_entry_created
+		,cache_entry_last_used`
+
+	cacheEntrySelectBase = `
+	SELECT` + cacheEntryColumns + `
+	FROM cache_entries`
+)
+
+// Find returns the cache entry of the repo branch with the given key.
+func (s *CacheEntryStore) Find(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+	key string,
+) (*types.CacheEntry, error) {
+	const sqlQuery = cacheEntrySelectBase + `
+	WHERE cache_entry_repo_id = $1 AND cache_entry_branch = $2 AND cache_entry_key = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &cacheEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, branch, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find cache entry")
+	}
+
+	return mapToCacheEntry(dst), nil
+}
+
+// FindByKeyPrefix returns the most recently created cache entry of the repo branch
+// whose key starts with the given prefix.
+func (s *CacheEntryStore) FindByKeyPrefix(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+	prefix string,
+) (*types.CacheEntry, error) {
+	// SUBSTR is used instead of LIKE to avoid having to escape wildcards in the prefix.
+	const sqlQuery = cacheEntrySelectBase + `
+	WHERE cache_entry_repo_id = $1 AND cache_entry_branch = $2 AND SUBSTR(cache_entry_key, 1, $3) = $4
+	ORDER BY cache_entry_created DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &cacheEntry{}
+	err := db.GetContext(ctx, dst, sqlQuery, repoID, branch, utf8.RuneCountInString(prefix), prefix)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find cache entry by key prefix")
+	}
+
+	return mapToCacheEntry(dst), nil
+}
+
+// Upsert creates a new or replaces the existing cache entry of the repo branch with the same key.
+func (s *CacheEntryStore) Upsert(ctx context.Context, e *types.CacheEntry) error {
+	const sqlQuery = `
+	INSERT INTO cache_entries (
+		 cache_entry_repo_id
+		,cache_entry_branch
+		,cache_entry_key
+		,cache_entry_size
+		,cache_entry_blob_path
+		,cache_entry_created
+		,cache_entry_last_used
+	) values (
+		 :cache_entry_repo_id
+		,:cache_entry_branch
+		,:cache_entry_key
+		,:cache_entry_size
+		,:cache_entry_blob_path
+		,:cache_entry_created
+		,:cache_entry_last_used
+	)
+	ON CONFLICT (cache_entry_repo_id, cache_entry_branch, cache_entry_key) DO UPDATE
+	SET
+		 cache_entry_size = EXCLUDED.cache_entry_size
+		,cache_entry_blob_path = EXCLUDED.cache_entry_blob_path
+		,cache_entry_created = EXCLUDED.cache_entry_created
+		,cache_entry_last_used = EXCLUDED.cache_entry_last_used
+	RETURNING cache_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalCacheEntry(e))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind cache entry object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&e.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert cache entry")
+	}
+
+	return nil
+}
+
+// UpdateLastUsed updates the time (unix millis) the cache entry was last restored.
+func (s *CacheEntryStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	const sqlQuery = `
+	UPDATE cache_entries
+	SET cache_entry_last_used = $1
+	WHERE cache_entry_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update last used time of cache entry")
+	}
+
+	return nil
+}
+
+// TotalSize returns the size of all cache entries of the repo.
+func (s *CacheEntryStore) TotalSize(ctx context.Context, repoID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT COALESCE(SUM(cache_entry_size), 0)
+	FROM cache_entries
+	WHERE cache_entry_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var size int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID).Scan(&size); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get total size of cache entries")
+	}
+
+	return size, nil
+}
+
+// ListLeastRecentlyUsed lists up to limit cache entries of the repo, least recently used first.
+func (s *CacheEntryStore) ListLeastRecentlyUsed(
+	ctx context.Context,
+	repoID int64,
+	limit int,
+) ([]*types.CacheEntry, error) {
+	const sqlQuery = cacheEntrySelectBase + `
+	WHERE cache_entry_repo_id = $1
+	ORDER BY cache_entry_last_used, cache_entry_id
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*cacheEntry{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list least recently used cache entries")
+	}
+
+	return mapToCacheEntries(dst), nil
+}
+
+// ListUnusedSince lists up to limit cache entries of all repos that weren't used since the provided time.
+func (s *CacheEntryStore) ListUnusedSince(ctx context.Context, since int64, limit int) ([]*types.CacheEntry, error) {
+	const sqlQuery = cacheEntrySelectBase + `
+	WHERE cache_entry_last_used < $1
+	ORDER BY cache_entry_last_used
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*cacheEntry{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, since, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list unused cache entries")
+	}
+
+	return mapToCacheEntries(dst), nil
+}
+
+// Delete deletes the cache entry with the given ID.
+func (s *CacheEntryStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM cache_entries
+	WHERE cache_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete cache entry")
+	}
+
+	return nil
+}
+
+func mapToInternalCacheEntry(in *types.CacheEntry) *cacheEntry {
+	return &cacheEntry{
+		ID:       in.ID,
+		RepoID:   in.RepoID,
+		Branch:   in.Branch,
+		Key:      in.Key,
+		Size:     in.Size,
+		BlobPath: in.BlobPath,
+		Created:  in.Created,
+		LastUsed: in.LastUsed,
+	}
+}
+
+func mapToCacheEntry(in *cacheEntry) *types.CacheEntry {
+	return &types.CacheEntry{
+		ID:       in.ID,
+		RepoID:   in.RepoID,
+		Branch:   in.Branch,
+		Key:      in.Key,
+		Size:     in.Size,
+		BlobPath: in.BlobPath,
+		Created:  in.Created,
+		LastUsed: in.LastUsed,
+	}
+}
+
+func mapToCacheEntries(in []*cacheEntry) []*types.CacheEntry {
+	res := make([]*types.CacheEntry, len(in))
+	for i := range in {
+		res[i] = mapToCacheEntry(in[i])
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_CacheEntry(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	cacheEntryStore := database.NewCacheEntryStore(db)
+
+	ctx := context.Background()
+
+	entries := []*types.CacheEntry{
+		{RepoID: 1, Branch: "main", Key: "go-mod-aaa", Size: 10, BlobPath: "a", Created: 1, LastUsed: 5},
+		{RepoID: 1, Branch: "main", Key: "go-mod-bbb", Size: 20, BlobPath: "b", Created: 2, LastUsed: 2},
+		{RepoID: 1, Branch: "feature", Key: "go_mod-ccc", Size: 40, BlobPath: "c", Created: 3, LastUsed: 3},
+	}
+	for _, e := range entries {
+		if err := cacheEntryStore.Upsert(ctx, e); err != nil {
+			t.Fatalf("failed to create cache entry: %v", err)
+		}
+	}
+
+	replaced := &types.CacheEntry{RepoID: 1, Branch: "main", Key: "go-mod-aaa", Size: 15, BlobPath: "a2",
+		Created: 4, LastUsed: 4}
+	if err := cacheEntryStore.Upsert(ctx, replaced); err != nil {
+		t.Fatalf("failed to replace cache entry: %v", err)
+	}
+	if replaced.ID != entries[0].ID {
+		t.Errorf("expected the cache entry to be replaced, got id %d instead of %d", replaced.ID, entries[0].ID)
+	}
+
+	found, err := cacheEntryStore.Find(ctx, 1, "main", "go-mod-aaa")
+	if err != nil {
+		t.Fatalf("failed to find cache entry: %v", err)
+	}
+	if found.Size != 15 || found.BlobPath != "a2" {
+		t.Errorf("unexpected cache entry: %+v", found)
+	}
+
+	found, err = cacheEntryStore.FindByKeyPrefix(ctx, 1, "main", "go-mod-")
+	if err != nil {
+		t.Fatalf("failed to find cache entry by prefix: %v", err)
+	}
+	if found.Key != "go-mod-aaa" {
+		t.Errorf("expected most recent cache entry, got: %+v", found)
+	}
+
+	// underscores must not be treated as wildcards.
+	_, err = cacheEntryStore.FindByKeyPrefix(ctx, 1, "feature", "go-mod")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+
+	size, err := cacheEntryStore.TotalSize(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to get total size: %v", err)
+	}
+	if size != 75 {
+		t.Errorf("expected total size 75, got %d", size)
+	}
+
+	if err = cacheEntryStore.UpdateLastUsed(ctx, entries[1].ID, 10); err != nil {
+		t.Fatalf("failed to update last used: %v", err)
+	}
+
+	lru, err := cacheEntryStore.ListLeastRecentlyUsed(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("failed to list least recently used: %v", err)
+	}
+	if len(lru) != 2 || lru[0].Key != "go_mod-ccc" || lru[1].Key != "go-mod-aaa" {
+		t.Fatalf("unexpected least recently used cache entries: %+v", lru)
+	}
+
+	unused, err := cacheEntryStore.ListUnusedSince(ctx, 4, 10)
+	if err != nil {
+		t.Fatalf("failed to list unused cache entries: %v", err)
+	}
+	if len(unused) != 1 || unused[0].Key != "go_mod-ccc" {
+		t.Fatalf("unexpected unused cache entries: %+v", unused)
+	}
+
+	if err = cacheEntryStore.Delete(ctx, unused[0].ID); err != nil {
+		t.Fatalf("failed to delete cache entry: %v", err)
+	}
+
+	if _, err = cacheEntryStore.Find(ctx, 1, "feature", "go_mod-ccc"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.DeploymentStore = (*DeploymentStore)(nil)
+
+// NewDeploymentStore returns a new DeploymentStore.
+func NewDeploymentStore(db *sqlx.DB) *DeploymentStore {
+	return &DeploymentStore{
+		db: db,
+	}
+}
+
+// DeploymentStore implements store.DeploymentStore backed by a relational database.
+// The status of a deployment isn't stored, it's taken from its execution.
+type DeploymentStore struct {
+	db *sqlx.DB
+}
+
+// deployment is an internal representation used to store deployment data in the database.
+type deployment struct {
+	ID            int64  `db:"deployment_id"`
+	EnvironmentID int64  `db:"deployment_environment_id"`
+	RepoID        int64  `db:"deployment_repo_id"`
+	PipelineID    int64  `db:"deployment_pipeline_id"`
+	ExecutionID   int64  `db:"deployment_execution_id"`
+	Ref           string `db:"deployment_ref"`
+	SHA           string `db:"deployment_sha"`
+	CreatedBy     int64  `db:"deployment_created_by"`
+	Created       int64  `db:"deployment_created"`
+}
+
+// deploymentInfo extends a deployment with the details of its environment, pipeline and execution.
+type deploymentInfo struct {
+	deployment
+	EnvironmentIdentifier string        `db:"environment_uid"`
+	PipelineIdentifier    string        `db:"pipeline_uid"`
+	ExecutionNumber       int64         `db:"execution_number"`
+	Status                enum.CIStatus `db:"execution_status"`
+	Started               int64         `db:"execution_started"`
+	Finished              int64         `db:"execution_finished"`
+}
+
+const (
+	deploymentInfoColumns = `
+		 deployment_id
+		,deployment_environment_id
+		,deployment_repo_id
+		,deployment_pipeline_id
+		,deployment_execution_id
+		,deployment_ref
+		,deployment_sha
+		,deployment_created_by
+		,deployment_created
+		,environment_uid
+		,pipeline_uid
+		,execution_number
+		,execution_status
+		,execution_started
+		,execution_finished`
+
+	deploymentInfoSelectBase = `
+	SELECT` + deploymentInfoColumns + `
+	FROM deployments
+	INNER JOIN environments ON environment_id = deployment_environment_id
+	INNER JOIN pipelines ON pipeline_id = deployment_pipeline_id
+	INNER JOIN executions ON execution_id = deployment_execution_id`
+)
+
+// Create creates a new deployment.
+func (s *DeploymentStore) Create(ctx context.Context, d *types.Deployment) error {
+	const sqlQuery = `
+	INSERT INTO deployments (
+		 deployment_environment_id
+		,deployment_repo_id
+		,deployment_pipeline_id
+		,deployment_execution_id
+		,deployment_ref
+		,deployment_sha
+		,deployment_created_by
+		,deployment_created
+	) values (
+		 :deployment_environment_id
+		,:deployment_repo_id
+		,:deployment_pipeline_id
+		,:deployment_execution_id
+		,:deployment_ref
+		,:deployment_sha
+		,:deployment_created_by
+		,:deployment_created
+	) RETURNING deployment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalDeployment(d))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind deployment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&d.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert deployment")
+	}
+
+	return nil
+}
+
+// Count counts the deployments to the environment.
+func (s *DeploymentStore) Count(
+	ctx context.Context,
+	environmentID int64,
+	filter *types.DeploymentFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("deployments").
+		InnerJoin("executions ON execution_id = deployment_execution_id").
+		Where("deployment_environment_id = ?", environmentID)
+
+	if filter.Status != "" {
+		stmt = stmt.Where("execution_status = ?", filter.Status)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List lists the deployments to the environment, most recent first.
+func (s *DeploymentStore) List(
+	ctx context.Context,
+	environmentID int64,
+	filter *types.DeploymentFilter,
+) ([]*types.Deployment, error) {
+	stmt := database.Builder.
+		Select(deploymentInfoColumns).
+		From("deployments").
+		InnerJoin("environments ON environment_id = deployment_environment_id").
+		InnerJoin("pipelines ON pipeline_id = deployment_pipeline_id").
+		InnerJoin("executions ON execution_id = deployment_execution_id").
+		Where("deployment_environment_id = ?", environmentID)
+
+	if filter.Status != "" {
+		stmt = stmt.Where("execution_status = ?", filter.Status)
+	}
+
+	stmt = stmt.OrderBy("deployment_id DESC")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*deploymentInfo{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list deployments")
+	}
+
+	return mapToDeployments(dst), nil
+}
+
+// ListLatestByRepo lists the most recent successful deployment of the repo to each environment.
+func (s *DeploymentStore) ListLatestByRepo(ctx context.Context, repoID int64) ([]*types.Deployment, error) {
+	const sqlQuery = deploymentInfoSelectBase + `
+	WHERE deployment_id IN (
+		SELECT MAX(deployment_id)
+		FROM deployments
+		INNER JOIN executions ON execution_id = deployment_execution_id
+		WHERE deployment_repo_id = $1 AND execution_status = $2
+		GROUP BY deployment_environment_id
+	)
+	ORDER BY LOWER(environment_uid)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*deploymentInfo{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, enum.CIStatusSuccess); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list latest deployments")
+	}
+
+	return mapToDeployments(dst), nil
+}
+
+func mapToInternalDeployment(in *types.Deployment) *deployment {
+	return &deployment{
+		ID:            in.ID,
+		EnvironmentID: in.EnvironmentID,
+		RepoID:        in.RepoID,
+		PipelineID:    in.PipelineID,
+		ExecutionID:   in.ExecutionID,
+		Ref:           in.Ref,
+		SHA:           in.SHA,
+		CreatedBy:     in.CreatedBy,
+		Created:       in.Created,
+	}
+}
+
+func mapToDeployments(in []*deploymentInfo) []*types.Deployment {
+	res := make([]*types.Deployment, len(in))
+	for i, d := range in {
+		res[i] = &types.Deployment{
+			ID:                    d.ID,
+			EnvironmentID:         d.EnvironmentID,
+			EnvironmentIdentifier: d.EnvironmentIdentifier,
+			RepoID:                d.RepoID,
+			PipelineID:            d.PipelineID,
+			PipelineIdentifier:    d.PipelineIdentifier,
+			ExecutionID:           d.ExecutionID,
+			ExecutionNumber:       d.ExecutionNumber,
+			Ref:                   d.Ref,
+			SHA:                   d.SHA,
+			Status:                d.Status,
+			Started:               d.Started,
+			Finished:              d.Finished,
+			CreatedBy:             d.CreatedBy,
+			Created:               d.Created,
+		}
+	}
+
+	return res
+}
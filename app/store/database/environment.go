@@ -0,0 +1,394 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.EnvironmentStore = (*EnvironmentStore)(nil)
+
+// NewEnvironmentStore returns a new EnvironmentStore.
+func NewEnvironmentStore(db *sqlx.DB) *EnvironmentStore {
+	return &EnvironmentStore{
+		db: db,
+	}
+}
+
+// EnvironmentStore implements store.EnvironmentStore backed by a relational database.
+type EnvironmentStore struct {
+	db *sqlx.DB
+}
+
+// environment is an internal representation used to store environment data in the database.
+type environment struct {
+	ID          int64    `db:"environment_id"`
+	Version     int64    `db:"environment_version"`
+	SpaceID     null.Int `db:"environment_space_id"`
+	RepoID      null.Int `db:"environment_repo_id"`
+	Identifier  string   `db:"environment_uid"`
+	Description string   `db:"environment_description"`
+	Protection  string   `db:"environment_protection"`
+	CreatedBy   int64    `db:"environment_created_by"`
+	Created     int64    `db:"environment_created"`
+	Updated     int64    `db:"environment_updated"`
+}
+
+const (
+	environmentColumns = `
+		 environment_id
+		,environment_version
+		,environment_space_id
+		,environment_repo_id
+		,environment_uid
+		,environment_description
+		,environment_protection
+		,environment_created_by
+		,environment_created
+		,environment_updated`
+
+	environmentSelectBase = `
+	SELECT` + environmentColumns + `
+	FROM environments`
+)
+
+// Find finds the environment by id.
+func (s *EnvironmentStore) Find(ctx context.Context, id int64) (*types.Environment, error) {
+	const sqlQuery = environmentSelectBase + `
+	WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &environment{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment")
+	}
+
+	return mapToEnvironment(dst)
+}
+
+// FindByIdentifier finds the environment with the given identifier for the given parent.
+func (s *EnvironmentStore) FindByIdentifier(
+	ctx context.Context,
+	parentType enum.EnvironmentParent,
+	parentID int64,
+	identifier string,
+) (*types.Environment, error) {
+	stmt := database.Builder.
+		Select(environmentColumns).
+		From("environments").
+		Where("LOWER(environment_uid) = ?", strings.ToLower(identifier))
+
+	stmt, err := applyEnvironmentParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &environment{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment")
+	}
+
+	return mapToEnvironment(dst)
+}
+
+// Create creates a new environment.
+func (s *EnvironmentStore) Create(ctx context.Context, env *types.Environment) error {
+	const sqlQuery = `
+	INSERT INTO environments (
+		 environment_version
+		,environment_space_id
+		,environment_repo_id
+		,environment_uid
+		,environment_description
+		,environment_protection
+		,environment_created_by
+		,environment_created
+		,environment_updated
+	) values (
+		 :environment_version
+		,:environment_space_id
+		,:environment_repo_id
+		,:environment_uid
+		,:environment_description
+		,:environment_protection
+		,:environment_created_by
+		,:environment_created
+		,:environment_updated
+	) RETURNING environment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEnv, err := mapToInternalEnvironment(env)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEnv)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&env.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert environment")
+	}
+
+	return nil
+}
+
+// Update updates an existing environment.
+func (s *EnvironmentStore) Update(ctx context.Context, env *types.Environment) error {
+	const sqlQuery = `
+	UPDATE environments
+	SET
+		 environment_version = :environment_version
+		,environment_uid = :environment_uid
+		,environment_description = :environment_description
+		,environment_protection = :environment_protection
+		,environment_updated = :environment_updated
+	WHERE environment_id = :environment_id AND environment_version = :environment_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbEnv, err := mapToInternalEnvironment(env)
+	if err != nil {
+		return err
+	}
+
+	// update Version (used for optimistic locking) and Updated time
+	dbEnv.Version++
+	dbEnv.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbEnv)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update environment")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	env.Version = dbEnv.Version
+	env.Updated = dbEnv.Updated
+
+	return nil
+}
+
+// Delete deletes the environment with the given id.
+func (s *EnvironmentStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM environments
+	WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete environment")
+	}
+
+	return nil
+}
+
+// Count counts the environments of the given parent.
+func (s *EnvironmentStore) Count(
+	ctx context.Context,
+	parentType enum.EnvironmentParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("environments")
+
+	stmt, err := applyEnvironmentParent(stmt, parentType, parentID)
+	if err != nil {
+		return 0, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(environment_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List lists the environments of the given parent.
+func (s *EnvironmentStore) List(
+	ctx context.Context,
+	parentType enum.EnvironmentParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.Environment, error) {
+	stmt := database.Builder.
+		Select(environmentColumns).
+		From("environments")
+
+	stmt, err := applyEnvironmentParent(stmt, parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(environment_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.OrderBy("LOWER(environment_uid)")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*environment{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list environments")
+	}
+
+	res := make([]*types.Environment, len(dst))
+	for i := range dst {
+		if res[i], err = mapToEnvironment(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func applyEnvironmentParent(
+	stmt squirrel.SelectBuilder,
+	parentType enum.EnvironmentParent,
+	parentID int64,
+) (squirrel.SelectBuilder, error) {
+	switch parentType {
+	case enum.EnvironmentParentRepo:
+		return stmt.Where("environment_repo_id = ?", parentID), nil
+	case enum.EnvironmentParentSpace:
+		return stmt.Where("environment_space_id = ?", parentID), nil
+	default:
+		return stmt, fmt.Errorf("environment parent type '%s' is not supported", parentType)
+	}
+}
+
+func mapToEnvironment(in *environment) (*types.Environment, error) {
+	res := &types.Environment{
+		ID:          in.ID,
+		Version:     in.Version,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if in.Protection != "" {
+		if err := json.Unmarshal([]byte(in.Protection), &res.Protection); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal protection of environment %d: %w", in.ID, err)
+		}
+	}
+
+	switch {
+	case in.RepoID.Valid && in.SpaceID.Valid:
+		return nil, fmt.Errorf("both repoID and spaceID are set for environment %d", in.ID)
+	case in.RepoID.Valid:
+		res.ParentType = enum.EnvironmentParentRepo
+		res.ParentID = in.RepoID.Int64
+	case in.SpaceID.Valid:
+		res.ParentType = enum.EnvironmentParentSpace
+		res.ParentID = in.SpaceID.Int64
+	default:
+		return nil, fmt.Errorf("neither repoID nor spaceID are set for environment %d", in.ID)
+	}
+
+	return res, nil
+}
+
+func mapToInternalEnvironment(in *types.Environment) (*environment, error) {
+	res := &environment{
+		ID:          in.ID,
+		Version:     in.Version,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if !in.Protection.IsEmpty() {
+		protection, err := json.Marshal(in.Protection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal protection of environment %d: %w", in.ID, err)
+		}
+		res.Protection = string(protection)
+	}
+
+	switch in.ParentType {
+	case enum.EnvironmentParentRepo:
+		res.RepoID = null.IntFrom(in.ParentID)
+	case enum.EnvironmentParentSpace:
+		res.SpaceID = null.IntFrom(in.ParentID)
+	default:
+		return nil, fmt.Errorf("environment parent type '%s' is not supported", in.ParentType)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_Environment(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	environmentStore := database.NewEnvironmentStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	spaceEnv := &types.Environment{
+		ParentType: enum.EnvironmentParentSpace,
+		ParentID:   1,
+		Identifier: "prod",
+		Protection: types.EnvironmentProtection{Branches: []string{"main"}},
+		CreatedBy:  userID,
+	}
+	if err := environmentStore.Create(ctx, spaceEnv); err != nil {
+		t.Fatalf("failed to create space environment: %v", err)
+	}
+
+	// the same identifier can be used by a repo of the space.
+	repoEnv := &types.Environment{
+		ParentType: enum.EnvironmentParentRepo,
+		ParentID:   1,
+		Identifier: "Prod",
+		CreatedBy:  userID,
+	}
+	if err := environmentStore.Create(ctx, repoEnv); err != nil {
+		t.Fatalf("failed to create repo environment: %v", err)
+	}
+
+	duplicate := *repoEnv
+	duplicate.Identifier = "PROD"
+	if err := environmentStore.Create(ctx, &duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got: %v", err)
+	}
+
+	found, err := environmentStore.FindByIdentifier(ctx, enum.EnvironmentParentSpace, 1, "PROD")
+	if err != nil {
+		t.Fatalf("failed to find environment: %v", err)
+	}
+	if found.ID != spaceEnv.ID || len(found.Protection.Branches) != 1 {
+		t.Errorf("unexpected environment: %+v", found)
+	}
+
+	found.Description = "production"
+	if err = environmentStore.Update(ctx, found); err != nil {
+		t.Fatalf("failed to update environment: %v", err)
+	}
+
+	stale := *spaceEnv
+	if err = environmentStore.Update(ctx, &stale); !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Fatalf("expected version conflict, got: %v", err)
+	}
+
+	filter := &types.ListQueryFilter{Pagination: types.Pagination{Size: 10}}
+	count, err := environmentStore.Count(ctx, enum.EnvironmentParentRepo, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to count environments: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 repo environment, got %d", count)
+	}
+
+	if err = environmentStore.Delete(ctx, repoEnv.ID); err != nil {
+		t.Fatalf("failed to delete environment: %v", err)
+	}
+
+	envs, err := environmentStore.List(ctx, enum.EnvironmentParentRepo, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to list environments: %v", err)
+	}
+	if len(envs) != 0 {
+		t.Errorf("expected no repo environments, got %d", len(envs))
+	}
+}
+
+func TestDatabase_Deployment(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	environmentStore := database.NewEnvironmentStore(db)
+	deploymentStore := database.NewDeploymentStore(db)
+	pipelineStore := database.NewPipelineStore(db)
+	executionStore := database.NewExecutionStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pipeline := &types.Pipeline{RepoID: 1, Identifier: "deploy", CreatedBy: userID}
+	if err := pipelineStore.Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	envs := map[string]*types.Environment{}
+	for _, identifier := range []string{"staging", "prod"} {
+		env := &types.Environment{
+			ParentType: enum.EnvironmentParentSpace,
+			ParentID:   1,
+			Identifier: identifier,
+			CreatedBy:  userID,
+		}
+		if err := environmentStore.Create(ctx, env); err != nil {
+			t.Fatalf("failed to create environment: %v", err)
+		}
+		envs[identifier] = env
+	}
+
+	deployments := []struct {
+		env    string
+		sha    string
+		status enum.CIStatus
+	}{
+		{env: "staging", sha: "a", status: enum.CIStatusSuccess},
+		{env: "prod", sha: "a", status: enum.CIStatusSuccess},
+		{env: "staging", sha: "b", status: enum.CIStatusSuccess},
+		{env: "prod", sha: "b", status: enum.CIStatusFailure},
+	}
+	for i, d := range deployments {
+		execution := &types.Execution{
+			PipelineID: pipeline.ID,
+			RepoID:     1,
+			CreatedBy:  userID,
+			Number:     int64(i + 1),
+			Status:     d.status,
+			After:      d.sha,
+			Deploy:     d.env,
+			DeployID:   envs[d.env].ID,
+		}
+		if err := executionStore.Create(ctx, execution); err != nil {
+			t.Fatalf("failed to create execution: %v", err)
+		}
+
+		err := deploymentStore.Create(ctx, &types.Deployment{
+			EnvironmentID: envs[d.env].ID,
+			RepoID:        1,
+			PipelineID:    pipeline.ID,
+			ExecutionID:   execution.ID,
+			SHA:           d.sha,
+			CreatedBy:     userID,
+		})
+		if err != nil {
+			t.Fatalf("failed to create deployment: %v", err)
+		}
+	}
+
+	history, err := deploymentStore.List(ctx, envs["prod"].ID, &types.DeploymentFilter{})
+	if err != nil {
+		t.Fatalf("failed to list deployments: %v", err)
+	}
+	if len(history) != 2 || history[0].SHA != "b" || history[0].Status != enum.CIStatusFailure {
+		t.Errorf("unexpected deployment history: %+v", history)
+	}
+
+	count, err := deploymentStore.Count(ctx, envs["prod"].ID,
+		&types.DeploymentFilter{Status: enum.CIStatusSuccess})
+	if err != nil {
+		t.Fatalf("failed to count deployments: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 successful deployment, got %d", count)
+	}
+
+	latest, err := deploymentStore.ListLatestByRepo(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list latest deployments: %v", err)
+	}
+	want := map[string]string{"prod": "a", "staging": "b"}
+	if len(latest) != len(want) {
+		t.Fatalf("expected %d latest deployments, got %d", len(want), len(latest))
+	}
+	for _, d := range latest {
+		if want[d.EnvironmentIdentifier] != d.SHA || d.PipelineIdentifier != "deploy" {
+			t.Errorf("unexpected latest deployment: %+v", d)
+		}
+	}
+}
@@ -82,6 +82,8 @@ type execution struct {
 	Created      int64              `db:"execution_created"`
 	Updated      int64              `db:"execution_updated"`
 	Version      int64              `db:"execution_version"`
+
+	ConcurrencyGroup string `db:"execution_concurrency_group"`
 }
 
 const (
@@ -122,6 +124,7 @@ const (
 		,execution_created
 		,execution_updated
 		,execution_version
+		,execution_concurrency_group
 	`
 )
 
@@ -199,6 +202,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_created
 		,execution_updated
 		,execution_version
+		,execution_concurrency_group
 	) VALUES (
 		:execution_pipeline_id
 		,:execution_repo_id
@@ -235,6 +239,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_created
 		,:execution_updated
 		,:execution_version
+		,:execution_concurrency_group
 	) RETURNING execution_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -373,3 +378,24 @@ func (s *executionStore) Delete(ctx context.Context, pipelineID int64, execution
 
 	return nil
 }
+
+// ListIncompleteInConcurrencyGroup lists the pending and running executions of the repo's concurrency group.
+func (s *executionStore) ListIncompleteInConcurrencyGroup(
+	ctx context.Context,
+	repoID int64,
+	group string,
+) ([]*types.Execution, error) {
+	const listQueryStmt = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_repo_id = $1 AND execution_concurrency_group = $2
+		AND execution_status IN ('pending', 'running')
+	ORDER BY execution_id ASC`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err := db.SelectContext(ctx, &dst, listQueryStmt, repoID, group); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list executions of concurrency group")
+	}
+	return mapInternalToExecutionList(dst)
+}
@@ -61,6 +61,8 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+
+		ConcurrencyGroup: in.ConcurrencyGroup,
 	}, nil
 }
 
@@ -102,6 +104,8 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+
+		ConcurrencyGroup: in.ConcurrencyGroup,
 	}
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -63,7 +64,10 @@ type membership struct {
 	Created   int64 `db:"membership_created"`
 	Updated   int64 `db:"membership_updated"`
 
-	Role enum.MembershipRole `db:"membership_role"`
+	Role   enum.MembershipRole `db:"membership_role"`
+	RoleID null.Int            `db:"membership_role_id"`
+
+	RoleIdentifier null.String `db:"role_uid"`
 }
 
 type membershipPrincipal struct {
@@ -83,11 +87,16 @@ const (
 		,membership_created_by
 		,membership_created
 		,membership_updated
-		,membership_role`
+		,membership_role
+		,membership_role_id
+		,role_uid`
+
+	membershipJoinRoles = "roles ON role_id = membership_role_id"
 
 	membershipSelectBase = `
 	SELECT` + membershipColumns + `
-	FROM memberships`
+	FROM memberships
+	LEFT JOIN ` + membershipJoinRoles
 )
 
 // Find finds the membership by space id and principal id.
@@ -131,6 +140,7 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,membership_created
 		,membership_updated
 		,membership_role
+		,membership_role_id
 	) values (
 		 :membership_space_id
 		,:membership_principal_id
@@ -138,6 +148,7 @@ func (s *MembershipStore) Create(ctx context.Context, membership *types.Membersh
 		,:membership_created
 		,:membership_updated
 		,:membership_role
+		,:membership_role_id
 	)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -161,6 +172,7 @@ func (s *MembershipStore) Update(ctx context.Context, membership *types.Membersh
 	SET
 		 membership_updated = :membership_updated
 		,membership_role = :membership_role
+		,membership_role_id = :membership_role_id
 	WHERE membership_space_id = :membership_space_id AND
 	      membership_principal_id = :membership_principal_id`
 
@@ -238,6 +250,7 @@ func (s *MembershipStore) ListUsers(ctx context.Context,
 		Select(columns).
 		From("memberships").
 		InnerJoin("principals ON membership_principal_id = principal_id").
+		LeftJoin(membershipJoinRoles).
 		Where("membership_space_id = ?", spaceID)
 
 	stmt = applyMembershipUserFilter(stmt, filter)
@@ -327,6 +340,7 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 		Select(columns).
 		From("memberships").
 		InnerJoin("spaces ON spaces.space_id = membership_space_id").
+		LeftJoin(membershipJoinRoles).
 		Where("membership_principal_id = ?", userID)
 
 	stmt = applyMembershipSpaceFilter(stmt, filter)
@@ -366,6 +380,23 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 	return result, nil
 }
 
+// CountByRoleID returns the number of memberships that have the provided custom role assigned.
+func (s *MembershipStore) CountByRoleID(ctx context.Context, roleID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM memberships
+	WHERE membership_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, roleID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing membership count by role query")
+	}
+
+	return count, nil
+}
+
 func applyMembershipSpaceFilter(
 	stmt squirrel.SelectBuilder,
 	opts types.MembershipSpaceFilter,
@@ -388,6 +419,9 @@ func mapToMembership(m *membership) types.Membership {
 		Created:   m.Created,
 		Updated:   m.Updated,
 		Role:      m.Role,
+
+		CustomRoleID: m.RoleID.Ptr(),
+		CustomRole:   m.RoleIdentifier.String,
 	}
 }
 
@@ -399,6 +433,7 @@ func mapToInternalMembership(m *types.Membership) membership {
 		Created:     m.Created,
 		Updated:     m.Updated,
 		Role:        m.Role,
+		RoleID:      null.IntFromPtr(m.CustomRoleID),
 	}
 }
 
@@ -0,0 +1,5 @@
+DELETE FROM memberships WHERE membership_role_id IS NOT NULL;
+
+ALTER TABLE memberships DROP COLUMN membership_role_id;
+
+DROP TABLE roles;
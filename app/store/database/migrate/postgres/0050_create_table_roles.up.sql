@@ -0,0 +1,27 @@
+CREATE TABLE roles (
+ role_id SERIAL PRIMARY KEY
+,role_space_id INTEGER NOT NULL
+,role_uid TEXT NOT NULL
+,role_description TEXT NOT NULL
+,role_permissions TEXT NOT NULL
+,role_created_by INTEGER NOT NULL
+,role_created BIGINT NOT NULL
+,role_updated BIGINT NOT NULL
+,CONSTRAINT fk_role_space_id FOREIGN KEY (role_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_role_created_by FOREIGN KEY (role_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX roles_space_id_uid
+	ON roles(role_space_id, LOWER(role_uid));
+
+ALTER TABLE memberships
+    ADD COLUMN membership_role_id INTEGER
+    CONSTRAINT fk_membership_role_id REFERENCES roles (role_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION;
@@ -0,0 +1,4 @@
+ALTER TABLE tokens DROP COLUMN token_permissions;
+ALTER TABLE tokens DROP COLUMN token_space_ids;
+ALTER TABLE tokens DROP COLUMN token_repo_ids;
+ALTER TABLE tokens DROP COLUMN token_last_used;
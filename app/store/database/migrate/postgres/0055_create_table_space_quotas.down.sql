@@ -0,0 +1,2 @@
+DROP TABLE repo_blob_sizes;
+DROP TABLE space_quotas;
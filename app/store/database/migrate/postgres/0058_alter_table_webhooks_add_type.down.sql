@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN webhook_chat_mentions;
+ALTER TABLE webhooks DROP COLUMN webhook_type;
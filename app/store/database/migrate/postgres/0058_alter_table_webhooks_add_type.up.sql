@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN webhook_type TEXT NOT NULL DEFAULT 'generic';
+ALTER TABLE webhooks ADD COLUMN webhook_chat_mentions TEXT NOT NULL DEFAULT '';
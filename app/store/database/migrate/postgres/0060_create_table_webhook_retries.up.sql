@@ -0,0 +1,23 @@
+CREATE TABLE webhook_retries (
+ webhook_retry_id SERIAL PRIMARY KEY
+,webhook_retry_webhook_id INTEGER NOT NULL
+,webhook_retry_trigger_id TEXT NOT NULL
+,webhook_retry_execution_id INTEGER NOT NULL
+,webhook_retry_attempts INTEGER NOT NULL
+,webhook_retry_next_attempt BIGINT NOT NULL
+,webhook_retry_created BIGINT NOT NULL
+,CONSTRAINT fk_webhook_retry_webhook_id FOREIGN KEY (webhook_retry_webhook_id)
+    REFERENCES webhooks (webhook_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_webhook_retry_execution_id FOREIGN KEY (webhook_retry_execution_id)
+    REFERENCES webhook_executions (webhook_execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX webhook_retries_webhook_id_trigger_id
+	ON webhook_retries(webhook_retry_webhook_id, webhook_retry_trigger_id);
+
+CREATE INDEX webhook_retries_next_attempt
+	ON webhook_retries(webhook_retry_next_attempt);
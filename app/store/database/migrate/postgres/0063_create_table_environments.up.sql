@@ -0,0 +1,69 @@
+CREATE TABLE environments (
+ environment_id SERIAL PRIMARY KEY
+,environment_version INTEGER NOT NULL
+,environment_space_id INTEGER
+,environment_repo_id INTEGER
+,environment_uid TEXT NOT NULL
+,environment_description TEXT NOT NULL
+,environment_protection TEXT NOT NULL
+,environment_created_by INTEGER NOT NULL
+,environment_created BIGINT NOT NULL
+,environment_updated BIGINT NOT NULL
+,CONSTRAINT fk_environment_space_id FOREIGN KEY (environment_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_environment_repo_id FOREIGN KEY (environment_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_environment_created_by FOREIGN KEY (environment_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX environments_space_id_uid
+	ON environments(environment_space_id, LOWER(environment_uid))
+	WHERE environment_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX environments_repo_id_uid
+	ON environments(environment_repo_id, LOWER(environment_uid))
+	WHERE environment_repo_id IS NOT NULL;
+
+CREATE TABLE deployments (
+ deployment_id SERIAL PRIMARY KEY
+,deployment_environment_id INTEGER NOT NULL
+,deployment_repo_id INTEGER NOT NULL
+,deployment_pipeline_id INTEGER NOT NULL
+,deployment_execution_id INTEGER NOT NULL
+,deployment_ref TEXT NOT NULL
+,deployment_sha TEXT NOT NULL
+,deployment_created_by INTEGER NOT NULL
+,deployment_created BIGINT NOT NULL
+,CONSTRAINT fk_deployment_environment_id FOREIGN KEY (deployment_environment_id)
+    REFERENCES environments (environment_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_deployment_repo_id FOREIGN KEY (deployment_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_deployment_pipeline_id FOREIGN KEY (deployment_pipeline_id)
+    REFERENCES pipelines (pipeline_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_deployment_execution_id FOREIGN KEY (deployment_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX deployments_execution_id
+	ON deployments(deployment_execution_id);
+
+CREATE INDEX deployments_environment_id_created
+	ON deployments(deployment_environment_id, deployment_created);
+
+CREATE INDEX deployments_repo_id
+	ON deployments(deployment_repo_id);
@@ -0,0 +1,2 @@
+DROP TABLE stage_approval_decisions;
+DROP TABLE stage_approvals;
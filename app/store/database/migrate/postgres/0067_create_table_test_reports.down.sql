@@ -0,0 +1,2 @@
+DROP TABLE test_cases;
+DROP TABLE test_reports;
@@ -0,0 +1,52 @@
+CREATE TABLE test_reports (
+ test_report_id SERIAL PRIMARY KEY
+,test_report_repo_id INTEGER NOT NULL
+,test_report_commit_sha TEXT NOT NULL
+,test_report_check_uid TEXT NOT NULL
+,test_report_branch TEXT NOT NULL
+,test_report_format TEXT NOT NULL
+,test_report_created_by INTEGER NOT NULL
+,test_report_created BIGINT NOT NULL
+,test_report_total INTEGER NOT NULL
+,test_report_passed INTEGER NOT NULL
+,test_report_failed INTEGER NOT NULL
+,test_report_skipped INTEGER NOT NULL
+,test_report_errored INTEGER NOT NULL
+,test_report_duration BIGINT NOT NULL
+,CONSTRAINT fk_test_report_created_by FOREIGN KEY (test_report_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_test_report_repo_id FOREIGN KEY (test_report_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX test_reports_repo_id_commit_sha_check_uid
+    ON test_reports(test_report_repo_id, test_report_commit_sha, test_report_check_uid);
+
+CREATE INDEX test_reports_repo_id_branch_check_uid_created
+    ON test_reports(test_report_repo_id, test_report_branch, test_report_check_uid, test_report_created);
+
+CREATE INDEX test_reports_repo_id_created
+    ON test_reports(test_report_repo_id, test_report_created);
+
+CREATE TABLE test_cases (
+ test_case_id SERIAL PRIMARY KEY
+,test_case_report_id INTEGER NOT NULL
+,test_case_suite TEXT NOT NULL
+,test_case_class_name TEXT NOT NULL
+,test_case_name TEXT NOT NULL
+,test_case_status TEXT NOT NULL
+,test_case_duration BIGINT NOT NULL
+,test_case_message TEXT NOT NULL
+,test_case_details TEXT NOT NULL
+,CONSTRAINT fk_test_case_report_id FOREIGN KEY (test_case_report_id)
+    REFERENCES test_reports (test_report_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX test_cases_report_id_status
+    ON test_cases(test_case_report_id, test_case_status);
@@ -0,0 +1,49 @@
+-- recreate table without the role column (sqlite can't drop columns that are part of a foreign key)
+CREATE TABLE memberships_new (
+ membership_space_id INTEGER NOT NULL
+,membership_principal_id INTEGER NOT NULL
+,membership_created_by INTEGER NOT NULL
+,membership_created BIGINT NOT NULL
+,membership_updated BIGINT NOT NULL
+,membership_role TEXT NOT NULL
+,CONSTRAINT pk_memberships PRIMARY KEY (membership_space_id, membership_principal_id)
+,CONSTRAINT fk_membership_space_id FOREIGN KEY (membership_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_principal_id FOREIGN KEY (membership_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_membership_created_by FOREIGN KEY (membership_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+-- copy over data (memberships with custom roles are removed)
+INSERT INTO memberships_new(
+     membership_space_id
+    ,membership_principal_id
+    ,membership_created_by
+    ,membership_created
+    ,membership_updated
+    ,membership_role
+)
+SELECT
+     membership_space_id
+    ,membership_principal_id
+    ,membership_created_by
+    ,membership_created
+    ,membership_updated
+    ,membership_role
+FROM memberships
+WHERE membership_role_id IS NULL;
+
+-- delete old table
+DROP TABLE memberships;
+
+-- rename table
+ALTER TABLE memberships_new RENAME TO memberships;
+
+DROP TABLE roles;
@@ -0,0 +1,13 @@
+CREATE TABLE totps (
+ totp_principal_id INTEGER PRIMARY KEY
+,totp_secret BLOB NOT NULL
+,totp_enabled BOOLEAN NOT NULL
+,totp_recovery_codes TEXT NOT NULL
+,totp_last_used_step BIGINT NOT NULL
+,totp_created BIGINT NOT NULL
+,totp_updated BIGINT NOT NULL
+,CONSTRAINT fk_totp_principal_id FOREIGN KEY (totp_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
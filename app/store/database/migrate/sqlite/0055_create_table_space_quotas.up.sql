@@ -0,0 +1,23 @@
+CREATE TABLE space_quotas (
+ space_quota_space_id INTEGER PRIMARY KEY
+,space_quota_max_repos BIGINT
+,space_quota_max_repo_size BIGINT
+,space_quota_max_blob_size BIGINT
+,space_quota_max_pipeline_minutes BIGINT
+,space_quota_max_concurrent_executions BIGINT
+,space_quota_created BIGINT NOT NULL
+,space_quota_updated BIGINT NOT NULL
+,CONSTRAINT fk_space_quota_space_id FOREIGN KEY (space_quota_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE repo_blob_sizes (
+ repo_blob_size_repo_id INTEGER PRIMARY KEY
+,repo_blob_size_size BIGINT NOT NULL
+,CONSTRAINT fk_repo_blob_size_repo_id FOREIGN KEY (repo_blob_size_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
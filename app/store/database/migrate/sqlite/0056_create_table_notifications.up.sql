@@ -0,0 +1,26 @@
+CREATE TABLE notifications (
+ notification_id INTEGER PRIMARY KEY AUTOINCREMENT
+,notification_principal_id INTEGER NOT NULL
+,notification_type TEXT NOT NULL
+,notification_repo_id INTEGER NOT NULL
+,notification_pullreq_id INTEGER NOT NULL
+,notification_pullreq_number INTEGER NOT NULL
+,notification_title TEXT NOT NULL
+,notification_url TEXT NOT NULL
+,notification_text TEXT NOT NULL
+,notification_actor_id INTEGER NOT NULL
+,notification_read BOOLEAN NOT NULL
+,notification_created BIGINT NOT NULL
+,notification_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_principal_id FOREIGN KEY (notification_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_repo_id FOREIGN KEY (notification_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notifications_principal_id_read_created
+	ON notifications(notification_principal_id, notification_read, notification_created);
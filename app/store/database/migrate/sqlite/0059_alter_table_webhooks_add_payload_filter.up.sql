@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_payload_filter TEXT NOT NULL DEFAULT '';
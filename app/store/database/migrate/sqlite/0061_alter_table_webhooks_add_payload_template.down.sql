@@ -0,0 +1,3 @@
+ALTER TABLE webhooks DROP COLUMN webhook_headers;
+ALTER TABLE webhooks DROP COLUMN webhook_payload_template;
+ALTER TABLE webhooks DROP COLUMN webhook_content_type;
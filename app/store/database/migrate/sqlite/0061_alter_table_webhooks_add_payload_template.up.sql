@@ -0,0 +1,3 @@
+ALTER TABLE webhooks ADD COLUMN webhook_content_type TEXT NOT NULL DEFAULT 'json';
+ALTER TABLE webhooks ADD COLUMN webhook_payload_template TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN webhook_headers TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,38 @@
+CREATE TABLE stage_approvals (
+ stage_approval_stage_id INTEGER PRIMARY KEY
+,stage_approval_execution_id INTEGER NOT NULL
+,stage_approval_repo_id INTEGER NOT NULL
+,stage_approval_approvers TEXT NOT NULL
+,stage_approval_min_approvals INTEGER NOT NULL
+,stage_approval_timeout BIGINT NOT NULL
+,stage_approval_created BIGINT NOT NULL
+,CONSTRAINT fk_stage_approval_stage_id FOREIGN KEY (stage_approval_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_stage_approval_execution_id FOREIGN KEY (stage_approval_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX stage_approvals_execution_id
+	ON stage_approvals(stage_approval_execution_id);
+
+CREATE TABLE stage_approval_decisions (
+ stage_approval_decision_stage_id INTEGER NOT NULL
+,stage_approval_decision_principal_id INTEGER NOT NULL
+,stage_approval_decision_decision TEXT NOT NULL
+,stage_approval_decision_comment TEXT NOT NULL
+,stage_approval_decision_created BIGINT NOT NULL
+,stage_approval_decision_updated BIGINT NOT NULL
+,CONSTRAINT pk_stage_approval_decisions PRIMARY KEY (stage_approval_decision_stage_id, stage_approval_decision_principal_id)
+,CONSTRAINT fk_stage_approval_decision_stage_id FOREIGN KEY (stage_approval_decision_stage_id)
+    REFERENCES stage_approvals (stage_approval_stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_stage_approval_decision_principal_id FOREIGN KEY (stage_approval_decision_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
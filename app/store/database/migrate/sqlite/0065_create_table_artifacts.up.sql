@@ -0,0 +1,17 @@
+CREATE TABLE artifacts (
+ artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_repo_id INTEGER NOT NULL
+,artifact_execution_id INTEGER NOT NULL
+,artifact_stage_id INTEGER NOT NULL
+,artifact_name TEXT NOT NULL
+,artifact_content_type TEXT NOT NULL
+,artifact_size BIGINT NOT NULL
+,artifact_blob_path TEXT NOT NULL
+,artifact_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX artifacts_execution_id_name
+	ON artifacts(artifact_execution_id, artifact_name);
+
+CREATE INDEX artifacts_created
+	ON artifacts(artifact_created);
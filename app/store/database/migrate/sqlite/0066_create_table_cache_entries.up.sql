@@ -0,0 +1,19 @@
+CREATE TABLE cache_entries (
+ cache_entry_id INTEGER PRIMARY KEY AUTOINCREMENT
+,cache_entry_repo_id INTEGER NOT NULL
+,cache_entry_branch TEXT NOT NULL
+,cache_entry_key TEXT NOT NULL
+,cache_entry_size BIGINT NOT NULL
+,cache_entry_blob_path TEXT NOT NULL
+,cache_entry_created BIGINT NOT NULL
+,cache_entry_last_used BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX cache_entries_repo_id_branch_key
+	ON cache_entries(cache_entry_repo_id, cache_entry_branch, cache_entry_key);
+
+CREATE INDEX cache_entries_repo_id_last_used
+	ON cache_entries(cache_entry_repo_id, cache_entry_last_used);
+
+CREATE INDEX cache_entries_last_used
+	ON cache_entries(cache_entry_last_used);
@@ -0,0 +1,7 @@
+DROP INDEX executions_repo_id_concurrency_group;
+
+ALTER TABLE stages DROP COLUMN stage_concurrency_limit;
+ALTER TABLE stages DROP COLUMN stage_concurrency_group;
+ALTER TABLE executions DROP COLUMN execution_concurrency_group;
+ALTER TABLE pipelines DROP COLUMN pipeline_concurrency_limit;
+ALTER TABLE pipelines DROP COLUMN pipeline_concurrency_group;
@@ -0,0 +1,8 @@
+ALTER TABLE pipelines ADD COLUMN pipeline_concurrency_group TEXT NOT NULL DEFAULT '';
+ALTER TABLE pipelines ADD COLUMN pipeline_concurrency_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE executions ADD COLUMN execution_concurrency_group TEXT NOT NULL DEFAULT '';
+ALTER TABLE stages ADD COLUMN stage_concurrency_group TEXT NOT NULL DEFAULT '';
+ALTER TABLE stages ADD COLUMN stage_concurrency_limit INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX executions_repo_id_concurrency_group
+    ON executions(execution_repo_id, execution_concurrency_group);
@@ -0,0 +1,304 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(db *sqlx.DB) *NotificationStore {
+	return &NotificationStore{
+		db: db,
+	}
+}
+
+// NotificationStore implements store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db *sqlx.DB
+}
+
+type notification struct {
+	ID            int64                 `db:"notification_id"`
+	PrincipalID   int64                 `db:"notification_principal_id"`
+	Type          enum.NotificationType `db:"notification_type"`
+	RepoID        int64                 `db:"notification_repo_id"`
+	PullReqID     int64                 `db:"notification_pullreq_id"`
+	PullReqNumber int64                 `db:"notification_pullreq_number"`
+	Title         string                `db:"notification_title"`
+	URL           string                `db:"notification_url"`
+	Text          string                `db:"notification_text"`
+	ActorID       int64                 `db:"notification_actor_id"`
+	Read          bool                  `db:"notification_read"`
+	Created       int64                 `db:"notification_created"`
+	Updated       int64                 `db:"notification_updated"`
+}
+
+const (
+	notificationColumns = `
+		 notification_id
+		,notification_principal_id
+		,notification_type
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_pullreq_number
+		,notification_title
+		,notification_url
+		,notification_text
+		,notification_actor_id
+		,notification_read
+		,notification_created
+		,notification_updated`
+)
+
+// Find finds the notification of the principal by id.
+func (s *NotificationStore) Find(ctx context.Context, principalID, id int64) (*types.Notification, error) {
+	const sqlQuery = `
+	SELECT` + notificationColumns + `
+	FROM notifications
+	WHERE notification_principal_id = $1 AND notification_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notification{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification")
+	}
+
+	return mapToNotification(dst), nil
+}
+
+// Create saves the notification.
+func (s *NotificationStore) Create(ctx context.Context, n *types.Notification) error {
+	const sqlQuery = `
+	INSERT INTO notifications (
+		 notification_principal_id
+		,notification_type
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_pullreq_number
+		,notification_title
+		,notification_url
+		,notification_text
+		,notification_actor_id
+		,notification_read
+		,notification_created
+		,notification_updated
+	) values (
+		 :notification_principal_id
+		,:notification_type
+		,:notification_repo_id
+		,:notification_pullreq_id
+		,:notification_pullreq_number
+		,:notification_title
+		,:notification_url
+		,:notification_text
+		,:notification_actor_id
+		,:notification_read
+		,:notification_created
+		,:notification_updated
+	) RETURNING notification_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotification(n))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&n.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification")
+	}
+
+	return nil
+}
+
+// List returns the notifications of the principal matching the filter, newest first.
+func (s *NotificationStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	stmt = applyNotificationFilter(stmt, filter)
+
+	stmt = stmt.OrderBy("notification_created DESC", "notification_id DESC")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*notification{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing notification list query")
+	}
+
+	res := make([]*types.Notification, len(dst))
+	for i := range dst {
+		res[i] = mapToNotification(dst[i])
+	}
+
+	return res, nil
+}
+
+// Count returns the number of notifications of the principal matching the filter.
+func (s *NotificationStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	stmt = applyNotificationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing notification count query")
+	}
+
+	return count, nil
+}
+
+// UpdateRead marks the notification of the principal as read or unread.
+func (s *NotificationStore) UpdateRead(ctx context.Context, principalID, id int64, read bool) error {
+	const sqlQuery = `
+	UPDATE notifications
+	SET
+		 notification_read = $1
+		,notification_updated = $2
+	WHERE notification_principal_id = $3 AND notification_id = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, read, time.Now().UnixMilli(), principalID, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notification rows")
+	}
+
+	if n == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// UpdateAllRead marks all unread notifications of the principal as read and returns their number.
+func (s *NotificationStore) UpdateAllRead(ctx context.Context, principalID int64) (int64, error) {
+	const sqlQuery = `
+	UPDATE notifications
+	SET
+		 notification_read = $1
+		,notification_updated = $2
+	WHERE notification_principal_id = $3 AND notification_read = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, true, time.Now().UnixMilli(), principalID, false)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to update notifications")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notification rows")
+	}
+
+	return n, nil
+}
+
+func applyNotificationFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.NotificationFilter,
+) squirrel.SelectBuilder {
+	if filter.Read != nil {
+		stmt = stmt.Where("notification_read = ?", *filter.Read)
+	}
+
+	return stmt
+}
+
+func mapToNotification(n *notification) *types.Notification {
+	return &types.Notification{
+		ID:            n.ID,
+		PrincipalID:   n.PrincipalID,
+		Type:          n.Type,
+		RepoID:        n.RepoID,
+		PullReqID:     n.PullReqID,
+		PullReqNumber: n.PullReqNumber,
+		Title:         n.Title,
+		URL:           n.URL,
+		Text:          n.Text,
+		ActorID:       n.ActorID,
+		Read:          n.Read,
+		Created:       n.Created,
+		Updated:       n.Updated,
+	}
+}
+
+func mapToInternalNotification(n *types.Notification) *notification {
+	return &notification{
+		ID:            n.ID,
+		PrincipalID:   n.PrincipalID,
+		Type:          n.Type,
+		RepoID:        n.RepoID,
+		PullReqID:     n.PullReqID,
+		PullReqNumber: n.PullReqNumber,
+		Title:         n.Title,
+		URL:           n.URL,
+		Text:          n.Text,
+		ActorID:       n.ActorID,
+		Read:          n.Read,
+		Created:       n.Created,
+		Updated:       n.Updated,
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64                 `db:"notification_digest_item_id"`
+	PrincipalID int64                 `db:"notification_digest_item_principal_id"`
+	Type        enum.NotificationType `db:"notification_digest_item_type"`
+	Subject     string                `db:"notification_digest_item_subject"`
+	Summary     string                `db:"notification_digest_item_summary"`
+	URL         string                `db:"notification_digest_item_url"`
+	Created     int64                 `db:"notification_digest_item_created"`
+}
+
+const (
+	notificationDigestItemColumns = `
+		 notification_digest_item_id
+		,notification_digest_item_principal_id
+		,notification_digest_item_type
+		,notification_digest_item_subject
+		,notification_digest_item_summary
+		,notification_digest_item_url
+		,notification_digest_item_created`
+)
+
+// Create saves the digest item.
+func (s *NotificationDigestStore) Create(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+	INSERT INTO notification_digest_items (
+		 notification_digest_item_principal_id
+		,notification_digest_item_type
+		,notification_digest_item_subject
+		,notification_digest_item_summary
+		,notification_digest_item_url
+		,notification_digest_item_created
+	) values (
+		 :notification_digest_item_principal_id
+		,:notification_digest_item_type
+		,:notification_digest_item_subject
+		,:notification_digest_item_summary
+		,:notification_digest_item_url
+		,:notification_digest_item_created
+	) RETURNING notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationDigestItem(item))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&item.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification digest item")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns the IDs of all principals with pending digest items.
+func (s *NotificationDigestStore) ListPrincipalIDs(ctx context.Context) ([]int64, error) {
+	const sqlQuery = `
+	SELECT DISTINCT notification_digest_item_principal_id
+	FROM notification_digest_items
+	ORDER BY notification_digest_item_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principals with notification digest items")
+	}
+
+	return ids, nil
+}
+
+// List returns the pending digest items of the principal, oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationDigestItem, error) {
+	const sqlQuery = `
+	SELECT` + notificationDigestItemColumns + `
+	FROM notification_digest_items
+	WHERE notification_digest_item_principal_id = $1
+	ORDER BY notification_digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*notificationDigestItem{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification digest items")
+	}
+
+	res := make([]*types.NotificationDigestItem, len(dst))
+	for i := range dst {
+		res[i] = mapToNotificationDigestItem(dst[i])
+	}
+
+	return res, nil
+}
+
+// DeleteUpTo deletes all digest items of the principal with an ID up to (including) the provided one.
+func (s *NotificationDigestStore) DeleteUpTo(ctx context.Context, principalID int64, id int64) error {
+	const sqlQuery = `
+	DELETE FROM notification_digest_items
+	WHERE notification_digest_item_principal_id = $1 AND notification_digest_item_id <= $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification digest items")
+	}
+
+	return nil
+}
+
+func mapToNotificationDigestItem(i *notificationDigestItem) *types.NotificationDigestItem {
+	return &types.NotificationDigestItem{
+		ID:          i.ID,
+		PrincipalID: i.PrincipalID,
+		Type:        i.Type,
+		Subject:     i.Subject,
+		Summary:     i.Summary,
+		URL:         i.URL,
+		Created:     i.Created,
+	}
+}
+
+func mapToInternalNotificationDigestItem(i *types.NotificationDigestItem) *notificationDigestItem {
+	return &notificationDigestItem{
+		ID:          i.ID,
+		PrincipalID: i.PrincipalID,
+		Type:        i.Type,
+		Subject:     i.Subject,
+		Summary:     i.Summary,
+		URL:         i.URL,
+		Created:     i.Created,
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	PrincipalID int64                     `db:"notification_preference_principal_id"`
+	RepoID      *int64                    `db:"notification_preference_repo_id"`
+	Type        enum.NotificationType     `db:"notification_preference_type"`
+	Delivery    enum.NotificationDelivery `db:"notification_preference_delivery"`
+	Created     int64                     `db:"notification_preference_created"`
+	Updated     int64                     `db:"notification_preference_updated"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_principal_id
+		,notification_preference_repo_id
+		,notification_preference_type
+		,notification_preference_delivery
+		,notification_preference_created
+		,notification_preference_updated`
+)
+
+// List returns all notification preferences of the principal.
+func (s *NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationPreference, error) {
+	const sqlQuery = `
+	SELECT` + notificationPreferenceColumns + `
+	FROM notification_preferences
+	WHERE notification_preference_principal_id = $1
+	ORDER BY notification_preference_repo_id IS NOT NULL, notification_preference_repo_id, notification_preference_type`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*notificationPreference{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	res := make([]*types.NotificationPreference, len(dst))
+	for i := range dst {
+		res[i] = mapToNotificationPreference(dst[i])
+	}
+
+	return res, nil
+}
+
+// Upsert creates or updates the notification preference.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, pref *types.NotificationPreference) error {
+	// the conflict target has to match one of the partial unique indexes.
+	conflictTarget := `(notification_preference_principal_id, notification_preference_type)
+	WHERE notification_preference_repo_id IS NULL`
+	if pref.RepoID != nil {
+		conflictTarget = `(notification_preference_principal_id, notification_preference_repo_id,
+		notification_preference_type)
+	WHERE notification_preference_repo_id IS NOT NULL`
+	}
+
+	sqlQuery := `
+	INSERT INTO notification_preferences (` + notificationPreferenceColumns + `
+	) values (
+		 :notification_preference_principal_id
+		,:notification_preference_repo_id
+		,:notification_preference_type
+		,:notification_preference_delivery
+		,:notification_preference_created
+		,:notification_preference_updated
+	)
+	ON CONFLICT ` + conflictTarget + ` DO UPDATE
+	SET
+		 notification_preference_delivery = EXCLUDED.notification_preference_delivery
+		,notification_preference_updated = EXCLUDED.notification_preference_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationPreference(pref))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification preference")
+	}
+
+	return nil
+}
+
+// Delete deletes the notification preference of the principal for the (optional) repository.
+func (s *NotificationPreferenceStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	repoID *int64,
+	notificationType enum.NotificationType,
+) error {
+	stmt := database.Builder.
+		Delete("notification_preferences").
+		Where("notification_preference_principal_id = ?", principalID).
+		Where("notification_preference_type = ?", notificationType)
+
+	if repoID != nil {
+		stmt = stmt.Where("notification_preference_repo_id = ?", *repoID)
+	} else {
+		stmt = stmt.Where("notification_preference_repo_id IS NULL")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification preference")
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the configured deliveries of the notification type in the repository
+// for the provided principals. A repository preference takes precedence over the global one.
+func (s *NotificationPreferenceStore) ListDeliveries(
+	ctx context.Context,
+	principalIDs []int64,
+	repoID int64,
+	notificationType enum.NotificationType,
+) (map[int64]enum.NotificationDelivery, error) {
+	if len(principalIDs) == 0 {
+		return map[int64]enum.NotificationDelivery{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs}).
+		Where("notification_preference_type = ?", notificationType).
+		Where("(notification_preference_repo_id IS NULL OR notification_preference_repo_id = ?)", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*notificationPreference{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification deliveries")
+	}
+
+	deliveries := make(map[int64]enum.NotificationDelivery, len(dst))
+	for _, pref := range dst {
+		if _, ok := deliveries[pref.PrincipalID]; ok && pref.RepoID == nil {
+			continue
+		}
+		deliveries[pref.PrincipalID] = pref.Delivery
+	}
+
+	return deliveries, nil
+}
+
+func mapToNotificationPreference(p *notificationPreference) *types.NotificationPreference {
+	return &types.NotificationPreference{
+		PrincipalID: p.PrincipalID,
+		RepoID:      p.RepoID,
+		Type:        p.Type,
+		Delivery:    p.Delivery,
+		Created:     p.Created,
+		Updated:     p.Updated,
+	}
+}
+
+func mapToInternalNotificationPreference(p *types.NotificationPreference) *notificationPreference {
+	return &notificationPreference{
+		PrincipalID: p.PrincipalID,
+		RepoID:      p.RepoID,
+		Type:        p.Type,
+		Delivery:    p.Delivery,
+		Created:     p.Created,
+		Updated:     p.Updated,
+	}
+}
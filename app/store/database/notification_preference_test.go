@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_NotificationPreference(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	preferenceStore := database.NewNotificationPreferenceStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	repoID := int64(1)
+	prefs := []*types.NotificationPreference{
+		{PrincipalID: userID, Type: enum.NotificationTypeCommentCreated, Delivery: enum.NotificationDeliveryDigest},
+		{PrincipalID: userID, Type: enum.NotificationTypeCommentCreated, Delivery: enum.NotificationDeliveryInstant},
+		{
+			PrincipalID: userID,
+			RepoID:      &repoID,
+			Type:        enum.NotificationTypeCommentCreated,
+			Delivery:    enum.NotificationDeliveryOff,
+		},
+	}
+	for _, pref := range prefs {
+		if err := preferenceStore.Upsert(ctx, pref); err != nil {
+			t.Fatalf("failed to upsert notification preference: %v", err)
+		}
+	}
+
+	list, err := preferenceStore.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to list notification preferences: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected the global preference to be updated in place, got %d preferences", len(list))
+	}
+
+	tests := []struct {
+		name   string
+		repoID int64
+		want   map[int64]enum.NotificationDelivery
+	}{
+		{
+			name:   "repo preference takes precedence",
+			repoID: 1,
+			want:   map[int64]enum.NotificationDelivery{userID: enum.NotificationDeliveryOff},
+		},
+		{
+			name:   "global preference",
+			repoID: 2,
+			want:   map[int64]enum.NotificationDelivery{userID: enum.NotificationDeliveryInstant},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := preferenceStore.ListDeliveries(ctx, []int64{userID}, tt.repoID,
+				enum.NotificationTypeCommentCreated)
+			if err != nil {
+				t.Fatalf("ListDeliveries() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListDeliveries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err = preferenceStore.Delete(ctx, userID, &repoID, enum.NotificationTypeCommentCreated); err != nil {
+		t.Fatalf("failed to delete notification preference: %v", err)
+	}
+
+	got, err := preferenceStore.ListDeliveries(ctx, []int64{userID}, repoID, enum.NotificationTypeCommentCreated)
+	if err != nil {
+		t.Fatalf("failed to list deliveries: %v", err)
+	}
+	if got[userID] != enum.NotificationDeliveryInstant {
+		t.Errorf("expected global preference after deleting repo preference, got %v", got)
+	}
+}
+
+func TestDatabase_NotificationDigest(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	digestStore := database.NewNotificationDigestStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	for i := int64(1); i <= 3; i++ {
+		item := &types.NotificationDigestItem{
+			PrincipalID: userID,
+			Type:        enum.NotificationTypeReviewSubmitted,
+			Subject:     "subject",
+			Summary:     "summary",
+			Created:     i,
+		}
+		if err := digestStore.Create(ctx, item); err != nil {
+			t.Fatalf("failed to create digest item: %v", err)
+		}
+	}
+
+	ids, err := digestStore.ListPrincipalIDs(ctx)
+	if err != nil {
+		t.Fatalf("failed to list principal ids: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int64{userID}) {
+		t.Fatalf("expected principal %d, got %v", userID, ids)
+	}
+
+	items, err := digestStore.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to list digest items: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 digest items, got %d", len(items))
+	}
+
+	if err = digestStore.DeleteUpTo(ctx, userID, items[1].ID); err != nil {
+		t.Fatalf("failed to delete digest items: %v", err)
+	}
+
+	items, err = digestStore.List(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to list digest items: %v", err)
+	}
+	if len(items) != 1 || items[0].Created != 3 {
+		t.Errorf("expected only the newest digest item to remain, got %+v", items)
+	}
+}
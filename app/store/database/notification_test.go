@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_Notification(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	notificationStore := database.NewNotificationStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	for i := int64(1); i <= 3; i++ {
+		notification := &types.Notification{
+			PrincipalID:   userID,
+			Type:          enum.NotificationTypeCommentCreated,
+			RepoID:        1,
+			PullReqID:     i,
+			PullReqNumber: i,
+			Title:         "title",
+			Created:       i,
+			Updated:       i,
+		}
+		if err := notificationStore.Create(ctx, notification); err != nil {
+			t.Fatalf("failed to create notification: %v", err)
+		}
+		if notification.ID == 0 {
+			t.Fatalf("expected notification id to be set")
+		}
+	}
+
+	unread := false
+	filter := &types.NotificationFilter{Pagination: types.Pagination{Page: 1, Size: 10}, Read: &unread}
+
+	list, err := notificationStore.List(ctx, userID, filter)
+	if err != nil {
+		t.Fatalf("failed to list notifications: %v", err)
+	}
+	if len(list) != 3 || list[0].PullReqNumber != 3 {
+		t.Fatalf("expected 3 notifications newest first, got %+v", list)
+	}
+
+	if err = notificationStore.UpdateRead(ctx, userID, list[0].ID, true); err != nil {
+		t.Fatalf("failed to mark notification as read: %v", err)
+	}
+
+	count, err := notificationStore.Count(ctx, userID, filter)
+	if err != nil {
+		t.Fatalf("failed to count notifications: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 unread notifications, got %d", count)
+	}
+
+	err = notificationStore.UpdateRead(ctx, userID+1, list[1].ID, true)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found for notification of another principal, got %v", err)
+	}
+
+	updated, err := notificationStore.UpdateAllRead(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to mark all notifications as read: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("expected 2 notifications to be marked as read, got %d", updated)
+	}
+
+	found, err := notificationStore.Find(ctx, userID, list[2].ID)
+	if err != nil {
+		t.Fatalf("failed to find notification: %v", err)
+	}
+	if !found.Read {
+		t.Errorf("expected notification to be read")
+	}
+}
@@ -51,6 +51,8 @@ const (
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
+	,pipeline_concurrency_group
+	,pipeline_concurrency_limit
 	`
 )
 
@@ -110,6 +112,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
+		,pipeline_concurrency_group
+		,pipeline_concurrency_limit
 	) VALUES (
 		:pipeline_description,
 		:pipeline_uid,
@@ -121,7 +125,9 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_config_path,
 		:pipeline_created,
 		:pipeline_updated,
-		:pipeline_version
+		:pipeline_version,
+		:pipeline_concurrency_group,
+		:pipeline_concurrency_limit
 	) RETURNING pipeline_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -149,7 +155,9 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
 		pipeline_updated = :pipeline_updated,
-		pipeline_version = :pipeline_version
+		pipeline_version = :pipeline_version,
+		pipeline_concurrency_group = :pipeline_concurrency_group,
+		pipeline_concurrency_limit = :pipeline_concurrency_limit
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
 	updatedAt := time.Now()
 	pipeline := *p
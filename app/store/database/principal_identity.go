@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PrincipalIdentityStore = (*PrincipalIdentityStore)(nil)
+
+// NewPrincipalIdentityStore returns a new PrincipalIdentityStore.
+func NewPrincipalIdentityStore(db *sqlx.DB) *PrincipalIdentityStore {
+	return &PrincipalIdentityStore{
+		db: db,
+	}
+}
+
+// PrincipalIdentityStore implements store.PrincipalIdentityStore backed by a relational database.
+type PrincipalIdentityStore struct {
+	db *sqlx.DB
+}
+
+type principalIdentity struct {
+	Provider    string `db:"principal_identity_provider"`
+	ExternalID  string `db:"principal_identity_external_id"`
+	PrincipalID int64  `db:"principal_identity_principal_id"`
+	Created     int64  `db:"principal_identity_created"`
+}
+
+const (
+	principalIdentityColumns = `
+		 principal_identity_provider
+		,principal_identity_external_id
+		,principal_identity_principal_id
+		,principal_identity_created`
+)
+
+// Find finds the identity of the external provider.
+func (s *PrincipalIdentityStore) Find(
+	ctx context.Context,
+	provider string,
+	externalID string,
+) (*types.PrincipalIdentity, error) {
+	const sqlQuery = `
+	SELECT` + principalIdentityColumns + `
+	FROM principal_identities
+	WHERE principal_identity_provider = $1 AND principal_identity_external_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &principalIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, provider, externalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find principal identity")
+	}
+
+	return (*types.PrincipalIdentity)(dst), nil
+}
+
+// Create links the principal to the identity of the external provider.
+func (s *PrincipalIdentityStore) Create(ctx context.Context, identity *types.PrincipalIdentity) error {
+	const sqlQuery = `
+	INSERT INTO principal_identities (
+		 principal_identity_provider
+		,principal_identity_external_id
+		,principal_identity_principal_id
+		,principal_identity_created
+	) values (
+		 :principal_identity_provider
+		,:principal_identity_external_id
+		,:principal_identity_principal_id
+		,:principal_identity_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, (*principalIdentity)(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind principal identity object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert principal identity")
+	}
+
+	return nil
+}
+
+// List lists the identities of the external provider ordered by their external id,
+// starting after the provided external id.
+func (s *PrincipalIdentityStore) List(
+	ctx context.Context,
+	provider string,
+	afterExternalID string,
+	limit int,
+) ([]*types.PrincipalIdentity, error) {
+	const sqlQuery = `
+	SELECT` + principalIdentityColumns + `
+	FROM principal_identities
+	WHERE principal_identity_provider = $1 AND principal_identity_external_id > $2
+	ORDER BY principal_identity_external_id
+	LIMIT $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*principalIdentity{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, provider, afterExternalID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principal identities")
+	}
+
+	identities := make([]*types.PrincipalIdentity, len(dst))
+	for i := range dst {
+		identities[i] = (*types.PrincipalIdentity)(dst[i])
+	}
+
+	return identities, nil
+}
+
+// DeleteByPrincipal unlinks the principal from all its identities of the external provider.
+func (s *PrincipalIdentityStore) DeleteByPrincipal(ctx context.Context, provider string, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM principal_identities
+	WHERE principal_identity_provider = $1 AND principal_identity_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, provider, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete principal identities")
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_PrincipalIdentity(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	identityStore := database.NewPrincipalIdentityStore(db)
+
+	identity := &types.PrincipalIdentity{
+		Provider:    types.PrincipalIdentityProviderLDAP,
+		ExternalID:  "uid=jdoe,ou=people,dc=example,dc=org",
+		PrincipalID: userID,
+		Created:     1,
+	}
+	if err := identityStore.Create(ctx, identity); err != nil {
+		t.Fatalf("failed to create principal identity: %v", err)
+	}
+
+	// an identity can only be linked to a single principal.
+	if err := identityStore.Create(ctx, identity); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("expected duplicate error, got: %v", err)
+	}
+
+	found, err := identityStore.Find(ctx, identity.Provider, identity.ExternalID)
+	if err != nil {
+		t.Fatalf("failed to find principal identity: %v", err)
+	}
+	if !reflect.DeepEqual(identity, found) {
+		t.Errorf("principal identity mismatch: want=%+v got=%+v", identity, found)
+	}
+
+	// identities are scoped by their provider.
+	_, err = identityStore.Find(ctx, types.PrincipalIdentityProviderOIDC, identity.ExternalID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+
+	other := &types.PrincipalIdentity{
+		Provider:    types.PrincipalIdentityProviderLDAP,
+		ExternalID:  "uid=asmith,ou=people,dc=example,dc=org",
+		PrincipalID: userID,
+		Created:     2,
+	}
+	if err = identityStore.Create(ctx, other); err != nil {
+		t.Fatalf("failed to create principal identity: %v", err)
+	}
+
+	list, err := identityStore.List(ctx, types.PrincipalIdentityProviderLDAP, "", 1)
+	if err != nil {
+		t.Fatalf("failed to list principal identities: %v", err)
+	}
+	if len(list) != 1 || !reflect.DeepEqual(other, list[0]) {
+		t.Errorf("expected first page to contain %+v, got %+v", other, list)
+	}
+
+	list, err = identityStore.List(ctx, types.PrincipalIdentityProviderLDAP, other.ExternalID, 10)
+	if err != nil {
+		t.Fatalf("failed to list principal identities: %v", err)
+	}
+	if len(list) != 1 || !reflect.DeepEqual(identity, list[0]) {
+		t.Errorf("expected second page to contain %+v, got %+v", identity, list)
+	}
+
+	list, err = identityStore.List(ctx, types.PrincipalIdentityProviderOIDC, "", 10)
+	if err != nil {
+		t.Fatalf("failed to list principal identities: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("expected no oidc identities, got %+v", list)
+	}
+
+	if err = identityStore.DeleteByPrincipal(ctx, types.PrincipalIdentityProviderLDAP, userID); err != nil {
+		t.Fatalf("failed to delete principal identities: %v", err)
+	}
+
+	_, err = identityStore.Find(ctx, identity.Provider, identity.ExternalID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error after delete, got: %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.QuotaStore = (*QuotaStore)(nil)
+
+// NewQuotaStore returns a new QuotaStore.
+func NewQuotaStore(db *sqlx.DB) *QuotaStore {
+	return &QuotaStore{
+		db: db,
+	}
+}
+
+// QuotaStore implements store.QuotaStore backed by a relational database.
+type QuotaStore struct {
+	db *sqlx.DB
+}
+
+type spaceQuota struct {
+	SpaceID                 int64    `db:"space_quota_space_id"`
+	MaxRepos                null.Int `db:"space_quota_max_repos"`
+	MaxRepoSize             null.Int `db:"space_quota_max_repo_size"`
+	MaxBlobSize             null.Int `db:"space_quota_max_blob_size"`
+	MaxPipelineMinutes      null.Int `db:"space_quota_max_pipeline_minutes"`
+	MaxConcurrentExecutions null.Int `db:"space_quota_max_concurrent_executions"`
+	Created                 int64    `db:"space_quota_created"`
+	Updated                 int64    `db:"space_quota_updated"`
+}
+
+type resourceUsage struct {
+	Repos                int64 `db:"repos"`
+	RepoSize             int64 `db:"repo_size"`
+	BlobSize             int64 `db:"blob_size"`
+	PipelineMillis       int64 `db:"pipeline_millis"`
+	ConcurrentExecutions int64 `db:"concurrent_executions"`
+}
+
+const (
+	spaceQuotaColumns = `
+		 space_quota_space_id
+		,space_quota_max_repos
+		,space_quota_max_repo_size
+		,space_quota_max_blob_size
+		,space_quota_max_pipeline_minutes
+		,space_quota_max_concurrent_executions
+		,space_quota_created
+		,space_quota_updated`
+)
+
+// Find finds the quota of the space.
+func (s *QuotaStore) Find(ctx context.Context, spaceID int64) (*types.SpaceQuota, error) {
+	const sqlQuery = `
+	SELECT` + spaceQuotaColumns + `
+	FROM space_quotas
+	WHERE space_quota_space_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &spaceQuota{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find space quota")
+	}
+
+	return mapToSpaceQuota(dst), nil
+}
+
+// Upsert creates the quota of the space or replaces the existing one.
+func (s *QuotaStore) Upsert(ctx context.Context, quota *types.SpaceQuota) error {
+	const sqlQuery = `
+	INSERT INTO space_quotas (` + spaceQuotaColumns + `
+	) values (
+		 :space_quota_space_id
+		,:space_quota_max_repos
+		,:space_quota_max_repo_size
+		,:space_quota_max_blob_size
+		,:space_quota_max_pipeline_minutes
+		,:space_quota_max_concurrent_executions
+		,:space_quota_created
+		,:space_quota_updated
+	)
+	ON CONFLICT (space_quota_space_id) DO UPDATE
+	SET
+		 space_quota_max_repos = EXCLUDED.space_quota_max_repos
+		,space_quota_max_repo_size = EXCLUDED.space_quota_max_repo_size
+		,space_quota_max_blob_size = EXCLUDED.space_quota_max_blob_size
+		,space_quota_max_pipeline_minutes = EXCLUDED.space_quota_max_pipeline_minutes
+		,space_quota_max_concurrent_executions = EXCLUDED.space_quota_max_concurrent_executions
+		,space_quota_updated = EXCLUDED.space_quota_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSpaceQuota(quota))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind space quota object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert space quota")
+	}
+
+	return nil
+}
+
+// Delete deletes the quota of the space.
+func (s *QuotaStore) Delete(ctx context.Context, spaceID int64) error {
+	const sqlQuery = `
+	DELETE FROM space_quotas
+	WHERE space_quota_space_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete space quota")
+	}
+
+	return nil
+}
+
+// Usage returns the resource consumption of the space together with all of its descendants.
+// Only pipeline executions started at or after the provided time (unix millis) count towards pipeline minutes.
+// Repositories that are soft deleted don't count towards the number of repositories,
+// but their storage is still in use until they are purged.
+func (s *QuotaStore) Usage(ctx context.Context, spaceID int64, since int64) (*types.ResourceUsage, error) {
+	const sqlQuery = `
+	WITH RECURSIVE space_tree AS (
+		SELECT space_id
+		FROM spaces
+		WHERE space_id = $1
+
+		UNION
+
+		SELECT s.space_id
+		FROM spaces s
+		JOIN space_tree t ON s.space_parent_id = t.space_id
+	)
+	SELECT
+		 (SELECT COUNT(*)
+			FROM repositories
+			WHERE repo_parent_id IN (SELECT space_id FROM space_tree) AND repo_deleted IS NULL
+		 ) AS repos
+		,(SELECT COALESCE(SUM(repo_size), 0)
+			FROM repositories
+			WHERE repo_parent_id IN (SELECT space_id FROM space_tree)
+		 ) AS repo_size
+		,(SELECT COALESCE(SUM(repo_blob_size_size), 0)
+			FROM repo_blob_sizes
+			JOIN repositories ON repo_id = repo_blob_size_repo_id
+			WHERE repo_parent_id IN (SELECT space_id FROM space_tree)
+		 ) AS blob_size
+		,(SELECT COALESCE(SUM(
+				CASE WHEN execution_finished > 0 THEN execution_finished ELSE $3 END - execution_started), 0)
+			FROM executions
+			JOIN repositories ON repo_id = execution_repo_id
+			WHERE repo_parent_id IN (SELECT space_id FROM space_tree) AND
+				execution_started > 0 AND execution_started >= $2
+		 ) AS pipeline_millis
+		,(SELECT COUNT(*)
+			FROM executions
+			JOIN repositories ON repo_id = execution_repo_id
+			WHERE repo_parent_id IN (SELECT space_id FROM space_tree) AND
+				execution_status IN ($4, $5)
+		 ) AS concurrent_executions`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &resourceUsage{}
+	if err := db.GetContext(ctx, dst, sqlQuery,
+		spaceID,
+		since,
+		time.Now().UnixMilli(),
+		enum.CIStatusPending,
+		enum.CIStatusRunning,
+	); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to get space resource usage")
+	}
+
+	return &types.ResourceUsage{
+		Repos:                dst.Repos,
+		RepoSize:             dst.RepoSize,
+		BlobSize:             dst.BlobSize,
+		PipelineMinutes:      dst.PipelineMillis / time.Minute.Milliseconds(),
+		ConcurrentExecutions: dst.ConcurrentExecutions,
+	}, nil
+}
+
+// AddBlobSize adds the size (in bytes) of an uploaded blob to the blob storage used by the repository.
+func (s *QuotaStore) AddBlobSize(ctx context.Context, repoID int64, size int64) error {
+	const sqlQuery = `
+	INSERT INTO repo_blob_sizes (
+		 repo_blob_size_repo_id
+		,repo_blob_size_size
+	) values (
+		 $1
+		,$2
+	)
+	ON CONFLICT (repo_blob_size_repo_id) DO UPDATE
+	SET repo_blob_size_size = repo_blob_sizes.repo_blob_size_size + EXCLUDED.repo_blob_size_size`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, size); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to add repository blob size")
+	}
+
+	return nil
+}
+
+func mapToSpaceQuota(q *spaceQuota) *types.SpaceQuota {
+	return &types.SpaceQuota{
+		SpaceID:                 q.SpaceID,
+		MaxRepos:                q.MaxRepos.Ptr(),
+		MaxRepoSize:             q.MaxRepoSize.Ptr(),
+		MaxBlobSize:             q.MaxBlobSize.Ptr(),
+		MaxPipelineMinutes:      q.MaxPipelineMinutes.Ptr(),
+		MaxConcurrentExecutions: q.MaxConcurrentExecutions.Ptr(),
+		Created:                 q.Created,
+		Updated:                 q.Updated,
+	}
+}
+
+func mapToInternalSpaceQuota(q *types.SpaceQuota) *spaceQuota {
+	return &spaceQuota{
+		SpaceID:                 q.SpaceID,
+		MaxRepos:                null.IntFromPtr(q.MaxRepos),
+		MaxRepoSize:             null.IntFromPtr(q.MaxRepoSize),
+		MaxBlobSize:             null.IntFromPtr(q.MaxBlobSize),
+		MaxPipelineMinutes:      null.IntFromPtr(q.MaxPipelineMinutes),
+		MaxConcurrentExecutions: null.IntFromPtr(q.MaxConcurrentExecutions),
+		Created:                 q.Created,
+		Updated:                 q.Updated,
+	}
+}
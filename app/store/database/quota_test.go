@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_Quota(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	quotaStore := database.NewQuotaStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 0)
+
+	createRepo(ctx, t, repoStore, 1, 1, 100)
+	createRepo(ctx, t, repoStore, 2, 2, 50)
+	createRepo(ctx, t, repoStore, 3, 3, 10)
+
+	for _, size := range []int64{20, 30} {
+		if err := quotaStore.AddBlobSize(ctx, 2, size); err != nil {
+			t.Fatalf("failed to add blob size: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		spaceID int64
+		want    types.ResourceUsage
+	}{
+		{
+			name:    "root space includes descendants",
+			spaceID: 1,
+			want:    types.ResourceUsage{Repos: 2, RepoSize: 150, BlobSize: 50},
+		},
+		{
+			name:    "child space",
+			spaceID: 2,
+			want:    types.ResourceUsage{Repos: 1, RepoSize: 50, BlobSize: 50},
+		},
+		{
+			name:    "other root space",
+			spaceID: 3,
+			want:    types.ResourceUsage{Repos: 1, RepoSize: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := quotaStore.Usage(ctx, tt.spaceID, 0)
+			if err != nil {
+				t.Fatalf("Usage() error = %v", err)
+			}
+			if !reflect.DeepEqual(*usage, tt.want) {
+				t.Errorf("Usage() = %+v, want %+v", *usage, tt.want)
+			}
+		})
+	}
+
+	maxRepos := int64(5)
+	quota := &types.SpaceQuota{SpaceID: 2, MaxRepos: &maxRepos, Created: 1, Updated: 1}
+	if err := quotaStore.Upsert(ctx, quota); err != nil {
+		t.Fatalf("failed to upsert quota: %v", err)
+	}
+
+	found, err := quotaStore.Find(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to find quota: %v", err)
+	}
+	if !reflect.DeepEqual(found, quota) {
+		t.Errorf("Find() = %+v, want %+v", found, quota)
+	}
+
+	if err = quotaStore.Delete(ctx, 2); err != nil {
+		t.Fatalf("failed to delete quota: %v", err)
+	}
+
+	if _, err = quotaStore.Find(ctx, 2); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Find() after delete error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
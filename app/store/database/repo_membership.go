@@ -0,0 +1,358 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMembershipStore = (*RepoMembershipStore)(nil)
+
+// NewRepoMembershipStore returns a new RepoMembershipStore.
+func NewRepoMembershipStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *RepoMembershipStore {
+	return &RepoMembershipStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RepoMembershipStore implements store.RepoMembershipStore backed by a relational database.
+type RepoMembershipStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type repoMembership struct {
+	RepoID      int64 `db:"repo_membership_repo_id"`
+	PrincipalID int64 `db:"repo_membership_principal_id"`
+
+	CreatedBy int64 `db:"repo_membership_created_by"`
+	Created   int64 `db:"repo_membership_created"`
+	Updated   int64 `db:"repo_membership_updated"`
+
+	Role   enum.MembershipRole `db:"repo_membership_role"`
+	RoleID null.Int            `db:"repo_membership_role_id"`
+
+	RoleIdentifier null.String `db:"role_uid"`
+}
+
+type repoMembershipPrincipal struct {
+	repoMembership
+	principalInfo
+}
+
+const (
+	repoMembershipColumns = `
+		 repo_membership_repo_id
+		,repo_membership_principal_id
+		,repo_membership_created_by
+		,repo_membership_created
+		,repo_membership_updated
+		,repo_membership_role
+		,repo_membership_role_id
+		,role_uid`
+
+	repoMembershipJoinRoles = "roles ON role_id = repo_membership_role_id"
+
+	repoMembershipSelectBase = `
+	SELECT` + repoMembershipColumns + `
+	FROM repo_memberships
+	LEFT JOIN ` + repoMembershipJoinRoles
+)
+
+// Find finds the repository membership by repo id and principal id.
+func (s *RepoMembershipStore) Find(ctx context.Context, key types.RepoMembershipKey) (*types.RepoMembership, error) {
+	const sqlQuery = repoMembershipSelectBase + `
+	WHERE repo_membership_repo_id = $1 AND repo_membership_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoMembership{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key.RepoID, key.PrincipalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo membership")
+	}
+
+	result := mapToRepoMembership(dst)
+
+	return &result, nil
+}
+
+// FindUser finds the repository membership by repo id and principal id and adds user info to it.
+func (s *RepoMembershipStore) FindUser(
+	ctx context.Context,
+	key types.RepoMembershipKey,
+) (*types.RepoMembershipUser, error) {
+	m, err := s.Find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, []int64{m.CreatedBy, m.PrincipalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repo membership principal infos: %w", err)
+	}
+
+	result := &types.RepoMembershipUser{RepoMembership: *m}
+
+	user, ok := infoMap[m.PrincipalID]
+	if !ok {
+		return nil, fmt.Errorf("failed to find repo membership principal info for principal %d", m.PrincipalID)
+	}
+
+	result.Principal = *user
+
+	if addedBy, ok := infoMap[m.CreatedBy]; ok {
+		result.AddedBy = *addedBy
+	}
+
+	return result, nil
+}
+
+// Create creates a new repository membership.
+func (s *RepoMembershipStore) Create(ctx context.Context, membership *types.RepoMembership) error {
+	const sqlQuery = `
+	INSERT INTO repo_memberships (
+		 repo_membership_repo_id
+		,repo_membership_principal_id
+		,repo_membership_created_by
+		,repo_membership_created
+		,repo_membership_updated
+		,repo_membership_role
+		,repo_membership_role_id
+	) values (
+		 :repo_membership_repo_id
+		,:repo_membership_principal_id
+		,:repo_membership_created_by
+		,:repo_membership_created
+		,:repo_membership_updated
+		,:repo_membership_role
+		,:repo_membership_role_id
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMembership(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of a member of a repository.
+func (s *RepoMembershipStore) Update(ctx context.Context, membership *types.RepoMembership) error {
+	const sqlQuery = `
+	UPDATE repo_memberships
+	SET
+		 repo_membership_updated = :repo_membership_updated
+		,repo_membership_role = :repo_membership_role
+		,repo_membership_role_id = :repo_membership_role_id
+	WHERE repo_membership_repo_id = :repo_membership_repo_id AND
+	      repo_membership_principal_id = :repo_membership_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMembership := mapToInternalRepoMembership(membership)
+	dbMembership.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMembership)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repo membership role")
+	}
+
+	membership.Updated = dbMembership.Updated
+
+	return nil
+}
+
+// Delete deletes the repository membership.
+func (s *RepoMembershipStore) Delete(ctx context.Context, key types.RepoMembershipKey) error {
+	const sqlQuery = `
+	DELETE from repo_memberships
+	WHERE repo_membership_repo_id = $1 AND
+	      repo_membership_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key.RepoID, key.PrincipalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "delete repo membership query failed")
+	}
+
+	return nil
+}
+
+// CountUsers returns a number of users repository memberships that matches the provided filter.
+func (s *RepoMembershipStore) CountUsers(ctx context.Context,
+	repoID int64,
+	filter types.MembershipUserFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repo_memberships").
+		InnerJoin("principals ON repo_membership_principal_id = principal_id").
+		Where("repo_membership_repo_id = ?", repoID)
+
+	stmt = applyMembershipUserFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert repo membership users count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing repo membership users count query")
+	}
+
+	return count, nil
+}
+
+// ListUsers returns a list of memberships of a repository.
+func (s *RepoMembershipStore) ListUsers(ctx context.Context,
+	repoID int64,
+	filter types.MembershipUserFilter,
+) ([]types.RepoMembershipUser, error) {
+	const columns = repoMembershipColumns + "," + principalInfoCommonColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("repo_memberships").
+		InnerJoin("principals ON repo_membership_principal_id = principal_id").
+		LeftJoin(repoMembershipJoinRoles).
+		Where("repo_membership_repo_id = ?", repoID)
+
+	stmt = applyMembershipUserFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	order := filter.Order
+	if order == enum.OrderDefault {
+		order = enum.OrderAsc
+	}
+
+	switch filter.Sort {
+	case enum.MembershipUserSortName:
+		stmt = stmt.OrderBy("principal_display_name " + order.String())
+	case enum.MembershipUserSortCreated:
+		stmt = stmt.OrderBy("repo_membership_created " + order.String())
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert repo membership users list query to sql: %w", err)
+	}
+
+	dst := make([]*repoMembershipPrincipal, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repo membership users list query")
+	}
+
+	// collect all principal IDs
+	ids := make([]int64, 0, len(dst))
+	for _, m := range dst {
+		ids = append(ids, m.repoMembership.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repo membership principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	result := make([]types.RepoMembershipUser, len(dst))
+	for i := range dst {
+		m := dst[i]
+		result[i].RepoMembership = mapToRepoMembership(&m.repoMembership)
+		result[i].Principal = mapToPrincipalInfo(&m.principalInfo)
+		if addedBy, ok := infoMap[m.repoMembership.CreatedBy]; ok {
+			result[i].AddedBy = *addedBy
+		}
+	}
+
+	return result, nil
+}
+
+// CountByRoleID returns the number of repository memberships that have the provided custom role assigned.
+func (s *RepoMembershipStore) CountByRoleID(ctx context.Context, roleID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM repo_memberships
+	WHERE repo_membership_role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, roleID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing repo membership count by role query")
+	}
+
+	return count, nil
+}
+
+func mapToRepoMembership(m *repoMembership) types.RepoMembership {
+	return types.RepoMembership{
+		RepoMembershipKey: types.RepoMembershipKey{
+			RepoID:      m.RepoID,
+			PrincipalID: m.PrincipalID,
+		},
+		CreatedBy:    m.CreatedBy,
+		Created:      m.Created,
+		Updated:      m.Updated,
+		Role:         m.Role,
+		CustomRoleID: m.RoleID.Ptr(),
+		CustomRole:   m.RoleIdentifier.String,
+	}
+}
+
+func mapToInternalRepoMembership(m *types.RepoMembership) repoMembership {
+	return repoMembership{
+		RepoID:      m.RepoID,
+		PrincipalID: m.PrincipalID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Role:        m.Role,
+		RoleID:      null.IntFromPtr(m.CustomRoleID),
+	}
+}
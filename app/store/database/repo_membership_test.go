@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_RepoMembership(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	repoMembershipStore := database.NewRepoMembershipStore(db,
+		cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	key := types.RepoMembershipKey{RepoID: 1, PrincipalID: userID}
+	if err := repoMembershipStore.Create(ctx, &types.RepoMembership{
+		RepoMembershipKey: key,
+		CreatedBy:         userID,
+		Role:              enum.MembershipRoleReader,
+	}); err != nil {
+		t.Fatalf("failed to create repo membership: %v", err)
+	}
+
+	membership, err := repoMembershipStore.FindUser(ctx, key)
+	if err != nil {
+		t.Fatalf("failed to find repo membership: %v", err)
+	}
+	if want, got := enum.MembershipRoleReader, membership.Role; want != got {
+		t.Errorf("role mismatch: want=%s got=%s", want, got)
+	}
+	if want, got := userID, membership.Principal.ID; want != got {
+		t.Errorf("principal mismatch: want=%d got=%d", want, got)
+	}
+
+	membership.Role = enum.MembershipRoleContributor
+	if err = repoMembershipStore.Update(ctx, &membership.RepoMembership); err != nil {
+		t.Fatalf("failed to update repo membership: %v", err)
+	}
+
+	memberships, err := repoMembershipStore.ListUsers(ctx, 1, types.MembershipUserFilter{
+		ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+	})
+	if err != nil {
+		t.Fatalf("failed to list repo memberships: %v", err)
+	}
+	if len(memberships) != 1 || memberships[0].Role != enum.MembershipRoleContributor {
+		t.Errorf("unexpected repo memberships: %+v", memberships)
+	}
+
+	if err = repoMembershipStore.Delete(ctx, key); err != nil {
+		t.Fatalf("failed to delete repo membership: %v", err)
+	}
+
+	_, err = repoMembershipStore.Find(ctx, key)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RoleStore = (*RoleStore)(nil)
+
+// NewRoleStore returns a new RoleStore.
+func NewRoleStore(db *sqlx.DB) *RoleStore {
+	return &RoleStore{
+		db: db,
+	}
+}
+
+// RoleStore implements store.RoleStore backed by a relational database.
+type RoleStore struct {
+	db *sqlx.DB
+}
+
+type role struct {
+	ID          int64  `db:"role_id"`
+	SpaceID     int64  `db:"role_space_id"`
+	Identifier  string `db:"role_uid"`
+	Description string `db:"role_description"`
+	Permissions string `db:"role_permissions"`
+
+	CreatedBy int64 `db:"role_created_by"`
+	Created   int64 `db:"role_created"`
+	Updated   int64 `db:"role_updated"`
+}
+
+const (
+	roleColumns = `
+		 role_id
+		,role_space_id
+		,role_uid
+		,role_description
+		,role_permissions
+		,role_created_by
+		,role_created
+		,role_updated`
+
+	roleSelectBase = `
+	SELECT` + roleColumns + `
+	FROM roles`
+)
+
+// Find finds the role by id.
+func (s *RoleStore) Find(ctx context.Context, id int64) (*types.Role, error) {
+	const sqlQuery = roleSelectBase + `
+	WHERE role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &role{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find role")
+	}
+
+	return mapToRole(dst), nil
+}
+
+// FindByIdentifier finds the role by space id and identifier.
+func (s *RoleStore) FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Role, error) {
+	const sqlQuery = roleSelectBase + `
+	WHERE role_space_id = $1 AND LOWER(role_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &role{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find role by identifier")
+	}
+
+	return mapToRole(dst), nil
+}
+
+// Create creates a new role.
+func (s *RoleStore) Create(ctx context.Context, role *types.Role) error {
+	const sqlQuery = `
+	INSERT INTO roles (
+		 role_space_id
+		,role_uid
+		,role_description
+		,role_permissions
+		,role_created_by
+		,role_created
+		,role_updated
+	) values (
+		 :role_space_id
+		,:role_uid
+		,:role_description
+		,:role_permissions
+		,:role_created_by
+		,:role_created
+		,:role_updated
+	) RETURNING role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRole(role))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind role object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&role.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert role")
+	}
+
+	return nil
+}
+
+// Update updates the description and the permissions of the role.
+func (s *RoleStore) Update(ctx context.Context, role *types.Role) error {
+	const sqlQuery = `
+	UPDATE roles
+	SET
+		 role_updated = :role_updated
+		,role_description = :role_description
+		,role_permissions = :role_permissions
+	WHERE role_id = :role_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRole := mapToInternalRole(role)
+	dbRole.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRole)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind role object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update role")
+	}
+
+	role.Updated = dbRole.Updated
+
+	return nil
+}
+
+// Delete deletes the role with the given id.
+func (s *RoleStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM roles
+	WHERE role_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete role")
+	}
+
+	return nil
+}
+
+// Count returns the number of roles in a space.
+func (s *RoleStore) Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("roles").
+		Where("role_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(role_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert role count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing role count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of roles in a space.
+func (s *RoleStore) List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Role, error) {
+	stmt := database.Builder.
+		Select(roleColumns).
+		From("roles").
+		Where("role_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(role_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("role_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert role list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*role, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing role list query")
+	}
+
+	result := make([]*types.Role, len(dst))
+	for i := range dst {
+		result[i] = mapToRole(dst[i])
+	}
+
+	return result, nil
+}
+
+func mapToRole(r *role) *types.Role {
+	return &types.Role{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		Description: r.Description,
+		Permissions: permissionsFromString(r.Permissions),
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}
+}
+
+func mapToInternalRole(r *types.Role) *role {
+	return &role{
+		ID:          r.ID,
+		SpaceID:     r.SpaceID,
+		Identifier:  r.Identifier,
+		Description: r.Description,
+		Permissions: permissionsToString(r.Permissions),
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+	}
+}
+
+// permissionsSeparator defines the character that's used to join permissions for storing them in the DB
+// ASSUMPTION: permissions are defined in an enum and don't contain ",".
+const permissionsSeparator = ","
+
+func permissionsFromString(permissionsString string) []enum.Permission {
+	if permissionsString == "" {
+		return []enum.Permission{}
+	}
+
+	rawPermissions := strings.Split(permissionsString, permissionsSeparator)
+
+	permissions := make([]enum.Permission, len(rawPermissions))
+	for i, rawPermission := range rawPermissions {
+		permissions[i] = enum.Permission(rawPermission)
+	}
+
+	return permissions
+}
+
+func permissionsToString(permissions []enum.Permission) string {
+	rawPermissions := make([]string, len(permissions))
+	for i := range permissions {
+		rawPermissions[i] = string(permissions[i])
+	}
+
+	return strings.Join(rawPermissions, permissionsSeparator)
+}
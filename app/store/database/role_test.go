@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MembershipWithCustomRole(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	roleStore := database.NewRoleStore(db)
+	membershipStore := database.NewMembershipStore(db,
+		cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)), spacePathStore, spaceStore)
+
+	role := &types.Role{
+		SpaceID:     1,
+		Identifier:  "deployer",
+		Permissions: []enum.Permission{enum.PermissionRepoEdit, enum.PermissionRepoPush},
+		CreatedBy:   userID,
+	}
+	if err := roleStore.Create(ctx, role); err != nil {
+		t.Fatalf("failed to create role: %v", err)
+	}
+
+	found, err := roleStore.FindByIdentifier(ctx, 1, "Deployer")
+	if err != nil {
+		t.Fatalf("failed to find role: %v", err)
+	}
+	if want, got := role.Permissions, found.Permissions; !reflect.DeepEqual(want, got) {
+		t.Errorf("permissions mismatch: want=%v got=%v", want, got)
+	}
+
+	key := types.MembershipKey{SpaceID: 1, PrincipalID: userID}
+	if err = membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: key,
+		CreatedBy:     userID,
+		Role:          enum.MembershipRoleCustom,
+		CustomRoleID:  &role.ID,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	membership, err := membershipStore.Find(ctx, key)
+	if err != nil {
+		t.Fatalf("failed to find membership: %v", err)
+	}
+	if membership.CustomRoleID == nil || *membership.CustomRoleID != role.ID {
+		t.Errorf("custom role ID mismatch: want=%d got=%v", role.ID, membership.CustomRoleID)
+	}
+	if want, got := role.Identifier, membership.CustomRole; want != got {
+		t.Errorf("custom role mismatch: want=%s got=%s", want, got)
+	}
+
+	count, err := membershipStore.CountByRoleID(ctx, role.ID)
+	if err != nil {
+		t.Fatalf("failed to count memberships: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("membership count mismatch: want=1 got=%d", count)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+type runner struct {
+	ID          int64  `db:"runner_id"`
+	Identifier  string `db:"runner_identifier"`
+	Description string `db:"runner_description"`
+	Labels      string `db:"runner_labels"`
+	TokenHash   string `db:"runner_token_hash"`
+	OS          string `db:"runner_os"`
+	Arch        string `db:"runner_arch"`
+	Version     string `db:"runner_version"`
+	LastSeen    int64  `db:"runner_last_seen"`
+	CreatedBy   int64  `db:"runner_created_by"`
+	Created     int64  `db:"runner_created"`
+	Updated     int64  `db:"runner_updated"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_identifier
+		,runner_description
+		,runner_labels
+		,runner_token_hash
+		,runner_os
+		,runner_arch
+		,runner_version
+		,runner_last_seen
+		,runner_created_by
+		,runner_created
+		,runner_updated`
+
+	runnerSelectBase = `
+	SELECT` + runnerColumns + `
+	FROM runners`
+)
+
+// Find finds the runner by id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByIdentifier finds the runner by its identifier.
+func (s *RunnerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE LOWER(runner_identifier) = LOWER($1)`
+
+	return s.find(ctx, sqlQuery, identifier)
+}
+
+// FindByTokenHash finds the runner by the hash of its token.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_token_hash = $1`
+
+	return s.find(ctx, sqlQuery, tokenHash)
+}
+
+func (s *RunnerStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+
+	return mapToRunner(dst)
+}
+
+// Create saves the runner details.
+func (s *RunnerStore) Create(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	INSERT INTO runners (
+		 runner_identifier
+		,runner_description
+		,runner_labels
+		,runner_token_hash
+		,runner_os
+		,runner_arch
+		,runner_version
+		,runner_last_seen
+		,runner_created_by
+		,runner_created
+		,runner_updated
+	) values (
+		 :runner_identifier
+		,:runner_description
+		,:runner_labels
+		,:runner_token_hash
+		,:runner_os
+		,:runner_arch
+		,:runner_version
+		,:runner_last_seen
+		,:runner_created_by
+		,:runner_created
+		,:runner_updated
+	) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRunner, err := mapToInternalRunner(r)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRunner)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert runner")
+	}
+
+	return nil
+}
+
+// Update updates the runner details.
+func (s *RunnerStore) Update(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET
+		 runner_description = :runner_description
+		,runner_labels = :runner_labels
+		,runner_os = :runner_os
+		,runner_arch = :runner_arch
+		,runner_version = :runner_version
+		,runner_last_seen = :runner_last_seen
+		,runner_updated = :runner_updated
+	WHERE runner_id = :runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbRunner, err := mapToInternalRunner(r)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRunner)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner")
+	}
+
+	return nil
+}
+
+// Delete deletes the runner.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM runners
+	WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete runner")
+	}
+
+	return nil
+}
+
+// List lists all runners.
+func (s *RunnerStore) List(ctx context.Context) ([]*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	ORDER BY runner_identifier`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runner{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list runners")
+	}
+
+	res := make([]*types.Runner, len(dst))
+	for i := range dst {
+		var err error
+		if res[i], err = mapToRunner(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func mapToRunner(in *runner) (*types.Runner, error) {
+	res := &types.Runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		TokenHash:   in.TokenHash,
+		OS:          in.OS,
+		Arch:        in.Arch,
+		Version:     in.Version,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if in.Labels != "" {
+		if err := json.Unmarshal([]byte(in.Labels), &res.Labels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal labels of runner %d: %w", in.ID, err)
+		}
+	}
+
+	return res, nil
+}
+
+func mapToInternalRunner(in *types.Runner) (*runner, error) {
+	res := &runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		TokenHash:   in.TokenHash,
+		OS:          in.OS,
+		Arch:        in.Arch,
+		Version:     in.Version,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if len(in.Labels) > 0 {
+		labels, err := json.Marshal(in.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal labels of runner %d: %w", in.ID, err)
+		}
+		res.Labels = string(labels)
+	}
+
+	return res, nil
+}
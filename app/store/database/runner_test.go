@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_Runner(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	runnerStore := database.NewRunnerStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	runner := &types.Runner{
+		Identifier: "Linux-1",
+		Labels:     map[string]string{"gpu": "true"},
+		TokenHash:  "hash-1",
+		CreatedBy:  userID,
+		Created:    1,
+		Updated:    1,
+	}
+	if err := runnerStore.Create(ctx, runner); err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	duplicate := *runner
+	duplicate.Identifier = "linux-1"
+	duplicate.TokenHash = "hash-2"
+	if err := runnerStore.Create(ctx, &duplicate); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("expected duplicate error, got: %v", err)
+	}
+
+	found, err := runnerStore.FindByTokenHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("failed to find runner by token hash: %v", err)
+	}
+	if found.ID != runner.ID || found.Labels["gpu"] != "true" {
+		t.Errorf("unexpected runner: %+v", found)
+	}
+
+	found.OS = "linux"
+	found.LastSeen = 42
+	if err = runnerStore.Update(ctx, found); err != nil {
+		t.Fatalf("failed to update runner: %v", err)
+	}
+
+	found, err = runnerStore.FindByIdentifier(ctx, "LINUX-1")
+	if err != nil {
+		t.Fatalf("failed to find runner by identifier: %v", err)
+	}
+	if found.OS != "linux" || found.LastSeen != 42 {
+		t.Errorf("runner wasn't updated: %+v", found)
+	}
+
+	if err = runnerStore.Delete(ctx, runner.ID); err != nil {
+		t.Fatalf("failed to delete runner: %v", err)
+	}
+
+	runners, err := runnerStore.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list runners: %v", err)
+	}
+	if len(runners) != 0 {
+		t.Errorf("expected no runners, got %d", len(runners))
+	}
+}
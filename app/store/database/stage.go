@@ -61,6 +61,8 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_concurrency_group
+	,stage_concurrency_limit
 	`
 )
 
@@ -93,6 +95,9 @@ type stage struct {
 	OnFailure     bool               `db:"stage_on_failure"`
 	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
 	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+
+	ConcurrencyGroup string `db:"stage_concurrency_group"`
+	ConcurrencyLimit int    `db:"stage_concurrency_limit"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +157,8 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_concurrency_group
+			,stage_concurrency_limit
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,6 +187,8 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
+			,:stage_concurrency_group
+			,:stage_concurrency_limit
 
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -189,7 +198,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind stage object")
 	}
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&st.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Stage query failed")
 	}
 	return nil
@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.StageApprovalStore = (*StageApprovalStore)(nil)
+
+// NewStageApprovalStore returns a new StageApprovalStore.
+func NewStageApprovalStore(db *sqlx.DB) *StageApprovalStore {
+	return &StageApprovalStore{
+		db: db,
+	}
+}
+
+// StageApprovalStore implements store.StageApprovalStore backed by a relational database.
+// The status of an approval isn't stored, it's taken from its stage.
+type StageApprovalStore struct {
+	db *sqlx.DB
+}
+
+// stageApproval is an internal representation used to store approval data in the database.
+type stageApproval struct {
+	StageID      int64  `db:"stage_approval_stage_id"`
+	ExecutionID  int64  `db:"stage_approval_execution_id"`
+	RepoID       int64  `db:"stage_approval_repo_id"`
+	Approvers    string `db:"stage_approval_approvers"`
+	MinApprovals int    `db:"stage_approval_min_approvals"`
+	Timeout      int64  `db:"stage_approval_timeout"`
+	Created      int64  `db:"stage_approval_created"`
+}
+
+// stageApprovalInfo extends an approval with the details of its stage.
+type stageApprovalInfo struct {
+	stageApproval
+	StageNumber int64         `db:"stage_number"`
+	StageName   string        `db:"stage_name"`
+	Status      enum.CIStatus `db:"stage_status"`
+	Started     int64         `db:"stage_started"`
+}
+
+// approvalDecision is an internal representation used to store approval decisions in the database.
+type approvalDecision struct {
+	StageID     int64                 `db:"stage_approval_decision_stage_id"`
+	PrincipalID int64                 `db:"stage_approval_decision_principal_id"`
+	Decision    enum.ApprovalDecision `db:"stage_approval_decision_decision"`
+	Comment     string                `db:"stage_approval_decision_comment"`
+	Created     int64                 `db:"stage_approval_decision_created"`
+	Updated     int64                 `db:"stage_approval_decision_updated"`
+}
+
+const (
+	stageApprovalSelectBase = `
+	SELECT
+		 stage_approval_stage_id
+		,stage_approval_execution_id
+		,stage_approval_repo_id
+		,stage_approval_approvers
+		,stage_approval_min_approvals
+		,stage_approval_timeout
+		,stage_approval_created
+		,stage_number
+		,stage_name
+		,stage_status
+		,stage_started
+	FROM stage_approvals
+	INNER JOIN stages ON stage_id = stage_approval_stage_id`
+
+	approvalDecisionSelectBase = `
+	SELECT
+		 stage_approval_decision_stage_id
+		,stage_approval_decision_principal_id
+		,stage_approval_decision_decision
+		,stage_approval_decision_comment
+		,stage_approval_decision_created
+		,stage_approval_decision_updated
+	FROM stage_approval_decisions`
+)
+
+// Create creates the approval requirements of an approval stage.
+func (s *StageApprovalStore) Create(ctx context.Context, approval *types.StageApproval) error {
+	const sqlQuery = `
+	INSERT INTO stage_approvals (
+		 stage_approval_stage_id
+		,stage_approval_execution_id
+		,stage_approval_repo_id
+		,stage_approval_approvers
+		,stage_approval_min_approvals
+		,stage_approval_timeout
+		,stage_approval_created
+	) values (
+		 :stage_approval_stage_id
+		,:stage_approval_execution_id
+		,:stage_approval_repo_id
+		,:stage_approval_approvers
+		,:stage_approval_min_approvals
+		,:stage_approval_timeout
+		,:stage_approval_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbApproval, err := mapToInternalStageApproval(approval)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbApproval)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind stage approval object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert stage approval")
+	}
+
+	return nil
+}
+
+// FindByStageID finds the approval requirements of the approval stage.
+func (s *StageApprovalStore) FindByStageID(ctx context.Context, stageID int64) (*types.StageApproval, error) {
+	const sqlQuery = stageApprovalSelectBase + `
+	WHERE stage_approval_stage_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &stageApprovalInfo{}
+	if err := db.GetContext(ctx, dst, sqlQuery, stageID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find stage approval")
+	}
+
+	return mapToStageApproval(dst)
+}
+
+// ListByExecution lists the approval requirements of all approval stages of the execution.
+func (s *StageApprovalStore) ListByExecution(
+	ctx context.Context,
+	executionID int64,
+) ([]*types.StageApproval, error) {
+	const sqlQuery = stageApprovalSelectBase + `
+	WHERE stage_approval_execution_id = $1
+	ORDER BY stage_number ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stageApprovalInfo{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list stage approvals")
+	}
+
+	return mapToStageApprovals(dst)
+}
+
+// ListExpired lists the approvals of blocked stages whose timeout passed.
+func (s *StageApprovalStore) ListExpired(ctx context.Context, now int64) ([]*types.StageApproval, error) {
+	const sqlQuery = stageApprovalSelectBase + `
+	WHERE stage_status = $1
+		AND stage_approval_timeout > 0
+		AND stage_started + stage_approval_timeout <= $2
+	ORDER BY stage_approval_stage_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stageApprovalInfo{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, enum.CIStatusBlocked, now); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired stage approvals")
+	}
+
+	return mapToStageApprovals(dst)
+}
+
+// UpsertDecision creates or replaces the decision of a principal on an approval stage.
+func (s *StageApprovalStore) UpsertDecision(ctx context.Context, decision *types.ApprovalDecision) error {
+	const sqlQuery = `
+	INSERT INTO stage_approval_decisions (
+		 stage_approval_decision_stage_id
+		,stage_approval_decision_principal_id
+		,stage_approval_decision_decision
+		,stage_approval_decision_comment
+		,stage_approval_decision_created
+		,stage_approval_decision_updated
+	) values (
+		 :stage_approval_decision_stage_id
+		,:stage_approval_decision_principal_id
+		,:stage_approval_decision_decision
+		,:stage_approval_decision_comment
+		,:stage_approval_decision_created
+		,:stage_approval_decision_updated
+	)
+	ON CONFLICT (stage_approval_decision_stage_id, stage_approval_decision_principal_id) DO
+	UPDATE SET
+		 stage_approval_decision_decision = :stage_approval_decision_decision
+		,stage_approval_decision_comment = :stage_approval_decision_comment
+		,stage_approval_decision_updated = :stage_approval_decision_updated
+	RETURNING stage_approval_decision_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	now := time.Now().UnixMilli()
+	dbDecision := &approvalDecision{
+		StageID:     decision.StageID,
+		PrincipalID: decision.PrincipalID,
+		Decision:    decision.Decision,
+		Comment:     decision.Comment,
+		Created:     now,
+		Updated:     now,
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbDecision)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind approval decision object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&decision.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert approval decision")
+	}
+
+	decision.Updated = now
+
+	return nil
+}
+
+// ListDecisions lists the decisions on the approval stage, in the order they got made.
+func (s *StageApprovalStore) ListDecisions(ctx context.Context, stageID int64) ([]*types.ApprovalDecision, error) {
+	const sqlQuery = approvalDecisionSelectBase + `
+	WHERE stage_approval_decision_stage_id = $1
+	ORDER BY stage_approval_decision_updated ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*approvalDecision{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, stageID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list approval decisions")
+	}
+
+	res := make([]*types.ApprovalDecision, len(dst))
+	for i, d := range dst {
+		res[i] = &types.ApprovalDecision{
+			StageID:     d.StageID,
+			PrincipalID: d.PrincipalID,
+			Decision:    d.Decision,
+			Comment:     d.Comment,
+			Created:     d.Created,
+			Updated:     d.Updated,
+		}
+	}
+
+	return res, nil
+}
+
+func mapToInternalStageApproval(in *types.StageApproval) (*stageApproval, error) {
+	approvers := in.ApproverIDs
+	if approvers == nil {
+		approvers = []int64{}
+	}
+
+	approversJSON, err := json.Marshal(approvers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal approvers of stage %d: %w", in.StageID, err)
+	}
+
+	return &stageApproval{
+		StageID:      in.StageID,
+		ExecutionID:  in.ExecutionID,
+		RepoID:       in.RepoID,
+		Approvers:    string(approversJSON),
+		MinApprovals: in.MinApprovals,
+		Timeout:      in.Timeout,
+		Created:      in.Created,
+	}, nil
+}
+
+func mapToStageApproval(in *stageApprovalInfo) (*types.StageApproval, error) {
+	res := &types.StageApproval{
+		StageID:      in.StageID,
+		ExecutionID:  in.ExecutionID,
+		RepoID:       in.RepoID,
+		StageNumber:  in.StageNumber,
+		StageName:    in.StageName,
+		Status:       in.Status,
+		MinApprovals: in.MinApprovals,
+		Timeout:      in.Timeout,
+		Started:      in.Started,
+		Created:      in.Created,
+	}
+
+	if err := json.Unmarshal([]byte(in.Approvers), &res.ApproverIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal approvers of stage %d: %w", in.StageID, err)
+	}
+
+	return res, nil
+}
+
+func mapToStageApprovals(in []*stageApprovalInfo) ([]*types.StageApproval, error) {
+	res := make([]*types.StageApproval, len(in))
+	for i := range in {
+		var err error
+		if res[i], err = mapToStageApproval(in[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_StageApproval(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pipelineStore := database.NewPipelineStore(db)
+	executionStore := database.NewExecutionStore(db)
+	stageStore := database.NewStageStore(db)
+	approvalStore := database.NewStageApprovalStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pipeline := &types.Pipeline{RepoID: 1, Identifier: "release", CreatedBy: userID}
+	if err := pipelineStore.Create(ctx, pipeline); err != nil {
+		t.Fatalf("failed to create pipeline: %v", err)
+	}
+
+	execution := &types.Execution{PipelineID: pipeline.ID, RepoID: 1, CreatedBy: userID, Number: 1}
+	if err := executionStore.Create(ctx, execution); err != nil {
+		t.Fatalf("failed to create execution: %v", err)
+	}
+
+	stages := map[string]*types.Stage{}
+	for i, name := range []string{"sign-off", "qa"} {
+		stage := &types.Stage{
+			ExecutionID: execution.ID,
+			RepoID:      1,
+			Number:      int64(i + 1),
+			Name:        name,
+			Kind:        "pipeline",
+			Type:        types.StageTypeApproval,
+			Status:      enum.CIStatusBlocked,
+			Started:     1000,
+		}
+		if err := stageStore.Create(ctx, stage); err != nil {
+			t.Fatalf("failed to create stage: %v", err)
+		}
+		stages[name] = stage
+	}
+
+	approvals := []*types.StageApproval{
+		{StageID: stages["sign-off"].ID, MinApprovals: 1, ApproverIDs: []int64{userID}, Timeout: 500},
+		{StageID: stages["qa"].ID, MinApprovals: 2},
+	}
+	for _, approval := range approvals {
+		approval.ExecutionID = execution.ID
+		approval.RepoID = 1
+		if err := approvalStore.Create(ctx, approval); err != nil {
+			t.Fatalf("failed to create approval: %v", err)
+		}
+	}
+
+	found, err := approvalStore.FindByStageID(ctx, stages["sign-off"].ID)
+	if err != nil {
+		t.Fatalf("failed to find approval: %v", err)
+	}
+	if found.StageName != "sign-off" || found.Status != enum.CIStatusBlocked ||
+		len(found.ApproverIDs) != 1 || found.Deadline() != 1500 {
+		t.Errorf("unexpected approval: %+v", found)
+	}
+
+	list, err := approvalStore.ListByExecution(ctx, execution.ID)
+	if err != nil {
+		t.Fatalf("failed to list approvals: %v", err)
+	}
+	if len(list) != 2 || list[0].StageNumber != 1 || list[1].ApproverIDs == nil {
+		t.Errorf("unexpected approvals: %+v", list)
+	}
+
+	// only approvals with a timeout expire.
+	expired, err := approvalStore.ListExpired(ctx, 2000)
+	if err != nil {
+		t.Fatalf("failed to list expired approvals: %v", err)
+	}
+	if len(expired) != 1 || expired[0].StageID != stages["sign-off"].ID {
+		t.Errorf("unexpected expired approvals: %+v", expired)
+	}
+
+	expired, err = approvalStore.ListExpired(ctx, 1499)
+	if err != nil {
+		t.Fatalf("failed to list expired approvals: %v", err)
+	}
+	if len(expired) != 0 {
+		t.Errorf("expected no expired approvals, got %d", len(expired))
+	}
+
+	decision := &types.ApprovalDecision{
+		StageID:     stages["qa"].ID,
+		PrincipalID: userID,
+		Decision:    enum.ApprovalDecisionRejected,
+	}
+	if err = approvalStore.UpsertDecision(ctx, decision); err != nil {
+		t.Fatalf("failed to create decision: %v", err)
+	}
+
+	// a principal can change its decision.
+	decision.Decision = enum.ApprovalDecisionApproved
+	decision.Comment = "lgtm"
+	if err = approvalStore.UpsertDecision(ctx, decision); err != nil {
+		t.Fatalf("failed to update decision: %v", err)
+	}
+
+	decisions, err := approvalStore.ListDecisions(ctx, stages["qa"].ID)
+	if err != nil {
+		t.Fatalf("failed to list decisions: %v", err)
+	}
+	if len(decisions) != 1 || decisions[0].Decision != enum.ApprovalDecisionApproved ||
+		decisions[0].Comment != "lgtm" {
+		t.Errorf("unexpected decisions: %+v", decisions)
+	}
+}
@@ -110,6 +110,9 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		OnFailure:   in.OnFailure,
 		DependsOn:   dependsOn,
 		Labels:      labels,
+
+		ConcurrencyGroup: in.ConcurrencyGroup,
+		ConcurrencyLimit: in.ConcurrencyLimit,
 	}, nil
 }
 
@@ -142,6 +145,9 @@ func mapStageToInternal(in *types.Stage) *stage {
 		OnFailure:   in.OnFailure,
 		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
 		Labels:      EncodeToSQLXJSON(in.Labels),
+
+		ConcurrencyGroup: in.ConcurrencyGroup,
+		ConcurrencyLimit: in.ConcurrencyLimit,
 	}
 }
 
@@ -220,6 +226,8 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&stage.ConcurrencyGroup,
+		&stage.ConcurrencyLimit,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -0,0 +1,452 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.TestReportStore = (*TestReportStore)(nil)
+
+// NewTestReportStore returns a new TestReportStore.
+func NewTestReportStore(db *sqlx.DB) *TestReportStore {
+	return &TestReportStore{
+		db: db,
+	}
+}
+
+// TestReportStore implements store.TestReportStore backed by a relational database.
+type TestReportStore struct {
+	db *sqlx.DB
+}
+
+type testReport struct {
+	ID              int64                 `db:"test_report_id"`
+	RepoID          int64                 `db:"test_report_repo_id"`
+	CommitSHA       string                `db:"test_report_commit_sha"`
+	CheckIdentifier string                `db:"test_report_check_uid"`
+	Branch          string                `db:"test_report_branch"`
+	Format          enum.TestReportFormat `db:"test_report_format"`
+	CreatedBy       int64                 `db:"test_report_created_by"`
+	Created         int64                 `db:"test_report_created"`
+	Total           int                   `db:"test_report_total"`
+	Passed          int                   `db:"test_report_passed"`
+	Failed          int                   `db:"test_report_failed"`
+	Skipped         int                   `db:"test_report_skipped"`
+	Errored         int                   `db:"test_report_errored"`
+	Duration        int64                 `db:"test_report_duration"`
+}
+
+type testCase struct {
+	ID              int64           `db:"test_case_id"`
+	ReportID        int64           `db:"test_case_report_id"`
+	CheckIdentifier string          `db:"test_report_check_uid"`
+	Suite           string          `db:"test_case_suite"`
+	ClassName       string          `db:"test_case_class_name"`
+	Name            string          `db:"test_case_name"`
+	Status          enum.TestStatus `db:"test_case_status"`
+	Duration        int64           `db:"test_case_duration"`
+	Message         string          `db:"test_case_message"`
+	Details         string          `db:"test_case_details"`
+}
+
+type testSummary struct {
+	CheckIdentifier string `db:"test_report_check_uid"`
+	Reports         int    `db:"reports"`
+	Total           int    `db:"total"`
+	Passed          int    `db:"passed"`
+	Failed          int    `db:"failed"`
+	Skipped         int    `db:"skipped"`
+	Errored         int    `db:"errored"`
+	Duration        int64  `db:"duration"`
+}
+
+type flakyTest struct {
+	CheckIdentifier string `db:"test_report_check_uid"`
+	Suite           string `db:"test_case_suite"`
+	ClassName       string `db:"test_case_class_name"`
+	Name            string `db:"test_case_name"`
+	FlakyCommits    int    `db:"flaky_commits"`
+}
+
+const (
+	testCaseColumns = `
+		 test_case_id
+		,test_case_report_id
+		,test_report_check_uid
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_details`
+)
+
+// Create creates a new test report.
+func (s *TestReportStore) Create(ctx context.Context, report *types.TestReport) error {
+	const sqlQuery = `
+	INSERT INTO test_reports (
+		 test_report_repo_id
+		,test_report_commit_sha
+		,test_report_check_uid
+		,test_report_branch
+		,test_report_format
+		,test_report_created_by
+		,test_report_created
+		,test_report_total
+		,test_report_passed
+		,test_report_failed
+		,test_report_skipped
+		,test_report_errored
+		,test_report_duration
+	) values (
+		 :test_report_repo_id
+		,:test_report_commit_sha
+		,:test_report_check_uid
+		,:test_report_branch
+		,:test_report_format
+		,:test_report_created_by
+		,:test_report_created
+		,:test_report_total
+		,:test_report_passed
+		,:test_report_failed
+		,:test_report_skipped
+		,:test_report_errored
+		,:test_report_duration
+	) RETURNING test_report_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalTestReport(report))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind test report object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&report.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert test report")
+	}
+
+	return nil
+}
+
+// CreateCase creates a new test case of a test report.
+func (s *TestReportStore) CreateCase(ctx context.Context, tc *types.TestCase) error {
+	const sqlQuery = `
+	INSERT INTO test_cases (
+		 test_case_report_id
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_details
+	) values (
+		 :test_case_report_id
+		,:test_case_suite
+		,:test_case_class_name
+		,:test_case_name
+		,:test_case_status
+		,:test_case_duration
+		,:test_case_message
+		,:test_case_details
+	) RETURNING test_case_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalTestCase(tc))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind test case object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&tc.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert test case")
+	}
+
+	return nil
+}
+
+// ListCases lists the test cases of all test reports of a commit.
+func (s *TestReportStore) ListCases(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.TestCaseListOptions,
+) ([]*types.TestCase, error) {
+	stmt := database.Builder.
+		Select(testCaseColumns).
+		From("test_cases").
+		InnerJoin("test_reports ON test_case_report_id = test_report_id").
+		Where("test_report_repo_id = ?", repoID).
+		Where("test_report_commit_sha = ?", commitSHA)
+
+	stmt = applyTestCaseListOptions(stmt, opts)
+
+	stmt = stmt.
+		Limit(database.Limit(opts.Size)).
+		Offset(database.Offset(opts.Page, opts.Size)).
+		OrderBy("test_report_check_uid, test_case_suite, test_case_class_name, test_case_name, test_case_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*testCase{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list test cases")
+	}
+
+	return mapToTestCases(dst), nil
+}
+
+// CountCases returns the number of test cases of all test reports of a commit.
+func (s *TestReportStore) CountCases(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.TestCaseListOptions,
+) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("test_cases").
+		InnerJoin("test_reports ON test_case_report_id = test_report_id").
+		Where("test_report_repo_id = ?", repoID).
+		Where("test_report_commit_sha = ?", commitSHA)
+
+	stmt = applyTestCaseListOptions(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count test cases")
+	}
+
+	return count, nil
+}
+
+func applyTestCaseListOptions(stmt squirrel.SelectBuilder, opts types.TestCaseListOptions) squirrel.SelectBuilder {
+	if opts.CheckIdentifier != "" {
+		stmt = stmt.Where("test_report_check_uid = ?", opts.CheckIdentifier)
+	}
+
+	if len(opts.Statuses) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"test_case_status": opts.Statuses})
+	}
+
+	return stmt
+}
+
+// Summarize returns the aggregated test counts of the test reports of a commit, per status check.
+func (s *TestReportStore) Summarize(ctx context.Context, repoID int64, commitSHA string) ([]types.TestSummary, error) {
+	const sqlQuery = `
+	SELECT
+		 test_report_check_uid
+		,COUNT(*) AS reports
+		,SUM(test_report_total) AS total
+		,SUM(test_report_passed) AS passed
+		,SUM(test_report_failed) AS failed
+		,SUM(test_report_skipped) AS skipped
+		,SUM(test_report_errored) AS errored
+		,SUM(test_report_duration) AS duration
+	FROM test_reports
+	WHERE test_report_repo_id = $1 AND test_report_commit_sha = $2
+	GROUP BY test_report_check_uid
+	ORDER BY test_report_check_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []testSummary{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, commitSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to summarize test reports")
+	}
+
+	res := make([]types.TestSummary, len(dst))
+	for i, summary := range dst {
+		res[i] = types.TestSummary{
+			CheckIdentifier: summary.CheckIdentifier,
+			Reports:         summary.Reports,
+			TestCounts: types.TestCounts{
+				Total:    summary.Total,
+				Passed:   summary.Passed,
+				Failed:   summary.Failed,
+				Skipped:  summary.Skipped,
+				Errored:  summary.Errored,
+				Duration: summary.Duration,
+			},
+		}
+	}
+
+	return res, nil
+}
+
+// FindLatestCommitOnBranch returns the commit SHA of the most recent test report
+// that was uploaded for the status check on the branch.
+func (s *TestReportStore) FindLatestCommitOnBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+	checkIdentifier string,
+) (string, error) {
+	const sqlQuery = `
+	SELECT test_report_commit_sha
+	FROM test_reports
+	WHERE test_report_repo_id = $1 AND test_report_branch = $2 AND test_report_check_uid = $3
+	ORDER BY test_report_created DESC, test_report_id DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var commitSHA string
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID, branch, checkIdentifier).Scan(&commitSHA); err != nil {
+		return "", database.ProcessSQLErrorf(ctx, err, "Failed to find latest test report on branch")
+	}
+
+	return commitSHA, nil
+}
+
+// ListFlaky lists up to limit tests of the repo that both passed and failed
+// for the same commit and status check in a test report created since the provided time (unix millis).
+func (s *TestReportStore) ListFlaky(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+	limit int,
+) ([]types.FlakyTest, error) {
+	const sqlQuery = `
+	SELECT
+		 test_report_check_uid
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+		,COUNT(*) AS flaky_commits
+	FROM (
+		SELECT
+			 test_report_check_uid
+			,test_case_suite
+			,test_case_class_name
+			,test_case_name
+			,test_report_commit_sha
+		FROM test_cases
+		INNER JOIN test_reports ON test_case_report_id = test_report_id
+		WHERE test_report_repo_id = $1 AND test_report_created >= $2
+		GROUP BY
+			 test_report_check_uid
+			,test_case_suite
+			,test_case_class_name
+			,test_case_name
+			,test_report_commit_sha
+		HAVING
+			SUM(CASE WHEN test_case_status = 'passed' THEN 1 ELSE 0 END) > 0 AND
+			SUM(CASE WHEN test_case_status IN ('failed', 'error') THEN 1 ELSE 0 END) > 0
+	) AS flaky
+	GROUP BY
+		 test_report_check_uid
+		,test_case_suite
+		,test_case_class_name
+		,test_case_name
+	ORDER BY flaky_commits DESC, test_report_check_uid, test_case_suite, test_case_class_name, test_case_name
+	LIMIT $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []flakyTest{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, since, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list flaky tests")
+	}
+
+	res := make([]types.FlakyTest, len(dst))
+	for i, flaky := range dst {
+		res[i] = types.FlakyTest(flaky)
+	}
+
+	return res, nil
+}
+
+func mapToInternalTestReport(in *types.TestReport) *testReport {
+	return &testReport{
+		ID:              in.ID,
+		RepoID:          in.RepoID,
+		CommitSHA:       in.CommitSHA,
+		CheckIdentifier: in.CheckIdentifier,
+		Branch:          in.Branch,
+		Format:          in.Format,
+		CreatedBy:       in.CreatedBy,
+		Created:         in.Created,
+		Total:           in.Total,
+		Passed:          in.Passed,
+		Failed:          in.Failed,
+		Skipped:         in.Skipped,
+		Errored:         in.Errored,
+		Duration:        in.Duration,
+	}
+}
+
+func mapToInternalTestCase(in *types.TestCase) *testCase {
+	return &testCase{
+		ID:              in.ID,
+		ReportID:        in.ReportID,
+		CheckIdentifier: in.CheckIdentifier,
+		Suite:           in.Suite,
+		ClassName:       in.ClassName,
+		Name:            in.Name,
+		Status:          in.Status,
+		Duration:        in.Duration,
+		Message:         in.Message,
+		Details:         in.Details,
+	}
+}
+
+func mapToTestCase(in *testCase) *types.TestCase {
+	return &types.TestCase{
+		ID:              in.ID,
+		ReportID:        in.ReportID,
+		CheckIdentifier: in.CheckIdentifier,
+		Suite:           in.Suite,
+		ClassName:       in.ClassName,
+		Name:            in.Name,
+		Status:          in.Status,
+		Duration:        in.Duration,
+		Message:         in.Message,
+		Details:         in.Details,
+	}
+}
+
+func mapToTestCases(in []*testCase) []*types.TestCase {
+	res := make([]*types.TestCase, len(in))
+	for i := range in {
+		res[i] = mapToTestCase(in[i])
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_TestReport(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	testReportStore := database.NewTestReportStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	createReport := func(sha, branch string, created int64, statuses ...enum.TestStatus) {
+		t.Helper()
+
+		report := &types.TestReport{
+			RepoID:          1,
+			CommitSHA:       sha,
+			CheckIdentifier: "unit",
+			Branch:          branch,
+			Format:          enum.TestReportFormatJUnit,
+			CreatedBy:       userID,
+			Created:         created,
+		}
+		cases := make([]*types.TestCase, len(statuses))
+		for i, status := range statuses {
+			cases[i] = &types.TestCase{
+				Suite:     "suite",
+				ClassName: "class",
+				Name:      string(rune('a' + i)),
+				Status:    status,
+				Duration:  10,
+			}
+			report.Add(cases[i])
+		}
+
+		if err := testReportStore.Create(ctx, report); err != nil {
+			t.Fatalf("failed to create test report: %v", err)
+		}
+		for _, tc := range cases {
+			tc.ReportID = report.ID
+			if err := testReportStore.CreateCase(ctx, tc); err != nil {
+				t.Fatalf("failed to create test case: %v", err)
+			}
+		}
+	}
+
+	createReport("main1", "main", 1, enum.TestStatusPassed, enum.TestStatusFailed)
+	createReport("main2", "main", 2, enum.TestStatusPassed, enum.TestStatusPassed)
+	// rerun of the same commit where test "a" fails this time.
+	createReport("main2", "main", 3, enum.TestStatusFailed, enum.TestStatusSkipped)
+
+	summaries, err := testReportStore.Summarize(ctx, 1, "main2")
+	if err != nil {
+		t.Fatalf("failed to summarize test reports: %v", err)
+	}
+	expected := types.TestCounts{Total: 4, Passed: 2, Failed: 1, Skipped: 1, Duration: 40}
+	if len(summaries) != 1 || summaries[0].Reports != 2 || summaries[0].TestCounts != expected {
+		t.Fatalf("unexpected summaries: %+v", summaries)
+	}
+
+	opts := types.TestCaseListOptions{
+		CheckIdentifier: "unit",
+		Statuses:        []enum.TestStatus{enum.TestStatusFailed, enum.TestStatusError},
+	}
+	failing, err := testReportStore.ListCases(ctx, 1, "main2", opts)
+	if err != nil {
+		t.Fatalf("failed to list test cases: %v", err)
+	}
+	if len(failing) != 1 || failing[0].Name != "a" || failing[0].CheckIdentifier != "unit" {
+		t.Fatalf("unexpected failing test cases: %+v", failing)
+	}
+
+	count, err := testReportStore.CountCases(ctx, 1, "main2", types.TestCaseListOptions{})
+	if err != nil {
+		t.Fatalf("failed to count test cases: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 test cases, got %d", count)
+	}
+
+	latest, err := testReportStore.FindLatestCommitOnBranch(ctx, 1, "main", "unit")
+	if err != nil {
+		t.Fatalf("failed to find latest commit on branch: %v", err)
+	}
+	if latest != "main2" {
+		t.Errorf("expected latest commit main2, got %s", latest)
+	}
+
+	flaky, err := testReportStore.ListFlaky(ctx, 1, 0, 10)
+	if err != nil {
+		t.Fatalf("failed to list flaky tests: %v", err)
+	}
+	if len(flaky) != 1 || flaky[0].Name != "a" || flaky[0].FlakyCommits != 1 {
+		t.Fatalf("unexpected flaky tests: %+v", flaky)
+	}
+
+	flaky, err = testReportStore.ListFlaky(ctx, 1, 3, 10)
+	if err != nil {
+		t.Fatalf("failed to list flaky tests: %v", err)
+	}
+	if len(flaky) != 0 {
+		t.Fatalf("expected no flaky tests in window, got: %+v", flaky)
+	}
+}
@@ -17,6 +17,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -42,23 +44,37 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+type token struct {
+	ID          int64          `db:"token_id"`
+	PrincipalID int64          `db:"token_principal_id"`
+	Type        enum.TokenType `db:"token_type"`
+	Identifier  string         `db:"token_uid"`
+	ExpiresAt   null.Int       `db:"token_expires_at"`
+	IssuedAt    int64          `db:"token_issued_at"`
+	CreatedBy   int64          `db:"token_created_by"`
+	LastUsed    null.Int       `db:"token_last_used"`
+	Permissions string         `db:"token_permissions"`
+	SpaceIDs    string         `db:"token_space_ids"`
+	RepoIDs     string         `db:"token_repo_ids"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +85,14 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, mapToInternalToken(token))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -88,6 +104,17 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	return nil
 }
 
+// UpdateLastUsed updates the time the token was last used for authentication.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update token last used time")
+	}
+
+	return nil
+}
+
 // Delete deletes the token with the given id.
 func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -153,7 +180,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +188,79 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	result := make([]*types.Token, len(dst))
+	for i := range dst {
+		result[i] = mapToToken(dst[i])
+	}
+
+	return result, nil
+}
+
+func mapToToken(t *token) *types.Token {
+	return &types.Token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		Identifier:  t.Identifier,
+		ExpiresAt:   t.ExpiresAt.Ptr(),
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+		LastUsed:    t.LastUsed.Ptr(),
+		TokenScope: types.TokenScope{
+			Permissions: permissionsFromString(t.Permissions),
+			SpaceIDs:    idsFromString(t.SpaceIDs),
+			RepoIDs:     idsFromString(t.RepoIDs),
+		},
+	}
+}
+
+func mapToInternalToken(t *types.Token) *token {
+	return &token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		Identifier:  t.Identifier,
+		ExpiresAt:   null.IntFromPtr(t.ExpiresAt),
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+		LastUsed:    null.IntFromPtr(t.LastUsed),
+		Permissions: permissionsToString(t.Permissions),
+		SpaceIDs:    idsToString(t.SpaceIDs),
+		RepoIDs:     idsToString(t.RepoIDs),
+	}
+}
+
+// idsSeparator defines the character that's used to join the IDs of the token scope for storing them in the DB.
+const idsSeparator = ","
+
+func idsFromString(idsString string) []int64 {
+	if idsString == "" {
+		return nil
+	}
+
+	rawIDs := strings.Split(idsString, idsSeparator)
+
+	ids := make([]int64, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func idsToString(ids []int64) string {
+	rawIDs := make([]string, len(ids))
+	for i := range ids {
+		rawIDs[i] = strconv.FormatInt(ids[i], 10)
+	}
+
+	return strings.Join(rawIDs, idsSeparator)
 }
 
 const tokenSelectBase = `
@@ -173,6 +272,10 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_last_used
+,token_permissions
+,token_space_ids
+,token_repo_ids
 FROM tokens
 ` //#nosec G101
 
@@ -208,6 +311,9 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_permissions
+	,token_space_ids
+	,token_repo_ids
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +321,14 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_permissions
+	,:token_space_ids
+	,:token_repo_ids
 ) RETURNING token_id
 `
+
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET token_last_used = $1
+WHERE token_id = $2
+`
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_TokenScope(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	tokenStore := database.NewTokenStore(db)
+
+	scope := types.TokenScope{
+		Permissions: []enum.Permission{enum.PermissionRepoPush, enum.PermissionRepoView},
+		SpaceIDs:    []int64{1, 2},
+		RepoIDs:     []int64{3},
+	}
+
+	token := &types.Token{
+		Type:        enum.TokenTypePAT,
+		Identifier:  "ci",
+		PrincipalID: userID,
+		CreatedBy:   userID,
+		TokenScope:  scope,
+	}
+	if err := tokenStore.Create(ctx, token); err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	found, err := tokenStore.Find(ctx, token.ID)
+	if err != nil {
+		t.Fatalf("failed to find token: %v", err)
+	}
+	if !reflect.DeepEqual(scope, found.TokenScope) {
+		t.Errorf("scope mismatch: want=%+v got=%+v", scope, found.TokenScope)
+	}
+	if found.LastUsed != nil {
+		t.Errorf("expected token to be unused, got last used: %d", *found.LastUsed)
+	}
+
+	const lastUsed = int64(1700000000000)
+	if err = tokenStore.UpdateLastUsed(ctx, token.ID, lastUsed); err != nil {
+		t.Fatalf("failed to update last used: %v", err)
+	}
+
+	found, err = tokenStore.FindByIdentifier(ctx, userID, "CI")
+	if err != nil {
+		t.Fatalf("failed to find token by identifier: %v", err)
+	}
+	if found.LastUsed == nil || *found.LastUsed != lastUsed {
+		t.Errorf("last used mismatch: want=%d got=%v", lastUsed, found.LastUsed)
+	}
+}
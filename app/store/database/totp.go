@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.TOTPStore = (*TOTPStore)(nil)
+
+// NewTOTPStore returns a new TOTPStore.
+func NewTOTPStore(db *sqlx.DB) *TOTPStore {
+	return &TOTPStore{
+		db: db,
+	}
+}
+
+// TOTPStore implements store.TOTPStore backed by a relational database.
+type TOTPStore struct {
+	db *sqlx.DB
+}
+
+type totp struct {
+	PrincipalID   int64  `db:"totp_principal_id"`
+	Secret        []byte `db:"totp_secret"`
+	Enabled       bool   `db:"totp_enabled"`
+	RecoveryCodes string `db:"totp_recovery_codes"`
+	LastUsedStep  int64  `db:"totp_last_used_step"`
+	Created       int64  `db:"totp_created"`
+	Updated       int64  `db:"totp_updated"`
+}
+
+const (
+	totpColumns = `
+		 totp_principal_id
+		,totp_secret
+		,totp_enabled
+		,totp_recovery_codes
+		,totp_last_used_step
+		,totp_created
+		,totp_updated`
+
+	totpSelectBase = `
+	SELECT` + totpColumns + `
+	FROM totps`
+)
+
+// Find finds the TOTP configuration of the principal.
+func (s *TOTPStore) Find(ctx context.Context, principalID int64) (*types.TOTP, error) {
+	const sqlQuery = totpSelectBase + `
+	WHERE totp_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &totp{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find totp")
+	}
+
+	return mapToTOTP(dst), nil
+}
+
+// Upsert creates the TOTP configuration of the principal or replaces the existing one.
+func (s *TOTPStore) Upsert(ctx context.Context, t *types.TOTP) error {
+	const sqlQuery = `
+	INSERT INTO totps (
+		 totp_principal_id
+		,totp_secret
+		,totp_enabled
+		,totp_recovery_codes
+		,totp_last_used_step
+		,totp_created
+		,totp_updated
+	) values (
+		 :totp_principal_id
+		,:totp_secret
+		,:totp_enabled
+		,:totp_recovery_codes
+		,:totp_last_used_step
+		,:totp_created
+		,:totp_updated
+	)
+	ON CONFLICT (totp_principal_id) DO UPDATE
+	SET
+		 totp_secret = EXCLUDED.totp_secret
+		,totp_enabled = EXCLUDED.totp_enabled
+		,totp_recovery_codes = EXCLUDED.totp_recovery_codes
+		,totp_last_used_step = EXCLUDED.totp_last_used_step
+		,totp_created = EXCLUDED.totp_created
+		,totp_updated = EXCLUDED.totp_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalTOTP(t))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind totp object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert totp")
+	}
+
+	return nil
+}
+
+// Update updates the enabled state and the recovery codes of the TOTP configuration.
+func (s *TOTPStore) Update(ctx context.Context, t *types.TOTP) error {
+	const sqlQuery = `
+	UPDATE totps
+	SET
+		 totp_enabled = :totp_enabled
+		,totp_recovery_codes = :totp_recovery_codes
+		,totp_updated = :totp_updated
+	WHERE totp_principal_id = :totp_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbTOTP := mapToInternalTOTP(t)
+	dbTOTP.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbTOTP)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind totp object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update totp")
+	}
+
+	t.Updated = dbTOTP.Updated
+
+	return nil
+}
+
+// UpdateLastUsedStep stores the time step of an accepted code.
+// It returns false if a code of the same or a later time step was already accepted,
+// which guarantees a code can't be used twice even with concurrent requests.
+func (s *TOTPStore) UpdateLastUsedStep(ctx context.Context, principalID int64, step int64) (bool, error) {
+	const sqlQuery = `
+	UPDATE totps
+	SET totp_last_used_step = $1
+	WHERE totp_principal_id = $2 AND totp_last_used_step < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, step, principalID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to update totp last used step")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated totp rows")
+	}
+
+	return n > 0, nil
+}
+
+// UpdateRecoveryCodes replaces the recovery codes of the TOTP configuration.
+// It returns false if the stored recovery codes don't match the expected ones anymore,
+// which guarantees a recovery code can't be used twice even with concurrent requests.
+func (s *TOTPStore) UpdateRecoveryCodes(
+	ctx context.Context,
+	principalID int64,
+	expected []string,
+	recoveryCodes []string,
+) (bool, error) {
+	const sqlQuery = `
+	UPDATE totps
+	SET
+		 totp_recovery_codes = $1
+		,totp_updated = $2
+	WHERE totp_principal_id = $3 AND totp_recovery_codes = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		strings.Join(recoveryCodes, recoveryCodesSeparator),
+		time.Now().UnixMilli(),
+		principalID,
+		strings.Join(expected, recoveryCodesSeparator),
+	)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to update totp recovery codes")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated totp rows")
+	}
+
+	return n > 0, nil
+}
+
+// Delete deletes the TOTP configuration of the principal.
+func (s *TOTPStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM totps
+	WHERE totp_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete totp")
+	}
+
+	return nil
+}
+
+func mapToTOTP(t *totp) *types.TOTP {
+	return &types.TOTP{
+		PrincipalID:   t.PrincipalID,
+		Secret:        string(t.Secret),
+		Enabled:       t.Enabled,
+		RecoveryCodes: recoveryCodesFromString(t.RecoveryCodes),
+		LastUsedStep:  t.LastUsedStep,
+		Created:       t.Created,
+		Updated:       t.Updated,
+	}
+}
+
+func mapToInternalTOTP(t *types.TOTP) *totp {
+	return &totp{
+		PrincipalID:   t.PrincipalID,
+		Secret:        []byte(t.Secret),
+		Enabled:       t.Enabled,
+		RecoveryCodes: strings.Join(t.RecoveryCodes, recoveryCodesSeparator),
+		LastUsedStep:  t.LastUsedStep,
+		Created:       t.Created,
+		Updated:       t.Updated,
+	}
+}
+
+// recoveryCodesSeparator defines the character that's used to join the recovery code hashes for storing them in the DB
+// ASSUMPTION: recovery codes are stored as hex encoded hashes which don't contain " ".
+const recoveryCodesSeparator = " "
+
+func recoveryCodesFromString(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+
+	return strings.Split(s, recoveryCodesSeparator)
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_TOTP(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	totpStore := database.NewTOTPStore(db)
+
+	totp := &types.TOTP{
+		PrincipalID:   userID,
+		Secret:        "\x00encrypted\xff",
+		RecoveryCodes: []string{"hash1", "hash2"},
+		Created:       1,
+		Updated:       1,
+	}
+	if err := totpStore.Upsert(ctx, totp); err != nil {
+		t.Fatalf("failed to create totp: %v", err)
+	}
+
+	// restarting the enrollment replaces the configuration.
+	totp.Secret = "\x00other\xff"
+	if err := totpStore.Upsert(ctx, totp); err != nil {
+		t.Fatalf("failed to replace totp: %v", err)
+	}
+
+	found, err := totpStore.Find(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find totp: %v", err)
+	}
+	if !reflect.DeepEqual(totp, found) {
+		t.Errorf("totp mismatch: want=%+v got=%+v", totp, found)
+	}
+
+	found.Enabled = true
+	found.RecoveryCodes = []string{"hash2"}
+	if err = totpStore.Update(ctx, found); err != nil {
+		t.Fatalf("failed to update totp: %v", err)
+	}
+
+	for _, test := range []struct {
+		step int64
+		exp  bool
+	}{
+		{step: 10, exp: true},
+		{step: 10, exp: false},
+		{step: 9, exp: false},
+		{step: 11, exp: true},
+	} {
+		ok, err := totpStore.UpdateLastUsedStep(ctx, userID, test.step)
+		if err != nil {
+			t.Fatalf("failed to update last used step: %v", err)
+		}
+		if ok != test.exp {
+			t.Errorf("update of last used step %d: want=%t got=%t", test.step, test.exp, ok)
+		}
+	}
+
+	// the recovery codes are only replaced if they weren't changed in the meantime.
+	for _, test := range []struct {
+		expected []string
+		exp      bool
+	}{
+		{expected: []string{"hash1", "hash2"}, exp: false},
+		{expected: []string{"hash2"}, exp: true},
+		{expected: []string{"hash2"}, exp: false},
+	} {
+		ok, err := totpStore.UpdateRecoveryCodes(ctx, userID, test.expected, []string{"hash3"})
+		if err != nil {
+			t.Fatalf("failed to update recovery codes: %v", err)
+		}
+		if ok != test.exp {
+			t.Errorf("update of recovery codes %v: want=%t got=%t", test.expected, test.exp, ok)
+		}
+	}
+
+	found, err = totpStore.Find(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find totp: %v", err)
+	}
+	if !found.Enabled || found.LastUsedStep != 11 || !reflect.DeepEqual(found.RecoveryCodes, []string{"hash3"}) {
+		t.Errorf("unexpected totp after update: %+v", found)
+	}
+
+	if err = totpStore.Delete(ctx, userID); err != nil {
+		t.Fatalf("failed to delete totp: %v", err)
+	}
+
+	if _, err = totpStore.Find(ctx, userID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("expected not found error after delete, got: %v", err)
+	}
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -67,6 +68,12 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	Type                  string      `db:"webhook_type"`
+	ChatMentions          string      `db:"webhook_chat_mentions"`
+	PayloadFilter         string      `db:"webhook_payload_filter"`
+	ContentType           string      `db:"webhook_content_type"`
+	PayloadTemplate       string      `db:"webhook_payload_template"`
+	Headers               string      `db:"webhook_headers"`
 }
 
 const (
@@ -87,7 +94,13 @@ const (
 		,webhook_insecure
 		,webhook_triggers
 		,webhook_latest_execution_result
-		,webhook_internal`
+		,webhook_internal
+		,webhook_type
+		,webhook_chat_mentions
+		,webhook_payload_filter
+		,webhook_content_type
+		,webhook_payload_template
+		,webhook_headers`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -174,6 +187,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers
 			,webhook_latest_execution_result
 			,webhook_internal
+			,webhook_type
+			,webhook_chat_mentions
+			,webhook_payload_filter
+			,webhook_content_type
+			,webhook_payload_template
+			,webhook_headers
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -190,6 +209,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_triggers
 			,:webhook_latest_execution_result
 			,:webhook_internal
+			,:webhook_type
+			,:webhook_chat_mentions
+			,:webhook_payload_filter
+			,:webhook_content_type
+			,:webhook_payload_template
+			,:webhook_headers
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -228,6 +253,12 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
+			,webhook_type = :webhook_type
+			,webhook_chat_mentions = :webhook_chat_mentions
+			,webhook_payload_filter = :webhook_payload_filter
+			,webhook_content_type = :webhook_content_type
+			,webhook_payload_template = :webhook_payload_template
+			,webhook_headers = :webhook_headers
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -463,6 +494,32 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
+		Type:                  enum.WebhookType(hook.Type),
+		ContentType:           enum.WebhookContentType(hook.ContentType),
+	}
+
+	if hook.ChatMentions != "" {
+		if err := json.Unmarshal([]byte(hook.ChatMentions), &res.ChatMentions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chat mentions of hook %d: %w", hook.ID, err)
+		}
+	}
+
+	if hook.PayloadFilter != "" {
+		if err := json.Unmarshal([]byte(hook.PayloadFilter), &res.PayloadFilter); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload filter of hook %d: %w", hook.ID, err)
+		}
+	}
+
+	if hook.PayloadTemplate != "" {
+		if err := json.Unmarshal([]byte(hook.PayloadTemplate), &res.PayloadTemplate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload template of hook %d: %w", hook.ID, err)
+		}
+	}
+
+	if hook.Headers != "" {
+		if err := json.Unmarshal([]byte(hook.Headers), &res.Headers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal headers of hook %d: %w", hook.ID, err)
+		}
 	}
 
 	switch {
@@ -499,6 +556,40 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
+		Type:                  string(hook.Type),
+		ContentType:           string(hook.ContentType),
+	}
+
+	if len(hook.ChatMentions) > 0 {
+		chatMentions, err := json.Marshal(hook.ChatMentions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal chat mentions of hook %d: %w", hook.ID, err)
+		}
+		res.ChatMentions = string(chatMentions)
+	}
+
+	if !hook.PayloadFilter.IsEmpty() {
+		payloadFilter, err := json.Marshal(hook.PayloadFilter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload filter of hook %d: %w", hook.ID, err)
+		}
+		res.PayloadFilter = string(payloadFilter)
+	}
+
+	if !hook.PayloadTemplate.IsEmpty() {
+		payloadTemplate, err := json.Marshal(hook.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload template of hook %d: %w", hook.ID, err)
+		}
+		res.PayloadTemplate = string(payloadTemplate)
+	}
+
+	if len(hook.Headers) > 0 {
+		headers, err := json.Marshal(hook.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal headers of hook %d: %w", hook.ID, err)
+		}
+		res.Headers = string(headers)
 	}
 
 	switch hook.ParentType {
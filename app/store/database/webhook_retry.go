@@ -0,0 +1,239 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.WebhookRetryStore = (*WebhookRetryStore)(nil)
+
+// NewWebhookRetryStore returns a new WebhookRetryStore.
+func NewWebhookRetryStore(db *sqlx.DB) *WebhookRetryStore {
+	return &WebhookRetryStore{
+		db: db,
+	}
+}
+
+// WebhookRetryStore implements store.WebhookRetryStore backed by a relational database.
+type WebhookRetryStore struct {
+	db *sqlx.DB
+}
+
+type webhookRetry struct {
+	ID          int64  `db:"webhook_retry_id"`
+	WebhookID   int64  `db:"webhook_retry_webhook_id"`
+	TriggerID   string `db:"webhook_retry_trigger_id"`
+	ExecutionID int64  `db:"webhook_retry_execution_id"`
+	Attempts    int    `db:"webhook_retry_attempts"`
+	NextAttempt int64  `db:"webhook_retry_next_attempt"`
+	Created     int64  `db:"webhook_retry_created"`
+}
+
+const (
+	webhookRetryColumns = `
+		 webhook_retry_id
+		,webhook_retry_webhook_id
+		,webhook_retry_trigger_id
+		,webhook_retry_execution_id
+		,webhook_retry_attempts
+		,webhook_retry_next_attempt
+		,webhook_retry_created`
+)
+
+// Create queues a retry. Nothing happens if a retry for the same webhook and trigger is queued already.
+func (s *WebhookRetryStore) Create(ctx context.Context, retry *types.WebhookRetry) error {
+	const sqlQuery = `
+	INSERT INTO webhook_retries (
+		 webhook_retry_webhook_id
+		,webhook_retry_trigger_id
+		,webhook_retry_execution_id
+		,webhook_retry_attempts
+		,webhook_retry_next_attempt
+		,webhook_retry_created
+	) values (
+		 :webhook_retry_webhook_id
+		,:webhook_retry_trigger_id
+		,:webhook_retry_execution_id
+		,:webhook_retry_attempts
+		,:webhook_retry_next_attempt
+		,:webhook_retry_created
+	) ON CONFLICT (webhook_retry_webhook_id, webhook_retry_trigger_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalWebhookRetry(retry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind webhook retry object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert webhook retry")
+	}
+
+	return nil
+}
+
+// Update updates the execution, attempts and next attempt of a queued retry.
+func (s *WebhookRetryStore) Update(ctx context.Context, retry *types.WebhookRetry) error {
+	const sqlQuery = `
+	UPDATE webhook_retries
+	SET
+		 webhook_retry_execution_id = :webhook_retry_execution_id
+		,webhook_retry_attempts = :webhook_retry_attempts
+		,webhook_retry_next_attempt = :webhook_retry_next_attempt
+	WHERE webhook_retry_id = :webhook_retry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalWebhookRetry(retry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind webhook retry object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update webhook retry")
+	}
+
+	return nil
+}
+
+// Delete removes a retry from the queue.
+func (s *WebhookRetryStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM webhook_retries
+	WHERE webhook_retry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete webhook retry")
+	}
+
+	return nil
+}
+
+// ListDue lists the retries that are due at the provided time, the longest overdue first.
+func (s *WebhookRetryStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.WebhookRetry, error) {
+	const sqlQuery = `
+	SELECT` + webhookRetryColumns + `
+	FROM webhook_retries
+	WHERE webhook_retry_next_attempt <= $1
+	ORDER BY webhook_retry_next_attempt, webhook_retry_id
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookRetry{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, now, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list due webhook retries")
+	}
+
+	res := make([]*types.WebhookRetry, len(dst))
+	for i := range dst {
+		res[i] = mapToWebhookRetry(dst[i])
+	}
+
+	return res, nil
+}
+
+// CreateForFailedSince queues a retry for every delivery of an enabled webhook
+// that failed since the provided time and never succeeded, using the latest failed execution.
+// It returns the number of queued retries.
+func (s *WebhookRetryStore) CreateForFailedSince(ctx context.Context, since int64, now int64) (int64, error) {
+	// NOTE: the WHERE clause of the outer select is required by sqlite to parse the upsert clause.
+	const sqlQuery = `
+	INSERT INTO webhook_retries (
+		 webhook_retry_webhook_id
+		,webhook_retry_trigger_id
+		,webhook_retry_execution_id
+		,webhook_retry_attempts
+		,webhook_retry_next_attempt
+		,webhook_retry_created
+	)
+	SELECT failed_webhook_id, failed_trigger_id, failed_execution_id, 0, CAST($1 AS BIGINT), CAST($1 AS BIGINT)
+	FROM (
+		SELECT
+			 e.webhook_execution_webhook_id AS failed_webhook_id
+			,e.webhook_execution_trigger_id AS failed_trigger_id
+			,MAX(e.webhook_execution_id) AS failed_execution_id
+		FROM webhook_executions e
+		INNER JOIN webhooks ON webhook_id = e.webhook_execution_webhook_id
+		WHERE e.webhook_execution_created >= $2
+			AND e.webhook_execution_result IN ($3, $4)
+			AND e.webhook_execution_retriggerable = TRUE
+			AND webhook_enabled = TRUE
+			AND NOT EXISTS (
+				SELECT 1
+				FROM webhook_executions s
+				WHERE s.webhook_execution_webhook_id = e.webhook_execution_webhook_id
+					AND s.webhook_execution_trigger_id = e.webhook_execution_trigger_id
+					AND s.webhook_execution_result = $5
+			)
+		GROUP BY e.webhook_execution_webhook_id, e.webhook_execution_trigger_id
+	) failed
+	WHERE TRUE
+	ON CONFLICT (webhook_retry_webhook_id, webhook_retry_trigger_id) DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, now, since,
+		enum.WebhookExecutionResultRetriableError,
+		enum.WebhookExecutionResultFatalError,
+		enum.WebhookExecutionResultSuccess,
+	)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to queue retries for failed webhook executions")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of queued webhook retries")
+	}
+
+	return n, nil
+}
+
+func mapToWebhookRetry(r *webhookRetry) *types.WebhookRetry {
+	return &types.WebhookRetry{
+		ID:          r.ID,
+		WebhookID:   r.WebhookID,
+		TriggerID:   r.TriggerID,
+		ExecutionID: r.ExecutionID,
+		Attempts:    r.Attempts,
+		NextAttempt: r.NextAttempt,
+		Created:     r.Created,
+	}
+}
+
+func mapToInternalWebhookRetry(r *types.WebhookRetry) *webhookRetry {
+	return &webhookRetry{
+		ID:          r.ID,
+		WebhookID:   r.WebhookID,
+		TriggerID:   r.TriggerID,
+		ExecutionID: r.ExecutionID,
+		Attempts:    r.Attempts,
+		NextAttempt: r.NextAttempt,
+		Created:     r.Created,
+	}
+}
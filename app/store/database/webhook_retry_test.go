@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_WebhookRetry(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+	retryStore := database.NewWebhookRetryStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	hook := &types.Webhook{
+		ParentID:   1,
+		ParentType: enum.WebhookParentRepo,
+		CreatedBy:  userID,
+		Identifier: "hook",
+		URL:        "https://example.com",
+		Enabled:    true,
+		Type:       enum.WebhookTypeGeneric,
+	}
+	if err := webhookStore.Create(ctx, hook); err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+
+	createExecution := func(triggerID string, result enum.WebhookExecutionResult, created int64) int64 {
+		execution := &types.WebhookExecution{
+			WebhookID:     hook.ID,
+			TriggerID:     triggerID,
+			TriggerType:   enum.WebhookTriggerBranchCreated,
+			Result:        result,
+			Retriggerable: true,
+			Created:       created,
+		}
+		if err := executionStore.Create(ctx, execution); err != nil {
+			t.Fatalf("failed to create webhook execution: %v", err)
+		}
+		return execution.ID
+	}
+
+	failedID := createExecution("event-1", enum.WebhookExecutionResultRetriableError, 10)
+
+	retry := &types.WebhookRetry{
+		WebhookID:   hook.ID,
+		TriggerID:   "event-1",
+		ExecutionID: failedID,
+		NextAttempt: 100,
+		Created:     10,
+	}
+	if err := retryStore.Create(ctx, retry); err != nil {
+		t.Fatalf("failed to create webhook retry: %v", err)
+	}
+	// a second retry of the same delivery is ignored
+	if err := retryStore.Create(ctx, retry); err != nil {
+		t.Fatalf("failed to create duplicate webhook retry: %v", err)
+	}
+
+	due, err := retryStore.ListDue(ctx, 99, 10)
+	if err != nil {
+		t.Fatalf("failed to list due webhook retries: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected no due retries, got %d", len(due))
+	}
+
+	due, err = retryStore.ListDue(ctx, 100, 10)
+	if err != nil {
+		t.Fatalf("failed to list due webhook retries: %v", err)
+	}
+	if len(due) != 1 || due[0].ExecutionID != failedID || due[0].Attempts != 0 {
+		t.Fatalf("expected the queued retry to be due, got %+v", due)
+	}
+
+	due[0].Attempts = 1
+	due[0].NextAttempt = 200
+	if err = retryStore.Update(ctx, due[0]); err != nil {
+		t.Fatalf("failed to update webhook retry: %v", err)
+	}
+
+	due, err = retryStore.ListDue(ctx, 200, 10)
+	if err != nil {
+		t.Fatalf("failed to list due webhook retries: %v", err)
+	}
+	if len(due) != 1 || due[0].Attempts != 1 {
+		t.Fatalf("expected the updated retry to be due, got %+v", due)
+	}
+
+	if err = retryStore.Delete(ctx, due[0].ID); err != nil {
+		t.Fatalf("failed to delete webhook retry: %v", err)
+	}
+
+	// only the latest failed execution of deliveries that never succeeded is queued.
+	createExecution("event-1", enum.WebhookExecutionResultSuccess, 20)
+	createExecution("event-2", enum.WebhookExecutionResultRetriableError, 30)
+	latestID := createExecution("event-2", enum.WebhookExecutionResultFatalError, 40)
+	createExecution("event-3", enum.WebhookExecutionResultSkipped, 50)
+	createExecution("event-4", enum.WebhookExecutionResultFatalError, 5)
+
+	n, err := retryStore.CreateForFailedSince(ctx, 10, 300)
+	if err != nil {
+		t.Fatalf("failed to queue retries for failed executions: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 queued retry, got %d", n)
+	}
+
+	due, err = retryStore.ListDue(ctx, 300, 10)
+	if err != nil {
+		t.Fatalf("failed to list due webhook retries: %v", err)
+	}
+	if len(due) != 1 || due[0].TriggerID != "event-2" || due[0].ExecutionID != latestID {
+		t.Fatalf("expected retry of the latest failed execution of event-2, got %+v", due)
+	}
+}
@@ -40,10 +40,26 @@ var WireSet = wire.NewSet(
 	ProvidePipelineStore,
 	ProvideStageStore,
 	ProvideStepStore,
+	ProvideRunnerStore,
+	ProvideEnvironmentStore,
+	ProvideDeploymentStore,
+	ProvideStageApprovalStore,
+	ProvideArtifactStore,
+	ProvideCacheEntryStore,
+	ProvideTestReportStore,
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
+	ProvideRoleStore,
+	ProvideRepoMembershipStore,
 	ProvideTokenStore,
+	ProvideTOTPStore,
+	ProvidePrincipalIdentityStore,
+	ProvideAuditStore,
+	ProvideQuotaStore,
+	ProvideNotificationStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationDigestStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
 	ProvideCodeCommentView,
@@ -52,6 +68,7 @@ var WireSet = wire.NewSet(
 	ProvidePullReqFileViewStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
+	ProvideWebhookRetryStore,
 	ProvideSettingsStore,
 	ProvideCheckStore,
 	ProvideConnectorStore,
@@ -141,6 +158,41 @@ func ProvideStepStore(db *sqlx.DB) store.StepStore {
 	return NewStepStore(db)
 }
 
+// ProvideRunnerStore provides a remote runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideEnvironmentStore provides a deployment environment store.
+func ProvideEnvironmentStore(db *sqlx.DB) store.EnvironmentStore {
+	return NewEnvironmentStore(db)
+}
+
+// ProvideDeploymentStore provides a deployment store.
+func ProvideDeploymentStore(db *sqlx.DB) store.DeploymentStore {
+	return NewDeploymentStore(db)
+}
+
+// ProvideStageApprovalStore provides a stage approval store.
+func ProvideStageApprovalStore(db *sqlx.DB) store.StageApprovalStore {
+	return NewStageApprovalStore(db)
+}
+
+// ProvideArtifactStore provides an artifact store.
+func ProvideArtifactStore(db *sqlx.DB) store.ArtifactStore {
+	return NewArtifactStore(db)
+}
+
+// ProvideCacheEntryStore provides a build cache entry store.
+func ProvideCacheEntryStore(db *sqlx.DB) store.CacheEntryStore {
+	return NewCacheEntryStore(db)
+}
+
+// ProvideTestReportStore provides a test report store.
+func ProvideTestReportStore(db *sqlx.DB) store.TestReportStore {
+	return NewTestReportStore(db)
+}
+
 // ProvideSecretStore provides a secret store.
 func ProvideSecretStore(db *sqlx.DB) store.SecretStore {
 	return NewSecretStore(db)
@@ -185,6 +237,54 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
 }
 
+// ProvideRepoMembershipStore provides a repository membership store.
+func ProvideRepoMembershipStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoMembershipStore {
+	return NewRepoMembershipStore(db, principalInfoCache)
+}
+
+// ProvideRoleStore provides a custom membership role store.
+func ProvideRoleStore(db *sqlx.DB) store.RoleStore {
+	return NewRoleStore(db)
+}
+
+// ProvidePrincipalIdentityStore provides a principal identity store.
+func ProvidePrincipalIdentityStore(db *sqlx.DB) store.PrincipalIdentityStore {
+	return NewPrincipalIdentityStore(db)
+}
+
+// ProvideTOTPStore provides a totp store.
+func ProvideTOTPStore(db *sqlx.DB) store.TOTPStore {
+	return NewTOTPStore(db)
+}
+
+// ProvideNotificationStore provides a notification store.
+func ProvideNotificationStore(db *sqlx.DB) store.NotificationStore {
+	return NewNotificationStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvideQuotaStore provides a quota store.
+func ProvideQuotaStore(db *sqlx.DB) store.QuotaStore {
+	return NewQuotaStore(db)
+}
+
+// ProvideAuditStore provides an audit store.
+func ProvideAuditStore(db *sqlx.DB) store.AuditStore {
+	return NewAuditStore(db)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
@@ -236,6 +336,11 @@ func ProvideWebhookExecutionStore(db *sqlx.DB) store.WebhookExecutionStore {
 	return NewWebhookExecutionStore(db)
 }
 
+// ProvideWebhookRetryStore provides a webhook retry store.
+func ProvideWebhookRetryStore(db *sqlx.DB) store.WebhookRetryStore {
+	return NewWebhookRetryStore(db)
+}
+
 // ProvideCheckStore provides a status check result store.
 func ProvideCheckStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
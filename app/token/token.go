@@ -48,9 +48,12 @@ func CreateUserSession(
 		principal,
 		identifier,
 		ptr.Duration(userSessionTokenLifeTime),
+		types.TokenScope{},
 	)
 }
 
+// CreatePAT creates a new personal access token.
+// The token can optionally be restricted to a set of permissions, spaces and repositories using the scope.
 func CreatePAT(
 	ctx context.Context,
 	tokenStore store.TokenStore,
@@ -58,6 +61,7 @@ func CreatePAT(
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scope types.TokenScope,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -67,6 +71,7 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scope,
 	)
 }
 
@@ -86,6 +91,7 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		types.TokenScope{},
 	)
 }
 
@@ -97,6 +103,7 @@ func create(
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scope types.TokenScope,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,6 +120,7 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		TokenScope:  scope,
 	}
 
 	err := tokenStore.Create(ctx, &token)
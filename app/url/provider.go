@@ -44,6 +44,14 @@ type Provider interface {
 	// interact with gitness and clone a repo.
 	GenerateContainerGITCloneURL(repoPath string) string
 
+	// GenerateContainerCacheURL generates a URL that can be used by CI container builds to
+	// interact with the build cache of gitness.
+	GenerateContainerCacheURL() string
+
+	// GenerateContainerArtifactURL generates a URL that can be used by CI container builds to
+	// upload artifacts of their stage to gitness.
+	GenerateContainerArtifactURL() string
+
 	// GenerateGITCloneURL generates the public git clone URL for the provided repo path.
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
@@ -63,6 +71,9 @@ type Provider interface {
 	// GenerateUIBuildURL returns the endpoint to use for viewing build executions.
 	GenerateUIBuildURL(repoPath, pipelineIdentifier string, seqNumber int64) string
 
+	// GenerateAPIArtifactURL returns the api url an artifact of a build execution can be downloaded from.
+	GenerateAPIArtifactURL(repoPath, pipelineIdentifier string, seqNumber int64, artifactID int64) string
+
 	// GetGITHostname returns the host for the git endpoint.
 	GetGITHostname() string
 
@@ -152,6 +163,14 @@ func (p *provider) GenerateContainerGITCloneURL(repoPath string) string {
 	return p.containerURL.JoinPath(GITMount, repoPath).String()
 }
 
+func (p *provider) GenerateContainerCacheURL() string {
+	return p.containerURL.JoinPath(APIMount, "v1", "cache").String()
+}
+
+func (p *provider) GenerateContainerArtifactURL() string {
+	return p.containerURL.JoinPath(APIMount, "v1", "artifacts").String()
+}
+
 func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
@@ -166,6 +185,15 @@ func (p *provider) GenerateUIBuildURL(repoPath, pipelineIdentifier string, seqNu
 		pipelineIdentifier, "execution", strconv.Itoa(int(seqNumber))).String()
 }
 
+func (p *provider) GenerateAPIArtifactURL(
+	repoPath, pipelineIdentifier string,
+	seqNumber int64,
+	artifactID int64,
+) string {
+	return p.apiURL.JoinPath("v1", "repos", repoPath, "+", "pipelines", pipelineIdentifier,
+		"executions", strconv.FormatInt(seqNumber, 10), "artifacts", strconv.FormatInt(artifactID, 10)).String()
+}
+
 func (p *provider) GenerateUIRepoURL(repoPath string) string {
 	return p.uiURL.JoinPath(repoPath).String()
 }
@@ -33,14 +33,18 @@ var (
 type Action string
 
 const (
-	ActionCreated Action = "created"
-	ActionUpdated Action = "updated" // update default branch, switching default branch, updating description
-	ActionDeleted Action = "deleted"
+	ActionCreated     Action = "created"
+	ActionUpdated     Action = "updated" // update default branch, switching default branch, updating description
+	ActionDeleted     Action = "deleted"
+	ActionBypassed    Action = "bypassed" // merging a pull request while bypassing protection rules
+	ActionLogin       Action = "login"
+	ActionLoginFailed Action = "login_failed"
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted:
+	case ActionCreated, ActionUpdated, ActionDeleted,
+		ActionBypassed, ActionLogin, ActionLoginFailed:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -53,19 +57,47 @@ const (
 	ResourceTypeRepository         ResourceType = "repository"
 	ResourceTypeBranchRule         ResourceType = "branch_rule"
 	ResourceTypeRepositorySettings ResourceType = "repository_settings"
+	ResourceTypeSpaceMembership    ResourceType = "space_membership"
+	ResourceTypeRepoMembership     ResourceType = "repository_membership"
+	ResourceTypeToken              ResourceType = "token"
+	ResourceTypeWebhook            ResourceType = "webhook"
+	ResourceTypeSecret             ResourceType = "secret"
+	ResourceTypePipeline           ResourceType = "pipeline"
+	ResourceTypePullRequest        ResourceType = "pull_request"
+	ResourceTypeUser               ResourceType = "user"
+	ResourceTypeSpaceQuota         ResourceType = "space_quota"
+	ResourceTypeRunner             ResourceType = "runner"
+	ResourceTypeEnvironment        ResourceType = "environment"
 )
 
 func (a ResourceType) Validate() error {
 	switch a {
 	case ResourceTypeRepository,
 		ResourceTypeBranchRule,
-		ResourceTypeRepositorySettings:
+		ResourceTypeRepositorySettings,
+		ResourceTypeSpaceMembership,
+		ResourceTypeRepoMembership,
+		ResourceTypeToken,
+		ResourceTypeWebhook,
+		ResourceTypeSecret,
+		ResourceTypePipeline,
+		ResourceTypePullRequest,
+		ResourceTypeUser,
+		ResourceTypeSpaceQuota,
+		ResourceTypeRunner,
+		ResourceTypeEnvironment:
 		return nil
 	default:
 		return ErrResourceTypeUndefined
 	}
 }
 
+// IsSpaceScoped returns true if resources of the type always belong to a space.
+// Users, tokens and runners are global, so events for them don't require a space path.
+func (a ResourceType) IsSpaceScoped() bool {
+	return a != ResourceTypeUser && a != ResourceTypeToken && a != ResourceTypeRunner
+}
+
 type Resource struct {
 	Type       ResourceType
 	Identifier string
@@ -113,7 +145,7 @@ func (e *Event) Validate() error {
 	if e.User.UID == "" {
 		return ErrUserIsRequired
 	}
-	if e.SpacePath == "" {
+	if e.SpacePath == "" && e.Resource.Type.IsSpaceScoped() {
 		return ErrSpacePathIsRequired
 	}
 	if err := e.Resource.Validate(); err != nil {
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+)
+
+// Store persists audit events.
+type Store interface {
+	Create(ctx context.Context, event *types.AuditEvent) error
+}
+
+// Persisted is an audit service that writes every event to the audit store.
+type Persisted struct {
+	store Store
+}
+
+func NewPersisted(store Store) *Persisted {
+	return &Persisted{
+		store: store,
+	}
+}
+
+func (s *Persisted) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource Resource,
+	action Action,
+	spacePath string,
+	options ...Option,
+) error {
+	event := Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      GetRealIP(ctx),
+		RequestMethod: GetRequestMethod(ctx),
+	}
+
+	if id := GetRequestID(ctx); id != "" {
+		WithData("request_id", id).Apply(&event)
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal old object: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new object: %w", err)
+	}
+
+	err = s.store.Create(ctx, &types.AuditEvent{
+		ID:                 event.ID,
+		Timestamp:          event.Timestamp,
+		Action:             string(event.Action),
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		SpacePath:          event.SpacePath,
+		PrincipalID:        event.User.ID,
+		PrincipalUID:       event.User.UID,
+		PrincipalType:      event.User.Type,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		Data:               event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+
+	return nil
+}
+
+// marshalObject converts an object of the diff to json.
+// Objects are marshaled with their public json representation, so fields hidden from the API
+// (like secret values) are never written to the audit log.
+func marshalObject(obj any) (json.RawMessage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(obj)
+}
@@ -20,6 +20,6 @@ var WireSet = wire.NewSet(
 	ProvideAuditService,
 )
 
-func ProvideAuditService() Service {
-	return New()
+func ProvideAuditService(store Store) Service {
+	return NewPersisted(store)
 }
@@ -92,3 +92,16 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	err := os.Remove(fileDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -112,6 +113,22 @@ func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error)
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+	return nil
+}
+
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
 	// Use workload identity impersonation default credentials (GKE environment)
 	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
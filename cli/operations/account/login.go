@@ -16,15 +16,21 @@ package account
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
 	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/client"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
+var totpCodeRegex = regexp.MustCompile(`^[0-9 ]+$`)
+
 type loginCommand struct {
 	server string
 }
@@ -42,7 +48,25 @@ func (c *loginCommand) run(*kingpin.ParseContext) error {
 		Password:        password,
 	}
 
-	ts, err := provide.OpenClient(c.server).Login(ctx, in)
+	cl := provide.OpenClient(c.server)
+
+	ts, err := cl.Login(ctx, in)
+	switch {
+	case client.IsTwoFactorEnrollmentRequired(err):
+		if err = enrollTOTP(ctx, cl, in); err != nil {
+			return err
+		}
+		in.TOTPCode = textui.TOTPCode()
+		ts, err = cl.Login(ctx, in)
+	case client.IsTwoFactorRequired(err):
+		code := textui.TOTPCode()
+		if totpCodeRegex.MatchString(code) {
+			in.TOTPCode = code
+		} else {
+			in.RecoveryCode = code
+		}
+		ts, err = cl.Login(ctx, in)
+	}
 	if err != nil {
 		return err
 	}
@@ -55,6 +79,22 @@ func (c *loginCommand) run(*kingpin.ParseContext) error {
 		Store()
 }
 
+// enrollTOTP starts the two-factor authentication enrollment and shows the secret and the recovery codes.
+func enrollTOTP(ctx context.Context, cl client.Client, in *user.LoginInput) error {
+	enrollment, err := cl.LoginTOTPEnroll(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Two-factor authentication is required. Add the following key to your authenticator app:")
+	fmt.Printf("\n  %s\n\n", enrollment.Secret)
+	fmt.Printf("Or use the key URI: %s\n\n", enrollment.URI)
+	fmt.Println("Store the following recovery codes in a safe place, each can be used once if you lose your device:")
+	fmt.Printf("\n  %s\n\n", strings.Join(enrollment.RecoveryCodes, "\n  "))
+
+	return nil
+}
+
 // RegisterLogin helper function to register the logout command.
 func RegisterLogin(app *kingpin.Application) {
 	c := &loginCommand{}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/funcmap"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const validationTmpl = `
+valid:  {{ .Valid }}
+format: {{ .Format }}
+event:  {{ .Event }}
+{{- if .Errors }}
+errors:
+{{- range .Errors }}
+  {{ if .Line }}line {{ .Line }}{{ if .Column }}:{{ .Column }}{{ end }}: {{ end }}{{ .Message }}
+{{- end }}
+{{- end }}
+{{- if .Stages }}
+stages:
+{{- range .Stages }}
+  {{ .Name }}: {{ if .Matched }}{{ .Status }}{{ else }}skipped, {{ .SkipReason }}{{ end }}
+  {{- range .DependsOn }}
+    depends on: {{ . }}
+  {{- end }}
+{{- end }}
+{{- end }}
+{{- if .Triggers }}
+triggers:
+{{- range .Triggers }}
+  {{ .Identifier }}: {{ if .Matched }}matched{{ else }}not matched{{ end }}
+{{- end }}
+{{- end }}
+`
+
+type validateCommand struct {
+	repoRef string
+	file    string
+	in      pipeline.ValidateInput
+	action  string
+	tmpl    string
+	json    bool
+}
+
+func (c *validateCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if c.file != "" {
+		data, err := os.ReadFile(c.file)
+		if err != nil {
+			return fmt.Errorf("failed to read pipeline file: %w", err)
+		}
+		c.in.Content = string(data)
+		if c.in.ConfigPath == "" {
+			c.in.ConfigPath = c.file
+		}
+	}
+	c.in.Action = enum.TriggerAction(c.action)
+
+	result, err := provide.Client().PipelineValidate(ctx, c.repoRef, &c.in)
+	if err != nil {
+		return err
+	}
+
+	if c.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	} else {
+		var tmpl *template.Template
+		tmpl, err = template.New("_").Funcs(funcmap.Funcs).Parse(c.tmpl + "\n")
+		if err != nil {
+			return err
+		}
+		err = tmpl.Execute(os.Stdout, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return errors.New("pipeline is invalid")
+	}
+
+	return nil
+}
+
+// helper function registers the pipeline validate command.
+func registerValidate(app *kingpin.CmdClause) {
+	c := &validateCommand{}
+
+	cmd := app.Command("validate", "validate a pipeline yaml and display its expanded stages").
+		Action(c.run)
+
+	cmd.Arg("repo", "path of the repository").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("file", "local pipeline file to validate instead of the one in the repository").
+		StringVar(&c.file)
+
+	cmd.Flag("pipeline", "identifier of an existing pipeline").
+		StringVar(&c.in.Pipeline)
+
+	cmd.Flag("config-path", "path of the pipeline yaml in the repository").
+		StringVar(&c.in.ConfigPath)
+
+	cmd.Flag("branch", "branch to validate the pipeline for").
+		StringVar(&c.in.Branch)
+
+	cmd.Flag("target-branch", "target branch of pull request actions").
+		StringVar(&c.in.TargetBranch)
+
+	cmd.Flag("action", "trigger action to match the stages against, e.g. pullreq_created").
+		StringVar(&c.action)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(validationTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -22,8 +22,11 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/trigger"
@@ -34,6 +37,7 @@ import (
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
 
@@ -294,6 +298,10 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		MaxRetries:          config.Webhook.MaxRetries,
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
+		RetryMaxAttempts:    config.Webhook.RetryMaxAttempts,
+		RetryBackoff:        config.Webhook.RetryBackoff,
+		RetryMaxBackoff:     config.Webhook.RetryMaxBackoff,
+		AutoDisable:         config.Webhook.AutoDisable,
 	}
 }
 
@@ -302,6 +310,7 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+		DigestCron:      config.Notification.DigestCron,
 	}
 }
 
@@ -340,11 +349,22 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideRateLimitConfig loads the ratelimit config from the main config.
+func ProvideRateLimitConfig(config *types.Config) ratelimit.Config {
+	return ratelimit.Config{
+		App:       config.RateLimit.AppNamespace,
+		Namespace: "ratelimit",
+		Provider:  config.RateLimit.Provider,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		ArtifactsRetentionTime:           config.CI.ArtifactRetentionTime,
+		CacheEntriesRetentionTime:        config.CI.CacheRetentionTime,
 	}
 }
 
@@ -355,6 +375,73 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 	}
 }
 
+// ProvideOIDCConfig loads the OIDC provider config from the main config.
+func ProvideOIDCConfig(config *types.Config) (oidc.Config, error) {
+	groupMappings, err := groupsync.ParseGroupMappings(config.OIDC.GroupMappings)
+	if err != nil {
+		return oidc.Config{}, fmt.Errorf("failed to parse oidc group mappings: %w", err)
+	}
+
+	redirectURL := config.OIDC.RedirectURL
+	if redirectURL == "" {
+		redirectURL, err = url.JoinPath(config.URL.API, "v1", "oidc", "callback")
+		if err != nil {
+			return oidc.Config{}, fmt.Errorf("failed to derive oidc redirect url: %w", err)
+		}
+	}
+
+	return oidc.Config{
+		Enabled:               config.OIDC.Enabled,
+		Issuer:                config.OIDC.Issuer,
+		ClientID:              config.OIDC.ClientID,
+		ClientSecret:          config.OIDC.ClientSecret,
+		RedirectURL:           redirectURL,
+		Scopes:                config.OIDC.Scopes,
+		UsernameClaim:         config.OIDC.UsernameClaim,
+		EmailClaim:            config.OIDC.EmailClaim,
+		NameClaim:             config.OIDC.NameClaim,
+		GroupsClaim:           config.OIDC.GroupsClaim,
+		SkipEmailVerification: config.OIDC.SkipEmailVerification,
+		AutoProvision:         config.OIDC.AutoProvision,
+		GroupMappings:         groupMappings,
+	}, nil
+}
+
+// ProvideLDAPConfig loads the LDAP config from the main config.
+func ProvideLDAPConfig(config *types.Config) (ldap.Config, error) {
+	groupMappings, err := groupsync.ParseGroupMappings(config.LDAP.GroupMappings)
+	if err != nil {
+		return ldap.Config{}, fmt.Errorf("failed to parse ldap group mappings: %w", err)
+	}
+
+	return ldap.Config{
+		Enabled:            config.LDAP.Enabled,
+		URL:                config.LDAP.URL,
+		StartTLS:           config.LDAP.StartTLS,
+		InsecureSkipVerify: config.LDAP.InsecureSkipVerify,
+		BindDN:             config.LDAP.BindDN,
+		BindPassword:       config.LDAP.BindPassword,
+		UserBaseDN:         config.LDAP.UserBaseDN,
+		UserFilter:         config.LDAP.UserFilter,
+		UsernameAttribute:  config.LDAP.UsernameAttribute,
+		EmailAttribute:     config.LDAP.EmailAttribute,
+		NameAttribute:      config.LDAP.NameAttribute,
+		GroupBaseDN:        config.LDAP.GroupBaseDN,
+		GroupFilter:        config.LDAP.GroupFilter,
+		GroupNameAttribute: config.LDAP.GroupNameAttribute,
+		MemberOfAttribute:  config.LDAP.MemberOfAttribute,
+		AutoProvision:      config.LDAP.AutoProvision,
+		GroupMappings:      groupMappings,
+	}, nil
+}
+
+// ProvideGroupSyncConfig loads the group sync service config from the main config.
+func ProvideGroupSyncConfig(config *types.Config) groupsync.Config {
+	return groupsync.Config{
+		Cron: config.LDAP.GroupSyncCron,
+	}
+}
+
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	return keywordsearch.Config{
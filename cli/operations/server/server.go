@@ -105,6 +105,31 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.GroupSync.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register group sync service")
+			return err
+		}
+
+		if err := system.services.NotificationDigest.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification digest service")
+			return err
+		}
+
+		if err := system.services.WebhookRetry.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register webhook retry service")
+			return err
+		}
+
+		if err := system.services.PipelineReclaimer.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline reclaimer")
+			return err
+		}
+
+		if err := system.services.PipelineApproval.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register pipeline approval service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
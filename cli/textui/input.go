@@ -82,3 +82,13 @@ func Password() string {
 
 	return strings.TrimSpace(password)
 }
+
+// TOTPCode returns the code of the authenticator app or a recovery code from stdin.
+func TOTPCode() string {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter Two-Factor Authentication Code (or Recovery Code): ")
+	code, _ := reader.ReadString('\n')
+
+	return strings.TrimSpace(code)
+}
@@ -24,6 +24,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 
+	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
@@ -76,6 +77,14 @@ func (c *HTTPClient) Login(ctx context.Context, input *user.LoginInput) (*types.
 	return out, err
 }
 
+// LoginTOTPEnroll starts the two-factor authentication enrollment of a user during login.
+func (c *HTTPClient) LoginTOTPEnroll(ctx context.Context, input *user.LoginInput) (*types.TOTPEnrollment, error) {
+	out := new(types.TOTPEnrollment)
+	uri := fmt.Sprintf("%s/api/v1/login/totp/enroll", c.base)
+	err := c.post(ctx, uri, true, input, out)
+	return out, err
+}
+
 // Register registers a new  user and returns a JWT token.
 func (c *HTTPClient) Register(ctx context.Context, input *user.RegisterInput) (*types.TokenResponse, error) {
 	out := new(types.TokenResponse)
@@ -143,6 +152,18 @@ func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
 	return err
 }
 
+// PipelineValidate validates a pipeline yaml of the repo and returns its expanded stages.
+func (c *HTTPClient) PipelineValidate(
+	ctx context.Context,
+	repoRef string,
+	in *pipeline.ValidateInput,
+) (*types.PipelineValidation, error) {
+	out := new(types.PipelineValidation)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pipelines/validate", c.base, url.PathEscape(repoRef))
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
 //
 // http request helper functions
 //
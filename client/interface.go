@@ -16,7 +16,9 @@ package client
 
 import (
 	"context"
+	"errors"
 
+	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
 )
@@ -26,6 +28,9 @@ type Client interface {
 	// Login authenticates the user and returns a JWT token.
 	Login(ctx context.Context, input *user.LoginInput) (*types.TokenResponse, error)
 
+	// LoginTOTPEnroll starts the two-factor authentication enrollment of a user during login.
+	LoginTOTPEnroll(ctx context.Context, input *user.LoginInput) (*types.TOTPEnrollment, error)
+
 	// Register registers a new  user and returns a JWT token.
 	Register(ctx context.Context, input *user.RegisterInput) (*types.TokenResponse, error)
 
@@ -49,15 +54,39 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// PipelineValidate validates a pipeline yaml of the repo and returns its expanded stages.
+	PipelineValidate(ctx context.Context, repoRef string, in *pipeline.ValidateInput) (*types.PipelineValidation, error)
 }
 
 // remoteError store the error payload returned
 // fro the remote API.
 type remoteError struct {
-	Message string `json:"message"`
+	Message string         `json:"message"`
+	Values  map[string]any `json:"values"`
 }
 
 // Error returns the error message.
 func (e *remoteError) Error() string {
 	return e.Message
 }
+
+// IsTwoFactorRequired returns true if the login failed because a code of the second factor is missing.
+func IsTwoFactorRequired(err error) bool {
+	return hasErrorValue(err, "two_factor_required")
+}
+
+// IsTwoFactorEnrollmentRequired returns true if the login failed because the user has to enroll a second factor.
+func IsTwoFactorEnrollmentRequired(err error) bool {
+	return hasErrorValue(err, "two_factor_enrollment_required")
+}
+
+func hasErrorValue(err error, key string) bool {
+	var rErr *remoteError
+	if !errors.As(err, &rErr) {
+		return false
+	}
+
+	v, _ := rErr.Values[key].(bool)
+	return v
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/cli/operations/account"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/pipeline"
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/cli/operations/swagger"
 	"github.com/harness/gitness/cli/operations/user"
@@ -44,6 +45,7 @@ func main() {
 
 	user.Register(app)
 	users.Register(app)
+	pipeline.Register(app)
 
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
@@ -10,19 +10,24 @@ package main
 import (
 	"context"
 
+	controlleraudit "github.com/harness/gitness/app/api/controller/audit"
+	controllerbuildcache "github.com/harness/gitness/app/api/controller/buildcache"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	controllerenvironment "github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controllernotification "github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -36,15 +41,21 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/reclaimer"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -56,6 +67,7 @@ import (
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	locker "github.com/harness/gitness/app/services/locker"
@@ -66,6 +78,7 @@ import (
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
 	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/testreport"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -87,6 +100,7 @@ import (
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -126,9 +140,20 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		system.WireSet,
 		authn.WireSet,
 		authz.WireSet,
+		cliserver.ProvideOIDCConfig,
+		oidc.WireSet,
+		cliserver.ProvideLDAPConfig,
+		ldap.WireSet,
+		cliserver.ProvideGroupSyncConfig,
+		groupsync.WireSet,
+		wire.Bind(new(groupsync.GroupSource), new(*ldap.Client)),
+		wire.Bind(new(webhook.OwnerNotifier), new(*notification.WebhookNotifier)),
+		wire.Bind(new(approval.Notifier), new(*notification.ApprovalNotifier)),
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		checkevents.WireSet,
+		pipelineevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -150,6 +175,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		locker.WireSet,
 		cliserver.ProvidePubsubConfig,
 		pubsub.WireSet,
+		cliserver.ProvideRateLimitConfig,
+		ratelimit.WireSet,
 		cliserver.ProvideJobsConfig,
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
@@ -183,6 +210,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		reposervice.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
+		testreport.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
@@ -191,6 +219,14 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
 		audit.WireSet,
+		controlleraudit.WireSet,
+		controllernotification.WireSet,
+		controllerrunner.WireSet,
+		controllerenvironment.WireSet,
+		controllerbuildcache.WireSet,
+		reclaimer.WireSet,
+		approval.WireSet,
+		wire.Bind(new(audit.Store), new(store.AuditStore)),
 	)
 	return &cliserver.System{}, nil
 }
@@ -9,19 +9,24 @@ package main
 import (
 	"context"
 
+	audit2 "github.com/harness/gitness/app/api/controller/audit"
+	"github.com/harness/gitness/app/api/controller/buildcache"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -35,17 +40,23 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/ldap"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
+	events5 "github.com/harness/gitness/app/events/check"
 	events4 "github.com/harness/gitness/app/events/git"
+	events6 "github.com/harness/gitness/app/events/pipeline"
 	events3 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/pipeline/approval"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/reclaimer"
 	"github.com/harness/gitness/app/pipeline/resolver"
-	"github.com/harness/gitness/app/pipeline/runner"
+	runner2 "github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
@@ -55,16 +66,18 @@ import (
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/groupsync"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/metric"
-	"github.com/harness/gitness/app/services/notification"
+	notification2 "github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	repo2 "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/testreport"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -86,6 +99,7 @@ import (
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -110,23 +124,50 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	spacePathStore := database.ProvideSpacePathStore(db, spacePathTransformation)
 	spacePathCache := cache.ProvidePathCache(spacePathStore, spacePathTransformation)
 	spaceStore := database.ProvideSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
+	repoMembershipStore := database.ProvideRepoMembershipStore(db, principalInfoCache)
+	roleStore := database.ProvideRoleStore(db)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, repoStore, membershipStore, repoMembershipStore, roleStore)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, repoStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	totpStore := database.ProvideTOTPStore(db)
+	principalIdentityStore := database.ProvidePrincipalIdentityStore(db)
+	encrypter, err := encrypt.ProvideEncrypter(config)
+	if err != nil {
+		return nil, err
+	}
+	oidcConfig, err := server.ProvideOIDCConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	provider := oidc.ProvideProvider(oidcConfig)
+	ldapConfig, err := server.ProvideLDAPConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client := ldap.ProvideClient(ldapConfig)
+	syncer := groupsync.ProvideSyncer(config, principalStore, spaceStore, membershipStore)
+	auditStore := database.ProvideAuditStore(db)
+	auditService := audit.ProvideAuditService(auditStore)
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	ratelimitConfig := server.ProvideRateLimitConfig(config)
+	rateLimiter := ratelimit.ProvideRateLimiter(ratelimitConfig, universalClient)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, spaceStore, repoStore, totpStore, encrypter, config, provider, client, syncer, auditService, principalIdentityStore, rateLimiter)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
-	provider, err := url.ProvideURLProvider(config)
+	urlProvider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
 	settingsStore := database.ProvideSettingsStore(db)
@@ -136,10 +177,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	typesConfig := server.ProvideGitConfig(config)
-	universalClient, err := server.ProvideRedis(config)
-	if err != nil {
-		return nil, err
-	}
 	cacheCache, err := api.ProvideLastCommitCache(typesConfig, universalClient)
 	if err != nil {
 		return nil, err
@@ -155,10 +192,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	encrypter, err := encrypt.ProvideEncrypter(config)
-	if err != nil {
-		return nil, err
-	}
 	jobStore := database.ProvideJobStore(db)
 	pubsubConfig := server.ProvidePubsubConfig(config)
 	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
@@ -173,7 +206,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	streamer := sse.ProvideEventsStreaming(pubSub)
 	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
+	repository, err := importer.ProvideRepoImporter(config, urlProvider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
@@ -189,15 +222,16 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	resourceLimiter, err := limiter.ProvideLimiter()
+	quotaStore := database.ProvideQuotaStore(db)
+	quota := limiter.ProvideQuota(config, spaceStore, repoStore, quotaStore)
+	resourceLimiter, err := limiter.ProvideLimiter(quota)
 	if err != nil {
 		return nil, err
 	}
 	lockerLocker := locker.ProvideLocker(mutexManager)
-	auditService := audit.ProvideAuditService()
 	repoIdentifier := check.ProvideRepoIdentifierCheck()
 	repoCheck := repo.ProvideRepoCheck()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck)
+	repoController := repo.ProvideController(config, transactor, urlProvider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, membershipStore, repoMembershipStore, roleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck)
 	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
@@ -213,21 +247,49 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
+	stageApprovalStore := database.ProvideStageApprovalStore(db)
+	reporter4, err := events6.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	environmentStore := database.ProvideEnvironmentStore(db)
+	deploymentStore := database.ProvideDeploymentStore(db)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, urlProvider, templateStore, pluginStore, resourceLimiter, stageApprovalStore, principalStore, reporter4, cancelerCanceler, spaceStore, environmentStore, deploymentStore)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
+	secretStore := database.ProvideSecretStore(db)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, urlProvider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, reporter4)
+	readerFactory4, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	notificationStore := database.ProvideNotificationStore(db)
+	mailerMailer := mailer.ProvideMailClient(config)
+	approvalNotifier := notification2.ProvideApprovalNotifier(mailerMailer, principalInfoCache, notificationStore, streamer, urlProvider)
+	approvalService, err := approval.ProvideService(ctx, config, jobScheduler, executor, readerFactory4, stageApprovalStore, stageStore, executionStore, pipelineStore, repoStore, executionManager, streamer, approvalNotifier)
+	if err != nil {
+		return nil, err
+	}
+	artifactStore := database.ProvideArtifactStore(db)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, stageApprovalStore, approvalService, principalInfoCache, artifactStore, blobStore, urlProvider)
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
-	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db)
-	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	exporterRepository, err := exporter.ProvideSpaceExporter(urlProvider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, auditService)
-	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
-	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
+	spaceController := space.ProvideController(config, transactor, urlProvider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, roleStore, repoMembershipStore, repository, exporterRepository, resourceLimiter, quota, quotaStore, auditService)
+	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, auditService, commitService, triggererTriggerer)
+	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore, auditService)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
 	templateController := template.ProvideController(templateStore, authorizer, spaceStore)
@@ -253,19 +315,30 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, urlProvider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker)
+	testReportStore := database.ProvideTestReportStore(db)
+	testreportService := testreport.ProvideService(transactor, testReportStore)
+	pullreqController := pullreq2.ProvideController(transactor, urlProvider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker, auditService, testreportService)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory3, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
+	webhookRetryStore := database.ProvideWebhookRetryStore(db)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory2, readerFactory3, readerFactory4, webhookStore, webhookExecutionStore, webhookRetryStore, repoStore, spaceStore, pullReqStore, pullReqActivityStore, executionStore, pipelineStore, secretStore, urlProvider, principalStore, gitInterface, encrypter)
+	if err != nil {
+		return nil, err
+	}
+	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, spaceStore, secretStore, webhookService, encrypter, auditService)
 	reporter2, err := events4.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -282,37 +355,45 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, reporter, gitInterface, pullReqStore, urlProvider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, auditService)
 	principalController := principal.ProvideController(principalStore)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
-	if err != nil {
-		return nil, err
-	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	reporter3, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, reporter3, testReportStore, testreportService)
+	systemController := system.NewController(principalStore, config)
+	uploadController := upload.ProvideController(authorizer, repoStore, blobStore, quotaStore, resourceLimiter)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
 	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
+	auditController := audit2.ProvideController(authorizer, auditStore)
+	notificationPreferenceStore := database.ProvideNotificationPreferenceStore(db)
+	notificationController := notification.ProvideController(authorizer, notificationStore, notificationPreferenceStore, repoStore, principalInfoCache, streamer)
+	runnerStore := database.ProvideRunnerStore(db)
+	clientClient := manager.ProvideExecutionClient(executionManager, urlProvider, config)
+	runnerController := runner.ProvideController(runnerStore, repoStore, pipelineStore, executionStore, stageStore, stepStore, artifactStore, quotaStore, blobStore, testreportService, resourceLimiter, urlProvider, clientClient, auditService, authorizer)
+	environmentController := environment.ProvideController(authorizer, environmentStore, deploymentStore, repoStore, spaceStore, principalStore, auditService)
+	cacheEntryStore := database.ProvideCacheEntryStore(db)
+	buildcacheController := buildcache.ProvideController(config, repoStore, cacheEntryStore, blobStore)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, auditController, notificationController, runnerController, environmentController, buildcacheController, rateLimiter)
+	gitHandler := router.ProvideGitHandler(config, urlProvider, authenticator, repoController, rateLimiter)
 	openapiService := openapi.ProvideOpenAPIService()
 	webHandler := router.ProvideWebHandler(config, openapiService)
-	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
+	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, urlProvider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
-	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
+	runtimeRunner, err := runner2.ProvideExecutionRunner(config, clientClient, resolverManager)
+	if err != nil {
+		return nil, err
+	}
+	poller := runner2.ProvideExecutionPoller(runtimeRunner, clientClient)
+	webhookNotifier := notification2.ProvideWebhookNotifier(mailerMailer, principalInfoCache)
+	retryService, err := webhook.ProvideRetryService(webhookConfig, jobScheduler, executor, webhookService, webhookStore, webhookRetryStore, webhookNotifier)
 	if err != nil {
 		return nil, err
 	}
-	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
 	if err != nil {
@@ -326,23 +407,27 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	repoService, err := repo2.ProvideService(ctx, config, reporter, readerFactory2, repoStore, urlProvider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
-	repoService, err := repo2.ProvideService(ctx, config, reporter, readerFactory2, repoStore, provider, gitInterface, lockerLocker)
+	cleanupConfig := server.ProvideCleanupConfig(config)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, artifactStore, cacheEntryStore, quotaStore, blobStore)
 	if err != nil {
 		return nil, err
 	}
-	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	notificationDigestStore := database.ProvideNotificationDigestStore(db)
+	notificationClient := notification2.ProvideMailClient(mailerMailer, notificationPreferenceStore, notificationDigestStore)
+	notificationConfig := server.ProvideNotificationConfig(config)
+	notificationService, err := notification2.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, urlProvider)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
-	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	inboxService, err := notification2.ProvideInboxService(ctx, notificationConfig, notificationStore, streamer, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, urlProvider)
+	if err != nil {
+		return nil, err
+	}
+	digestService, err := notification2.ProvideDigestService(notificationConfig, jobScheduler, executor, mailerMailer, notificationDigestStore, principalInfoCache)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +436,16 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService)
+	groupsyncConfig := server.ProvideGroupSyncConfig(config)
+	groupsyncService, err := groupsync.ProvideService(groupsyncConfig, jobScheduler, executor, principalIdentityStore, syncer, client)
+	if err != nil {
+		return nil, err
+	}
+	reclaimerReclaimer, err := reclaimer.ProvideReclaimer(config, transactor, jobScheduler, executor, runnerStore, stageStore, stepStore, logStream, schedulerScheduler)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, retryService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, inboxService, digestService, keywordsearchService, groupsyncService, reclaimerReclaimer, approvalService)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
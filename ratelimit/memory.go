@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemory is a local implementation of a RateLimiter, buckets aren't shared between instances.
+type InMemory struct {
+	config Config
+
+	mutex       sync.Mutex
+	buckets     map[string]*inMemBucket
+	lastCleanup time.Time
+}
+
+type inMemBucket struct {
+	tokens  float64
+	updated time.Time
+	expires time.Time
+}
+
+// NewInMemory creates a new InMemory instance.
+func NewInMemory(config Config) *InMemory {
+	return &InMemory{
+		config:      config,
+		buckets:     make(map[string]*inMemBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// cleanupInterval is the interval in which unused buckets are removed.
+const cleanupInterval = time.Minute
+
+func (m *InMemory) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	key = formatKey(m.config.App, m.config.Namespace, key)
+	now := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.cleanup(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &inMemBucket{
+			tokens:  float64(limit.Burst),
+			updated: now,
+		}
+		m.buckets[key] = bucket
+	}
+
+	bucket.tokens = refill(limit, bucket.tokens, now.Sub(bucket.updated))
+	bucket.updated = now
+	bucket.expires = now.Add(ttl(limit))
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return newResult(limit, bucket.tokens, allowed), nil
+}
+
+// cleanup removes the buckets that are full by now, as they are equal to a new bucket.
+func (m *InMemory) cleanup(now time.Time) {
+	if now.Sub(m.lastCleanup) < cleanupInterval {
+		return
+	}
+
+	for key, bucket := range m.buckets {
+		if now.After(bucket.expires) {
+			delete(m.buckets, key)
+		}
+	}
+
+	m.lastCleanup = now
+}
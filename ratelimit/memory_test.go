@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_inMem_Allow(t *testing.T) {
+	limiter := NewInMemory(Config{
+		App:       "gitness",
+		Namespace: "test",
+	})
+	ctx := context.Background()
+	limit := Limit{Rate: 10, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res, err := limiter.Allow(ctx, "key1", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, 3, res.Limit)
+		require.Equal(t, i, res.Remaining)
+	}
+
+	res, err := limiter.Allow(ctx, "key1", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+	require.Greater(t, res.RetryAfter, time.Duration(0))
+	require.LessOrEqual(t, res.RetryAfter, 100*time.Millisecond)
+
+	// other keys have their own bucket.
+	res, err = limiter.Allow(ctx, "key2", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	// the bucket is refilled over time.
+	time.Sleep(150 * time.Millisecond)
+
+	res, err = limiter.Allow(ctx, "key1", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Zero(t, res.RetryAfter)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TrustedProxies are the networks of the reverse proxies in front of the server.
+// Only requests coming from a trusted proxy are limited by the client IP forwarded in their headers.
+type TrustedProxies []*net.IPNet
+
+// Decode parses a comma separated list of IPs and CIDRs, it's used when loading the config from the environment.
+func (p *TrustedProxies) Decode(value string) error {
+	proxies := TrustedProxies{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy ip %q", entry)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy network %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+
+	*p = proxies
+
+	return nil
+}
+
+// Contains returns true if the ip belongs to a trusted proxy.
+func (p TrustedProxies) Contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
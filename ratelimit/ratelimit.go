@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// RateLimiter limits the rate of operations using token buckets.
+// Every key has its own bucket which holds up to Limit.Burst tokens and is refilled with Limit.Rate tokens per second,
+// each operation consumes a single token.
+type RateLimiter interface {
+	// Allow consumes a token from the bucket of the key, the result reports whether the operation is allowed.
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limit defines the size and the refill rate of a token bucket.
+type Limit struct {
+	// Rate is the number of tokens added to the bucket per second.
+	Rate float64
+
+	// Burst is the maximum number of tokens in the bucket.
+	Burst int
+}
+
+// Result is the outcome of a rate limited operation.
+type Result struct {
+	// Allowed is true if a token was available for the operation.
+	Allowed bool
+
+	// Limit is the maximum number of tokens in the bucket.
+	Limit int
+
+	// Remaining is the number of tokens left in the bucket.
+	Remaining int
+
+	// RetryAfter is the time until the next token is available, zero if the operation was allowed.
+	RetryAfter time.Duration
+
+	// ResetAfter is the time until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// refill returns the number of tokens in the bucket after the elapsed time.
+func refill(limit Limit, tokens float64, elapsed time.Duration) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+}
+
+// newResult creates the result of an operation for the tokens left in the bucket.
+func newResult(limit Limit, tokens float64, allowed bool) Result {
+	res := Result{
+		Allowed:    allowed,
+		Limit:      limit.Burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: tokensDuration(limit, float64(limit.Burst)-tokens),
+	}
+
+	if !allowed {
+		res.RetryAfter = tokensDuration(limit, 1-tokens)
+	}
+
+	return res
+}
+
+// tokensDuration returns the time it takes to refill the provided number of tokens.
+func tokensDuration(limit Limit, tokens float64) time.Duration {
+	if tokens <= 0 || limit.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / limit.Rate * float64(time.Second)))
+}
+
+// ttl returns the time after which the state of an unused bucket can be dropped, as it's full by then.
+func ttl(limit Limit) time.Duration {
+	return tokensDuration(limit, float64(limit.Burst)) + time.Second
+}
+
+func formatKey(app, ns, key string) string {
+	return app + ":" + ns + ":" + key
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// allowScript atomically refills the token bucket stored in the hash and consumes a token if available.
+// It returns whether a token was consumed and the number of tokens left (as string to keep the fraction).
+var allowScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+
+local elapsed = math.max(0, now - updated)
+tokens = math.min(burst, tokens + elapsed / 1000 * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`)
+
+// Redis is a RateLimiter storing the token buckets in redis, buckets are shared between all instances.
+type Redis struct {
+	config Config
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis instance.
+func NewRedis(config Config, client redis.UniversalClient) *Redis {
+	return &Redis{
+		config: config,
+		client: client,
+	}
+}
+
+func (r *Redis) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	key = formatKey(r.config.App, r.config.Namespace, key)
+
+	res, err := allowScript.Run(ctx, r.client, []string{key},
+		limit.Rate,
+		limit.Burst,
+		time.Now().UnixMilli(),
+		ttl(limit).Milliseconds(),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+
+	allowed, ok := res[0].(int64)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected rate limit script result type: %T", res[0])
+	}
+
+	tokensStr, ok := res[1].(string)
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected rate limit script result type: %T", res[1])
+	}
+
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse number of tokens: %w", err)
+	}
+
+	return newResult(limit, tokens, allowed == 1), nil
+}
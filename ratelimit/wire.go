@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideRateLimiter,
+)
+
+// ProvideRateLimiter provides a rate limiter based on the configured provider.
+func ProvideRateLimiter(config Config, client redis.UniversalClient) RateLimiter {
+	switch config.Provider {
+	case ProviderMemory:
+		return NewInMemory(config)
+	case ProviderRedis:
+		return NewRedis(config, client)
+	}
+	return nil
+}
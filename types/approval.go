@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// StageTypeApproval is the type of pipeline stages that don't run on a runner,
+// but block the execution until they got approved.
+const StageTypeApproval = "approval"
+
+// StageApproval holds the approval requirements of an approval stage of an execution.
+type StageApproval struct {
+	StageID      int64         `json:"-"`
+	ExecutionID  int64         `json:"execution_id"`
+	RepoID       int64         `json:"repo_id"`
+	StageNumber  int64         `json:"stage_number"`
+	StageName    string        `json:"stage_name"`
+	Status       enum.CIStatus `json:"status"`
+	ApproverIDs  []int64       `json:"-"`
+	MinApprovals int           `json:"min_approvals"`
+	// Timeout is the time in milliseconds the stage waits for approval, zero means it waits forever.
+	Timeout int64 `json:"timeout,omitempty"`
+	// Started is the time the stage got blocked waiting for approval.
+	Started int64 `json:"started,omitempty"`
+	Created int64 `json:"created"`
+
+	Approvers []*PrincipalInfo    `json:"approvers"`
+	Decisions []*ApprovalDecision `json:"decisions"`
+}
+
+// Deadline returns the time the approval times out or zero if there is none.
+func (a *StageApproval) Deadline() int64 {
+	if a.Timeout <= 0 || a.Started == 0 {
+		return 0
+	}
+	return a.Started + a.Timeout
+}
+
+// IsApprover returns true if the principal is one of the configured approvers of the stage.
+func (a *StageApproval) IsApprover(principalID int64) bool {
+	for _, id := range a.ApproverIDs {
+		if id == principalID {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalDecision is the decision of a principal on an approval stage.
+type ApprovalDecision struct {
+	StageID     int64                 `json:"-"`
+	PrincipalID int64                 `json:"-"`
+	Principal   *PrincipalInfo        `json:"principal,omitempty"`
+	Decision    enum.ApprovalDecision `json:"decision"`
+	Comment     string                `json:"comment,omitempty"`
+	Created     int64                 `json:"created"`
+	Updated     int64                 `json:"updated"`
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Artifact is a file produced by a pipeline execution that is kept in the blob store.
+type Artifact struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"-"`
+	ExecutionID int64  `json:"-"`
+	StageID     int64  `json:"-"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	BlobPath    string `json:"-"`
+	Created     int64  `json:"created"`
+
+	// URL is the API url the artifact can be downloaded from, e.g. to link it from a check result.
+	URL string `json:"url,omitempty"`
+}
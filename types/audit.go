@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditEvent is a persisted entry of the audit log.
+type AuditEvent struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	Action    string `json:"action"`
+
+	ResourceType       string `json:"resource_type"`
+	ResourceIdentifier string `json:"resource_identifier"`
+	SpacePath          string `json:"space_path,omitempty"`
+
+	PrincipalID   int64              `json:"principal_id"`
+	PrincipalUID  string             `json:"principal_uid"`
+	PrincipalType enum.PrincipalType `json:"principal_type"`
+
+	ClientIP      string `json:"client_ip,omitempty"`
+	RequestMethod string `json:"request_method,omitempty"`
+
+	OldObject json.RawMessage   `json:"old_object,omitempty"`
+	NewObject json.RawMessage   `json:"new_object,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// AuditFilter stores audit log query parameters.
+type AuditFilter struct {
+	ListQueryFilter
+	CreatedFilter
+
+	// Actions and ResourceTypes restrict the events to the provided values.
+	Actions       []string `json:"action"`
+	ResourceTypes []string `json:"resource_type"`
+
+	PrincipalUID string `json:"principal_uid"`
+
+	// SpacePath restricts the events to the space and all of its descendants.
+	SpacePath string `json:"space_path"`
+
+	Order enum.Order `json:"order"`
+}
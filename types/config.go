@@ -22,6 +22,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/ratelimit"
 )
 
 // Config stores the system configuration.
@@ -148,6 +149,21 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// RunnerStaleTimeout is the duration after which a remote runner without heartbeat is considered stale.
+		// The stages accepted by a stale runner are put back into the queue.
+		RunnerStaleTimeout time.Duration `envconfig:"GITNESS_CI_RUNNER_STALE_TIMEOUT" default:"2m"`
+
+		// ArtifactRetentionTime is the duration after which artifacts of executions will be purged.
+		ArtifactRetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION_TIME" default:"720h"` // 30 days
+
+		// CacheMaxSize is the maximum size (in bytes) of the build cache of a repository.
+		// Once exceeded, the least recently used cache entries get evicted.
+		CacheMaxSize int64 `envconfig:"GITNESS_CI_CACHE_MAX_SIZE" default:"10737418240"` // 10 GiB
+		// CacheMaxEntrySize is the maximum size (in bytes) of a single build cache entry.
+		CacheMaxEntrySize int64 `envconfig:"GITNESS_CI_CACHE_MAX_ENTRY_SIZE" default:"2147483648"` // 2 GiB
+		// CacheRetentionTime is the duration after which build cache entries that weren't used get purged.
+		CacheRetentionTime time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION_TIME" default:"168h"` // 7 days
 	}
 
 	// Database defines the database configuration parameters.
@@ -179,6 +195,82 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// Auth defines the configuration of the authentication methods.
+	Auth struct {
+		// PasswordLoginDisabled disables the login and registration with local passwords.
+		// Users are expected to log in via a single sign-on provider instead. Existing users (like the admin)
+		// have to be linked to their identity of the provider via the admin API before it is disabled.
+		PasswordLoginDisabled bool `envconfig:"GITNESS_AUTH_PASSWORD_LOGIN_DISABLED" default:"false"`
+
+		// TwoFactorRequired requires all users logging in to use two-factor authentication, independent of the method.
+		// Users that aren't enrolled yet don't get a session until they completed the enrollment.
+		TwoFactorRequired bool `envconfig:"GITNESS_AUTH_TWO_FACTOR_REQUIRED" default:"false"`
+	}
+
+	// OIDC defines the configuration of the OpenID Connect single sign-on provider.
+	OIDC struct {
+		Enabled      bool   `envconfig:"GITNESS_OIDC_ENABLED"       default:"false"`
+		Issuer       string `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		// RedirectURL defaults to the OIDC callback endpoint of the API.
+		RedirectURL string   `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+		Scopes      []string `envconfig:"GITNESS_OIDC_SCOPES"       default:"openid,profile,email"`
+
+		UsernameClaim string `envconfig:"GITNESS_OIDC_USERNAME_CLAIM" default:"preferred_username"`
+		EmailClaim    string `envconfig:"GITNESS_OIDC_EMAIL_CLAIM"    default:"email"`
+		NameClaim     string `envconfig:"GITNESS_OIDC_NAME_CLAIM"     default:"name"`
+		GroupsClaim   string `envconfig:"GITNESS_OIDC_GROUPS_CLAIM"   default:"groups"`
+
+		// SkipEmailVerification allows logins of users whose email isn't verified by the provider.
+		SkipEmailVerification bool `envconfig:"GITNESS_OIDC_SKIP_EMAIL_VERIFICATION" default:"false"`
+		// AutoProvision creates a new user on the first login via the provider.
+		AutoProvision bool `envconfig:"GITNESS_OIDC_AUTO_PROVISION" default:"true"`
+
+		// GroupMappings maps groups of the groups claim to space memberships.
+		// Each mapping is in the format "group:space_path:role", e.g. "developers:acme/backend:contributor".
+		GroupMappings []string `envconfig:"GITNESS_OIDC_GROUP_MAPPINGS"`
+	}
+
+	// LDAP defines the configuration of the LDAP / Active Directory authentication.
+	LDAP struct {
+		Enabled            bool   `envconfig:"GITNESS_LDAP_ENABLED"              default:"false"`
+		URL                string `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool   `envconfig:"GITNESS_LDAP_START_TLS"            default:"false"`
+		InsecureSkipVerify bool   `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY" default:"false"`
+
+		// BindDN and BindPassword are the credentials of the service account used to search for users and groups.
+		// If no BindDN is provided, an anonymous bind is used.
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		UserBaseDN string `envconfig:"GITNESS_LDAP_USER_BASE_DN"`
+		// UserFilter is the filter used to find the user on login, {login} is replaced with the login identifier.
+		UserFilter string `envconfig:"GITNESS_LDAP_USER_FILTER" default:"(&(objectClass=person)(|(uid={login})(mail={login})))"` //nolint:lll // struct tags can't be multiline
+
+		UsernameAttribute string `envconfig:"GITNESS_LDAP_USERNAME_ATTRIBUTE" default:"uid"`
+		EmailAttribute    string `envconfig:"GITNESS_LDAP_EMAIL_ATTRIBUTE"    default:"mail"`
+		NameAttribute     string `envconfig:"GITNESS_LDAP_NAME_ATTRIBUTE"     default:"cn"`
+
+		// GroupBaseDN is the base DN of the group search.
+		// If it is empty, the groups are read from the memberOf attribute of the user instead (Active Directory).
+		GroupBaseDN string `envconfig:"GITNESS_LDAP_GROUP_BASE_DN"`
+		// GroupFilter is the filter used to find the groups of a user,
+		// {dn} and {username} are replaced with the DN and the username of the user.
+		GroupFilter        string `envconfig:"GITNESS_LDAP_GROUP_FILTER"         default:"(|(member={dn})(uniqueMember={dn})(memberUid={username}))"` //nolint:lll // struct tags can't be multiline
+		GroupNameAttribute string `envconfig:"GITNESS_LDAP_GROUP_NAME_ATTRIBUTE" default:"cn"`
+		MemberOfAttribute  string `envconfig:"GITNESS_LDAP_MEMBER_OF_ATTRIBUTE"  default:"memberOf"`
+
+		// AutoProvision creates a new user on the first login via LDAP.
+		AutoProvision bool `envconfig:"GITNESS_LDAP_AUTO_PROVISION" default:"true"`
+
+		// GroupMappings maps LDAP groups to space memberships.
+		// Each mapping is in the format "group:space_path:role", e.g. "developers:acme/backend:contributor".
+		GroupMappings []string `envconfig:"GITNESS_LDAP_GROUP_MAPPINGS"`
+		// GroupSyncCron is the schedule of the job that syncs the LDAP groups of all users to space memberships.
+		GroupSyncCron string `envconfig:"GITNESS_LDAP_GROUP_SYNC_CRON" default:"17 * * * *"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -284,6 +376,28 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	RateLimit struct {
+		Enabled bool `envconfig:"GITNESS_RATE_LIMIT_ENABLED" default:"false"`
+		// Provider is a name of the rate limit state backend like redis or memory.
+		Provider ratelimit.Provider `envconfig:"GITNESS_RATE_LIMIT_PROVIDER"      default:"inmemory"`
+		// AppNamespace is just service app prefix to avoid conflicts on key definition
+		AppNamespace string `envconfig:"GITNESS_RATE_LIMIT_APP_NAMESPACE" default:"gitness"`
+
+		// Rates are the number of requests per second a principal (or client IP for anonymous requests)
+		// can make on average, bursts define how many requests can be made at once.
+		RESTRate         float64 `envconfig:"GITNESS_RATE_LIMIT_REST_RATE"          default:"20"`
+		RESTBurst        int     `envconfig:"GITNESS_RATE_LIMIT_REST_BURST"         default:"200"`
+		UploadPackRate   float64 `envconfig:"GITNESS_RATE_LIMIT_UPLOAD_PACK_RATE"   default:"2"`
+		UploadPackBurst  int     `envconfig:"GITNESS_RATE_LIMIT_UPLOAD_PACK_BURST"  default:"40"`
+		ReceivePackRate  float64 `envconfig:"GITNESS_RATE_LIMIT_RECEIVE_PACK_RATE"  default:"0.5"`
+		ReceivePackBurst int     `envconfig:"GITNESS_RATE_LIMIT_RECEIVE_PACK_BURST" default:"10"`
+
+		// TrustedProxies is a comma separated list of IPs and CIDRs of the reverse proxies in front of the server.
+		// Anonymous requests are limited by the client IP in the forwarded headers only if sent by a trusted proxy,
+		// otherwise by the remote address of the request, as the headers can be set by anyone.
+		TrustedProxies ratelimit.TrustedProxies `envconfig:"GITNESS_RATE_LIMIT_TRUSTED_PROXIES"`
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -306,6 +420,14 @@ type Config struct {
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
+		// RetryMaxAttempts is the number of times a delivery that failed with a retriable error is re-attempted.
+		RetryMaxAttempts int `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_ATTEMPTS" default:"10"`
+		// RetryBackoff is the delay before the first re-attempt of a delivery, it doubles with every attempt.
+		RetryBackoff time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BACKOFF" default:"1m"`
+		// RetryMaxBackoff is the maximum delay between two re-attempts of a delivery.
+		RetryMaxBackoff time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_BACKOFF" default:"4h"`
+		// AutoDisable disables webhooks with deliveries that still fail after all re-attempts.
+		AutoDisable bool `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE" default:"true"`
 	}
 
 	Trigger struct {
@@ -342,6 +464,9 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		// DigestCron is the cron expression of the job sending the digest emails (daily at 8:00 UTC by default).
+		DigestCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_CRON" default:"0 8 * * *"`
 	}
 
 	KeywordSearch struct {
@@ -353,4 +478,14 @@ type Config struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
+
+	// Quota defines the default resource limits of top level spaces.
+	// They apply to every top level space without an explicit quota, a value of zero means unlimited.
+	Quota struct {
+		MaxRepos                int64 `envconfig:"GITNESS_QUOTA_MAX_REPOS"`
+		MaxRepoSize             int64 `envconfig:"GITNESS_QUOTA_MAX_REPO_SIZE"` // in KiB
+		MaxBlobSize             int64 `envconfig:"GITNESS_QUOTA_MAX_BLOB_SIZE"` // in bytes
+		MaxPipelineMinutes      int64 `envconfig:"GITNESS_QUOTA_MAX_PIPELINE_MINUTES"`
+		MaxConcurrentExecutions int64 `envconfig:"GITNESS_QUOTA_MAX_CONCURRENT_EXECUTIONS"`
+	}
 }
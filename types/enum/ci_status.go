@@ -43,7 +43,7 @@ func (status CIStatus) ConvertToCheckStatus() CheckStatus {
 	if status == CIStatusSuccess || status == CIStatusSkipped {
 		return CheckStatusSuccess
 	}
-	if status == CIStatusFailure {
+	if status == CIStatusFailure || status == CIStatusDeclined {
 		return CheckStatusFailure
 	}
 	if status == CIStatusRunning {
@@ -84,6 +84,7 @@ func (status CIStatus) IsDone() bool {
 // IsFailed returns true if in a failed state.
 func (status CIStatus) IsFailed() bool {
 	return status == CIStatusFailure ||
+		status == CIStatusDeclined ||
 		status == CIStatusKilled ||
 		status == CIStatusError
 }
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// EnvironmentParent defines different types of parents of a deployment environment.
+type EnvironmentParent string
+
+func (EnvironmentParent) Enum() []interface{} { return toInterfaceSlice(environmentParents) }
+
+const (
+	// EnvironmentParentRepo describes a repo as environment owner.
+	EnvironmentParentRepo EnvironmentParent = "repo"
+
+	// EnvironmentParentSpace describes a space as environment owner.
+	EnvironmentParentSpace EnvironmentParent = "space"
+)
+
+var environmentParents = sortEnum([]EnvironmentParent{
+	EnvironmentParentRepo,
+	EnvironmentParentSpace,
+})
@@ -28,6 +28,7 @@ var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleExecutor,
 	MembershipRoleContributor,
 	MembershipRoleSpaceOwner,
+	MembershipRoleCustom,
 })
 
 var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{}, 0,
@@ -89,6 +90,7 @@ func init() {
 }
 
 // Permissions returns the list of permissions for the role.
+// MembershipRoleCustom has no permissions on its own, they are defined by the custom role assigned to the membership.
 func (m MembershipRole) Permissions() []Permission {
 	switch m {
 	case MembershipRoleReader:
@@ -109,4 +111,5 @@ const (
 	MembershipRoleExecutor    MembershipRole = "executor"
 	MembershipRoleContributor MembershipRole = "contributor"
 	MembershipRoleSpaceOwner  MembershipRole = "space_owner"
+	MembershipRoleCustom      MembershipRole = "custom"
 )
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationType defines the kind of notification in the inbox of a user.
+type NotificationType string
+
+func (NotificationType) Enum() []interface{} { return toInterfaceSlice(NotificationTypes) }
+func (t NotificationType) Sanitize() (NotificationType, bool) {
+	return Sanitize(t, GetAllNotificationTypes)
+}
+func GetAllNotificationTypes() ([]NotificationType, NotificationType) { return NotificationTypes, "" }
+
+// NotificationType enumeration.
+const (
+	// NotificationTypeReviewRequested is sent to a user that got added as reviewer of a pull request.
+	NotificationTypeReviewRequested NotificationType = "review_requested"
+	// NotificationTypeReviewerAdded is sent to the author of a pull request when a reviewer got added.
+	NotificationTypeReviewerAdded NotificationType = "reviewer_added"
+	// NotificationTypeReviewSubmitted is sent when a review got submitted on a pull request.
+	NotificationTypeReviewSubmitted NotificationType = "review_submitted"
+	// NotificationTypeCommentCreated is sent to the author of a pull request when a comment got created.
+	NotificationTypeCommentCreated NotificationType = "comment_created"
+	// NotificationTypeCommentMention is sent to users mentioned in a comment.
+	NotificationTypeCommentMention NotificationType = "comment_mention"
+	// NotificationTypeCommentReply is sent to the participants of a comment thread that got a reply.
+	NotificationTypeCommentReply NotificationType = "comment_reply"
+	// NotificationTypePullReqBranchUpdated is sent when the source branch of a pull request got updated.
+	NotificationTypePullReqBranchUpdated NotificationType = "pullreq_branch_updated"
+	// NotificationTypePullReqStateChanged is sent when a pull request got merged, closed or reopened.
+	NotificationTypePullReqStateChanged NotificationType = "pullreq_state_changed"
+	// NotificationTypeApprovalRequested is sent to the approvers of a pipeline stage waiting for approval.
+	NotificationTypeApprovalRequested NotificationType = "approval_requested"
+)
+
+var NotificationTypes = sortEnum([]NotificationType{
+	NotificationTypeReviewRequested,
+	NotificationTypeReviewerAdded,
+	NotificationTypeReviewSubmitted,
+	NotificationTypeCommentCreated,
+	NotificationTypeCommentMention,
+	NotificationTypeCommentReply,
+	NotificationTypePullReqBranchUpdated,
+	NotificationTypePullReqStateChanged,
+	NotificationTypeApprovalRequested,
+})
+
+// NotificationDelivery defines how emails of a notification type are delivered to a user.
+type NotificationDelivery string
+
+func (NotificationDelivery) Enum() []interface{} { return toInterfaceSlice(NotificationDeliveries) }
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return NotificationDeliveries, NotificationDeliveryInstant
+}
+
+// NotificationDelivery enumeration.
+const (
+	// NotificationDeliveryOff disables the emails.
+	NotificationDeliveryOff NotificationDelivery = "off"
+	// NotificationDeliveryInstant sends an email as soon as the event happened.
+	NotificationDeliveryInstant NotificationDelivery = "instant"
+	// NotificationDeliveryDigest batches the notifications into a periodic digest email.
+	NotificationDeliveryDigest NotificationDelivery = "digest"
+)
+
+var NotificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryOff,
+	NotificationDeliveryInstant,
+	NotificationDeliveryDigest,
+})
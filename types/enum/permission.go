@@ -27,11 +27,47 @@ const (
 	ResourceTypeSecret         ResourceType = "SECRET"
 	ResourceTypeConnector      ResourceType = "CONNECTOR"
 	ResourceTypeTemplate       ResourceType = "TEMPLATE"
+	ResourceTypeSystem         ResourceType = "SYSTEM"
 )
 
 // Permission represents the different types of permissions a principal can have.
 type Permission string
 
+func (Permission) Enum() []interface{}                        { return toInterfaceSlice(MembershipPermissions) }
+func (p Permission) Sanitize() (Permission, bool)             { return Sanitize(p, GetAllMembershipPermissions) }
+func GetAllMembershipPermissions() ([]Permission, Permission) { return MembershipPermissions, "" }
+
+// MembershipPermissions is the list of permissions that can be granted through a space membership.
+var MembershipPermissions = sortEnum([]Permission{
+	PermissionSpaceView,
+	PermissionSpaceEdit,
+	PermissionSpaceDelete,
+	PermissionRepoView,
+	PermissionRepoEdit,
+	PermissionRepoDelete,
+	PermissionRepoPush,
+	PermissionRepoReportCommitCheck,
+	PermissionServiceAccountView,
+	PermissionServiceAccountEdit,
+	PermissionServiceAccountDelete,
+	PermissionPipelineView,
+	PermissionPipelineEdit,
+	PermissionPipelineDelete,
+	PermissionPipelineExecute,
+	PermissionSecretView,
+	PermissionSecretEdit,
+	PermissionSecretDelete,
+	PermissionSecretAccess,
+	PermissionConnectorView,
+	PermissionConnectorEdit,
+	PermissionConnectorDelete,
+	PermissionConnectorAccess,
+	PermissionTemplateView,
+	PermissionTemplateEdit,
+	PermissionTemplateDelete,
+	PermissionTemplateAccess,
+})
+
 const (
 	/*
 	   ----- SPACE -----
@@ -52,6 +88,13 @@ const (
 	PermissionRepoReportCommitCheck Permission = "repo_reportCommitCheck"
 )
 
+const (
+	/*
+		----- SYSTEM -----
+	*/
+	PermissionSystemAdmin Permission = "system_admin"
+)
+
 const (
 	/*
 		----- USER -----
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// QuotaResource defines the resources that can be limited by a space quota.
+type QuotaResource string
+
+func (QuotaResource) Enum() []interface{}                    { return toInterfaceSlice(QuotaResources) }
+func (r QuotaResource) Sanitize() (QuotaResource, bool)      { return Sanitize(r, GetAllQuotaResources) }
+func GetAllQuotaResources() ([]QuotaResource, QuotaResource) { return QuotaResources, "" }
+
+// QuotaResource enumeration.
+const (
+	// QuotaResourceRepos is the number of repositories.
+	QuotaResourceRepos QuotaResource = "repos"
+	// QuotaResourceRepoSize is the total size of all repositories in KiB.
+	QuotaResourceRepoSize QuotaResource = "repo_size"
+	// QuotaResourceBlobSize is the total size of all uploaded blobs in bytes.
+	QuotaResourceBlobSize QuotaResource = "blob_size"
+	// QuotaResourcePipelineMinutes is the number of pipeline minutes used in the current calendar month.
+	QuotaResourcePipelineMinutes QuotaResource = "pipeline_minutes"
+	// QuotaResourceConcurrentExecutions is the number of pending and running pipeline executions.
+	QuotaResourceConcurrentExecutions QuotaResource = "concurrent_executions"
+)
+
+var QuotaResources = sortEnum([]QuotaResource{
+	QuotaResourceRepos,
+	QuotaResourceRepoSize,
+	QuotaResourceBlobSize,
+	QuotaResourcePipelineMinutes,
+	QuotaResourceConcurrentExecutions,
+})
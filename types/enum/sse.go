@@ -24,8 +24,12 @@ const (
 	SSETypeExecutionCompleted SSEType = "execution_completed"
 	SSETypeExecutionCanceled  SSEType = "execution_canceled"
 
+	SSETypeApprovalRequested SSEType = "approval_requested"
+
 	SSETypeRepositoryImportCompleted SSEType = "repository_import_completed"
 	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
 
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
+
+	SSETypeNotificationCreated SSEType = "notification_created"
 )
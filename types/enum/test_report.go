@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "golang.org/x/exp/slices"
+
+// TestStatus defines the outcome of a single test case of a test report.
+type TestStatus string
+
+func (TestStatus) Enum() []interface{}               { return toInterfaceSlice(testStatuses) }
+func (s TestStatus) Sanitize() (TestStatus, bool)    { return Sanitize(s, GetAllTestStatuses) }
+func GetAllTestStatuses() ([]TestStatus, TestStatus) { return testStatuses, "" }
+
+// TestStatus enumeration.
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusSkipped TestStatus = "skipped"
+	TestStatusError   TestStatus = "error"
+)
+
+var testStatuses = sortEnum([]TestStatus{
+	TestStatusPassed,
+	TestStatusFailed,
+	TestStatusSkipped,
+	TestStatusError,
+})
+
+// IsFailure returns true if the test case failed or couldn't be executed because of an error.
+func (s TestStatus) IsFailure() bool {
+	return slices.Contains([]TestStatus{TestStatusFailed, TestStatusError}, s)
+}
+
+// TestReportFormat defines the format of an uploaded test report.
+type TestReportFormat string
+
+func (TestReportFormat) Enum() []interface{} { return toInterfaceSlice(testReportFormats) }
+func (f TestReportFormat) Sanitize() (TestReportFormat, bool) {
+	return Sanitize(f, GetAllTestReportFormats)
+}
+func GetAllTestReportFormats() ([]TestReportFormat, TestReportFormat) { return testReportFormats, "" }
+
+// TestReportFormat enumeration.
+const (
+	TestReportFormatJUnit TestReportFormat = "junit"
+	TestReportFormatXUnit TestReportFormat = "xunit"
+)
+
+var testReportFormats = sortEnum([]TestReportFormat{
+	TestReportFormatJUnit,
+	TestReportFormatXUnit,
+})
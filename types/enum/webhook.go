@@ -99,6 +99,69 @@ var webhookParents = sortEnum([]WebhookParent{
 	WebhookParentSpace,
 })
 
+// WebhookType defines the format of the requests sent by a webhook.
+type WebhookType string
+
+func (WebhookType) Enum() []interface{}             { return toInterfaceSlice(webhookTypes) }
+func (s WebhookType) Sanitize() (WebhookType, bool) { return Sanitize(s, GetAllWebhookTypes) }
+func GetAllWebhookTypes() ([]WebhookType, WebhookType) {
+	return webhookTypes, WebhookTypeGeneric
+}
+
+const (
+	// WebhookTypeGeneric sends the gitness webhook payloads as is.
+	WebhookTypeGeneric WebhookType = "generic"
+
+	// WebhookTypeSlack posts formatted messages to a Slack incoming webhook.
+	WebhookTypeSlack WebhookType = "slack"
+
+	// WebhookTypeMSTeams posts formatted messages to a Microsoft Teams incoming webhook.
+	WebhookTypeMSTeams WebhookType = "msteams"
+)
+
+var webhookTypes = sortEnum([]WebhookType{
+	WebhookTypeGeneric,
+	WebhookTypeSlack,
+	WebhookTypeMSTeams,
+})
+
+// IsChat returns true if the webhook posts formatted messages to a chat service.
+func (s WebhookType) IsChat() bool {
+	return s == WebhookTypeSlack || s == WebhookTypeMSTeams
+}
+
+// WebhookContentType defines how the body of the requests sent by a webhook is encoded.
+type WebhookContentType string
+
+func (WebhookContentType) Enum() []interface{} { return toInterfaceSlice(webhookContentTypes) }
+func (s WebhookContentType) Sanitize() (WebhookContentType, bool) {
+	return Sanitize(s, GetAllWebhookContentTypes)
+}
+func GetAllWebhookContentTypes() ([]WebhookContentType, WebhookContentType) {
+	return webhookContentTypes, WebhookContentTypeJSON
+}
+
+const (
+	// WebhookContentTypeJSON sends the body as application/json.
+	WebhookContentTypeJSON WebhookContentType = "json"
+
+	// WebhookContentTypeForm sends the body as application/x-www-form-urlencoded.
+	WebhookContentTypeForm WebhookContentType = "form"
+)
+
+var webhookContentTypes = sortEnum([]WebhookContentType{
+	WebhookContentTypeJSON,
+	WebhookContentTypeForm,
+})
+
+// MIMEType returns the value of the Content-Type header of the requests.
+func (s WebhookContentType) MIMEType() string {
+	if s == WebhookContentTypeForm {
+		return "application/x-www-form-urlencoded"
+	}
+	return "application/json"
+}
+
 // WebhookExecutionResult defines the different results of a webhook execution.
 type WebhookExecutionResult string
 
@@ -113,12 +176,17 @@ const (
 
 	// WebhookExecutionResultFatalError describes a webhook execution result that failed with an unrecoverable error.
 	WebhookExecutionResultFatalError WebhookExecutionResult = "fatal_error"
+
+	// WebhookExecutionResultSkipped describes a webhook execution that was skipped as the payload didn't match
+	// the payload filter of the webhook.
+	WebhookExecutionResultSkipped WebhookExecutionResult = "skipped"
 )
 
 var webhookExecutionResults = sortEnum([]WebhookExecutionResult{
 	WebhookExecutionResultSuccess,
 	WebhookExecutionResultRetriableError,
 	WebhookExecutionResultFatalError,
+	WebhookExecutionResultSkipped,
 })
 
 // WebhookTrigger defines the different types of webhook triggers available.
@@ -158,6 +226,27 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqCommentUpdated gets triggered when a pull request comment gets edited.
+	WebhookTriggerPullReqCommentUpdated WebhookTrigger = "pullreq_comment_updated"
+	// WebhookTriggerPullReqCommentStatusUpdated gets triggered when a pull request comment gets resolved or reactivated.
+	WebhookTriggerPullReqCommentStatusUpdated WebhookTrigger = "pullreq_comment_status_updated"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a review is submitted for a pull request.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+	// WebhookTriggerPullReqReviewerAdded gets triggered when a reviewer is added to a pull request.
+	WebhookTriggerPullReqReviewerAdded WebhookTrigger = "pullreq_reviewer_added"
+	// WebhookTriggerPullReqReviewerRemoved gets triggered when a reviewer is removed from a pull request.
+	WebhookTriggerPullReqReviewerRemoved WebhookTrigger = "pullreq_reviewer_removed"
+
+	// WebhookTriggerCheckStatusUpdated gets triggered when the status of a commit check changes.
+	WebhookTriggerCheckStatusUpdated WebhookTrigger = "check_status_updated"
+
+	// WebhookTriggerExecutionCompleted gets triggered when a pipeline execution is completed.
+	WebhookTriggerExecutionCompleted WebhookTrigger = "execution_completed"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +262,13 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqCommentUpdated,
+	WebhookTriggerPullReqCommentStatusUpdated,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqReviewerAdded,
+	WebhookTriggerPullReqReviewerRemoved,
+	WebhookTriggerCheckStatusUpdated,
+	WebhookTriggerExecutionCompleted,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
 })
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Environment is a deployment target (e.g. dev, staging or prod) of a repository or a space.
+// Environments of a space are available to all repositories in the space and its subspaces.
+type Environment struct {
+	ID         int64                  `json:"-"`
+	Version    int64                  `json:"-"`
+	ParentID   int64                  `json:"parent_id"`
+	ParentType enum.EnvironmentParent `json:"parent_type"`
+
+	Identifier  string                `json:"identifier"`
+	Description string                `json:"description"`
+	Protection  EnvironmentProtection `json:"protection"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// EnvironmentProtection restricts who can deploy what to an environment.
+type EnvironmentProtection struct {
+	// Branches contains the globstar patterns of the branches that can be deployed.
+	// If empty, all branches can be deployed.
+	Branches []string `json:"branches,omitempty"`
+
+	// PrincipalIDs contains the principals that are allowed to deploy.
+	// If empty, all principals with permission to execute the pipeline can deploy.
+	PrincipalIDs []int64 `json:"principal_ids,omitempty"`
+}
+
+// IsEmpty returns true if the protection doesn't restrict deployments.
+func (p EnvironmentProtection) IsEmpty() bool {
+	return len(p.Branches) == 0 && len(p.PrincipalIDs) == 0
+}
+
+// Deployment is a pipeline execution that targets an environment.
+// The status of a deployment is the status of its execution.
+type Deployment struct {
+	ID                    int64         `json:"id"`
+	EnvironmentID         int64         `json:"-"`
+	EnvironmentIdentifier string        `json:"environment"`
+	RepoID                int64         `json:"repo_id"`
+	PipelineID            int64         `json:"pipeline_id"`
+	PipelineIdentifier    string        `json:"pipeline"`
+	ExecutionID           int64         `json:"-"`
+	ExecutionNumber       int64         `json:"execution_number"`
+	Ref                   string        `json:"ref"`
+	SHA                   string        `json:"sha"`
+	Status                enum.CIStatus `json:"status"`
+	Started               int64         `json:"started,omitempty"`
+	Finished              int64         `json:"finished,omitempty"`
+	CreatedBy             int64         `json:"created_by"`
+	Created               int64         `json:"created"`
+}
+
+// DeploymentFilter stores deployment query parameters for listing.
+type DeploymentFilter struct {
+	Pagination
+	// Status restricts the deployments to the ones with the provided status.
+	Status enum.CIStatus `json:"status"`
+}
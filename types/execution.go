@@ -55,4 +55,7 @@ type Execution struct {
 	Updated      int64             `json:"updated"`
 	Version      int64             `json:"-"`
 	Stages       []*Stage          `json:"stages,omitempty"`
+
+	// ConcurrencyGroup is the expanded concurrency group key of the pipeline at the time the execution was triggered.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
 }
@@ -33,6 +33,10 @@ type Membership struct {
 	Updated   int64 `json:"updated"`
 
 	Role enum.MembershipRole `json:"role"`
+
+	// CustomRoleID is the ID of the custom role of the membership; only set if Role is enum.MembershipRoleCustom.
+	CustomRoleID *int64 `json:"-"`
+	CustomRole   string `json:"custom_role,omitempty"`
 }
 
 // MembershipUser adds user info to the Membership data.
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Notification is an entry in the inbox of a user.
+type Notification struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"-"`
+	Type        enum.NotificationType `json:"type"`
+
+	RepoID        int64  `json:"repo_id"`
+	PullReqID     int64  `json:"pullreq_id"`
+	PullReqNumber int64  `json:"pullreq_number"`
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+
+	// Text holds details specific to the notification type, like the text of a comment or the new pull request state.
+	Text string `json:"text,omitempty"`
+
+	ActorID int64          `json:"-"`
+	Actor   *PrincipalInfo `json:"actor,omitempty"`
+
+	Read    bool  `json:"read"`
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+// NotificationFilter stores notification inbox query parameters.
+type NotificationFilter struct {
+	Pagination
+
+	// Read restricts the notifications to read or unread ones.
+	Read *bool `json:"read"`
+}
+
+// NotificationPreference defines how emails of a notification type are delivered to a user.
+// A preference for a repository takes precedence over the global preference of the user.
+type NotificationPreference struct {
+	PrincipalID int64                     `json:"-"`
+	RepoID      *int64                    `json:"repo_id,omitempty"`
+	Type        enum.NotificationType     `json:"type"`
+	Delivery    enum.NotificationDelivery `json:"delivery"`
+	Created     int64                     `json:"created"`
+	Updated     int64                     `json:"updated"`
+}
+
+// NotificationDigestItem is a notification waiting to be sent as part of the next digest email of a user.
+type NotificationDigestItem struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"-"`
+	Type        enum.NotificationType `json:"type"`
+	Subject     string                `json:"subject"`
+	Summary     string                `json:"summary"`
+	URL         string                `json:"url"`
+	Created     int64                 `json:"created"`
+}
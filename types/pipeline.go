@@ -14,7 +14,11 @@
 
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
 
 type Pipeline struct {
 	ID          int64  `db:"pipeline_id"              json:"-"`
@@ -32,6 +36,12 @@ type Pipeline struct {
 	Execution *Execution `db:"-"                        json:"execution,omitempty"`
 	Updated   int64      `db:"pipeline_updated"         json:"updated"`
 	Version   int64      `db:"pipeline_version"         json:"-"`
+
+	// ConcurrencyGroup is the key expression of the concurrency group of the pipeline's executions,
+	// e.g. "deploy-${branch}". Older executions of the same group and ref are cancelled by newer ones.
+	ConcurrencyGroup string `db:"pipeline_concurrency_group" json:"concurrency_group"`
+	// ConcurrencyLimit is the maximum number of executions of a concurrency group that run at the same time.
+	ConcurrencyLimit int `db:"pipeline_concurrency_limit" json:"concurrency_limit"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -46,3 +56,50 @@ func (s Pipeline) MarshalJSON() ([]byte, error) {
 		UID:   s.Identifier,
 	})
 }
+
+// PipelineValidation is the result of a dry-run of a pipeline yaml: the yaml is converted,
+// resolved and parsed the same way as for an execution, but nothing gets executed.
+type PipelineValidation struct {
+	Valid bool `json:"valid"`
+	// Format is the format of the yaml, either "drone" or "v1".
+	Format string `json:"format,omitempty"`
+	// Event is the event the stage triggers were matched against.
+	Event string `json:"event"`
+	// Expanded is the pipeline yaml after the jsonnet or starlark conversion.
+	Expanded string                     `json:"expanded,omitempty"`
+	Errors   []PipelineValidationError  `json:"errors,omitempty"`
+	Stages   []*PipelineValidationStage `json:"stages,omitempty"`
+	// Triggers are the triggers of the pipeline along with whether they fire for the event.
+	Triggers []*PipelineValidationTrigger `json:"triggers,omitempty"`
+}
+
+// PipelineValidationError is an error in a pipeline yaml.
+// Line and column are 1-based and are omitted if the error can't be attributed to a position.
+type PipelineValidationError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// PipelineValidationStage is a stage of the expanded stage DAG of a pipeline yaml.
+type PipelineValidationStage struct {
+	Name      string        `json:"name"`
+	Kind      string        `json:"kind,omitempty"`
+	Type      string        `json:"type,omitempty"`
+	DependsOn []string      `json:"depends_on,omitempty"`
+	Status    enum.CIStatus `json:"status,omitempty"`
+	// Events and Branches are the events and branches the stage is restricted to, if any.
+	Events   []string `json:"events,omitempty"`
+	Branches []string `json:"branches,omitempty"`
+	// Matched is true if the stage would be executed for the event, otherwise SkipReason tells why it wouldn't be.
+	Matched    bool   `json:"matched"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// PipelineValidationTrigger is a trigger of a pipeline that got validated.
+type PipelineValidationTrigger struct {
+	Identifier string               `json:"identifier"`
+	Actions    []enum.TriggerAction `json:"actions"`
+	Disabled   bool                 `json:"disabled"`
+	Matched    bool                 `json:"matched"`
+}
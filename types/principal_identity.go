@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+const (
+	// PrincipalIdentityProviderLDAP is the provider of identities of the LDAP directory, identified by their DN.
+	PrincipalIdentityProviderLDAP = "ldap"
+	// PrincipalIdentityProviderOIDC is the provider of identities of the OIDC provider, identified by their subject.
+	PrincipalIdentityProviderOIDC = "oidc"
+)
+
+// PrincipalIdentity links a principal to its identity at an external identity provider.
+// Logins via an external provider are only accepted for the principal linked to the identity,
+// which prevents taking over existing users by e.g. matching emails.
+type PrincipalIdentity struct {
+	Provider    string `json:"provider"`
+	ExternalID  string `json:"external_id"`
+	PrincipalID int64  `json:"-"`
+	Created     int64  `json:"created"`
+}
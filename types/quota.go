@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// SpaceQuota defines the resource limits of a space.
+// The limits apply to the space together with all of its descendants, nil means no limit is set.
+type SpaceQuota struct {
+	SpaceID                 int64  `json:"-"`
+	MaxRepos                *int64 `json:"max_repos"`
+	MaxRepoSize             *int64 `json:"max_repo_size"` // in KiB
+	MaxBlobSize             *int64 `json:"max_blob_size"` // in bytes
+	MaxPipelineMinutes      *int64 `json:"max_pipeline_minutes"`
+	MaxConcurrentExecutions *int64 `json:"max_concurrent_executions"`
+	Created                 int64  `json:"created"`
+	Updated                 int64  `json:"updated"`
+}
+
+// Limit returns the limit of the provided resource, or nil if it's not limited.
+func (q *SpaceQuota) Limit(resource enum.QuotaResource) *int64 {
+	switch resource {
+	case enum.QuotaResourceRepos:
+		return q.MaxRepos
+	case enum.QuotaResourceRepoSize:
+		return q.MaxRepoSize
+	case enum.QuotaResourceBlobSize:
+		return q.MaxBlobSize
+	case enum.QuotaResourcePipelineMinutes:
+		return q.MaxPipelineMinutes
+	case enum.QuotaResourceConcurrentExecutions:
+		return q.MaxConcurrentExecutions
+	}
+	return nil
+}
+
+// IsEmpty returns true if the quota doesn't limit any resource.
+func (q *SpaceQuota) IsEmpty() bool {
+	for _, resource := range enum.QuotaResources {
+		if q.Limit(resource) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ResourceUsage holds the resource consumption of a space together with all of its descendants.
+type ResourceUsage struct {
+	Repos                int64 `json:"repos"`
+	RepoSize             int64 `json:"repo_size"` // in KiB
+	BlobSize             int64 `json:"blob_size"` // in bytes
+	PipelineMinutes      int64 `json:"pipeline_minutes"`
+	ConcurrentExecutions int64 `json:"concurrent_executions"`
+}
+
+// Get returns the consumption of the provided resource.
+func (u *ResourceUsage) Get(resource enum.QuotaResource) int64 {
+	switch resource {
+	case enum.QuotaResourceRepos:
+		return u.Repos
+	case enum.QuotaResourceRepoSize:
+		return u.RepoSize
+	case enum.QuotaResourceBlobSize:
+		return u.BlobSize
+	case enum.QuotaResourcePipelineMinutes:
+		return u.PipelineMinutes
+	case enum.QuotaResourceConcurrentExecutions:
+		return u.ConcurrentExecutions
+	}
+	return 0
+}
+
+// ResourceQuotaUsage compares the consumption of a resource with its effective limit.
+type ResourceQuotaUsage struct {
+	Resource enum.QuotaResource `json:"resource"`
+	Usage    int64              `json:"usage"`
+
+	// Limit is the effective limit of the resource, nil if the resource isn't limited.
+	// It takes into account the quotas of all parent spaces and their consumption.
+	Limit *int64 `json:"limit"`
+
+	// LimitedBy is the path of the space whose quota sets the effective limit.
+	LimitedBy string `json:"limited_by,omitempty"`
+}
+
+// SpaceQuotaUsage describes the quota and the resource consumption of a space.
+type SpaceQuotaUsage struct {
+	// Quota is the quota configured on the space itself, nil if there's none.
+	Quota     *SpaceQuota          `json:"quota"`
+	Resources []ResourceQuotaUsage `json:"resources"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoMembershipKey can be used as a key for finding a user's repository membership info.
+type RepoMembershipKey struct {
+	RepoID      int64
+	PrincipalID int64
+}
+
+// RepoMembership represents a user's membership of a single repository.
+type RepoMembership struct {
+	RepoMembershipKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+
+	// CustomRoleID is the ID of the custom role of the membership; only set if Role is enum.MembershipRoleCustom.
+	CustomRoleID *int64 `json:"-"`
+	CustomRole   string `json:"custom_role,omitempty"`
+}
+
+// RepoMembershipUser adds user info to the RepoMembership data.
+type RepoMembershipUser struct {
+	RepoMembership
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
+
+// EffectiveMembership is a membership that grants a principal access to a repository.
+// InheritedFrom holds the path of the space the membership is inherited from,
+// it's empty for memberships of the repository itself.
+type EffectiveMembership struct {
+	Role          enum.MembershipRole `json:"role"`
+	CustomRole    string              `json:"custom_role,omitempty"`
+	InheritedFrom string              `json:"inherited_from,omitempty"`
+}
+
+// RepoMembershipEffective describes the effective access of a principal to a repository.
+type RepoMembershipEffective struct {
+	Principal   PrincipalInfo         `json:"principal"`
+	Memberships []EffectiveMembership `json:"memberships"`
+	Permissions []enum.Permission     `json:"permissions"`
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// Role represents a custom membership role defined in a space.
+type Role struct {
+	ID          int64             `json:"-"`
+	SpaceID     int64             `json:"-"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// HasPermission returns true if the role grants the provided permission.
+// Permissions of the role are expected to be sorted.
+func (r *Role) HasPermission(permission enum.Permission) bool {
+	_, ok := slices.BinarySearch(r.Permissions, permission)
+	return ok
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RunnerMachinePrefix is the prefix of the machine name of stages executed by remote runners.
+const RunnerMachinePrefix = "runner:"
+
+// Runner is a remote runner that executes pipeline stages on a separate machine.
+type Runner struct {
+	ID          int64             `json:"-"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	TokenHash   string            `json:"-"`
+
+	// OS, Arch and Version are reported by the runner with every heartbeat.
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	LastSeen int64  `json:"last_seen"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// Machine returns the machine name of the stages accepted by the runner.
+func (r *Runner) Machine() string {
+	return RunnerMachinePrefix + r.Identifier
+}
+
+// RunnerResponse is returned when a runner gets registered.
+// It's the only time the token of the runner is exposed.
+type RunnerResponse struct {
+	Token  string `json:"token"`
+	Runner Runner `json:"runner"`
+}
@@ -45,4 +45,9 @@ type Stage struct {
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
 	Steps       []*Step           `json:"steps,omitempty"`
+
+	// ConcurrencyGroup and ConcurrencyLimit restrict the number of executions
+	// of the same concurrency group the scheduler runs at the same time.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
+	ConcurrencyLimit int    `json:"concurrency_limit,omitempty"`
 }
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// TestReport is a parsed test report (e.g. JUnit XML) that was uploaded for a status check of a commit.
+// A check can have multiple reports, e.g. one per stage of a pipeline or one per rerun.
+type TestReport struct {
+	ID              int64                 `json:"id"`
+	RepoID          int64                 `json:"-"`
+	CommitSHA       string                `json:"commit_sha"`
+	CheckIdentifier string                `json:"check_identifier"`
+	Branch          string                `json:"branch,omitempty"`
+	Format          enum.TestReportFormat `json:"format"`
+	CreatedBy       int64                 `json:"-"`
+	Created         int64                 `json:"created"`
+
+	TestCounts
+}
+
+// TestCounts holds the number of tests per outcome and their total duration (in milliseconds).
+type TestCounts struct {
+	Total    int   `json:"total"`
+	Passed   int   `json:"passed"`
+	Failed   int   `json:"failed"`
+	Skipped  int   `json:"skipped"`
+	Errored  int   `json:"errored"`
+	Duration int64 `json:"duration"`
+}
+
+// Add counts the provided test case.
+func (c *TestCounts) Add(testCase *TestCase) {
+	c.Total++
+	c.Duration += testCase.Duration
+
+	switch testCase.Status {
+	case enum.TestStatusPassed:
+		c.Passed++
+	case enum.TestStatusFailed:
+		c.Failed++
+	case enum.TestStatusSkipped:
+		c.Skipped++
+	case enum.TestStatusError:
+		c.Errored++
+	}
+}
+
+// TestCase is a single test case of a test report.
+type TestCase struct {
+	ID              int64           `json:"-"`
+	ReportID        int64           `json:"report_id"`
+	CheckIdentifier string          `json:"check_identifier"`
+	Suite           string          `json:"suite"`
+	ClassName       string          `json:"class_name"`
+	Name            string          `json:"name"`
+	Status          enum.TestStatus `json:"status"`
+	Duration        int64           `json:"duration"`
+	Message         string          `json:"message,omitempty"`
+	Details         string          `json:"details,omitempty"`
+}
+
+// TestCaseListOptions holds list test cases query parameters.
+type TestCaseListOptions struct {
+	Pagination
+	CheckIdentifier string            `json:"check_identifier"`
+	Statuses        []enum.TestStatus `json:"statuses"`
+}
+
+// TestSummary holds the aggregated test counts of all reports of a status check for a commit.
+type TestSummary struct {
+	CheckIdentifier string `json:"check_identifier"`
+	Reports         int    `json:"reports"`
+
+	TestCounts
+}
+
+// FlakyTest is a test that both passed and failed for the same commit and status check.
+type FlakyTest struct {
+	CheckIdentifier string `json:"check_identifier"`
+	Suite           string `json:"suite"`
+	ClassName       string `json:"class_name"`
+	Name            string `json:"name"`
+	// FlakyCommits is the number of commits for which the test both passed and failed.
+	FlakyCommits int `json:"flaky_commits"`
+}
+
+// PullReqTestSummary summarizes the test reports of the latest commit of a pull request.
+type PullReqTestSummary struct {
+	CommitSHA string        `json:"commit_sha"`
+	Checks    []TestSummary `json:"checks"`
+	// NewFailures are the tests that fail for the pull request,
+	// but didn't fail for the latest report of the same check on the target branch.
+	NewFailures []*TestCase `json:"new_failures"`
+	// Flaky are the failing tests of the pull request that are known to be flaky.
+	Flaky []FlakyTest `json:"flaky"`
+}
@@ -18,20 +18,56 @@ import (
 	"encoding/json"
 
 	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
 )
 
 // Represents server side infos stored for tokens we distribute.
 type Token struct {
 	// TODO: int64 ID doesn't match DB
-	ID          int64          `db:"token_id"                 json:"-"`
-	PrincipalID int64          `db:"token_principal_id"       json:"principal_id"`
-	Type        enum.TokenType `db:"token_type"               json:"type"`
-	Identifier  string         `db:"token_uid"                json:"identifier"`
+	ID          int64          `json:"-"`
+	PrincipalID int64          `json:"principal_id"`
+	Type        enum.TokenType `json:"type"`
+	Identifier  string         `json:"identifier"`
 	// ExpiresAt is an optional unix time that if specified restricts the validity of a token.
-	ExpiresAt *int64 `db:"token_expires_at"         json:"expires_at,omitempty"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
 	// IssuedAt is the unix time at which the token was issued.
-	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
-	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	IssuedAt  int64 `json:"issued_at"`
+	CreatedBy int64 `json:"created_by"`
+	// LastUsed is the unix time at which the token was last used for authentication.
+	LastUsed *int64 `json:"last_used,omitempty"`
+
+	TokenScope
+}
+
+// TokenScope optionally restricts what a token can be used for.
+// An empty scope doesn't restrict the token, it carries all the rights of its principal.
+type TokenScope struct {
+	// Permissions restricts the token to the listed permissions (kept sorted).
+	Permissions []enum.Permission `json:"permissions,omitempty"`
+	// SpaceIDs and RepoIDs restrict the token to resources inside the listed spaces and repositories.
+	SpaceIDs []int64 `json:"space_ids,omitempty"`
+	RepoIDs  []int64 `json:"repo_ids,omitempty"`
+}
+
+// IsRestricted returns true if the scope restricts the token in any way.
+func (s TokenScope) IsRestricted() bool {
+	return len(s.Permissions) > 0 || s.IsResourceRestricted()
+}
+
+// IsResourceRestricted returns true if the token is restricted to specific spaces or repositories.
+func (s TokenScope) IsResourceRestricted() bool {
+	return len(s.SpaceIDs) > 0 || len(s.RepoIDs) > 0
+}
+
+// HasPermission returns true if the scope allows the permission.
+func (s TokenScope) HasPermission(permission enum.Permission) bool {
+	if len(s.Permissions) == 0 {
+		return true
+	}
+
+	_, found := slices.BinarySearch(s.Permissions, permission)
+	return found
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// TOTP holds the time-based one-time password configuration of a user.
+type TOTP struct {
+	PrincipalID int64 `json:"-"`
+
+	// Secret is stored encrypted.
+	Secret string `json:"-"`
+
+	// Enabled is false until the enrollment was confirmed with a valid code.
+	Enabled bool `json:"enabled"`
+
+	// RecoveryCodes are the hashes of the unused recovery codes.
+	RecoveryCodes []string `json:"-"`
+
+	// LastUsedStep is the time step of the last accepted code, used to prevent replays.
+	LastUsedStep int64 `json:"-"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+// TOTPEnrollment is returned when a user starts the TOTP enrollment.
+// The secret and the recovery codes are only returned once.
+type TOTPEnrollment struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TOTPStatus describes the TOTP configuration of a user.
+type TOTPStatus struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
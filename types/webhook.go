@@ -42,6 +42,58 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	// Type defines whether the raw payload is sent or a formatted chat message is posted.
+	Type enum.WebhookType `json:"type"`
+	// ChatMentions maps principal UIDs to their handles in the chat service (only used by chat webhooks).
+	// For Slack the handle is the member ID, for Teams it is inserted into the message as is.
+	ChatMentions map[string]string `json:"chat_mentions,omitempty"`
+
+	// PayloadFilter optionally restricts the webhook executions to payloads matching the filter.
+	PayloadFilter *WebhookPayloadFilter `json:"payload_filter,omitempty"`
+
+	// ContentType defines how the request body is encoded.
+	ContentType enum.WebhookContentType `json:"content_type"`
+	// PayloadTemplate optionally replaces the default payload with a body generated from the payload.
+	PayloadTemplate *WebhookPayloadTemplate `json:"payload_template,omitempty"`
+	// Headers are added to every request of the webhook. The values can reference secrets of the space
+	// the webhook belongs to, e.g. `Bearer ${{ secrets.get("jira_token") }}`.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// WebhookPayloadFilter describes the conditions a trigger payload has to satisfy for the webhook to be executed.
+// Empty conditions are ignored, all other conditions have to be satisfied.
+type WebhookPayloadFilter struct {
+	// Branches are glob patterns matched against the branch of branch triggers.
+	Branches []string `json:"branches,omitempty"`
+	// Tags are glob patterns matched against the tag of tag triggers.
+	Tags []string `json:"tags,omitempty"`
+	// TargetBranches are glob patterns matched against the target branch of pull request triggers.
+	TargetBranches []string `json:"target_branches,omitempty"`
+	// Expression is a boolean expression evaluated against the JSON payload,
+	// e.g. `principal.uid == "admin" && pull_req.is_draft == false`.
+	Expression string `json:"expression,omitempty"`
+}
+
+// IsEmpty returns true if the filter doesn't contain any conditions.
+func (f *WebhookPayloadFilter) IsEmpty() bool {
+	return f == nil ||
+		len(f.Branches) == 0 && len(f.Tags) == 0 && len(f.TargetBranches) == 0 && f.Expression == ""
+}
+
+// WebhookPayloadTemplate describes how the request body is generated from the payload of a trigger.
+// Only one of Template and Mapping can be set.
+type WebhookPayloadTemplate struct {
+	// Template is a Go text/template rendered with the JSON payload, e.g. `{"summary": {{json .pull_req.title}}}`.
+	Template string `json:"template,omitempty"`
+	// Mapping maps the fields of the body to JSONPath expressions evaluated against the JSON payload,
+	// e.g. {"summary": "$.pull_req.title"}.
+	Mapping map[string]string `json:"mapping,omitempty"`
+}
+
+// IsEmpty returns true if the template doesn't replace the default payload.
+func (t *WebhookPayloadTemplate) IsEmpty() bool {
+	return t == nil || t.Template == "" && len(t.Mapping) == 0
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -97,6 +149,18 @@ type WebhookExecutionResponse struct {
 	Body       string `json:"body"`
 }
 
+// WebhookRetry is a failed webhook delivery waiting to be re-attempted.
+// Each attempt retriggers the latest failed execution of the delivery.
+type WebhookRetry struct {
+	ID          int64  `json:"id"`
+	WebhookID   int64  `json:"webhook_id"`
+	TriggerID   string `json:"-"`
+	ExecutionID int64  `json:"execution_id"`
+	Attempts    int    `json:"attempts"`
+	NextAttempt int64  `json:"next_attempt"`
+	Created     int64  `json:"created"`
+}
+
 // WebhookFilter stores Webhook query parameters for listing.
 type WebhookFilter struct {
 	Query        string           `json:"query"`